@@ -0,0 +1,105 @@
+// Command resharing-auditor checks a completed refresh of the resharing protocol from its public transcript
+// (see protocols/resharing/auditor)
+//
+// Usage:
+//
+//	resharing-auditor transcript
+//
+// where transcript is a file containing a msgpack-encoded auditor.Transcript
+// It prints the report of the audit and exits with status 1 if the refresh failed
+// (or 2 if the audit could not be performed)
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/protocols/resharing/auditor"
+	log "github.com/sirupsen/logrus"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s transcript\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	verbose := flag.Bool("v", false, "log the misbehaviors found while auditing")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if !*verbose {
+		log.SetLevel(log.WarnLevel)
+	}
+
+	report, err := audit(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "audit failed: %v\n", err)
+		os.Exit(2)
+	}
+
+	printReport(report)
+	if report.Verdict != auditor.VerdictValid {
+		os.Exit(1)
+	}
+}
+
+// audit reads the transcript in the file path and audits it
+func audit(path string) (*auditor.Report, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var tr auditor.Transcript
+	err = msgpack.Decode(b, &tr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode transcript: %w", err)
+	}
+
+	return auditor.AuditTranscript(&tr)
+}
+
+// printReport prints the report on the standard output
+func printReport(report *auditor.Report) {
+	fmt.Printf("verdict: %v\n", report.Verdict)
+	if report.Reason != "" {
+		fmt.Printf("reason: %s\n", report.Reason)
+	}
+	fmt.Printf("disqualified dealers: %v\n", report.DisqualifiedDealers)
+	fmt.Printf("qualified dealers: %v\n", report.QualifiedDealers)
+	fmt.Printf("qualified dealers digest: %s\n", hex.EncodeToString(report.QualifiedDealersDigest))
+	fmt.Printf("blamed dealers:\n")
+	for _, b := range report.Blame.Dealers {
+		fmt.Printf("  %d: %v: %s\n", b.Dealer, b.Reason, b.Detail)
+	}
+	fmt.Printf("blamed verifiers:\n")
+	for _, b := range report.Blame.Verifiers {
+		fmt.Printf("  %d: %v: %s\n", b.Verifier, b.Reason, b.Detail)
+	}
+	if report.BatchNextCommitments != nil {
+		for u := range report.BatchNextCommitments {
+			fmt.Printf("next commitments of secret %d:\n", u)
+			for l := range report.BatchNextCommitments[u] {
+				fmt.Printf("  %d: %s\n", l, hex.EncodeToString(report.BatchNextCommitments[u][l][:]))
+			}
+		}
+	} else {
+		fmt.Printf("next commitments:\n")
+		for l := range report.NextCommitments {
+			fmt.Printf("  %d: %s\n", l, hex.EncodeToString(report.NextCommitments[l][:]))
+		}
+	}
+	if report.FeldmanCommitments != nil {
+		fmt.Printf("Feldman commitments:\n")
+		for l := range report.FeldmanCommitments {
+			fmt.Printf("  %d: %s\n", l, hex.EncodeToString(report.FeldmanCommitments[l][:]))
+		}
+	}
+}
@@ -21,3 +21,28 @@ type BroadcastChannel interface {
 	Send(msg []byte)
 	ReceiveRound() (int, []BroadcastMessage)
 }
+
+// PayloadFrom returns the payload of the message sent by the party id among the messages of a round
+// It returns nil if the party did not send any message
+func PayloadFrom(messages []BroadcastMessage, id int) []byte {
+	// messages are normally ordered by sender
+	if id >= 0 && id < len(messages) && messages[id].SenderID == id {
+		return messages[id].Payload
+	}
+	for i := range messages {
+		if messages[i].SenderID == id {
+			return messages[i].Payload
+		}
+	}
+	return nil
+}
+
+// PayloadsFrom returns the payloads of the messages sent by the parties ids among the messages of a round
+// payloads[x] is nil if the party ids[x] did not send any message (see PayloadFrom)
+func PayloadsFrom(messages []BroadcastMessage, ids []int) (payloads [][]byte) {
+	payloads = make([][]byte, len(ids))
+	for x, id := range ids {
+		payloads[x] = PayloadFrom(messages, id)
+	}
+	return payloads
+}
@@ -9,6 +9,7 @@ import "C"
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
 	"log"
 	"unsafe"
 )
@@ -98,6 +99,52 @@ func RandomScalar() *Scalar {
 	return &r
 }
 
+// RandomScalarFrom is like RandomScalar but uses rnd as randomness source
+// It reads 64 bytes from rnd and reduces them modulo L, so the output is (statistically close to) uniform
+// If rnd is nil, it is the same as RandomScalar
+func RandomScalarFrom(rnd io.Reader) (*Scalar, error) {
+	if rnd == nil {
+		return RandomScalar(), nil
+	}
+
+	var b [64]byte
+	_, err := io.ReadFull(rnd, b[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read randomness: %w", err)
+	}
+	return ReduceScalar(&b), nil
+}
+
+// ReduceScalar reduces a 64-byte little-endian integer modulo L, where L is the order
+// of the main subgroup
+// If the input is uniform, the output is statistically close to uniform
+func ReduceScalar(b *[64]byte) *Scalar {
+	var r Scalar
+
+	C.crypto_core_ed25519_scalar_reduce((*C.uchar)(&r[0]), (*C.uchar)(&b[0]))
+	return &r
+}
+
+// RandomPointFrom is like RandomPoint but uses rnd as randomness source
+// If rnd is nil, it is the same as RandomPoint
+func RandomPointFrom(rnd io.Reader) (*Point, error) {
+	if rnd == nil {
+		return RandomPoint(), nil
+	}
+
+	var b [C.crypto_core_ed25519_UNIFORMBYTES]byte
+	_, err := io.ReadFull(rnd, b[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read randomness: %w", err)
+	}
+
+	var p Point
+	if C.crypto_core_ed25519_from_uniform((*C.uchar)(&p[0]), (*C.uchar)(&b[0])) != 0 {
+		return nil, fmt.Errorf("error while mapping to curve")
+	}
+	return &p, nil
+}
+
 // Just used for benchmarking
 func sodium32RandomBytes() *[32]byte {
 	var r [32]byte
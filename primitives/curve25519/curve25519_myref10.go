@@ -78,6 +78,10 @@ func (p *Polynomial) Evaluate(x *Scalar) *Scalar {
 
 // EvaluateC is like Evaluate but with a pointer to the return value as first argument
 func (p *Polynomial) EvaluateC(out *Scalar, x *Scalar) {
+	if len(p.Coefficients) == 0 {
+		*out = ScalarZero // zero polynomial
+		return
+	}
 	// TODO WARNING: using pointsToSum[0][0] assumes that the slice is contiguous
 	myref10.Crypto_ed25519_polynomial_evaluation(&out[0], &p.Coefficients[0][0], len(p.Coefficients)-1, &x[0])
 }
@@ -91,7 +95,16 @@ func RandomScalarChacha20C(out *Scalar, key *Chacha20Key, nonce uint64) {
 }
 
 func RandomChacha20Key() (k Chacha20Key, err error) {
-	_, err = io.ReadFull(rand.Reader, k[:])
+	return RandomChacha20KeyFrom(rand.Reader)
+}
+
+// RandomChacha20KeyFrom is like RandomChacha20Key but reads the key from rnd
+// If rnd is nil, crypto/rand is used
+func RandomChacha20KeyFrom(rnd io.Reader) (k Chacha20Key, err error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	_, err = io.ReadFull(rnd, k[:])
 	if err != nil {
 		return [32]byte{}, err
 	}
@@ -2,6 +2,7 @@ package curve25519
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/shaih/go-yosovss/primitives/curve25519/myref10"
 )
@@ -73,7 +74,13 @@ func ScalarMatrixMul(mat1 *ScalarMatrix, mat2 *ScalarMatrix) (*ScalarMatrix, err
 }
 
 func (m *ScalarMatrix) Random() error {
-	key, err := RandomChacha20Key()
+	return m.RandomFrom(nil)
+}
+
+// RandomFrom is like Random but uses rnd as randomness source
+// If rnd is nil, system randomness is used
+func (m *ScalarMatrix) RandomFrom(rnd io.Reader) error {
+	key, err := RandomChacha20KeyFrom(rnd)
 	if err != nil {
 		return err
 	}
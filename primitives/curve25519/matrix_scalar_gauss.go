@@ -0,0 +1,194 @@
+package curve25519
+
+import "fmt"
+
+// This file contains linear algebra over scalars (i.e., modulo the order L of the main subgroup)
+// based on Gaussian elimination.
+// None of these functions are constant-time. They should only be used on public matrices.
+
+// NewScalarMatrixIdentity creates the n x n identity matrix
+func NewScalarMatrixIdentity(n int) *ScalarMatrix {
+	m := NewScalarMatrix(n, n)
+	for i := 0; i < n; i++ {
+		m.Set(i, i, &ScalarOne)
+	}
+	return m
+}
+
+// Copy returns a deep copy of the matrix
+func (m *ScalarMatrix) Copy() *ScalarMatrix {
+	entries := make([]Scalar, len(m.entries))
+	copy(entries, m.entries)
+	return NewScalarMatrixFromEntries(m.rows, m.columns, entries)
+}
+
+// Transpose returns the transpose of the matrix
+func (m *ScalarMatrix) Transpose() *ScalarMatrix {
+	res := NewScalarMatrix(m.columns, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.columns; j++ {
+			res.Set(j, i, m.At(i, j))
+		}
+	}
+	return res
+}
+
+// reducedRowEchelon computes the reduced row echelon form of the matrix
+// only using the first columns columns as pivot candidates
+// (the remaining columns are just transformed alongside, e.g., for augmented matrices)
+// It returns the reduced matrix and the list of pivot columns (pivots[r] is the pivot column of row r)
+func (m *ScalarMatrix) reducedRowEchelon(columns int) (red *ScalarMatrix, pivots []int, err error) {
+	red = m.Copy()
+	pivots = make([]int, 0, m.rows)
+
+	r := 0 // current row
+	for c := 0; c < columns && r < red.rows; c++ {
+		// Find a non-zero entry in column c at or below row r
+		p := -1
+		for i := r; i < red.rows; i++ {
+			if !ScalarEqual(red.At(i, c), &ScalarZero) {
+				p = i
+				break
+			}
+		}
+		if p < 0 {
+			continue // no pivot in this column
+		}
+
+		// Swap rows p and r
+		if p != r {
+			for j := 0; j < red.columns; j++ {
+				x := *red.At(p, j)
+				red.Set(p, j, red.At(r, j))
+				red.Set(r, j, &x)
+			}
+		}
+
+		// Normalize row r so that the pivot is 1
+		inv, err := InvertScalar(red.At(r, c))
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to invert pivot (%d,%d): %w", r, c, err)
+		}
+		for j := c; j < red.columns; j++ {
+			red.Set(r, j, MultScalar(red.At(r, j), inv))
+		}
+
+		// Eliminate column c from all the other rows
+		for i := 0; i < red.rows; i++ {
+			if i == r || ScalarEqual(red.At(i, c), &ScalarZero) {
+				continue
+			}
+			f := *red.At(i, c)
+			for j := c; j < red.columns; j++ {
+				red.Set(i, j, SubScalar(red.At(i, j), MultScalar(&f, red.At(r, j))))
+			}
+		}
+
+		pivots = append(pivots, c)
+		r++
+	}
+
+	return red, pivots, nil
+}
+
+// Rank returns the rank of the matrix
+func (m *ScalarMatrix) Rank() (int, error) {
+	_, pivots, err := m.reducedRowEchelon(m.columns)
+	if err != nil {
+		return 0, err
+	}
+	return len(pivots), nil
+}
+
+// Kernel returns a matrix whose columns form a basis of the (right) kernel of m,
+// i.e., of the vectors x such that m * x = 0
+// The result has size m.Columns() x (m.Columns() - rank)
+// and may thus have 0 columns if the kernel is trivial
+func (m *ScalarMatrix) Kernel() (*ScalarMatrix, error) {
+	red, pivots, err := m.reducedRowEchelon(m.columns)
+	if err != nil {
+		return nil, err
+	}
+
+	isPivot := make([]bool, m.columns)
+	for _, c := range pivots {
+		isPivot[c] = true
+	}
+
+	ker := NewScalarMatrix(m.columns, m.columns-len(pivots))
+	k := 0
+	for f := 0; f < m.columns; f++ {
+		if isPivot[f] {
+			continue
+		}
+		// basis vector for the free column f: x_f = 1, x_{pivots[r]} = -red[r][f]
+		ker.Set(f, k, &ScalarOne)
+		for r, c := range pivots {
+			ker.Set(c, k, NegateScalar(red.At(r, f)))
+		}
+		k++
+	}
+
+	return ker, nil
+}
+
+// ScalarMatrixSolve returns a matrix x such that a * x = b
+// If there are multiple solutions, the one with all free variables set to 0 is returned
+// Returns an error if there is no solution
+func ScalarMatrixSolve(a *ScalarMatrix, b *ScalarMatrix) (*ScalarMatrix, error) {
+	if a.rows != b.rows {
+		return nil, fmt.Errorf("incorrect size for solving: %d != %d", a.rows, b.rows)
+	}
+
+	// Augmented matrix [a | b]
+	aug := NewScalarMatrix(a.rows, a.columns+b.columns)
+	for i := 0; i < a.rows; i++ {
+		for j := 0; j < a.columns; j++ {
+			aug.Set(i, j, a.At(i, j))
+		}
+		for j := 0; j < b.columns; j++ {
+			aug.Set(i, a.columns+j, b.At(i, j))
+		}
+	}
+
+	red, pivots, err := aug.reducedRowEchelon(a.columns)
+	if err != nil {
+		return nil, err
+	}
+
+	// The system is inconsistent iff a row without pivot has a non-zero right-hand side
+	for i := len(pivots); i < red.rows; i++ {
+		for j := 0; j < b.columns; j++ {
+			if !ScalarEqual(red.At(i, a.columns+j), &ScalarZero) {
+				return nil, fmt.Errorf("the linear system has no solution")
+			}
+		}
+	}
+
+	x := NewScalarMatrix(a.columns, b.columns)
+	for r, c := range pivots {
+		for j := 0; j < b.columns; j++ {
+			x.Set(c, j, red.At(r, a.columns+j))
+		}
+	}
+
+	return x, nil
+}
+
+// Inverse returns the inverse of a square matrix
+// Returns an error if the matrix is not square or not invertible
+func (m *ScalarMatrix) Inverse() (*ScalarMatrix, error) {
+	if m.rows != m.columns {
+		return nil, fmt.Errorf("only square matrices can be inverted: %d != %d", m.rows, m.columns)
+	}
+
+	rank, err := m.Rank()
+	if err != nil {
+		return nil, err
+	}
+	if rank != m.rows {
+		return nil, fmt.Errorf("matrix is not invertible: rank %d < %d", rank, m.rows)
+	}
+
+	return ScalarMatrixSolve(m, NewScalarMatrixIdentity(m.rows))
+}
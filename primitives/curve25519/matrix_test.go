@@ -151,3 +151,106 @@ func TestEncodeDecode(t *testing.T) {
 		t.Errorf("decoded matrix does not match original matrix")
 	}
 }
+
+func TestMatrixRankKernel(t *testing.T) {
+	// third row is the sum of the first two
+	mat := &ScalarMatrix{
+		rows:    3,
+		columns: 4,
+		entries: []Scalar{
+			*GetScalar(1), *GetScalar(2), *GetScalar(3), *GetScalar(4),
+			*GetScalar(2), *GetScalar(7), *GetScalar(1), *GetScalar(8),
+			*GetScalar(3), *GetScalar(9), *GetScalar(4), *GetScalar(12),
+		},
+	}
+
+	rank, err := mat.Rank()
+	if err != nil {
+		t.Error(err)
+	}
+	if rank != 2 {
+		t.Errorf("incorrect rank: expected 2 but got %d", rank)
+	}
+
+	ker, err := mat.Kernel()
+	if err != nil {
+		t.Error(err)
+	}
+	if ker.Rows() != 4 || ker.Columns() != 2 {
+		t.Errorf("incorrect kernel size %dx%d", ker.Rows(), ker.Columns())
+	}
+	prod, err := ScalarMatrixMul(mat, ker)
+	if err != nil {
+		t.Error(err)
+	}
+	if !prod.IsZero() {
+		t.Errorf("kernel vectors are not in the kernel")
+	}
+	rank, err = ker.Rank()
+	if err != nil {
+		t.Error(err)
+	}
+	if rank != 2 {
+		t.Errorf("kernel vectors are not linearly independent")
+	}
+
+	// Full-rank matrix has trivial kernel
+	ker, err = NewScalarMatrixIdentity(3).Kernel()
+	if err != nil {
+		t.Error(err)
+	}
+	if ker.Columns() != 0 {
+		t.Errorf("identity matrix must have a trivial kernel")
+	}
+}
+
+func TestMatrixSolveInverse(t *testing.T) {
+	mat := NewScalarMatrix(5, 5)
+	err := mat.Random()
+	if err != nil {
+		t.Error(err)
+	}
+
+	inv, err := mat.Inverse()
+	if err != nil {
+		t.Error(err)
+	}
+	prod, err := ScalarMatrixMul(mat, inv)
+	if err != nil {
+		t.Error(err)
+	}
+	if !ScalarMatrixEqual(prod, NewScalarMatrixIdentity(5)) {
+		t.Errorf("incorrect inverse")
+	}
+
+	x := NewScalarMatrix(5, 2)
+	err = x.Random()
+	if err != nil {
+		t.Error(err)
+	}
+	b, err := ScalarMatrixMul(mat, x)
+	if err != nil {
+		t.Error(err)
+	}
+	sol, err := ScalarMatrixSolve(mat, b)
+	if err != nil {
+		t.Error(err)
+	}
+	if !ScalarMatrixEqual(x, sol) {
+		t.Errorf("incorrect solution of linear system")
+	}
+
+	// Singular matrix: last row is 0
+	for j := 0; j < 5; j++ {
+		mat.Set(4, j, &ScalarZero)
+	}
+	_, err = mat.Inverse()
+	if err == nil {
+		t.Errorf("singular matrix must not be invertible")
+	}
+	b.Set(4, 0, &ScalarOne)
+	_, err = ScalarMatrixSolve(mat, b)
+	if err == nil {
+		t.Errorf("inconsistent system must not have a solution")
+	}
+}
@@ -5,7 +5,9 @@ import "C"
 
 import (
 	"fmt"
+	"io"
 	"log"
+	"unsafe"
 )
 
 func init() {
@@ -61,8 +63,52 @@ func Encrypt(pk PublicKey, m Message) (Ciphertext, error) {
 	return Ciphertext(c), nil
 }
 
+// EncryptFrom is like Encrypt but uses rnd as randomness source for the ephemeral key
+// The ciphertext has the same format as the one of Encrypt (crypto_box_seal)
+// and can be decrypted with Decrypt
+// If rnd is nil, it is the same as Encrypt
+func EncryptFrom(rnd io.Reader, pk PublicKey, m Message) (Ciphertext, error) {
+	if rnd == nil {
+		return Encrypt(pk, m)
+	}
+
+	// Generate the ephemeral key pair from a seed read from rnd
+	var seed [C.crypto_box_SEEDBYTES]byte
+	_, err := io.ReadFull(rnd, seed[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read randomness: %w", err)
+	}
+	var epk PublicKey
+	var esk PrivateKey
+	C.crypto_box_seed_keypair((*C.uchar)(&epk[0]), (*C.uchar)(&esk[0]), (*C.uchar)(&seed[0]))
+	defer C.sodium_memzero(unsafe.Pointer(&esk[0]), C.size_t(len(esk)))
+
+	// Nonce is Blake2b(epk || pk) as in crypto_box_seal
+	var nonce [C.crypto_box_NONCEBYTES]byte
+	nonceIn := make([]byte, 0, len(epk)+len(pk))
+	nonceIn = append(nonceIn, epk[:]...)
+	nonceIn = append(nonceIn, pk[:]...)
+	C.crypto_generichash(
+		(*C.uchar)(&nonce[0]), C.size_t(len(nonce)), (*C.uchar)(&nonceIn[0]), C.ulonglong(len(nonceIn)), nil, 0)
+
+	// Ciphertext is epk || crypto_box_easy(m)
+	c := make([]byte, len(m)+C.crypto_box_SEALBYTES)
+	copy(c, epk[:])
+	result := C.crypto_box_easy(
+		(*C.uchar)(&c[len(epk)]), (*C.uchar)(&m[0]), C.ulonglong(len(m)),
+		(*C.uchar)(&nonce[0]), (*C.uchar)(&pk[0]), (*C.uchar)(&esk[0]))
+	if result != 0 {
+		return Ciphertext(c), fmt.Errorf("failed to perform encryption: %d", result)
+	}
+	return Ciphertext(c), nil
+}
+
 // Decrypt uses the private key to decrypt the ciphertext and produce a message
+// It returns an error (and never panics) if the ciphertext is too short to be valid
 func Decrypt(pk PublicKey, sk PrivateKey, c Ciphertext) (Message, error) {
+	if len(c) <= C.crypto_box_SEALBYTES {
+		return nil, fmt.Errorf("failed to perform decryption: ciphertext too short")
+	}
 	m := make([]byte, len(c)-C.crypto_box_SEALBYTES)
 
 	result := C.crypto_box_seal_open(
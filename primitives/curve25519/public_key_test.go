@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEncryption(t *testing.T) {
@@ -50,6 +51,12 @@ func TestDecryption(t *testing.T) {
 
 	_, err = Decrypt(pk1, sk1, c)
 	assert.Error(t, err, "Decryption errors with modified ciphertext")
+
+	// Truncated ciphertexts (crypto_box_SEALBYTES = 48)
+	for _, l := range []int{0, 1, 48, 49} {
+		_, err = Decrypt(pk1, sk1, c[:l])
+		assert.Errorf(t, err, "Decryption errors with ciphertext of length %d", l)
+	}
 }
 
 func TestSignature(t *testing.T) {
@@ -67,3 +74,25 @@ func TestSignature(t *testing.T) {
 
 	assert.True(t, Verify(pk, m, sig), "Signature and verification are consistent")
 }
+
+func TestEncryptionFrom(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	pk, sk := GenerateKeys()
+	m := Message([]byte("deterministic encryption test message"))
+
+	c1, err := EncryptFrom(NewDeterministicRandom([]byte("seed")), pk, m)
+	require.NoError(err)
+	c2, err := EncryptFrom(NewDeterministicRandom([]byte("seed")), pk, m)
+	require.NoError(err)
+	c3, err := EncryptFrom(NewDeterministicRandom([]byte("other seed")), pk, m)
+	require.NoError(err)
+
+	assert.Equal(c1, c2, "same seed gives same ciphertext")
+	assert.NotEqual(c1, c3, "different seeds give different ciphertexts")
+
+	dec, err := Decrypt(pk, sk, c1)
+	require.NoError(err)
+	assert.Equal(m, dec, "EncryptFrom and Decrypt are consistent")
+}
@@ -0,0 +1,59 @@
+package curve25519
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"io"
+)
+
+// Functions taking a randomness source rnd (an io.Reader) use the system randomness when rnd is nil,
+// i.e., the one of the corresponding function without rnd:
+// libsodium for RandomScalarFrom, RandomPointFrom, and EncryptFrom (see RandomScalar, RandomPoint, and Encrypt)
+// and crypto/rand for RandomChacha20KeyFrom
+// The same holds for all the functions and protocols built on them (they say "system randomness if nil")
+
+// DeterministicRandom is a deterministic randomness source (an io.Reader)
+// seeded by an arbitrary seed.
+// Two DeterministicRandom with the same seed output the same stream of bytes.
+// It is meant to be used as a randomness source for known-answer tests
+// and bit-exact reproductions of protocol runs.
+// The stream is SHA512(seed || 0) || SHA512(seed || 1) || ... where the counter is 8-byte little-endian
+type DeterministicRandom struct {
+	seed    []byte
+	counter uint64
+	buf     []byte // remaining bytes of the current block
+}
+
+// NewDeterministicRandom creates a deterministic randomness source from a seed
+func NewDeterministicRandom(seed []byte) *DeterministicRandom {
+	s := make([]byte, len(seed))
+	copy(s, seed)
+	return &DeterministicRandom{seed: s}
+}
+
+// Read fills p with the next len(p) bytes of the stream. It never fails.
+func (r *DeterministicRandom) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.buf) == 0 {
+			r.nextBlock()
+		}
+		k := copy(p[n:], r.buf)
+		r.buf = r.buf[k:]
+		n += k
+	}
+	return n, nil
+}
+
+func (r *DeterministicRandom) nextBlock() {
+	h := sha512.New()
+	h.Write(r.seed)
+	var c [8]byte
+	binary.LittleEndian.PutUint64(c[:], r.counter)
+	h.Write(c[:])
+	r.buf = h.Sum(nil)
+	r.counter++
+}
+
+// check DeterministicRandom implements io.Reader
+var _ io.Reader = (*DeterministicRandom)(nil)
@@ -0,0 +1,56 @@
+package curve25519
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeterministicRandom(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// Reading in one go or in pieces gives the same stream
+	r1 := NewDeterministicRandom([]byte("seed"))
+	b1 := make([]byte, 200)
+	_, err := r1.Read(b1)
+	require.NoError(err)
+
+	r2 := NewDeterministicRandom([]byte("seed"))
+	b2 := make([]byte, 0, 200)
+	for _, l := range []int{1, 63, 64, 72} {
+		b := make([]byte, l)
+		_, err = r2.Read(b)
+		require.NoError(err)
+		b2 = append(b2, b...)
+	}
+	assert.Equal(b1, b2)
+
+	// Different seeds give different streams
+	r3 := NewDeterministicRandom([]byte("other seed"))
+	b3 := make([]byte, 200)
+	_, err = r3.Read(b3)
+	require.NoError(err)
+	assert.NotEqual(b1, b3)
+
+	// Random scalars, points, and keys are reproducible
+	s1, err := RandomScalarFrom(NewDeterministicRandom([]byte("seed")))
+	require.NoError(err)
+	s2, err := RandomScalarFrom(NewDeterministicRandom([]byte("seed")))
+	require.NoError(err)
+	assert.Equal(s1, s2)
+
+	p1, err := RandomPointFrom(NewDeterministicRandom([]byte("seed")))
+	require.NoError(err)
+	p2, err := RandomPointFrom(NewDeterministicRandom([]byte("seed")))
+	require.NoError(err)
+	assert.Equal(p1, p2)
+	assert.True(IsValidPoint(p1))
+
+	m1 := NewScalarMatrix(3, 4)
+	require.NoError(m1.RandomFrom(NewDeterministicRandom([]byte("seed"))))
+	m2 := NewScalarMatrix(3, 4)
+	require.NoError(m2.RandomFrom(NewDeterministicRandom([]byte("seed"))))
+	assert.Equal(m1, m2)
+}
@@ -21,6 +21,10 @@ func (p *Polynomial) Degree() int {
 func (p *Polynomial) EvaluateNaive(x *Scalar) *Scalar {
 	degree := p.Degree()
 	evaluation := &Scalar{}
+	if degree < 0 {
+		*evaluation = ScalarZero
+		return evaluation
+	}
 	*evaluation = p.Coefficients[degree]
 	for i := degree - 1; i >= 0; i-- {
 		evaluation = MultScalar(evaluation, x)
@@ -52,3 +56,205 @@ func LagrangeCoeffs(coords []Scalar, x *Scalar) ([]Scalar, error) {
 	}
 	return lambdas, nil
 }
+
+// NewPolynomial creates a polynomial from its coefficients (constant term first)
+// The coefficients are copied
+func NewPolynomial(coefficients []Scalar) *Polynomial {
+	p := &Polynomial{
+		Coefficients: make([]Scalar, len(coefficients)),
+	}
+	copy(p.Coefficients, coefficients)
+	return p
+}
+
+// IsZero returns true if p is the zero polynomial
+// (including when p has no coefficients at all)
+func (p *Polynomial) IsZero() bool {
+	for i := range p.Coefficients {
+		if !ScalarEqual(&p.Coefficients[i], &ScalarZero) {
+			return false
+		}
+	}
+	return true
+}
+
+// trimmed returns a polynomial with the same coefficients as p
+// but without the leading zero coefficients
+// The zero polynomial is returned with no coefficients
+func (p *Polynomial) trimmed() *Polynomial {
+	if p.IsZero() {
+		return &Polynomial{Coefficients: []Scalar{}}
+	}
+	return NewPolynomial(p.Coefficients[:p.Degree()+1])
+}
+
+// AddPolynomial computes the sum of two polynomials
+func AddPolynomial(p, q *Polynomial) *Polynomial {
+	n := len(p.Coefficients)
+	if len(q.Coefficients) > n {
+		n = len(q.Coefficients)
+	}
+
+	r := &Polynomial{Coefficients: make([]Scalar, n)}
+	for i := 0; i < n; i++ {
+		switch {
+		case i >= len(p.Coefficients):
+			r.Coefficients[i] = q.Coefficients[i]
+		case i >= len(q.Coefficients):
+			r.Coefficients[i] = p.Coefficients[i]
+		default:
+			r.Coefficients[i] = *AddScalar(&p.Coefficients[i], &q.Coefficients[i])
+		}
+	}
+	return r.trimmed()
+}
+
+// SubPolynomial computes the difference p - q of two polynomials
+func SubPolynomial(p, q *Polynomial) *Polynomial {
+	return AddPolynomial(p, NegatePolynomial(q))
+}
+
+// NegatePolynomial computes the additive inverse of a polynomial
+func NegatePolynomial(p *Polynomial) *Polynomial {
+	r := &Polynomial{Coefficients: make([]Scalar, len(p.Coefficients))}
+	for i := range p.Coefficients {
+		r.Coefficients[i] = *NegateScalar(&p.Coefficients[i])
+	}
+	return r
+}
+
+// MultPolynomialScalar computes the product of a polynomial by a scalar
+func MultPolynomialScalar(p *Polynomial, x *Scalar) *Polynomial {
+	r := &Polynomial{Coefficients: make([]Scalar, len(p.Coefficients))}
+	for i := range p.Coefficients {
+		r.Coefficients[i] = *MultScalar(&p.Coefficients[i], x)
+	}
+	return r.trimmed()
+}
+
+// MultPolynomial computes the product of two polynomials
+// Uses schoolbook multiplication
+func MultPolynomial(p, q *Polynomial) *Polynomial {
+	pp := p.trimmed()
+	qq := q.trimmed()
+	if len(pp.Coefficients) == 0 || len(qq.Coefficients) == 0 {
+		return &Polynomial{Coefficients: []Scalar{}}
+	}
+
+	r := &Polynomial{Coefficients: make([]Scalar, len(pp.Coefficients)+len(qq.Coefficients)-1)}
+	for i := range pp.Coefficients {
+		for j := range qq.Coefficients {
+			x := MultScalar(&pp.Coefficients[i], &qq.Coefficients[j])
+			r.Coefficients[i+j] = *AddScalar(&r.Coefficients[i+j], x)
+		}
+	}
+	return r
+}
+
+// DivPolynomial computes the Euclidean division of a by b
+// It returns the quotient q and the remainder r such that
+// a = q * b + r and deg(r) < deg(b)
+// Returns an error if b is the zero polynomial
+func DivPolynomial(a, b *Polynomial) (q *Polynomial, r *Polynomial, err error) {
+	bb := b.trimmed()
+	if len(bb.Coefficients) == 0 {
+		return nil, nil, fmt.Errorf("division by the zero polynomial")
+	}
+
+	r = a.trimmed()
+	degB := len(bb.Coefficients) - 1
+	if len(r.Coefficients)-1 < degB {
+		return &Polynomial{Coefficients: []Scalar{}}, r, nil
+	}
+
+	leadInv, err := InvertScalar(&bb.Coefficients[degB])
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to invert leading coefficient: %w", err)
+	}
+
+	q = &Polynomial{Coefficients: make([]Scalar, len(r.Coefficients)-degB)}
+	for k := len(r.Coefficients) - 1; k >= degB; k-- {
+		// coefficient of the quotient for X^(k-degB)
+		c := MultScalar(&r.Coefficients[k], leadInv)
+		q.Coefficients[k-degB] = *c
+		// r = r - c X^(k-degB) b
+		for i := 0; i <= degB; i++ {
+			x := MultScalar(c, &bb.Coefficients[i])
+			r.Coefficients[k-degB+i] = *SubScalar(&r.Coefficients[k-degB+i], x)
+		}
+	}
+
+	return q.trimmed(), r.trimmed(), nil
+}
+
+// Derivative computes the formal derivative of the polynomial p
+func (p *Polynomial) Derivative() *Polynomial {
+	if len(p.Coefficients) <= 1 {
+		return &Polynomial{Coefficients: []Scalar{}}
+	}
+
+	r := &Polynomial{Coefficients: make([]Scalar, len(p.Coefficients)-1)}
+	for i := 1; i < len(p.Coefficients); i++ {
+		r.Coefficients[i-1] = *MultScalar(&p.Coefficients[i], GetScalar(uint64(i)))
+	}
+	return r.trimmed()
+}
+
+// EvaluateMulti evaluates the polynomial p at all the points xs
+// evaluations[k] = p(xs[k])
+func (p *Polynomial) EvaluateMulti(xs []Scalar) (evaluations []Scalar) {
+	evaluations = make([]Scalar, len(xs))
+	for k := range xs {
+		p.EvaluateC(&evaluations[k], &xs[k])
+	}
+	return evaluations
+}
+
+// vanishingPolynomial computes the polynomial prod_k (X - xs[k])
+func vanishingPolynomial(xs []Scalar) *Polynomial {
+	v := &Polynomial{Coefficients: []Scalar{ScalarOne}}
+	for k := range xs {
+		v = MultPolynomial(v, &Polynomial{Coefficients: []Scalar{*NegateScalar(&xs[k]), ScalarOne}})
+	}
+	return v
+}
+
+// InterpolatePolynomial computes the unique polynomial p of degree < len(xs)
+// such that p(xs[k]) = ys[k] for all k
+// Returns an error if the xs are not distinct or if xs and ys have different lengths
+func InterpolatePolynomial(xs []Scalar, ys []Scalar) (*Polynomial, error) {
+	if len(xs) != len(ys) {
+		return nil, fmt.Errorf("xs and ys do not have the same length")
+	}
+
+	// v = prod_k (X - xs[k]) and its derivative that gives the denominators
+	// of the Lagrange polynomials: prod_{k != i} (xs[i] - xs[k]) = v'(xs[i])
+	v := vanishingPolynomial(xs)
+	denoms := v.Derivative().EvaluateMulti(xs)
+
+	p := &Polynomial{Coefficients: make([]Scalar, len(xs))}
+	for i := range xs {
+		if ScalarEqual(&denoms[i], &ScalarZero) {
+			return nil, fmt.Errorf("interpolation points are not distinct")
+		}
+		denomInv, err := InvertScalar(&denoms[i])
+		if err != nil {
+			return nil, fmt.Errorf("unable to invert denominator for term %d: %w", i, err)
+		}
+
+		// li = v / (X - xs[i])
+		li, _, err := DivPolynomial(v, &Polynomial{Coefficients: []Scalar{*NegateScalar(&xs[i]), ScalarOne}})
+		if err != nil {
+			return nil, err
+		}
+
+		// p += ys[i] / v'(xs[i]) * li
+		c := MultScalar(&ys[i], denomInv)
+		for k := range li.Coefficients {
+			x := MultScalar(c, &li.Coefficients[k])
+			p.Coefficients[k] = *AddScalar(&p.Coefficients[k], x)
+		}
+	}
+
+	return p.trimmed(), nil
+}
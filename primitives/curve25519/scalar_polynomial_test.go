@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDegree(t *testing.T) {
@@ -44,3 +45,97 @@ func TestLagrangeCoeffs(t *testing.T) {
 	assert.Equal(t, expectedLambdas, lambdas)
 
 }
+
+func randomPolynomial(d int) *Polynomial {
+	p := &Polynomial{Coefficients: make([]Scalar, d+1)}
+	for i := 0; i <= d; i++ {
+		p.Coefficients[i] = *RandomScalar()
+	}
+	return p
+}
+
+func TestPolynomialAddSubMult(t *testing.T) {
+	assert := assert.New(t)
+
+	p := randomPolynomial(5)
+	q := randomPolynomial(3)
+	x := RandomScalar()
+
+	px := p.Evaluate(x)
+	qx := q.Evaluate(x)
+
+	assert.Equal(*AddScalar(px, qx), *AddPolynomial(p, q).Evaluate(x))
+	assert.Equal(*SubScalar(px, qx), *SubPolynomial(p, q).Evaluate(x))
+	assert.Equal(*MultScalar(px, qx), *MultPolynomial(p, q).Evaluate(x))
+	assert.Equal(8, MultPolynomial(p, q).Degree())
+
+	// p - p is the zero polynomial
+	assert.True(SubPolynomial(p, p).IsZero())
+	assert.Equal(-1, SubPolynomial(p, p).Degree())
+	assert.Equal(ScalarZero, *SubPolynomial(p, p).Evaluate(x))
+}
+
+func TestPolynomialDiv(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	a := randomPolynomial(7)
+	b := randomPolynomial(3)
+
+	q, r, err := DivPolynomial(a, b)
+	require.NoError(err)
+	assert.Equal(4, q.Degree())
+	assert.Less(r.Degree(), b.Degree())
+
+	// a = q * b + r
+	assert.True(SubPolynomial(a, AddPolynomial(MultPolynomial(q, b), r)).IsZero())
+
+	// exact division
+	q, r, err = DivPolynomial(MultPolynomial(a, b), b)
+	require.NoError(err)
+	assert.True(r.IsZero())
+	assert.True(SubPolynomial(q, a).IsZero())
+
+	// division by zero
+	_, _, err = DivPolynomial(a, &Polynomial{Coefficients: []Scalar{ScalarZero}})
+	assert.Error(err)
+}
+
+func TestPolynomialDerivative(t *testing.T) {
+	assert := assert.New(t)
+
+	// (1 + 2X + 3X^2)' = 2 + 6X
+	p := NewPolynomial([]Scalar{*GetScalar(1), *GetScalar(2), *GetScalar(3)})
+	assert.Equal([]Scalar{*GetScalar(2), *GetScalar(6)}, p.Derivative().Coefficients)
+
+	assert.True(NewPolynomial([]Scalar{*GetScalar(5)}).Derivative().IsZero())
+}
+
+func TestPolynomialInterpolateEvaluateMulti(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	p := randomPolynomial(6)
+	xs := make([]Scalar, 7)
+	for i := range xs {
+		xs[i] = *RandomScalar()
+	}
+
+	ys := p.EvaluateMulti(xs)
+	for i := range xs {
+		assert.Equal(*p.Evaluate(&xs[i]), ys[i])
+	}
+
+	q, err := InterpolatePolynomial(xs, ys)
+	require.NoError(err)
+	assert.Equal(p.Coefficients, q.Coefficients)
+
+	// non-distinct points
+	xs[1] = xs[0]
+	_, err = InterpolatePolynomial(xs, ys)
+	assert.Error(err)
+
+	// different lengths
+	_, err = InterpolatePolynomial(xs, ys[1:])
+	assert.Error(err)
+}
@@ -60,7 +60,11 @@ func SymmetricEncrypt(key Key, nonce Nonce, m Message) (SymmetricCiphertext, err
 }
 
 // SymmetricDecrypt uses the symmetric key and nonce to decrypt the ciphertext and produce the original message
+// It returns an error (and never panics) if the ciphertext is too short to be valid
 func SymmetricDecrypt(key Key, nonce Nonce, c SymmetricCiphertext) (Message, error) {
+	if len(c) <= C.crypto_secretbox_MACBYTES {
+		return nil, fmt.Errorf("failed to perform symmetric decryption: ciphertext too short")
+	}
 	m := make([]byte, len(c)-C.crypto_box_MACBYTES)
 
 	result := C.crypto_secretbox_open_easy((*C.uchar)(&m[0]), (*C.uchar)(&c[0]), C.ulonglong(len(c)), (*C.uchar)(&nonce[0]), (*C.uchar)(&key[0]))
@@ -53,4 +53,10 @@ func TestSymmetricDecryption(t *testing.T) {
 
 	_, err = SymmetricDecrypt(key1, nonce1, c)
 	assert.Error(t, err, "Symmetric decryption errors with modified ciphertext")
+
+	// Truncated ciphertexts (crypto_secretbox_MACBYTES = 16)
+	for _, l := range []int{0, 1, 16, 17} {
+		_, err = SymmetricDecrypt(key1, nonce1, c[:l])
+		assert.Errorf(t, err, "Symmetric decryption errors with ciphertext of length %d", l)
+	}
 }
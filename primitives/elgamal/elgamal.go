@@ -0,0 +1,274 @@
+package elgamal
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+)
+
+// This package implements an exponential ElGamal encryption of scalars
+// that is compatible with linear NIZK proofs (see primitives/nizk)
+//
+// A key pair is (sk, PK = sk G) where G is the base curve25519.BaseXYG
+// A scalar m is split into NumChunks chunks m_0,...,m_{NumChunks-1} of ChunkBits bits
+// (m = sum_k 2^{ChunkBits k} m_k) and each chunk is encrypted independently:
+//    R_k = r_k G
+//    E_k = m_k G + r_k PK
+// Decryption computes m_k G = E_k - sk R_k and recovers m_k by baby-step giant-step,
+// which is efficient because m_k is small
+//
+// The chunks can be recombined homomorphically (see Ciphertext.Aggregate) into
+//    R = r G
+//    E = m G + r PK
+// where r = sum_k 2^{ChunkBits k} r_k is the aggregated randomness,
+// which is a linear relation that can be proven (see nizk.NewVerifiableEncStatement)
+//
+// A proof on the aggregated ciphertext does not prove that the chunks are small:
+// a malicious encryptor can make the ciphertext impossible to decrypt
+// (but cannot make it decrypt to another message)
+// Decryption is guaranteed only if each chunk is also proven to be a ChunkBits-bit integer
+// (see EncryptChunksFrom and nizk.NewElGamalPedersenEqStatement)
+
+const (
+	// ChunkBits is the number of bits of each chunk
+	ChunkBits = 16
+	// NumChunks is the number of chunks of a scalar
+	NumChunks = 256 / ChunkBits
+
+	chunkBytes = ChunkBits / 8
+
+	// baby-step giant-step parameters: a chunk is i * 2^babyBits + j
+	// with j in [0, 2^babyBits) and i in [0, 2^(ChunkBits-babyBits))
+	babyBits = 12
+)
+
+// PublicKey is an ElGamal public key PK = sk G
+type PublicKey = curve25519.PointXY
+
+// PrivateKey is an ElGamal private key sk
+type PrivateKey = curve25519.Scalar
+
+// Ciphertext is the encryption of a scalar, chunk by chunk
+type Ciphertext struct {
+	R []curve25519.PointXY `codec:"R"` // R[k] = r_k G
+	E []curve25519.PointXY `codec:"E"` // E[k] = m_k G + r_k PK
+}
+
+// GenerateKeyFrom generates a key pair using the randomness source rnd
+// (system randomness if nil)
+func GenerateKeyFrom(rnd io.Reader) (*PublicKey, *PrivateKey, error) {
+	sk, err := curve25519.RandomScalarFrom(rnd)
+	if err != nil {
+		return nil, nil, err
+	}
+	pk, err := curve25519.MultBaseGPointXYScalar(sk)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pk, sk, nil
+}
+
+// chunkScalars[k] = 2^{ChunkBits k}
+var chunkScalars = func() []curve25519.Scalar {
+	s := make([]curve25519.Scalar, NumChunks)
+	for k := 0; k < NumChunks; k++ {
+		s[k][k*chunkBytes] = 1
+	}
+	return s
+}()
+
+// chunk returns the k-th chunk of m
+func chunk(m *curve25519.Scalar, k int) *curve25519.Scalar {
+	var c curve25519.Scalar
+	copy(c[:chunkBytes], m[k*chunkBytes:(k+1)*chunkBytes])
+	return &c
+}
+
+// EncryptFrom encrypts m under pk using the randomness source rnd (system randomness if nil)
+// It returns the ciphertext and the aggregated randomness r (see top of file),
+// which is the witness required to prove statements about the ciphertext
+func EncryptFrom(rnd io.Reader, pk *PublicKey, m *curve25519.Scalar) (*Ciphertext, *curve25519.Scalar, error) {
+	c, _, rs, err := EncryptChunksFrom(rnd, pk, m)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, AggregateRandomness(rs), nil
+}
+
+// EncryptChunksFrom is the same as EncryptFrom but returns the chunks m_k of m
+// and their randomness r_k (see top of file) instead of the aggregated randomness,
+// which are the witnesses required to prove statements about each chunk
+// (e.g., that the chunks are small, see nizk.NewElGamalPedersenEqStatement)
+func EncryptChunksFrom(
+	rnd io.Reader, pk *PublicKey, m *curve25519.Scalar,
+) (c *Ciphertext, chunks []curve25519.Scalar, rs []curve25519.Scalar, err error) {
+	chacha20Key, err := curve25519.RandomChacha20KeyFrom(rnd)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	c = &Ciphertext{
+		R: make([]curve25519.PointXY, NumChunks),
+		E: make([]curve25519.PointXY, NumChunks),
+	}
+	chunks = make([]curve25519.Scalar, NumChunks)
+	rs = make([]curve25519.Scalar, NumChunks)
+	for k := 0; k < NumChunks; k++ {
+		chunks[k] = *chunk(m, k)
+		curve25519.RandomScalarChacha20C(&rs[k], &chacha20Key, uint64(k))
+
+		r, err := curve25519.MultBaseGPointXYScalar(&rs[k])
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		c.R[k] = *r
+
+		e, err := curve25519.MultiMultPointXYScalar(
+			[]curve25519.PointXY{curve25519.BaseXYG, *pk},
+			[]curve25519.Scalar{chunks[k], rs[k]},
+		)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		c.E[k] = *e
+	}
+
+	return c, chunks, rs, nil
+}
+
+// AggregateRandomness returns the aggregated randomness r = sum_k 2^{ChunkBits k} r_k
+// of the randomness rs of the chunks (see top of file)
+func AggregateRandomness(rs []curve25519.Scalar) *curve25519.Scalar {
+	r := curve25519.ScalarZero
+	for k := range rs {
+		r = *curve25519.AddScalar(&r, curve25519.MultScalar(&chunkScalars[k], &rs[k]))
+	}
+	return &r
+}
+
+// CheckShape verifies that the ciphertext has the correct number of chunks
+// and that all its points are on the curve
+func (c *Ciphertext) CheckShape() error {
+	if len(c.R) != NumChunks || len(c.E) != NumChunks {
+		return fmt.Errorf("invalid number of chunks")
+	}
+	for k := 0; k < NumChunks; k++ {
+		if !curve25519.IsOnCurveXY(&c.R[k]) || !curve25519.IsOnCurveXY(&c.E[k]) {
+			return fmt.Errorf("chunk %d is not on the curve", k)
+		}
+	}
+	return nil
+}
+
+// Aggregate returns the aggregated ciphertext (R, E) = (r G, m G + r PK), see top of file
+// The shape of the ciphertext must have been checked before (see CheckShape)
+func (c *Ciphertext) Aggregate() (r *curve25519.PointXY, e *curve25519.PointXY, err error) {
+	r, err = curve25519.MultiMultPointXYScalarVarTime(c.R, chunkScalars)
+	if err != nil {
+		return nil, nil, err
+	}
+	e, err = curve25519.MultiMultPointXYScalarVarTime(c.E, chunkScalars)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r, e, nil
+}
+
+// Decrypt decrypts the ciphertext c using the private key sk
+// It returns an error if one of the chunks is not a ChunkBits-bit integer
+func Decrypt(sk *PrivateKey, c *Ciphertext) (*curve25519.Scalar, error) {
+	err := c.CheckShape()
+	if err != nil {
+		return nil, err
+	}
+
+	masks := make([]curve25519.PointXY, NumChunks)
+	for k := 0; k < NumChunks; k++ {
+		skR, err := curve25519.MultPointXYScalar(&c.R[k], sk)
+		if err != nil {
+			return nil, err
+		}
+		masks[k] = *skR
+	}
+	return DecryptWithMasks(c, masks)
+}
+
+// DecryptWithMasks decrypts the ciphertext c given the masks masks[k] = sk R_k
+// This allows decryption without knowing sk, e.g., when sk is secret-shared
+// and the masks are computed jointly
+// It returns an error if one of the chunks is not a ChunkBits-bit integer
+func DecryptWithMasks(c *Ciphertext, masks []curve25519.PointXY) (*curve25519.Scalar, error) {
+	err := c.CheckShape()
+	if err != nil {
+		return nil, err
+	}
+	if len(masks) != NumChunks {
+		return nil, fmt.Errorf("invalid number of masks")
+	}
+
+	var b [64]byte
+	for k := 0; k < NumChunks; k++ {
+		// mk = m_k G
+		mk, err := curve25519.SubPointXY(&c.E[k], &masks[k])
+		if err != nil {
+			return nil, err
+		}
+
+		x, err := smallDLog(mk)
+		if err != nil {
+			return nil, fmt.Errorf("chunk %d: %w", k, err)
+		}
+		b[k*chunkBytes] = byte(x)
+		b[k*chunkBytes+1] = byte(x >> 8)
+	}
+
+	// the recombined message may not be reduced if the encryptor is malicious
+	return curve25519.ReduceScalar(&b), nil
+}
+
+// babySteps maps j G to j for j in [0, 2^babyBits)
+// giantStep is 2^babyBits G
+var (
+	bsgsOnce  sync.Once
+	babySteps map[curve25519.PointXY]int
+	giantStep curve25519.PointXY
+	bsgsErr   error
+)
+
+func initBSGS() {
+	babySteps = make(map[curve25519.PointXY]int, 1<<babyBits)
+	p := curve25519.PointXYInfinity
+	for j := 0; j < 1<<babyBits; j++ {
+		babySteps[p] = j
+		q, err := curve25519.AddPointXY(&p, &curve25519.BaseXYG)
+		if err != nil {
+			bsgsErr = err
+			return
+		}
+		p = *q
+	}
+	giantStep = p
+}
+
+// smallDLog returns x in [0, 2^ChunkBits) such that p = x G
+func smallDLog(p *curve25519.PointXY) (int, error) {
+	bsgsOnce.Do(initBSGS)
+	if bsgsErr != nil {
+		return 0, bsgsErr
+	}
+
+	q := *p
+	for i := 0; i < 1<<(ChunkBits-babyBits); i++ {
+		if j, ok := babySteps[q]; ok {
+			return i<<babyBits + j, nil
+		}
+		r, err := curve25519.SubPointXY(&q, &giantStep)
+		if err != nil {
+			return 0, err
+		}
+		q = *r
+	}
+	return 0, fmt.Errorf("discrete logarithm is not small")
+}
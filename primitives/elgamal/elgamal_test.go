@@ -0,0 +1,128 @@
+package elgamal
+
+import (
+	"testing"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	pk, sk, err := GenerateKeyFrom(nil)
+	require.NoError(err)
+
+	for _, m := range []*curve25519.Scalar{
+		&curve25519.ScalarZero,
+		&curve25519.ScalarOne,
+		curve25519.NegateScalar(&curve25519.ScalarOne), // largest scalar
+		curve25519.RandomScalar(),
+	} {
+		c, _, err := EncryptFrom(nil, pk, m)
+		require.NoError(err)
+		require.NoError(c.CheckShape())
+
+		m2, err := Decrypt(sk, c)
+		require.NoError(err)
+		assert.Equal(*m, *m2)
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	pk, _, err := GenerateKeyFrom(nil)
+	require.NoError(err)
+
+	m := curve25519.RandomScalar()
+	c, r, err := EncryptFrom(nil, pk, m)
+	require.NoError(err)
+
+	aggR, aggE, err := c.Aggregate()
+	require.NoError(err)
+
+	// R = r G
+	expectedR, err := curve25519.MultBaseGPointXYScalar(r)
+	require.NoError(err)
+	assert.Equal(*expectedR, *aggR)
+
+	// E = m G + r PK
+	expectedE, err := curve25519.MultiMultPointXYScalar(
+		[]curve25519.PointXY{curve25519.BaseXYG, *pk}, []curve25519.Scalar{*m, *r})
+	require.NoError(err)
+	assert.Equal(*expectedE, *aggE)
+}
+
+func TestEncryptChunks(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	pk, sk, err := GenerateKeyFrom(nil)
+	require.NoError(err)
+
+	m := curve25519.RandomScalar()
+	c, chunks, rs, err := EncryptChunksFrom(nil, pk, m)
+	require.NoError(err)
+	require.Len(chunks, NumChunks)
+	require.Len(rs, NumChunks)
+
+	// m = sum_k 2^{ChunkBits k} m_k
+	recombined := curve25519.ScalarZero
+	for k := 0; k < NumChunks; k++ {
+		recombined = *curve25519.AddScalar(&recombined, curve25519.MultScalar(&chunkScalars[k], &chunks[k]))
+	}
+	assert.Equal(*m, recombined)
+
+	// R_k = r_k G and E_k = m_k G + r_k PK
+	for k := 0; k < NumChunks; k++ {
+		expectedR, err := curve25519.MultBaseGPointXYScalar(&rs[k])
+		require.NoError(err)
+		assert.Equal(*expectedR, c.R[k])
+		expectedE, err := curve25519.MultiMultPointXYScalar(
+			[]curve25519.PointXY{curve25519.BaseXYG, *pk}, []curve25519.Scalar{chunks[k], rs[k]})
+		require.NoError(err)
+		assert.Equal(*expectedE, c.E[k])
+	}
+
+	// the aggregated randomness matches the aggregated ciphertext
+	aggR, _, err := c.Aggregate()
+	require.NoError(err)
+	expectedR, err := curve25519.MultBaseGPointXYScalar(AggregateRandomness(rs))
+	require.NoError(err)
+	assert.Equal(*expectedR, *aggR)
+
+	m2, err := Decrypt(sk, c)
+	require.NoError(err)
+	assert.Equal(*m, *m2)
+}
+
+func TestDecryptInvalid(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	pk, sk, err := GenerateKeyFrom(nil)
+	require.NoError(err)
+	_, otherSK, err := GenerateKeyFrom(nil)
+	require.NoError(err)
+
+	c, _, err := EncryptFrom(nil, pk, curve25519.RandomScalar())
+	require.NoError(err)
+
+	// Wrong key: chunks are (with overwhelming probability) not small
+	_, err = Decrypt(otherSK, c)
+	assert.Error(err)
+
+	// Chunk that is not small
+	c.E[3] = *curve25519.RandomPointXY()
+	_, err = Decrypt(sk, c)
+	assert.Error(err)
+
+	// Missing chunk
+	c.E = c.E[1:]
+	_, err = Decrypt(sk, c)
+	assert.Error(err)
+}
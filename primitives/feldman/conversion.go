@@ -0,0 +1,98 @@
+package feldman
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/nizk"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+)
+
+// This file handles the conversion of Pedersen commitments to Feldman commitments
+//
+// Let C_0,...,C_n be Pedersen commitments to a value x_0 and its shares x_1,...,x_n,
+// that is, C_k = x_k G + r_k H (see vss.FixedRShare)
+// Holder of the share k publishes Y_k = x_k G with a proof that it is consistent with C_k
+// (see nizk.NewPedersenExpStatement)
+// Then anybody can compute all the Feldman commitments F_k = x_k G (including F_0 = x_0 G)
+// from any t+1 valid Y_k by Lagrange interpolation "in the exponent" (see InterpolateGCommitments)
+
+// ExpShare is a Feldman commitment Y = x G to the value x committed in a Pedersen commitment C
+// together with a proof of consistency
+type ExpShare struct {
+	Y    GCommitment          `codec:"Y"` // Y = x G
+	Com  []curve25519.PointXY `codec:"c"` // Com and Resp are the proof for nizk.NewPedersenExpStatement
+	Resp []curve25519.Scalar  `codec:"r"`
+}
+
+// ProveExpShare computes the Feldman commitment to the value x committed in c = x G + r H
+// and its proof of consistency
+// rnd is the randomness source (system randomness if nil)
+// t is the transcript of the proof (see nizk.Prove) and is modified by the function
+func ProveExpShare(
+	rnd io.Reader, t *transcript.Transcript, c *pedersen.Commitment, x, r *curve25519.Scalar,
+) (*ExpShare, error) {
+	y, err := curve25519.MultBaseGPointXYScalar(x)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := nizk.Prove(rnd, t, nizk.NewPedersenExpStatement(c, y), nizk.LinearWitness{*x, *r})
+	if err != nil {
+		return nil, err
+	}
+	return &ExpShare{Y: *y, Com: proof.Com, Resp: proof.Resp}, nil
+}
+
+// VerifyExpShare verifies that es is consistent with the Pedersen commitment c
+// t must be the same transcript as the one given to ProveExpShare
+// t is modified by the function
+func VerifyExpShare(t *transcript.Transcript, c *pedersen.Commitment, es *ExpShare) error {
+	if !curve25519.IsOnCurveXY(&es.Y) {
+		return fmt.Errorf("Feldman commitment not on the curve")
+	}
+	return nizk.Verify(t, nizk.NewPedersenExpStatement(c, &es.Y), &nizk.LinearProof{Com: es.Com, Resp: es.Resp})
+}
+
+// BatchAddExpShare is the same as VerifyExpShare but adds the verification to the item of the batch b
+func BatchAddExpShare(
+	b *nizk.Batch, item int, t *transcript.Transcript, c *pedersen.Commitment, es *ExpShare,
+) error {
+	if !curve25519.IsOnCurveXY(&es.Y) {
+		return fmt.Errorf("Feldman commitment not on the curve")
+	}
+	return b.AddProof(item, t, nizk.NewPedersenExpStatement(c, &es.Y), &nizk.LinearProof{Com: es.Com, Resp: es.Resp})
+}
+
+// InterpolateGCommitments returns the Feldman commitments f(k) G for all k in ks
+// from the Feldman commitments ys[q] = f(indices[q]) G
+// where f is a polynomial of degree at most len(indices)-1
+// The indices must be distinct
+func InterpolateGCommitments(indices []int, ys []GCommitment, ks []int) ([]GCommitment, error) {
+	if len(indices) != len(ys) {
+		return nil, fmt.Errorf("indices and Y do not have the same length")
+	}
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("no commitment to interpolate")
+	}
+
+	coords := make([]curve25519.Scalar, len(indices))
+	for q, i := range indices {
+		curve25519.GetScalarC(&coords[q], uint64(i))
+	}
+
+	fs := make([]GCommitment, len(ks))
+	for q, k := range ks {
+		coeffs, err := curve25519.LagrangeCoeffs(coords, curve25519.GetScalar(uint64(k)))
+		if err != nil {
+			return nil, err
+		}
+		f, err := curve25519.MultiMultPointXYScalarVarTime(ys, coeffs)
+		if err != nil {
+			return nil, err
+		}
+		fs[q] = *f
+	}
+	return fs, nil
+}
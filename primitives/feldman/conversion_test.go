@@ -0,0 +1,60 @@
+package feldman
+
+import (
+	"testing"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpShareConversion(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n = 5
+		d = 2
+	)
+
+	params, err := vss.NewVSSParams(pedersen.GenerateParams(), n, d)
+	require.NoError(err)
+	secret := curve25519.RandomScalar()
+	shares, commitments, err := vss.FixedRShare(params, secret, curve25519.RandomScalar())
+	require.NoError(err)
+
+	// Expected Feldman commitments
+	expected := make([]GCommitment, n+1)
+	f0, err := curve25519.MultBaseGPointXYScalar(secret)
+	require.NoError(err)
+	expected[0] = *f0
+	for k := 1; k <= n; k++ {
+		fk, err := curve25519.MultBaseGPointXYScalar(&shares[k-1].S)
+		require.NoError(err)
+		expected[k] = *fk
+	}
+
+	// Holders of the shares 2, 4, 5 convert their shares
+	indices := []int{2, 4, 5}
+	ys := make([]GCommitment, len(indices))
+	for q, i := range indices {
+		es, err := ProveExpShare(nil, transcript.New("test"), &commitments[i], &shares[i-1].S, &shares[i-1].R)
+		require.NoError(err)
+		require.NoError(VerifyExpShare(transcript.New("test"), &commitments[i], es))
+		// the share is not consistent with another commitment
+		assert.Error(VerifyExpShare(transcript.New("test"), &commitments[1], es))
+		ys[q] = es.Y
+	}
+
+	ks := []int{0, 1, 2, 3, 4, 5}
+	fs, err := InterpolateGCommitments(indices, ys, ks)
+	require.NoError(err)
+	assert.Equal(expected, fs)
+
+	valid, err := vss.VerifyCommitmentsInCode(fs, &params.ParityMatrix)
+	require.NoError(err)
+	assert.True(valid)
+}
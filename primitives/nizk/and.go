@@ -0,0 +1,35 @@
+package nizk
+
+// And returns the AND-composition of the statements stmts
+// i.e., a statement which is true iff all the statements are true
+// The witness of the composed statement is the concatenation of the witnesses
+// of the statements (see AndWitness)
+//
+// A proof of the composed statement uses a single challenge for all the statements
+// To share a witness scalar between multiple statements, build directly a single
+// LinearStatement where multiple equations refer to the same witness index
+func And(stmts ...*LinearStatement) *LinearStatement {
+	res := &LinearStatement{}
+	for _, stmt := range stmts {
+		offset := res.NumWitnesses
+		for _, eq := range stmt.Equations {
+			terms := make([]Term, len(eq.Terms))
+			for t, term := range eq.Terms {
+				terms[t] = Term{Base: term.Base, Index: term.Index + offset}
+			}
+			res.Equations = append(res.Equations, Equation{Terms: terms, X: eq.X})
+		}
+		res.NumWitnesses += stmt.NumWitnesses
+	}
+	return res
+}
+
+// AndWitness returns the witness of the AND-composition of statements
+// with witnesses wits (in the same order as in And)
+func AndWitness(wits ...LinearWitness) LinearWitness {
+	var res LinearWitness
+	for _, wit := range wits {
+		res = append(res, wit...)
+	}
+	return res
+}
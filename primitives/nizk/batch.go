@@ -0,0 +1,191 @@
+package nizk
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+)
+
+// BatchItem is a proof to be verified by BatchVerify
+type BatchItem struct {
+	Transcript *transcript.Transcript // transcript of the proof, see Prove
+	Stmt       *LinearStatement
+	Proof      *LinearProof
+}
+
+// multiMultAccumulator accumulates pairs (point, scalar) to compute sum scalar * point
+// Identical points are merged, which makes the final multi-scalar multiplication cheaper
+// when the same bases are used in many equations (e.g., G and H)
+type multiMultAccumulator struct {
+	pts     []curve25519.PointXY
+	scalars []curve25519.Scalar
+	index   map[curve25519.PointXY]int
+}
+
+func newMultiMultAccumulator() *multiMultAccumulator {
+	return &multiMultAccumulator{
+		index: map[curve25519.PointXY]int{},
+	}
+}
+
+// add adds s * p to the accumulator
+func (acc *multiMultAccumulator) add(p *curve25519.PointXY, s *curve25519.Scalar) {
+	if i, ok := acc.index[*p]; ok {
+		acc.scalars[i] = *curve25519.AddScalar(&acc.scalars[i], s)
+		return
+	}
+	acc.index[*p] = len(acc.pts)
+	acc.pts = append(acc.pts, *p)
+	acc.scalars = append(acc.scalars, *s)
+}
+
+// isZero returns true if the accumulated sum is the point at infinity
+func (acc *multiMultAccumulator) isZero() (bool, error) {
+	if len(acc.pts) == 0 {
+		return true, nil
+	}
+	r, err := curve25519.MultiMultPointXYScalarVarTime(acc.pts, acc.scalars)
+	if err != nil {
+		return false, err
+	}
+	return curve25519.PointXYEqual(r, &curve25519.PointXYInfinity), nil
+}
+
+// checkProofShape verifies the statement and that the proof has the correct lengths
+// and that all the points of the proof and of the statement (except the bases) are on the curve
+func checkProofShape(stmt *LinearStatement, proof *LinearProof) error {
+	err := stmt.CheckStatement()
+	if err != nil {
+		return err
+	}
+	if len(proof.Com) != len(stmt.Equations) {
+		return fmt.Errorf("invalid number of commitments: %d != %d", len(proof.Com), len(stmt.Equations))
+	}
+	if len(proof.Resp) != stmt.NumWitnesses {
+		return fmt.Errorf("invalid number of responses: %d != %d", len(proof.Resp), stmt.NumWitnesses)
+	}
+	for i := range stmt.Equations {
+		if !curve25519.IsOnCurveXY(&stmt.Equations[i].X) {
+			return fmt.Errorf("X[%d] is not on the curve", i)
+		}
+		if !curve25519.IsOnCurveXY(&proof.Com[i]) {
+			return fmt.Errorf("com[%d] is not on the curve", i)
+		}
+	}
+	return nil
+}
+
+// batcher combines equations of multiple proofs into a single equation using random coefficients e
+//    sum_{proofs} sum_i e_i (sum_j A_{i,j} Resp_j - Com_i - ch * X_i) = 0
+// which costs a single multi-scalar multiplication
+type batcher struct {
+	acc *multiMultAccumulator
+	key curve25519.Chacha20Key // key to generate the random coefficients e_i
+	ie  uint64                 // index of the next coefficient e_i
+}
+
+func newBatcher() (*batcher, error) {
+	// TODO: optimization is having the e_i 128 bits long instead
+	chacha20key, err := curve25519.RandomChacha20Key()
+	if err != nil {
+		return nil, fmt.Errorf("error generating chacha20 key: %w", err)
+	}
+	return &batcher{
+		acc: newMultiMultAccumulator(),
+		key: chacha20key,
+	}, nil
+}
+
+// addClaim adds the claim c multiplied by a new random coefficient e_i
+func (b *batcher) addClaim(c *Claim) {
+	var e curve25519.Scalar
+	curve25519.RandomScalarChacha20C(&e, &b.key, b.ie)
+	b.ie++
+
+	for k := range c.Points {
+		b.acc.add(&c.Points[k], curve25519.MultScalar(&e, &c.Scalars[k]))
+	}
+}
+
+// addProof adds the equations of the proof for stmt with challenge chal
+// The shape of the proof must have been checked before (see checkProofShape)
+func (b *batcher) addProof(stmt *LinearStatement, proof *LinearProof, chal *curve25519.Scalar) {
+	for _, c := range proofClaims(stmt, proof, chal) {
+		b.addClaim(&c)
+	}
+}
+
+// proofClaims returns the claims equivalent to the verification equations of the proof
+// for stmt with challenge chal, i.e., for all i:
+//    - sum_j A_{i,j} Resp_j + ch * X_i + Com_i = 0
+// The shape of the proof must have been checked before (see checkProofShape)
+func proofClaims(stmt *LinearStatement, proof *LinearProof, chal *curve25519.Scalar) []Claim {
+	claims := make([]Claim, len(stmt.Equations))
+	for i := range stmt.Equations {
+		eq := &stmt.Equations[i]
+		c := Claim{
+			Points:  make([]curve25519.PointXY, 0, len(eq.Terms)+2),
+			Scalars: make([]curve25519.Scalar, 0, len(eq.Terms)+2),
+		}
+		for _, term := range eq.Terms {
+			c.Points = append(c.Points, term.Base)
+			c.Scalars = append(c.Scalars, *curve25519.NegateScalar(&proof.Resp[term.Index]))
+		}
+		c.Points = append(c.Points, eq.X, proof.Com[i])
+		c.Scalars = append(c.Scalars, *chal, curve25519.ScalarOne)
+		claims[i] = c
+	}
+	return claims
+}
+
+// check verifies the combined equation
+func (b *batcher) check() error {
+	ok, err := b.acc.isZero()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("batch verification equation failed")
+	}
+	return nil
+}
+
+// verifyClaims verifies all the claims at once
+func verifyClaims(claims []Claim) error {
+	b, err := newBatcher()
+	if err != nil {
+		return err
+	}
+	for i := range claims {
+		b.addClaim(&claims[i])
+	}
+	return b.check()
+}
+
+// BatchVerify verifies all the proofs of items at once
+// It returns an error if one of the proofs is invalid
+// (but does not say which one - use Verify on each proof to find it)
+// All the equations of all the proofs are combined using random coefficients (see batcher)
+// Bases common to multiple equations/proofs are merged
+func BatchVerify(items []BatchItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	b, err := newBatcher()
+	if err != nil {
+		return err
+	}
+
+	for p := range items {
+		err := checkProofShape(items[p].Stmt, items[p].Proof)
+		if err != nil {
+			return fmt.Errorf("proof %d: %w", p, err)
+		}
+		chal := items[p].Stmt.Challenge(items[p].Transcript, items[p].Proof.Com)
+		b.addProof(items[p].Stmt, items[p].Proof, &chal)
+	}
+
+	return b.check()
+}
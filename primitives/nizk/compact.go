@@ -0,0 +1,66 @@
+package nizk
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+)
+
+// This file implements the compact form of the proofs of linear statements:
+// the proof contains the challenge and the responses instead of the commitments and the responses
+// The proof is then smaller (32 bytes per witness + 32 bytes instead of 64 bytes per equation
+// + 32 bytes per witness), but its verification cannot be batched with other proofs
+//
+// Verification recomputes the commitments Com_i = sum_j A_{i,j} Resp_j - ch X_i
+// and checks that the challenge computed from them is ch
+
+// CompactLinearProof is a proof for a LinearStatement in compact form
+type CompactLinearProof struct {
+	Chal curve25519.Scalar   `codec:"e"` // Chal is the challenge
+	Resp []curve25519.Scalar `codec:"r"` // Resp[j] is the response for witness j
+}
+
+// ProveCompact is the same as Prove but outputs a proof in compact form
+func ProveCompact(
+	rnd io.Reader, t *transcript.Transcript, stmt *LinearStatement, wit LinearWitness,
+) (*CompactLinearProof, error) {
+	proof, chal, err := prove(rnd, t, stmt, wit)
+	if err != nil {
+		return nil, err
+	}
+	return &CompactLinearProof{
+		Chal: *chal,
+		Resp: proof.Resp,
+	}, nil
+}
+
+// VerifyCompact verifies a proof in compact form for the statement stmt
+// t must be the same transcript as the one given to ProveCompact
+// t is modified by the function
+func VerifyCompact(t *transcript.Transcript, stmt *LinearStatement, proof *CompactLinearProof) error {
+	err := stmt.CheckStatement()
+	if err != nil {
+		return err
+	}
+	if len(proof.Resp) != stmt.NumWitnesses {
+		return fmt.Errorf("invalid number of responses: %d != %d", len(proof.Resp), stmt.NumWitnesses)
+	}
+	for i := range stmt.Equations {
+		if !curve25519.IsOnCurveXY(&stmt.Equations[i].X) {
+			return fmt.Errorf("X[%d] is not on the curve", i)
+		}
+	}
+
+	com, err := simulateCom(stmt, proof.Resp, &proof.Chal)
+	if err != nil {
+		return err
+	}
+
+	chal := stmt.Challenge(t, com)
+	if !curve25519.ScalarEqual(&chal, &proof.Chal) {
+		return fmt.Errorf("verification of compact proof failed")
+	}
+	return nil
+}
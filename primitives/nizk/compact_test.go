@@ -0,0 +1,39 @@
+package nizk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactProve(t *testing.T) {
+	for _, n := range []int{1, 2, 5, 10} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			stmt, wit := genDblDLEqStmtWit(t, n)
+
+			proof, err := ProveCompact(nil, transcript.New("test"), stmt, wit)
+			require.NoError(err)
+			assert.NoError(VerifyCompact(transcript.New("test"), stmt, proof))
+
+			// Wrong domain
+			assert.Error(VerifyCompact(transcript.New("other"), stmt, proof))
+
+			// Compact proof is smaller than the batchable one
+			batchProof, err := Prove(nil, transcript.New("test"), stmt, wit)
+			require.NoError(err)
+			assert.Less(len(msgpack.Encode(proof)), len(msgpack.Encode(batchProof)))
+
+			// Broken response
+			proof.Resp[0] = *curve25519.NegateScalar(&proof.Resp[0])
+			assert.Error(VerifyCompact(transcript.New("test"), stmt, proof))
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package nizk
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+)
+
+// This file contains the statements of commonly used proofs
+// as instances of LinearStatement
+
+// NewDLStatement returns the statement of a proof of knowledge of discrete logarithms
+// x_0,...,x_{n-1} such that for all i: X_i = x_i G_i
+// The witness is (x_0,...,x_{n-1})
+func NewDLStatement(g []curve25519.PointXY, x []curve25519.PointXY) (*LinearStatement, error) {
+	if len(x) != len(g) {
+		return nil, fmt.Errorf("G and X do not have the same length")
+	}
+	if len(x) == 0 {
+		return nil, fmt.Errorf("G/X is empty")
+	}
+
+	n := len(g)
+	stmt := &LinearStatement{
+		NumWitnesses: n,
+		Equations:    make([]Equation, n),
+	}
+	for i := 0; i < n; i++ {
+		stmt.Equations[i] = Equation{
+			Terms: []Term{{Base: g[i], Index: i}},
+			X:     x[i],
+		}
+	}
+	return stmt, nil
+}
+
+// NewDblDLEqStatement returns the statement of a proof of knowledge of
+// x_0,...,x_{n-1},y_0,...,y_{n-1} such that for all i:
+//    Z_i = x_i G + y_i H (G,H are the two main basis)
+//    Z'_i = x_i G_i + y_i H_i
+// The witness is (x_0,...,x_{n-1},y_0,...,y_{n-1})
+// The equations are (Z_0,...,Z_{n-1},Z'_0,...,Z'_{n-1}) in this order
+func NewDblDLEqStatement(
+	g []curve25519.PointXY, h []curve25519.PointXY, z []curve25519.PointXY, zPrime []curve25519.PointXY,
+) (*LinearStatement, error) {
+	if len(g) != len(h) {
+		return nil, fmt.Errorf("G and H do not have the same length")
+	}
+	if len(g) != len(z) {
+		return nil, fmt.Errorf("G and Z do not have the same length")
+	}
+	if len(g) != len(zPrime) {
+		return nil, fmt.Errorf("G and ZPrime do not have the same length")
+	}
+	if len(g) == 0 {
+		return nil, fmt.Errorf("G is empty")
+	}
+
+	n := len(g)
+	stmt := &LinearStatement{
+		NumWitnesses: 2 * n,
+		Equations:    make([]Equation, 2*n),
+	}
+	for i := 0; i < n; i++ {
+		stmt.Equations[i] = Equation{
+			Terms: []Term{{Base: curve25519.BaseXYG, Index: i}, {Base: curve25519.BaseXYH, Index: n + i}},
+			X:     z[i],
+		}
+		stmt.Equations[n+i] = Equation{
+			Terms: []Term{{Base: g[i], Index: i}, {Base: h[i], Index: n + i}},
+			X:     zPrime[i],
+		}
+	}
+	return stmt, nil
+}
+
+// NewPedersenBitStatements returns the two statements to prove with ProveOr
+// that the Pedersen commitment c = b G + r H commits to a bit b in {0,1}:
+//    statement 0: c = r H       (b = 0)
+//    statement 1: c - G = r H   (b = 1)
+// In both cases, the witness is (r)
+func NewPedersenBitStatements(c *curve25519.PointXY) ([]*LinearStatement, error) {
+	cMinusG, err := curve25519.SubPointXY(c, &curve25519.BaseXYG)
+	if err != nil {
+		return nil, err
+	}
+	return []*LinearStatement{
+		{
+			NumWitnesses: 1,
+			Equations:    []Equation{{Terms: []Term{{Base: curve25519.BaseXYH, Index: 0}}, X: *c}},
+		},
+		{
+			NumWitnesses: 1,
+			Equations:    []Equation{{Terms: []Term{{Base: curve25519.BaseXYH, Index: 0}}, X: *cMinusG}},
+		},
+	}, nil
+}
+
+// NewVerifiableEncStatement returns the statement of a proof that the ElGamal ciphertexts
+// (R_l, E_l) under the public key PK encrypt an opening of the vector commitment C
+// (see primitives/elgamal, (R_l, E_l) are usually aggregated ciphertexts),
+// i.e., a proof of knowledge of m_0,...,m_{n-1},r_0,...,r_{n-1} such that:
+//    R_l = r_l G                for all l
+//    E_l = m_l G + r_l PK       for all l
+//    C = sum_l m_l G_l
+// The witness is (m_0,...,m_{n-1},r_0,...,r_{n-1})
+// The equations are (R_0,...,R_{n-1},E_0,...,E_{n-1},C) in this order
+func NewVerifiableEncStatement(
+	g []curve25519.PointXY, c *curve25519.PointXY, pk *curve25519.PointXY, r []curve25519.PointXY, e []curve25519.PointXY,
+) (*LinearStatement, error) {
+	if len(g) != len(r) {
+		return nil, fmt.Errorf("G and R do not have the same length")
+	}
+	if len(g) != len(e) {
+		return nil, fmt.Errorf("G and E do not have the same length")
+	}
+	if len(g) == 0 {
+		return nil, fmt.Errorf("G is empty")
+	}
+
+	n := len(g)
+	stmt := &LinearStatement{
+		NumWitnesses: 2 * n,
+		Equations:    make([]Equation, 2*n+1),
+	}
+	comTerms := make([]Term, n)
+	for l := 0; l < n; l++ {
+		stmt.Equations[l] = Equation{
+			Terms: []Term{{Base: curve25519.BaseXYG, Index: n + l}},
+			X:     r[l],
+		}
+		stmt.Equations[n+l] = Equation{
+			Terms: []Term{{Base: curve25519.BaseXYG, Index: l}, {Base: *pk, Index: n + l}},
+			X:     e[l],
+		}
+		comTerms[l] = Term{Base: g[l], Index: l}
+	}
+	stmt.Equations[2*n] = Equation{
+		Terms: comTerms,
+		X:     *c,
+	}
+	return stmt, nil
+}
+
+// NewElGamalPedersenEqStatement returns the statement of a proof that the ElGamal ciphertexts
+// (R_k, E_k) under the public key PK (see primitives/elgamal, usually the chunks of a ciphertext)
+// encrypt the values committed in the Pedersen commitments P_k = m_k G + s_k H,
+// i.e., a proof of knowledge of m_0,...,m_{n-1},r_0,...,r_{n-1},s_0,...,s_{n-1} such that:
+//    R_k = r_k G                for all k
+//    E_k = m_k G + r_k PK       for all k
+//    P_k = m_k G + s_k H        for all k
+// The witness is (m_0,...,m_{n-1},r_0,...,r_{n-1},s_0,...,s_{n-1})
+// The equations are (R_0,...,R_{n-1},E_0,...,E_{n-1},P_0,...,P_{n-1}) in this order
+func NewElGamalPedersenEqStatement(
+	pk *curve25519.PointXY, r []curve25519.PointXY, e []curve25519.PointXY, p []curve25519.PointXY,
+) (*LinearStatement, error) {
+	if len(r) != len(e) {
+		return nil, fmt.Errorf("R and E do not have the same length")
+	}
+	if len(r) != len(p) {
+		return nil, fmt.Errorf("R and P do not have the same length")
+	}
+	if len(r) == 0 {
+		return nil, fmt.Errorf("R is empty")
+	}
+
+	n := len(r)
+	stmt := &LinearStatement{
+		NumWitnesses: 3 * n,
+		Equations:    make([]Equation, 3*n),
+	}
+	for k := 0; k < n; k++ {
+		stmt.Equations[k] = Equation{
+			Terms: []Term{{Base: curve25519.BaseXYG, Index: n + k}},
+			X:     r[k],
+		}
+		stmt.Equations[n+k] = Equation{
+			Terms: []Term{{Base: curve25519.BaseXYG, Index: k}, {Base: *pk, Index: n + k}},
+			X:     e[k],
+		}
+		stmt.Equations[2*n+k] = Equation{
+			Terms: []Term{{Base: curve25519.BaseXYG, Index: k}, {Base: curve25519.BaseXYH, Index: 2*n + k}},
+			X:     p[k],
+		}
+	}
+	return stmt, nil
+}
+
+// NewPedersenExpStatement returns the statement of a proof that Y = x G
+// where x is the value committed in the Pedersen commitment C = x G + r H
+// (G,H are the two main basis), i.e., a proof of knowledge of x, r such that:
+//    Y = x G
+//    C = x G + r H
+// The witness is (x, r)
+// The equations are (Y, C) in this order
+func NewPedersenExpStatement(c *curve25519.PointXY, y *curve25519.PointXY) *LinearStatement {
+	return &LinearStatement{
+		NumWitnesses: 2,
+		Equations: []Equation{
+			{
+				Terms: []Term{{Base: curve25519.BaseXYG, Index: 0}},
+				X:     *y,
+			},
+			{
+				Terms: []Term{{Base: curve25519.BaseXYG, Index: 0}, {Base: curve25519.BaseXYH, Index: 1}},
+				X:     *c,
+			},
+		},
+	}
+}
+
+// NewPedersenDLEqStatement returns the statement of a proof that D_l = x B_l for all l
+// where x is the value committed in the Pedersen commitment C = x G + r H
+// (G,H are the two main basis), i.e., a proof of knowledge of x, r such that:
+//    D_l = x B_l        for all l
+//    C = x G + r H
+// The witness is (x, r)
+// The equations are (D_0,...,D_{n-1},C) in this order
+func NewPedersenDLEqStatement(
+	c *curve25519.PointXY, b []curve25519.PointXY, d []curve25519.PointXY,
+) (*LinearStatement, error) {
+	if len(b) != len(d) {
+		return nil, fmt.Errorf("B and D do not have the same length")
+	}
+
+	n := len(b)
+	stmt := &LinearStatement{
+		NumWitnesses: 2,
+		Equations:    make([]Equation, n+1),
+	}
+	for l := 0; l < n; l++ {
+		stmt.Equations[l] = Equation{
+			Terms: []Term{{Base: b[l], Index: 0}},
+			X:     d[l],
+		}
+	}
+	stmt.Equations[n] = Equation{
+		Terms: []Term{{Base: curve25519.BaseXYG, Index: 0}, {Base: curve25519.BaseXYH, Index: 1}},
+		X:     *c,
+	}
+	return stmt, nil
+}
@@ -0,0 +1,207 @@
+package nizk
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+)
+
+// This file implements a generic sigma protocol (made non-interactive with Fiat-Shamir)
+// for linear relations over the group, i.e., proof of knowledge of scalars w_0,...,w_{k-1} such that
+//    X_i = sum_j A_{i,j} w_j     for all i in [0,m-1]
+// where the A_{i,j} (bases) and the X_i are public points
+// The matrix A is sparse: each equation only lists its non-zero terms
+//
+// The proof works as follows:
+// generate random scalars r_0,...,r_{k-1}
+// and associated points Com_i = sum_j A_{i,j} r_j
+// absorb the statement and the commitments into the transcript
+// squeeze a challenge scalar ch
+// set Resp_j = r_j + ch * w_j
+//
+// Verification checks that for all i: sum_j A_{i,j} Resp_j = Com_i + ch * X_i
+// For efficiency, all the equations are batched using random coefficients e_i
+// and verified with a single multi-scalar multiplication
+//
+// WARNING: Actually the proof is only modulo p
+//    X_i may have extraneous factors modulo the co-factor
+//    and this may not be caught
+//
+// Bases A_{i,j} are assumed to be valid points (they are usually public parameters)
+// The points X_i and Com_i are checked to be on the curve during verification
+
+// Term is a term A_{i,j} w_j of a linear equation
+type Term struct {
+	Base  curve25519.PointXY // A_{i,j}
+	Index int                // j: index of the witness scalar
+}
+
+// Equation is a linear equation X = sum_t t.Base * w[t.Index]
+type Equation struct {
+	Terms []Term
+	X     curve25519.PointXY
+}
+
+// LinearStatement is a statement of a linear relation, see comment at top of file
+type LinearStatement struct {
+	NumWitnesses int // k
+	Equations    []Equation
+}
+
+// LinearWitness is a witness for a LinearStatement
+// w[j] is the j-th witness scalar
+type LinearWitness = []curve25519.Scalar
+
+// LinearProof is a proof for a LinearStatement
+// For batching the proof contain com and resp instead of ch and resp
+// which is more compact but not possible to batch (see CompactLinearProof)
+type LinearProof struct {
+	Com  []curve25519.PointXY `codec:"c"` // Com[i] is the commitment for equation i
+	Resp []curve25519.Scalar  `codec:"r"` // Resp[j] is the response for witness j
+}
+
+// CheckStatement verifies that the statement is well-formed
+func (stmt *LinearStatement) CheckStatement() error {
+	if stmt.NumWitnesses <= 0 {
+		return fmt.Errorf("statement has no witness")
+	}
+	if len(stmt.Equations) == 0 {
+		return fmt.Errorf("statement has no equation")
+	}
+	for i := range stmt.Equations {
+		for _, term := range stmt.Equations[i].Terms {
+			if term.Index < 0 || term.Index >= stmt.NumWitnesses {
+				return fmt.Errorf("equation %d refers to invalid witness %d", i, term.Index)
+			}
+		}
+	}
+	return nil
+}
+
+// IsSatisfied returns true if wit is a valid witness for the statement
+// Mostly useful for debugging and tests
+func (stmt *LinearStatement) IsSatisfied(wit LinearWitness) (bool, error) {
+	if len(wit) != stmt.NumWitnesses {
+		return false, fmt.Errorf("invalid witness length: %d != %d", len(wit), stmt.NumWitnesses)
+	}
+	for i := range stmt.Equations {
+		x, err := evalEquation(&stmt.Equations[i], wit)
+		if err != nil {
+			return false, err
+		}
+		if !curve25519.PointXYEqual(x, &stmt.Equations[i].X) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// evalEquation computes sum_t t.Base * w[t.Index] for the equation eq
+func evalEquation(eq *Equation, w []curve25519.Scalar) (*curve25519.PointXY, error) {
+	if len(eq.Terms) == 0 {
+		x := curve25519.PointXYInfinity
+		return &x, nil
+	}
+	bases := make([]curve25519.PointXY, len(eq.Terms))
+	scalars := make([]curve25519.Scalar, len(eq.Terms))
+	for t, term := range eq.Terms {
+		bases[t] = term.Base
+		scalars[t] = w[term.Index]
+	}
+	return curve25519.MultiMultPointXYScalar(bases, scalars)
+}
+
+// appendToTranscript absorbs the statement and the commitments into the transcript
+func (stmt *LinearStatement) appendToTranscript(t *transcript.Transcript, com []curve25519.PointXY) {
+	t.AppendUint64("k", uint64(stmt.NumWitnesses))
+	t.AppendUint64("m", uint64(len(stmt.Equations)))
+	for i := range stmt.Equations {
+		eq := &stmt.Equations[i]
+		t.AppendUint64("terms", uint64(len(eq.Terms)))
+		for _, term := range eq.Terms {
+			t.AppendUint64("j", uint64(term.Index))
+			t.AppendPointXY("A", &term.Base)
+		}
+		t.AppendPointXY("X", &eq.X)
+	}
+	t.AppendPointsXY("com", com)
+}
+
+// Challenge computes the challenge of the proof for statement stmt with commitments com
+// t is the transcript of the proof and is modified
+func (stmt *LinearStatement) Challenge(t *transcript.Transcript, com []curve25519.PointXY) curve25519.Scalar {
+	stmt.appendToTranscript(t, com)
+	return t.ChallengeScalar("chal")
+}
+
+// Prove generates a NIZK PoK for the statement stmt using witness wit
+// Does not verify the validity of the witness
+// rnd is the randomness source (system randomness if nil)
+// t is the transcript of the proof: it must be created with a domain separator specific
+// to the usage and must already contain all the context the proof needs to be bound to
+// t is modified by the function
+func Prove(
+	rnd io.Reader, t *transcript.Transcript, stmt *LinearStatement, wit LinearWitness,
+) (*LinearProof, error) {
+	proof, _, err := prove(rnd, t, stmt, wit)
+	return proof, err
+}
+
+// prove is the same as Prove but also returns the challenge
+func prove(
+	rnd io.Reader, t *transcript.Transcript, stmt *LinearStatement, wit LinearWitness,
+) (*LinearProof, *curve25519.Scalar, error) {
+	err := stmt.CheckStatement()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(wit) != stmt.NumWitnesses {
+		return nil, nil, fmt.Errorf("invalid witness length: %d != %d", len(wit), stmt.NumWitnesses)
+	}
+
+	k := stmt.NumWitnesses
+	m := len(stmt.Equations)
+
+	// generate comLog[j] = r_j randomly using the Chacha generator
+	chacha20Key, err := curve25519.RandomChacha20KeyFrom(rnd)
+	if err != nil {
+		return nil, nil, err
+	}
+	comLog := make([]curve25519.Scalar, k)
+	for j := 0; j < k; j++ {
+		curve25519.RandomScalarChacha20C(&comLog[j], &chacha20Key, uint64(j))
+	}
+
+	// compute com[i] = sum_j A_{i,j} r_j
+	com := make([]curve25519.PointXY, m)
+	for i := 0; i < m; i++ {
+		c, err := evalEquation(&stmt.Equations[i], comLog)
+		if err != nil {
+			return nil, nil, err
+		}
+		com[i] = *c
+	}
+
+	chal := stmt.Challenge(t, com)
+
+	// resp[j] = comLog[j] + chal * w_j
+	resp := make([]curve25519.Scalar, k)
+	for j := 0; j < k; j++ {
+		r := curve25519.MultScalar(&chal, &wit[j])
+		resp[j] = *curve25519.AddScalar(r, &comLog[j])
+	}
+
+	return &LinearProof{
+		Com:  com,
+		Resp: resp,
+	}, &chal, nil
+}
+
+// Verify verifies the proof for the statement stmt
+// t must be the same transcript as the one given to Prove (see Prove)
+// t is modified by the function
+func Verify(t *transcript.Transcript, stmt *LinearStatement, proof *LinearProof) error {
+	return BatchVerify([]BatchItem{{Transcript: t, Stmt: stmt, Proof: proof}})
+}
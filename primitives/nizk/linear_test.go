@@ -0,0 +1,415 @@
+package nizk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/elgamal"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// genLinearStmtWit generates a random valid statement with m equations, k witnesses
+// and numTerms terms per equation, together with its witness
+func genLinearStmtWit(t *testing.T, m, k, numTerms int) (*LinearStatement, LinearWitness) {
+	require := require.New(t)
+
+	wit := make(LinearWitness, k)
+	for j := 0; j < k; j++ {
+		wit[j] = *curve25519.RandomScalar()
+	}
+
+	stmt := &LinearStatement{
+		NumWitnesses: k,
+		Equations:    make([]Equation, m),
+	}
+	for i := 0; i < m; i++ {
+		stmt.Equations[i].Terms = make([]Term, numTerms)
+		for t := 0; t < numTerms; t++ {
+			base, err := curve25519.PointToPointXY(curve25519.RandomPoint())
+			require.NoError(err)
+			stmt.Equations[i].Terms[t] = Term{Base: *base, Index: (i + t) % k}
+		}
+		x, err := evalEquation(&stmt.Equations[i], wit)
+		require.NoError(err)
+		stmt.Equations[i].X = *x
+	}
+
+	return stmt, wit
+}
+
+func genDLStmtWit(t *testing.T, n int) (*LinearStatement, LinearWitness) {
+	require := require.New(t)
+
+	g := make([]curve25519.PointXY, n)
+	x := make([]curve25519.PointXY, n)
+	wit := make(LinearWitness, n)
+	for i := 0; i < n; i++ {
+		wit[i] = *curve25519.RandomScalar()
+		gi, err := curve25519.PointToPointXY(curve25519.RandomPoint())
+		require.NoError(err)
+		g[i] = *gi
+		xi, err := curve25519.MultPointXYScalar(&g[i], &wit[i])
+		require.NoError(err)
+		x[i] = *xi
+	}
+
+	stmt, err := NewDLStatement(g, x)
+	require.NoError(err)
+	return stmt, wit
+}
+
+func genDblDLEqStmtWit(t *testing.T, n int) (*LinearStatement, LinearWitness) {
+	require := require.New(t)
+
+	g := make([]curve25519.PointXY, n)
+	h := make([]curve25519.PointXY, n)
+	z := make([]curve25519.PointXY, n)
+	zPrime := make([]curve25519.PointXY, n)
+	x := make([]curve25519.Scalar, n)
+	y := make([]curve25519.Scalar, n)
+	for i := 0; i < n; i++ {
+		x[i] = *curve25519.RandomScalar()
+		y[i] = *curve25519.RandomScalar()
+		gi, err := curve25519.PointToPointXY(curve25519.RandomPoint())
+		require.NoError(err)
+		g[i] = *gi
+		hi, err := curve25519.PointToPointXY(curve25519.RandomPoint())
+		require.NoError(err)
+		h[i] = *hi
+		zi, err := curve25519.DoubleMultBaseGHPointXYScalar(&x[i], &y[i])
+		require.NoError(err)
+		z[i] = *zi
+		zPrimeI, err := curve25519.MultiMultPointXYScalar(
+			[]curve25519.PointXY{g[i], h[i]}, []curve25519.Scalar{x[i], y[i]})
+		require.NoError(err)
+		zPrime[i] = *zPrimeI
+	}
+
+	stmt, err := NewDblDLEqStatement(g, h, z, zPrime)
+	require.NoError(err)
+	return stmt, AndWitness(x, y)
+}
+
+func TestLinearProveCorrect(t *testing.T) {
+	testCases := []struct {
+		m        int
+		k        int
+		numTerms int
+	}{
+		{1, 1, 1},
+		{3, 2, 2},
+		{5, 5, 3},
+		{2, 6, 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("m=%d,k=%d,terms=%d", tc.m, tc.k, tc.numTerms), func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			stmt, wit := genLinearStmtWit(t, tc.m, tc.k, tc.numTerms)
+			ok, err := stmt.IsSatisfied(wit)
+			require.NoError(err)
+			require.True(ok)
+
+			proof, err := Prove(nil, transcript.New("test"), stmt, wit)
+			require.NoError(err)
+
+			assert.NoError(Verify(transcript.New("test"), stmt, proof))
+
+			// Wrong domain
+			assert.Error(Verify(transcript.New("other"), stmt, proof))
+
+			// Broken response
+			proof.Resp[0] = *curve25519.NegateScalar(&proof.Resp[0])
+			assert.Error(Verify(transcript.New("test"), stmt, proof))
+		})
+	}
+}
+
+func TestLinearProveIncorrectWitness(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	stmt, wit := genLinearStmtWit(t, 3, 3, 2)
+	wit[1] = *curve25519.RandomScalar()
+
+	ok, err := stmt.IsSatisfied(wit)
+	require.NoError(err)
+	assert.False(ok)
+
+	proof, err := Prove(nil, transcript.New("test"), stmt, wit)
+	require.NoError(err)
+	assert.Error(Verify(transcript.New("test"), stmt, proof))
+}
+
+func TestInstances(t *testing.T) {
+	for _, n := range []int{1, 2, 5, 10} {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			dlStmt, dlWit := genDLStmtWit(t, n)
+			proof, err := Prove(nil, transcript.New("test"), dlStmt, dlWit)
+			require.NoError(err)
+			assert.NoError(Verify(transcript.New("test"), dlStmt, proof))
+
+			dblStmt, dblWit := genDblDLEqStmtWit(t, n)
+			proof, err = Prove(nil, transcript.New("test"), dblStmt, dblWit)
+			require.NoError(err)
+			assert.NoError(Verify(transcript.New("test"), dblStmt, proof))
+
+			// Z' not matching Z
+			dblStmt.Equations[n].X = dblStmt.Equations[0].X
+			proof, err = Prove(nil, transcript.New("test"), dblStmt, dblWit)
+			require.NoError(err)
+			assert.Error(Verify(transcript.New("test"), dblStmt, proof))
+		})
+	}
+}
+
+func TestVerifiableEnc(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const n = 4
+
+	pk, _, err := elgamal.GenerateKeyFrom(nil)
+	require.NoError(err)
+
+	g := make([]curve25519.PointXY, n)
+	m := make([]curve25519.Scalar, n)
+	r := make([]curve25519.Scalar, n)
+	aggR := make([]curve25519.PointXY, n)
+	aggE := make([]curve25519.PointXY, n)
+	for l := 0; l < n; l++ {
+		gl, err := curve25519.PointToPointXY(curve25519.RandomPoint())
+		require.NoError(err)
+		g[l] = *gl
+		m[l] = *curve25519.RandomScalar()
+
+		c, rl, err := elgamal.EncryptFrom(nil, pk, &m[l])
+		require.NoError(err)
+		r[l] = *rl
+		rAgg, eAgg, err := c.Aggregate()
+		require.NoError(err)
+		aggR[l] = *rAgg
+		aggE[l] = *eAgg
+	}
+	com, err := curve25519.MultiMultPointXYScalar(g, m)
+	require.NoError(err)
+
+	stmt, err := NewVerifiableEncStatement(g, com, pk, aggR, aggE)
+	require.NoError(err)
+	wit := AndWitness(m, r)
+	ok, err := stmt.IsSatisfied(wit)
+	require.NoError(err)
+	require.True(ok)
+
+	proof, err := Prove(nil, transcript.New("test"), stmt, wit)
+	require.NoError(err)
+	assert.NoError(Verify(transcript.New("test"), stmt, proof))
+
+	// Ciphertexts of another opening
+	m[0] = *curve25519.RandomScalar()
+	c, rl, err := elgamal.EncryptFrom(nil, pk, &m[0])
+	require.NoError(err)
+	r[0] = *rl
+	rAgg, eAgg, err := c.Aggregate()
+	require.NoError(err)
+	aggR[0] = *rAgg
+	aggE[0] = *eAgg
+	stmt, err = NewVerifiableEncStatement(g, com, pk, aggR, aggE)
+	require.NoError(err)
+	proof, err = Prove(nil, transcript.New("test"), stmt, AndWitness(m, r))
+	require.NoError(err)
+	assert.Error(Verify(transcript.New("test"), stmt, proof))
+}
+
+func TestElGamalPedersenEq(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	pk, _, err := elgamal.GenerateKeyFrom(nil)
+	require.NoError(err)
+
+	c, chunks, rs, err := elgamal.EncryptChunksFrom(nil, pk, curve25519.RandomScalar())
+	require.NoError(err)
+	s := make([]curve25519.Scalar, elgamal.NumChunks)
+	p := make([]curve25519.PointXY, elgamal.NumChunks)
+	for k := range p {
+		s[k] = *curve25519.RandomScalar()
+		pComK, err := curve25519.DoubleMultBaseGHPointXYScalar(&chunks[k], &s[k])
+		require.NoError(err)
+		p[k] = *pComK
+	}
+
+	stmt, err := NewElGamalPedersenEqStatement(pk, c.R, c.E, p)
+	require.NoError(err)
+	wit := AndWitness(chunks, rs, s)
+	ok, err := stmt.IsSatisfied(wit)
+	require.NoError(err)
+	require.True(ok)
+
+	proof, err := Prove(nil, transcript.New("test"), stmt, wit)
+	require.NoError(err)
+	assert.NoError(Verify(transcript.New("test"), stmt, proof))
+
+	// Commitment to another chunk
+	chunks[0] = *curve25519.AddScalar(&chunks[0], &curve25519.ScalarOne)
+	p0, err := curve25519.DoubleMultBaseGHPointXYScalar(&chunks[0], &s[0])
+	require.NoError(err)
+	p[0] = *p0
+	stmt, err = NewElGamalPedersenEqStatement(pk, c.R, c.E, p)
+	require.NoError(err)
+	proof, err = Prove(nil, transcript.New("test"), stmt, AndWitness(chunks, rs, s))
+	require.NoError(err)
+	assert.Error(Verify(transcript.New("test"), stmt, proof))
+
+	_, err = NewElGamalPedersenEqStatement(pk, c.R, c.E, p[1:])
+	assert.Error(err)
+}
+
+func TestPedersenExp(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	x := curve25519.RandomScalar()
+	r := curve25519.RandomScalar()
+	c, err := curve25519.DoubleMultBaseGHPointXYScalar(x, r)
+	require.NoError(err)
+	y, err := curve25519.MultBaseGPointXYScalar(x)
+	require.NoError(err)
+
+	stmt := NewPedersenExpStatement(c, y)
+	wit := LinearWitness{*x, *r}
+	ok, err := stmt.IsSatisfied(wit)
+	require.NoError(err)
+	require.True(ok)
+
+	proof, err := Prove(nil, transcript.New("test"), stmt, wit)
+	require.NoError(err)
+	assert.NoError(Verify(transcript.New("test"), stmt, proof))
+
+	// Y = x G + H is not x G, even if C - Y = (r - 1) H
+	yh, err := curve25519.AddPointXY(y, &curve25519.BaseXYH)
+	require.NoError(err)
+	stmt = NewPedersenExpStatement(c, yh)
+	proof, err = Prove(nil, transcript.New("test"), stmt, wit)
+	require.NoError(err)
+	assert.Error(Verify(transcript.New("test"), stmt, proof))
+}
+
+func TestPedersenDLEq(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const n = 3
+
+	x := curve25519.RandomScalar()
+	r := curve25519.RandomScalar()
+	c, err := curve25519.DoubleMultBaseGHPointXYScalar(x, r)
+	require.NoError(err)
+
+	b := make([]curve25519.PointXY, n)
+	d := make([]curve25519.PointXY, n)
+	for l := 0; l < n; l++ {
+		b[l] = *curve25519.RandomPointXY()
+		dl, err := curve25519.MultPointXYScalar(&b[l], x)
+		require.NoError(err)
+		d[l] = *dl
+	}
+
+	stmt, err := NewPedersenDLEqStatement(c, b, d)
+	require.NoError(err)
+	wit := LinearWitness{*x, *r}
+	ok, err := stmt.IsSatisfied(wit)
+	require.NoError(err)
+	require.True(ok)
+
+	proof, err := Prove(nil, transcript.New("test"), stmt, wit)
+	require.NoError(err)
+	assert.NoError(Verify(transcript.New("test"), stmt, proof))
+
+	// D_1 is not x B_1
+	d[1] = *curve25519.RandomPointXY()
+	stmt, err = NewPedersenDLEqStatement(c, b, d)
+	require.NoError(err)
+	proof, err = Prove(nil, transcript.New("test"), stmt, wit)
+	require.NoError(err)
+	assert.Error(Verify(transcript.New("test"), stmt, proof))
+}
+
+func TestAnd(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	stmt1, wit1 := genDLStmtWit(t, 3)
+	stmt2, wit2 := genDblDLEqStmtWit(t, 2)
+	stmt3, wit3 := genLinearStmtWit(t, 2, 3, 3)
+
+	stmt := And(stmt1, stmt2, stmt3)
+	wit := AndWitness(wit1, wit2, wit3)
+	assert.Equal(3+4+3, stmt.NumWitnesses)
+	assert.Equal(3+4+2, len(stmt.Equations))
+
+	ok, err := stmt.IsSatisfied(wit)
+	require.NoError(err)
+	require.True(ok)
+
+	proof, err := Prove(nil, transcript.New("test"), stmt, wit)
+	require.NoError(err)
+	assert.NoError(Verify(transcript.New("test"), stmt, proof))
+
+	// One false statement makes the composition false
+	stmt2.Equations[0].X = stmt2.Equations[1].X
+	stmt = And(stmt1, stmt2, stmt3)
+	proof, err = Prove(nil, transcript.New("test"), stmt, wit)
+	require.NoError(err)
+	assert.Error(Verify(transcript.New("test"), stmt, proof))
+}
+
+func TestBatchVerify(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const numProofs = 5
+
+	stmts := make([]*LinearStatement, numProofs)
+	proofs := make([]*LinearProof, numProofs)
+	for p := 0; p < numProofs; p++ {
+		var wit LinearWitness
+		if p%2 == 0 {
+			stmts[p], wit = genDLStmtWit(t, p+1)
+		} else {
+			stmts[p], wit = genDblDLEqStmtWit(t, p+1)
+		}
+		var err error
+		proofs[p], err = Prove(nil, transcript.New(fmt.Sprintf("proof %d", p)), stmts[p], wit)
+		require.NoError(err)
+	}
+
+	items := func() []BatchItem {
+		items := make([]BatchItem, numProofs)
+		for p := 0; p < numProofs; p++ {
+			items[p] = BatchItem{
+				Transcript: transcript.New(fmt.Sprintf("proof %d", p)),
+				Stmt:       stmts[p],
+				Proof:      proofs[p],
+			}
+		}
+		return items
+	}
+
+	assert.NoError(BatchVerify(items()))
+	assert.NoError(BatchVerify(nil))
+
+	// Break one proof
+	proofs[3].Resp[2] = *curve25519.RandomScalar()
+	assert.Error(BatchVerify(items()))
+	assert.Error(Verify(transcript.New("proof 3"), stmts[3], proofs[3]))
+	assert.NoError(Verify(transcript.New("proof 2"), stmts[2], proofs[2]))
+}
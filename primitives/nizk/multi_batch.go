@@ -0,0 +1,145 @@
+package nizk
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+)
+
+// This file implements batch verification of claims coming from multiple items
+// (e.g., all the proofs and commitment checks of all the dealers of a round)
+// while still being able to tell which items are invalid
+//
+// A claim is any equation of the form sum_k s_k P_k = 0
+// (verification of linear proofs, linearity tests of commitments, ...)
+// All the claims of all the items are multiplied by independent random coefficients
+// and summed into a single multi-scalar multiplication (see batcher)
+//
+// If the combined equation does not hold, the items are split into two halves
+// that are verified recursively (bisection), so that finding c invalid items
+// out of m costs O(c log m) multi-scalar multiplications
+// In the honest case, a single multi-scalar multiplication is computed
+
+// Claim is a claim that sum_k Scalars[k] * Points[k] is the point at infinity
+// All the points must be on the curve (this is not checked)
+type Claim struct {
+	Points  []curve25519.PointXY
+	Scalars []curve25519.Scalar
+}
+
+// Batch accumulates claims of multiple items to verify them all at once
+// Items are identified by an integer chosen by the caller
+// A Batch is not safe for concurrent use
+type Batch struct {
+	items  []int           // items in order of insertion
+	claims map[int][]Claim // claims[item] are the claims of the item
+}
+
+// NewBatch creates an empty batch
+func NewBatch() *Batch {
+	return &Batch{
+		claims: map[int][]Claim{},
+	}
+}
+
+// AddClaim adds the claim c to the item
+func (b *Batch) AddClaim(item int, c Claim) error {
+	if len(c.Points) != len(c.Scalars) {
+		return fmt.Errorf("claim has %d points but %d scalars", len(c.Points), len(c.Scalars))
+	}
+	if _, ok := b.claims[item]; !ok {
+		b.items = append(b.items, item)
+	}
+	b.claims[item] = append(b.claims[item], c)
+	return nil
+}
+
+// AddProof adds the verification equations of the proof for stmt to the item
+// t must be the same transcript as the one given to Prove (see Prove)
+// t is modified by the function
+// It returns an error if the proof is malformed, in which case nothing is added
+func (b *Batch) AddProof(item int, t *transcript.Transcript, stmt *LinearStatement, proof *LinearProof) error {
+	err := checkProofShape(stmt, proof)
+	if err != nil {
+		return err
+	}
+	chal := stmt.Challenge(t, proof.Com)
+	for _, c := range proofClaims(stmt, proof, &chal) {
+		err = b.AddClaim(item, c)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddRangeProof adds the verification of the range proof for the commitment c to the item
+// t must be the same transcript as the one given to ProveRange
+// t is modified by the function
+// It returns an error if the proof is malformed, in which case nothing is added
+func (b *Batch) AddRangeProof(
+	item int, t *transcript.Transcript, c *curve25519.PointXY, k int, proof *RangeProof,
+) error {
+	claims, err := rangeClaims(t, c, k, proof)
+	if err != nil {
+		return err
+	}
+	for _, claim := range claims {
+		err = b.AddClaim(item, claim)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Verify verifies all the claims of all the items
+// and returns the sorted list of the items with at least one invalid claim
+// (empty if all the claims are valid)
+func (b *Batch) Verify() (invalidItems []int, err error) {
+	invalidItems, err = b.verifyItems(b.items)
+	if err != nil {
+		return nil, err
+	}
+	sort.Ints(invalidItems)
+	return invalidItems, nil
+}
+
+// verifyItems returns the invalid items among items, using bisection
+func (b *Batch) verifyItems(items []int) ([]int, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	bt, err := newBatcher()
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		for k := range b.claims[item] {
+			bt.addClaim(&b.claims[item][k])
+		}
+	}
+	ok, err := bt.acc.isZero()
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return nil, nil
+	}
+	if len(items) == 1 {
+		return items, nil
+	}
+
+	left, err := b.verifyItems(items[:len(items)/2])
+	if err != nil {
+		return nil, err
+	}
+	right, err := b.verifyItems(items[len(items)/2:])
+	if err != nil {
+		return nil, err
+	}
+	return append(left, right...), nil
+}
@@ -0,0 +1,105 @@
+package nizk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// genClaim generates a valid claim a G + b H - (a G + b H) = 0
+func genClaim(t *testing.T) Claim {
+	require := require.New(t)
+
+	a := curve25519.RandomScalar()
+	b := curve25519.RandomScalar()
+	p, err := curve25519.DoubleMultBaseGHPointXYScalar(a, b)
+	require.NoError(err)
+
+	return Claim{
+		Points:  []curve25519.PointXY{curve25519.BaseXYG, curve25519.BaseXYH, *p},
+		Scalars: []curve25519.Scalar{*a, *b, *curve25519.NegateScalar(&curve25519.ScalarOne)},
+	}
+}
+
+func TestBatchBisection(t *testing.T) {
+	testCases := []struct {
+		numItems int
+		invalid  []int
+	}{
+		{1, nil},
+		{1, []int{0}},
+		{7, nil},
+		{7, []int{3}},
+		{8, []int{0, 7}},
+		{13, []int{1, 2, 6, 12}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("items=%d,invalid=%v", tc.numItems, tc.invalid), func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			isInvalid := map[int]bool{}
+			for _, i := range tc.invalid {
+				isInvalid[i] = true
+			}
+
+			b := NewBatch()
+			for i := 0; i < tc.numItems; i++ {
+				// Items are not added in order and their identifiers are not contiguous
+				item := 10 * (tc.numItems - 1 - i)
+
+				stmt, wit := genDLStmtWit(t, 3)
+				proof, err := Prove(nil, transcript.New(fmt.Sprintf("item %d", item)), stmt, wit)
+				require.NoError(err)
+
+				c := genClaim(t)
+				if isInvalid[tc.numItems-1-i] {
+					if i%2 == 0 {
+						proof.Resp[1] = *curve25519.RandomScalar()
+					} else {
+						c.Scalars[0] = *curve25519.RandomScalar()
+					}
+				}
+
+				require.NoError(b.AddProof(item, transcript.New(fmt.Sprintf("item %d", item)), stmt, proof))
+				require.NoError(b.AddClaim(item, c))
+			}
+
+			invalidItems, err := b.Verify()
+			require.NoError(err)
+
+			expected := []int{}
+			for _, i := range tc.invalid {
+				expected = append(expected, 10*i)
+			}
+			assert.Equal(expected, append([]int{}, invalidItems...))
+		})
+	}
+}
+
+func TestBatchMalformed(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	b := NewBatch()
+
+	c := genClaim(t)
+	c.Scalars = c.Scalars[1:]
+	assert.Error(b.AddClaim(0, c))
+
+	stmt, wit := genDLStmtWit(t, 3)
+	proof, err := Prove(nil, transcript.New("test"), stmt, wit)
+	require.NoError(err)
+	proof.Com = proof.Com[1:]
+	assert.Error(b.AddProof(0, transcript.New("test"), stmt, proof))
+
+	// Nothing was added
+	invalidItems, err := b.Verify()
+	require.NoError(err)
+	assert.Empty(invalidItems)
+}
@@ -0,0 +1,204 @@
+package nizk
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+)
+
+// This file implements OR-composition of linear statements
+// following Cramer-Damgard-Schoenmakers (CDS94):
+// the prover shows that (at least) one of the statements S_0,...,S_{b-1} holds
+// without revealing which one
+//
+// The proof works as follows, if the prover knows a witness w for S_k:
+// for all i != k, simulate a proof of S_i:
+//    choose random challenge ch_i and random responses Resp_i
+//    and set Com_i = A_i Resp_i - ch_i X_i
+// for k, generate random scalars r and set Com_k = A_k r
+// absorb all the statements and the commitments into the transcript
+// and squeeze a challenge scalar ch
+// set ch_k = ch - sum_{i != k} ch_i and Resp_k = r + ch_k w
+//
+// Verification checks that sum_i ch_i = ch and that each (Com_i, ch_i, Resp_i)
+// is an accepting transcript for S_i (all the equations are batched)
+
+// OrProof is a proof for the OR-composition of statements
+// Branch i corresponds to statement i
+type OrProof struct {
+	Chal   []curve25519.Scalar `codec:"e"` // Chal[i] is the challenge of branch i
+	Proofs []LinearProof       `codec:"p"` // Proofs[i] contains the commitments and responses of branch i
+}
+
+// orChallenge computes the challenge of an OR-proof
+func orChallenge(t *transcript.Transcript, stmts []*LinearStatement, proofs []LinearProof) curve25519.Scalar {
+	t.AppendUint64("or", uint64(len(stmts)))
+	for i, stmt := range stmts {
+		stmt.appendToTranscript(t, proofs[i].Com)
+	}
+	return t.ChallengeScalar("chal")
+}
+
+// ProveOr generates a NIZK PoK of a witness of one of the statements stmts
+// wit must be a witness for stmts[k]
+// Does not verify the validity of the witness
+// rnd is the randomness source (system randomness if nil)
+// t is the transcript of the proof (see Prove) and is modified by the function
+func ProveOr(
+	rnd io.Reader, t *transcript.Transcript, stmts []*LinearStatement, k int, wit LinearWitness,
+) (*OrProof, error) {
+	if len(stmts) == 0 {
+		return nil, fmt.Errorf("no statement")
+	}
+	if k < 0 || k >= len(stmts) {
+		return nil, fmt.Errorf("invalid branch index %d", k)
+	}
+	for i, stmt := range stmts {
+		err := stmt.CheckStatement()
+		if err != nil {
+			return nil, fmt.Errorf("statement %d: %w", i, err)
+		}
+	}
+	if len(wit) != stmts[k].NumWitnesses {
+		return nil, fmt.Errorf("invalid witness length: %d != %d", len(wit), stmts[k].NumWitnesses)
+	}
+
+	chacha20Key, err := curve25519.RandomChacha20KeyFrom(rnd)
+	if err != nil {
+		return nil, err
+	}
+	nonce := uint64(0)
+	randomScalar := func() (s curve25519.Scalar) {
+		curve25519.RandomScalarChacha20C(&s, &chacha20Key, nonce)
+		nonce++
+		return
+	}
+
+	proof := &OrProof{
+		Chal:   make([]curve25519.Scalar, len(stmts)),
+		Proofs: make([]LinearProof, len(stmts)),
+	}
+
+	// Real branch: commitments of random scalars comLog
+	comLog := make([]curve25519.Scalar, stmts[k].NumWitnesses)
+	for j := range comLog {
+		comLog[j] = randomScalar()
+	}
+	proof.Proofs[k].Com = make([]curve25519.PointXY, len(stmts[k].Equations))
+	for i := range stmts[k].Equations {
+		c, err := evalEquation(&stmts[k].Equations[i], comLog)
+		if err != nil {
+			return nil, err
+		}
+		proof.Proofs[k].Com[i] = *c
+	}
+
+	// Simulated branches
+	for b, stmt := range stmts {
+		if b == k {
+			continue
+		}
+		proof.Chal[b] = randomScalar()
+		proof.Proofs[b].Resp = make([]curve25519.Scalar, stmt.NumWitnesses)
+		for j := range proof.Proofs[b].Resp {
+			proof.Proofs[b].Resp[j] = randomScalar()
+		}
+		proof.Proofs[b].Com, err = simulateCom(stmt, proof.Proofs[b].Resp, &proof.Chal[b])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Challenge of the real branch: ch_k = ch - sum_{b != k} ch_b
+	chal := orChallenge(t, stmts, proof.Proofs)
+	chalK := &chal
+	for b := range stmts {
+		if b != k {
+			chalK = curve25519.SubScalar(chalK, &proof.Chal[b])
+		}
+	}
+	proof.Chal[k] = *chalK
+
+	// Responses of the real branch: resp[j] = comLog[j] + ch_k * w_j
+	proof.Proofs[k].Resp = make([]curve25519.Scalar, len(wit))
+	for j := range wit {
+		r := curve25519.MultScalar(chalK, &wit[j])
+		proof.Proofs[k].Resp[j] = *curve25519.AddScalar(r, &comLog[j])
+	}
+
+	return proof, nil
+}
+
+// simulateCom computes the commitments Com_i = sum_j A_{i,j} Resp_j - ch X_i
+// so that (Com, ch, Resp) is an accepting transcript for stmt
+func simulateCom(
+	stmt *LinearStatement, resp []curve25519.Scalar, chal *curve25519.Scalar,
+) ([]curve25519.PointXY, error) {
+	negChal := curve25519.NegateScalar(chal)
+	com := make([]curve25519.PointXY, len(stmt.Equations))
+	for i := range stmt.Equations {
+		eq := &stmt.Equations[i]
+		bases := make([]curve25519.PointXY, 0, len(eq.Terms)+1)
+		scalars := make([]curve25519.Scalar, 0, len(eq.Terms)+1)
+		for _, term := range eq.Terms {
+			bases = append(bases, term.Base)
+			scalars = append(scalars, resp[term.Index])
+		}
+		bases = append(bases, eq.X)
+		scalars = append(scalars, *negChal)
+		c, err := curve25519.MultiMultPointXYScalar(bases, scalars)
+		if err != nil {
+			return nil, err
+		}
+		com[i] = *c
+	}
+	return com, nil
+}
+
+// VerifyOr verifies an OR-proof for the statements stmts
+// t must be the same transcript as the one given to ProveOr
+// t is modified by the function
+func VerifyOr(t *transcript.Transcript, stmts []*LinearStatement, proof *OrProof) error {
+	claims, err := orClaims(t, stmts, proof)
+	if err != nil {
+		return err
+	}
+	return verifyClaims(claims)
+}
+
+// orClaims verifies the shape and the challenges of the OR-proof for the statements stmts
+// and returns the claims equivalent to the verification equations of all the branches
+// t is the transcript of the proof (see VerifyOr) and is modified by the function
+func orClaims(t *transcript.Transcript, stmts []*LinearStatement, proof *OrProof) ([]Claim, error) {
+	if len(stmts) == 0 {
+		return nil, fmt.Errorf("no statement")
+	}
+	if len(proof.Chal) != len(stmts) || len(proof.Proofs) != len(stmts) {
+		return nil, fmt.Errorf("invalid number of branches")
+	}
+	for i, stmt := range stmts {
+		err := checkProofShape(stmt, &proof.Proofs[i])
+		if err != nil {
+			return nil, fmt.Errorf("branch %d: %w", i, err)
+		}
+	}
+
+	// Verify that the challenges sum to the challenge
+	chal := orChallenge(t, stmts, proof.Proofs)
+	sum := curve25519.ScalarZero
+	for i := range proof.Chal {
+		sum = *curve25519.AddScalar(&sum, &proof.Chal[i])
+	}
+	if !curve25519.ScalarEqual(&sum, &chal) {
+		return nil, fmt.Errorf("challenges do not sum to the challenge")
+	}
+
+	// Equations of each branch
+	var claims []Claim
+	for i, stmt := range stmts {
+		claims = append(claims, proofClaims(stmt, &proof.Proofs[i], &proof.Chal[i])...)
+	}
+	return claims, nil
+}
@@ -0,0 +1,100 @@
+package nizk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrProveCorrect(t *testing.T) {
+	testCases := []struct {
+		numBranches int
+	}{
+		{1},
+		{2},
+		{5},
+	}
+
+	for _, tc := range testCases {
+		for k := 0; k < tc.numBranches; k++ {
+			t.Run(fmt.Sprintf("branches=%d,k=%d", tc.numBranches, k), func(t *testing.T) {
+				require := require.New(t)
+				assert := assert.New(t)
+
+				// Only statement k is true
+				stmts := make([]*LinearStatement, tc.numBranches)
+				var wit LinearWitness
+				for b := 0; b < tc.numBranches; b++ {
+					var w LinearWitness
+					stmts[b], w = genLinearStmtWit(t, b+1, 2, 2)
+					if b == k {
+						wit = w
+					} else {
+						stmts[b].Equations[0].X = *curve25519.RandomPointXY()
+					}
+				}
+
+				proof, err := ProveOr(nil, transcript.New("test"), stmts, k, wit)
+				require.NoError(err)
+				assert.NoError(VerifyOr(transcript.New("test"), stmts, proof))
+
+				// Wrong domain
+				assert.Error(VerifyOr(transcript.New("other"), stmts, proof))
+
+				// Modified challenge
+				proof.Chal[0] = *curve25519.AddScalar(&proof.Chal[0], &curve25519.ScalarOne)
+				assert.Error(VerifyOr(transcript.New("test"), stmts, proof))
+			})
+		}
+	}
+}
+
+func TestOrProveAllFalse(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	stmts := make([]*LinearStatement, 3)
+	var wit LinearWitness
+	for b := range stmts {
+		stmts[b], wit = genLinearStmtWit(t, 2, 2, 1)
+		stmts[b].Equations[1].X = *curve25519.RandomPointXY()
+	}
+
+	proof, err := ProveOr(nil, transcript.New("test"), stmts, 2, wit)
+	require.NoError(err)
+	assert.Error(VerifyOr(transcript.New("test"), stmts, proof))
+}
+
+func TestPedersenBit(t *testing.T) {
+	for _, bit := range []uint64{0, 1, 2} {
+		t.Run(fmt.Sprintf("b=%d", bit), func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			r := curve25519.RandomScalar()
+			c, err := curve25519.DoubleMultBaseGHPointXYScalar(curve25519.GetScalar(bit), r)
+			require.NoError(err)
+
+			stmts, err := NewPedersenBitStatements(c)
+			require.NoError(err)
+
+			k := int(bit)
+			if bit > 1 {
+				k = 1 // try to cheat
+			}
+			proof, err := ProveOr(nil, transcript.New("bit"), stmts, k, LinearWitness{*r})
+			require.NoError(err)
+
+			err = VerifyOr(transcript.New("bit"), stmts, proof)
+			if bit <= 1 {
+				assert.NoError(err)
+			} else {
+				assert.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package nizk
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+)
+
+// This file implements range proofs over Pedersen commitments C = v G + r H
+// (G, H are the two main bases, see curve25519.DoubleMultBaseGHPointXYScalar)
+// showing that v is in [0, 2^k), using bit decomposition:
+//
+// The prover commits to each bit b_i of v: C_i = b_i G + r_i H
+// where r_1,...,r_{k-1} are random and r_0 = r - sum_{i>0} 2^i r_i
+// so that C = sum_i 2^i C_i
+// and proves that each C_i commits to a bit with an OR-proof (see NewPedersenBitStatements)
+//
+// Verification checks that C = sum_i 2^i C_i and all the OR-proofs
+// All the equations are batched in a single multi-scalar multiplication
+//
+// The proof has size O(k): k points and k OR-proofs (3 points and 4 scalars each)
+// A Bulletproofs-style proof would be logarithmic in k but is much more complex
+
+// MaxRangeBits is the maximum number of bits k of a range proof
+// It ensures that 2^k is smaller than the order L of the group (L > 2^252),
+// so that v in [0, 2^k) modulo L implies v in [0, 2^k) over the integers
+const MaxRangeBits = 252
+
+// RangeProof is a proof that a Pedersen commitment commits to a value in [0, 2^k)
+// k = len(Bits)
+type RangeProof struct {
+	Bits      []curve25519.PointXY `codec:"b"` // Bits[i] = C_i is a Pedersen commitment to the i-th bit
+	BitProofs []OrProof            `codec:"p"` // BitProofs[i] proves that Bits[i] commits to a bit
+}
+
+// rangeTranscripts absorbs the statement and the bit commitments into t
+// and returns the k transcripts of the OR-proofs
+func rangeTranscripts(
+	t *transcript.Transcript, c *curve25519.PointXY, bits []curve25519.PointXY,
+) []*transcript.Transcript {
+	t.AppendUint64("k", uint64(len(bits)))
+	t.AppendPointXY("C", c)
+	t.AppendPointsXY("bits", bits)
+
+	ts := make([]*transcript.Transcript, len(bits))
+	for i := range bits {
+		ts[i] = t.Clone()
+		ts[i].AppendUint64("i", uint64(i))
+	}
+	return ts
+}
+
+// twoPow returns the scalar 2^i for i < 256
+func twoPow(i int) *curve25519.Scalar {
+	var s curve25519.Scalar
+	s[i/8] = 1 << (i % 8)
+	return &s
+}
+
+// ProveRange generates a proof that the Pedersen commitment c = v G + r H commits to v in [0, 2^k)
+// Does not verify that c is a commitment to v with randomness r
+// rnd is the randomness source (system randomness if nil)
+// t is the transcript of the proof (see Prove) and is modified by the function
+func ProveRange(
+	rnd io.Reader, t *transcript.Transcript, c *curve25519.PointXY, k int, v, r *curve25519.Scalar,
+) (*RangeProof, error) {
+	if k <= 0 || k > MaxRangeBits {
+		return nil, fmt.Errorf("invalid number of bits %d", k)
+	}
+	// v must be a k-bit integer
+	for i := k; i < 256; i++ {
+		if v[i/8]>>(i%8)&1 != 0 {
+			return nil, fmt.Errorf("value is not in range")
+		}
+	}
+
+	chacha20Key, err := curve25519.RandomChacha20KeyFrom(rnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// randomness of the bit commitments
+	rBits := make([]curve25519.Scalar, k)
+	rBits[0] = *r
+	for i := 1; i < k; i++ {
+		curve25519.RandomScalarChacha20C(&rBits[i], &chacha20Key, uint64(i))
+		rBits[0] = *curve25519.SubScalar(&rBits[0], curve25519.MultScalar(twoPow(i), &rBits[i]))
+	}
+
+	proof := &RangeProof{
+		Bits:      make([]curve25519.PointXY, k),
+		BitProofs: make([]OrProof, k),
+	}
+	bits := make([]int, k)
+	for i := 0; i < k; i++ {
+		bits[i] = int(v[i/8] >> (i % 8) & 1)
+		ci, err := curve25519.DoubleMultBaseGHPointXYScalar(curve25519.GetScalar(uint64(bits[i])), &rBits[i])
+		if err != nil {
+			return nil, err
+		}
+		proof.Bits[i] = *ci
+	}
+
+	ts := rangeTranscripts(t, c, proof.Bits)
+	for i := 0; i < k; i++ {
+		stmts, err := NewPedersenBitStatements(&proof.Bits[i])
+		if err != nil {
+			return nil, err
+		}
+		bitProof, err := ProveOr(rnd, ts[i], stmts, bits[i], LinearWitness{rBits[i]})
+		if err != nil {
+			return nil, err
+		}
+		proof.BitProofs[i] = *bitProof
+	}
+
+	return proof, nil
+}
+
+// VerifyRange verifies a proof that the Pedersen commitment c commits to a value in [0, 2^k)
+// t must be the same transcript as the one given to ProveRange
+// t is modified by the function
+func VerifyRange(t *transcript.Transcript, c *curve25519.PointXY, k int, proof *RangeProof) error {
+	claims, err := rangeClaims(t, c, k, proof)
+	if err != nil {
+		return err
+	}
+	return verifyClaims(claims)
+}
+
+// rangeClaims verifies the shape of the range proof and the challenges of the OR-proofs
+// and returns the claims equivalent to the remaining verification equations
+// t is the transcript of the proof (see VerifyRange) and is modified by the function
+func rangeClaims(t *transcript.Transcript, c *curve25519.PointXY, k int, proof *RangeProof) ([]Claim, error) {
+	if k <= 0 || k > MaxRangeBits {
+		return nil, fmt.Errorf("invalid number of bits %d", k)
+	}
+	if len(proof.Bits) != k || len(proof.BitProofs) != k {
+		return nil, fmt.Errorf("invalid number of bits in proof")
+	}
+	if !curve25519.IsOnCurveXY(c) {
+		return nil, fmt.Errorf("commitment is not on the curve")
+	}
+	for i := range proof.Bits {
+		if !curve25519.IsOnCurveXY(&proof.Bits[i]) {
+			return nil, fmt.Errorf("bit commitment %d is not on the curve", i)
+		}
+	}
+
+	// C = sum_i 2^i C_i
+	sumClaim := Claim{
+		Points:  make([]curve25519.PointXY, 0, k+1),
+		Scalars: make([]curve25519.Scalar, 0, k+1),
+	}
+	for i := range proof.Bits {
+		sumClaim.Points = append(sumClaim.Points, proof.Bits[i])
+		sumClaim.Scalars = append(sumClaim.Scalars, *twoPow(i))
+	}
+	sumClaim.Points = append(sumClaim.Points, *c)
+	sumClaim.Scalars = append(sumClaim.Scalars, *curve25519.NegateScalar(&curve25519.ScalarOne))
+	claims := []Claim{sumClaim}
+
+	// Each C_i commits to a bit
+	ts := rangeTranscripts(t, c, proof.Bits)
+	for i := range proof.Bits {
+		stmts, err := NewPedersenBitStatements(&proof.Bits[i])
+		if err != nil {
+			return nil, err
+		}
+		bitClaims, err := orClaims(ts[i], stmts, &proof.BitProofs[i])
+		if err != nil {
+			return nil, fmt.Errorf("bit %d: %w", i, err)
+		}
+		claims = append(claims, bitClaims...)
+	}
+
+	return claims, nil
+}
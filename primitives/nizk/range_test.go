@@ -0,0 +1,92 @@
+package nizk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangeProof(t *testing.T) {
+	testCases := []struct {
+		k int
+		v uint64
+	}{
+		{1, 0},
+		{1, 1},
+		{8, 0},
+		{8, 255},
+		{16, 12345},
+		{64, 1<<64 - 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("k=%d,v=%d", tc.k, tc.v), func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			v := curve25519.GetScalar(tc.v)
+			r := curve25519.RandomScalar()
+			c, err := curve25519.DoubleMultBaseGHPointXYScalar(v, r)
+			require.NoError(err)
+
+			proof, err := ProveRange(nil, transcript.New("range"), c, tc.k, v, r)
+			require.NoError(err)
+			assert.NoError(VerifyRange(transcript.New("range"), c, tc.k, proof))
+
+			// Wrong domain
+			assert.Error(VerifyRange(transcript.New("other"), c, tc.k, proof))
+
+			// Wrong number of bits
+			assert.Error(VerifyRange(transcript.New("range"), c, tc.k+1, proof))
+
+			// Another commitment
+			c2, err := curve25519.DoubleMultBaseGHPointXYScalar(v, curve25519.RandomScalar())
+			require.NoError(err)
+			assert.Error(VerifyRange(transcript.New("range"), c2, tc.k, proof))
+		})
+	}
+}
+
+func TestRangeProofOutOfRange(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const k = 8
+
+	r := curve25519.RandomScalar()
+
+	// Honest prover refuses to prove a value out of range
+	v := curve25519.GetScalar(1 << k)
+	c, err := curve25519.DoubleMultBaseGHPointXYScalar(v, r)
+	require.NoError(err)
+	_, err = ProveRange(nil, transcript.New("range"), c, k, v, r)
+	assert.Error(err)
+
+	// Negative value -1 = L-1
+	_, err = ProveRange(nil, transcript.New("range"), c, k, curve25519.NegateScalar(&curve25519.ScalarOne), r)
+	assert.Error(err)
+
+	// Cheating prover: proof for v = 2^k - 1 used for the commitment to 2^k
+	v = curve25519.GetScalar(1<<k - 1)
+	cv, err := curve25519.DoubleMultBaseGHPointXYScalar(v, r)
+	require.NoError(err)
+	proof, err := ProveRange(nil, transcript.New("range"), cv, k, v, r)
+	require.NoError(err)
+	assert.Error(VerifyRange(transcript.New("range"), c, k, proof))
+
+	// Cheating prover: bit commitment to 2
+	bit1, err := curve25519.AddPointXY(&proof.Bits[1], &curve25519.BaseXYG)
+	require.NoError(err)
+	bit0, err := curve25519.SubPointXY(&proof.Bits[0], &curve25519.BaseXYG)
+	require.NoError(err)
+	proof.Bits[0], proof.Bits[1] = *bit0, *bit1
+	assert.Error(VerifyRange(transcript.New("range"), cv, k, proof))
+
+	// Invalid number of bits
+	_, err = ProveRange(nil, transcript.New("range"), cv, MaxRangeBits+1, v, r)
+	assert.Error(err)
+}
@@ -2,6 +2,7 @@ package pedersen
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/shaih/go-yosovss/primitives/curve25519"
 )
@@ -40,8 +41,17 @@ func GenerateParams() *Params {
 
 // GenerateCommitment creates a commitment for some value m
 func GenerateCommitment(params *Params, m *Message) (*Commitment, *Decommitment, error) {
+	return GenerateCommitmentFrom(nil, params, m)
+}
+
+// GenerateCommitmentFrom is like GenerateCommitment but uses rnd as randomness source
+// If rnd is nil, system randomness is used
+func GenerateCommitmentFrom(rnd io.Reader, params *Params, m *Message) (*Commitment, *Decommitment, error) {
 
-	r := curve25519.RandomScalar()
+	r, err := curve25519.RandomScalarFrom(rnd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("commitment generation failed: %w", err)
+	}
 
 	c, err := curve25519.DoubleMultBaseGHPointXYScalar(m, r) // Compute g^m * h^r
 	if err != nil {
@@ -2,6 +2,7 @@ package shamir
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/shaih/go-yosovss/primitives/curve25519"
 )
@@ -18,6 +19,12 @@ type Message = curve25519.Scalar
 
 // GenerateShares creates shares of t-of-n Shamir secret sharing for some secret m
 func GenerateShares(m Message, t int, n int) (shares []Share, err error) {
+	return GenerateSharesFrom(nil, m, t, n)
+}
+
+// GenerateSharesFrom is like GenerateShares but uses rnd as randomness source
+// If rnd is nil, system randomness is used
+func GenerateSharesFrom(rnd io.Reader, m Message, t int, n int) (shares []Share, err error) {
 	// The shares to be distributed to participants
 	shares = make([]Share, n)
 
@@ -28,7 +35,7 @@ func GenerateShares(m Message, t int, n int) (shares []Share, err error) {
 	f.Coefficients[0] = curve25519.Scalar(m)
 
 	// Generate random values for remaining coefficients
-	chacha20Key, err := curve25519.RandomChacha20Key()
+	chacha20Key, err := curve25519.RandomChacha20KeyFrom(rnd)
 	if err != nil {
 		return nil, err
 	}
@@ -27,3 +27,24 @@ func TestShamirSecretSharing(t *testing.T) {
 
 	assert.Equal(t, m, *res, "Commitment is consistent")
 }
+
+func TestShamirSecretSharingDeterministic(t *testing.T) {
+	m := Message(*curve25519.RandomScalar())
+
+	shares1, err := GenerateSharesFrom(curve25519.NewDeterministicRandom([]byte("seed")), m, 3, 5)
+	if err != nil {
+		log.Fatal(err)
+	}
+	shares2, err := GenerateSharesFrom(curve25519.NewDeterministicRandom([]byte("seed")), m, 3, 5)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	assert.Equal(t, shares1, shares2, "Same seed gives same shares")
+
+	res, err := Reconstruct(shares1[2:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	assert.Equal(t, m, *res, "Deterministic shares are consistent")
+}
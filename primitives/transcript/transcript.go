@@ -0,0 +1,153 @@
+package transcript
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"io"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+)
+
+// Transcript is a Fiat-Shamir transcript used to derive challenges of NIZK proofs
+// (or any other public randomness derived from public values)
+//
+// The transcript has a state of 64 bytes that is updated every time a value is absorbed:
+//    state = SHA512(state || op || len(label) || label || len(data) || data)
+// where op is a one-byte operation separator, and lengths are 8-byte little-endian
+// The initial state is derived from a domain separator, so that two transcripts
+// with different domains never produce related challenges
+//
+// Challenge scalars are squeezed by wide reduction:
+// a 64-byte output of SHA512 is reduced modulo the order L of the main subgroup,
+// so that the scalars are statistically close to uniform (bias ~2^{-259})
+// Any squeeze is absorbed back into the state, so that later challenges depend
+// on earlier ones
+//
+// A Transcript is not safe for concurrent use
+type Transcript struct {
+	state [sha512.Size]byte
+}
+
+// Operation separators
+const (
+	opDomain  byte = 0
+	opAppend  byte = 1
+	opSqueeze byte = 2
+)
+
+// protocolLabel is the label used to derive the initial state of any transcript
+const protocolLabel = "yosovss-transcript-v1"
+
+// New creates a new transcript with the domain separator domain
+// Each type of proof/usage must use a different domain
+func New(domain string) *Transcript {
+	t := &Transcript{}
+	t.update(opDomain, protocolLabel, []byte(domain))
+	return t
+}
+
+// update absorbs (op, label, data) into the state
+func (t *Transcript) update(op byte, label string, data []byte) {
+	h := sha512.New()
+	h.Write(t.state[:])
+	h.Write([]byte{op})
+	writeLengthPrefixed(h, []byte(label))
+	writeLengthPrefixed(h, data)
+	h.Sum(t.state[:0])
+}
+
+func writeLengthPrefixed(w io.Writer, data []byte) {
+	var l [8]byte
+	binary.LittleEndian.PutUint64(l[:], uint64(len(data)))
+	_, _ = w.Write(l[:])
+	_, _ = w.Write(data)
+}
+
+// Clone returns a copy of the transcript
+// Both transcripts can then be used independently
+func (t *Transcript) Clone() *Transcript {
+	c := *t
+	return &c
+}
+
+// AppendMessage absorbs arbitrary bytes with the given label
+func (t *Transcript) AppendMessage(label string, data []byte) {
+	t.update(opAppend, label, data)
+}
+
+// AppendUint64 absorbs an integer with the given label
+func (t *Transcript) AppendUint64(label string, x uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], x)
+	t.update(opAppend, label, b[:])
+}
+
+// AppendScalar absorbs a scalar with the given label
+func (t *Transcript) AppendScalar(label string, s *curve25519.Scalar) {
+	t.update(opAppend, label, s[:])
+}
+
+// AppendScalars absorbs a vector of scalars with the given label
+// The number of scalars is absorbed too
+func (t *Transcript) AppendScalars(label string, s []curve25519.Scalar) {
+	data := make([]byte, 0, len(s)*len(curve25519.Scalar{}))
+	for i := range s {
+		data = append(data, s[i][:]...)
+	}
+	t.update(opAppend, label, data)
+}
+
+// AppendPoint absorbs a point with the given label
+func (t *Transcript) AppendPoint(label string, p *curve25519.Point) {
+	t.update(opAppend, label, p[:])
+}
+
+// AppendPointXY absorbs a point (in XY format) with the given label
+func (t *Transcript) AppendPointXY(label string, p *curve25519.PointXY) {
+	t.update(opAppend, label, p[:])
+}
+
+// AppendPointsXY absorbs a vector of points (in XY format) with the given label
+// The number of points is absorbed too
+func (t *Transcript) AppendPointsXY(label string, p []curve25519.PointXY) {
+	data := make([]byte, 0, len(p)*len(curve25519.PointXY{}))
+	for i := range p {
+		data = append(data, p[i][:]...)
+	}
+	t.update(opAppend, label, data)
+}
+
+// ChallengeScalar squeezes a uniformly distributed scalar with the given label
+func (t *Transcript) ChallengeScalar(label string) curve25519.Scalar {
+	return t.ChallengeScalars(label, 1)[0]
+}
+
+// ChallengeScalars squeezes n uniformly distributed scalars with the given label
+// The n scalars are independent (in the random oracle model)
+func (t *Transcript) ChallengeScalars(label string, n int) []curve25519.Scalar {
+	// Derive a seed from the current state and absorb it back
+	// so that the next challenges are different
+	var nb [8]byte
+	binary.LittleEndian.PutUint64(nb[:], uint64(n))
+	t.update(opSqueeze, label, nb[:])
+	seed := t.state
+
+	// Expand the seed into n scalars using wide reduction
+	out := make([]curve25519.Scalar, n)
+	var wide [sha512.Size]byte
+	var ib [8]byte
+	for i := 0; i < n; i++ {
+		binary.LittleEndian.PutUint64(ib[:], uint64(i))
+		h := sha512.New()
+		h.Write(seed[:])
+		h.Write([]byte{opSqueeze})
+		h.Write(ib[:])
+		h.Sum(wide[:0])
+		out[i] = *curve25519.ReduceScalar(&wide)
+	}
+
+	// Separate the state from the seed used above
+	t.update(opSqueeze, label, nil)
+
+	return out
+}
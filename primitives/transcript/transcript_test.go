@@ -0,0 +1,64 @@
+package transcript
+
+import (
+	"testing"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTranscript(domain string, s *curve25519.Scalar) *Transcript {
+	t := New(domain)
+	t.AppendMessage("msg", []byte("hello"))
+	t.AppendUint64("n", 42)
+	t.AppendScalar("s", s)
+	t.AppendPointsXY("pts", []curve25519.PointXY{curve25519.BaseXYG, curve25519.BaseXYH})
+	return t
+}
+
+func TestTranscriptDeterministic(t *testing.T) {
+	assert := assert.New(t)
+
+	s := curve25519.RandomScalar()
+
+	c1 := newTestTranscript("test", s).ChallengeScalars("e", 5)
+	c2 := newTestTranscript("test", s).ChallengeScalars("e", 5)
+	assert.Equal(c1, c2, "same inputs give same challenges")
+
+	// All the squeezed scalars are different
+	for i := 0; i < len(c1); i++ {
+		for j := i + 1; j < len(c1); j++ {
+			assert.NotEqual(c1[i], c1[j], "challenges %d and %d are equal", i, j)
+		}
+	}
+}
+
+func TestTranscriptSeparation(t *testing.T) {
+	assert := assert.New(t)
+
+	s := curve25519.RandomScalar()
+	c := newTestTranscript("test", s).ChallengeScalar("e")
+
+	// Different domain
+	assert.NotEqual(c, newTestTranscript("other", s).ChallengeScalar("e"))
+
+	// Different label for the challenge
+	assert.NotEqual(c, newTestTranscript("test", s).ChallengeScalar("f"))
+
+	// Different absorbed value
+	assert.NotEqual(c, newTestTranscript("test", curve25519.RandomScalar()).ChallengeScalar("e"))
+
+	// Same bytes but different labels/splitting
+	t1 := New("test")
+	t1.AppendMessage("a", []byte("bc"))
+	t2 := New("test")
+	t2.AppendMessage("ab", []byte("c"))
+	assert.NotEqual(t1.ChallengeScalar("e"), t2.ChallengeScalar("e"))
+
+	// Successive challenges are different and depend on the previous ones
+	t3 := newTestTranscript("test", s)
+	t4 := t3.Clone()
+	assert.Equal(c, t3.ChallengeScalar("e"))
+	assert.NotEqual(c, t3.ChallengeScalar("e"))
+	assert.Equal(c, t4.ChallengeScalar("e"), "clone is independent")
+}
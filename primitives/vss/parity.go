@@ -1,7 +1,10 @@
 package vss
 
 import (
+	"fmt"
+
 	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
 	"github.com/shaih/go-yosovss/primitives/vss/paritycpp"
 )
 
@@ -25,3 +28,88 @@ func ComputeParityMatrix(n, t int) (*curve25519.ScalarMatrix, error) {
 
 	return mat, nil
 }
+
+// GeneratorMatrix computes the generator matrix G of the code of valid sharings
+// for a polynomial of degree t-1 evaluated at 0,1,...,n
+// A sharing sigma = (sigma_0,...,sigma_n) is valid iff sigma = u * G for some u
+// The row i of G is (0^i, 1^i, ..., n^i) (with 0^0 = 1)
+//
+// Matrix has size t x (n+1)
+func GeneratorMatrix(n, t int) *curve25519.ScalarMatrix {
+	gen := curve25519.NewScalarMatrix(t, n+1)
+
+	for j := 0; j <= n; j++ {
+		jScalar := curve25519.GetScalar(uint64(j))
+		// first row is just 1
+		gen.Set(0, j, &curve25519.ScalarOne)
+		// other columns are product of previous row with j
+		for i := 1; i < t; i++ {
+			gen.Set(i, j, curve25519.MultScalar(gen.At(i-1, j), jScalar))
+		}
+	}
+
+	return gen
+}
+
+// ParityMatrixFromGenerator computes a parity-check matrix H for the linear code
+// generated by the rows of gen, i.e., such that sigma * H = 0 iff
+// sigma is in the row space of gen
+// Contrary to ComputeParityMatrix, this works for any linear code
+// (not only Shamir sharings) and is computed in Go
+//
+// Matrix has size gen.Columns() x (gen.Columns() - rank(gen))
+func ParityMatrixFromGenerator(gen *curve25519.ScalarMatrix) (*curve25519.ScalarMatrix, error) {
+	return gen.Kernel()
+}
+
+// VerifyParityMatrix checks that h is a parity-check matrix for the code generated by gen
+// i.e., gen * h = 0 and the columns of h span the whole dual code
+func VerifyParityMatrix(gen, h *curve25519.ScalarMatrix) error {
+	prod, err := curve25519.ScalarMatrixMul(gen, h)
+	if err != nil {
+		return err
+	}
+	if !prod.IsZero() {
+		return fmt.Errorf("product of generator matrix and parity-check matrix is not 0")
+	}
+
+	rankGen, err := gen.Rank()
+	if err != nil {
+		return err
+	}
+	rankH, err := h.Rank()
+	if err != nil {
+		return err
+	}
+	if rankGen+rankH != gen.Columns() {
+		return fmt.Errorf("parity-check matrix has rank %d but expected %d", rankH, gen.Columns()-rankGen)
+	}
+
+	return nil
+}
+
+// VerifyCommitmentsInCode verifies that a vector of commitments lies in the linear code
+// defined by the parity-check matrix h, i.e., that commitments * h = 0
+// (the commitments are then commitments to a codeword, e.g., a valid sharing)
+func VerifyCommitmentsInCode(commitments []pedersen.Commitment, h *curve25519.ScalarMatrix) (bool, error) {
+	if len(commitments) != h.Rows() {
+		return false, fmt.Errorf("number of commitments is %d not equal to number of rows of parity matrix %d",
+			len(commitments),
+			h.Rows(),
+		)
+	}
+
+	comVector := curve25519.PointXYMatrixFromEntries(1, len(commitments), commitments)
+	y, err := curve25519.PointXYMatrixScalarMatrixMul(comVector, h)
+	if err != nil {
+		return false, err
+	}
+
+	// Checking that the vector y is zero
+	for j := 0; j < h.Columns(); j++ {
+		if !curve25519.PointXYEqual(&curve25519.PointXYInfinity, y.At(0, j)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
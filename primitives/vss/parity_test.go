@@ -5,26 +5,11 @@ import (
 	"testing"
 
 	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func generatorMatrix(n, t int) *curve25519.ScalarMatrix {
-	gen := curve25519.NewScalarMatrix(t, n+1)
-
-	for j := 0; j <= n; j++ {
-		jScalar := curve25519.GetScalar(uint64(j))
-		// first row is just 1
-		gen.Set(0, j, &curve25519.ScalarOne)
-		// other columns are product of previous row with j
-		for i := 1; i < t; i++ {
-			gen.Set(i, j, curve25519.MultScalar(gen.At(i-1, j), jScalar))
-		}
-	}
-
-	return gen
-}
-
 func TestComputeParityMatrix1x1(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -56,7 +41,7 @@ func TestComputeParityMatrix(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(fmt.Sprintf("n=%d,t=%d", tc.n, tc.t), func(t *testing.T) {
-			gen := generatorMatrix(tc.n, tc.t)
+			gen := GeneratorMatrix(tc.n, tc.t)
 
 			m, err := ComputeParityMatrix(tc.n, tc.t)
 
@@ -78,3 +63,72 @@ func TestComputeParityMatrix(t *testing.T) {
 		})
 	}
 }
+
+func TestParityMatrixFromGenerator(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	testCases := []struct {
+		n int
+		t int
+	}{
+		{5, 3},
+		{5, 5},
+		{5, 1},
+		{10, 7},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("n=%d,t=%d", tc.n, tc.t), func(t *testing.T) {
+			gen := GeneratorMatrix(tc.n, tc.t)
+
+			h, err := ParityMatrixFromGenerator(gen)
+			require.NoError(err)
+			require.Equal(tc.n+1, h.Rows())
+			require.Equal(tc.n+1-tc.t, h.Columns())
+			assert.NoError(VerifyParityMatrix(gen, h))
+
+			// The parity matrix computed by paritycpp must also be a valid parity-check matrix
+			m, err := ComputeParityMatrix(tc.n, tc.t)
+			require.NoError(err)
+			assert.NoError(VerifyParityMatrix(gen, m))
+
+			// A matrix with too few columns is not a parity-check matrix
+			if tc.t < tc.n {
+				hh := curve25519.NewScalarMatrix(tc.n+1, tc.n-tc.t)
+				for i := 0; i < tc.n+1; i++ {
+					for j := 0; j < tc.n-tc.t; j++ {
+						hh.Set(i, j, h.At(i, j))
+					}
+				}
+				assert.Error(VerifyParityMatrix(gen, hh))
+			}
+		})
+	}
+}
+
+func TestVerifyCommitmentsInCode(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	n, tt := 6, 3
+
+	params, err := NewVSSParams(pedersen.GenerateParams(), n, tt-1)
+	require.NoError(err)
+	_, commitments, err := FixedRShare(params, curve25519.RandomScalar(), curve25519.RandomScalar())
+	require.NoError(err)
+
+	h, err := ParityMatrixFromGenerator(GeneratorMatrix(n, tt))
+	require.NoError(err)
+
+	valid, err := VerifyCommitmentsInCode(commitments, h)
+	require.NoError(err)
+	assert.True(valid)
+
+	commitments[1] = *curve25519.RandomPointXY()
+	valid, err = VerifyCommitmentsInCode(commitments, h)
+	require.NoError(err)
+	assert.False(valid)
+
+	_, err = VerifyCommitmentsInCode(commitments[1:], h)
+	assert.Error(err)
+}
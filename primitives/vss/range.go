@@ -0,0 +1,50 @@
+package vss
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/nizk"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+)
+
+// secretRangeTranscript returns the transcript for the range proof of a shared secret
+// It only depends on commitments[0] (absorbed by nizk.ProveRange)
+// so that the proof remains valid when the shares are refreshed
+func secretRangeTranscript() *transcript.Transcript {
+	return transcript.New("vss_secret_range")
+}
+
+// RangeFixedRShareFrom is like FixedRShareFrom but also returns a proof that the secret s is in [0, 2^k)
+// i.e., that commitments[0] commits to a value in [0, 2^k) (see nizk.ProveRange)
+// The proof only depends on commitments[0], so it remains valid after any refresh of the sharing
+// that keeps commitments[0] (e.g., protocols/resharing), and needs to be made only once, by the dealer of the secret
+// It fails if s is not in [0, 2^k)
+func RangeFixedRShareFrom(rnd io.Reader, params *Params, s, r *curve25519.Scalar, k int) (
+	shares []Share, commitments []pedersen.Commitment, proof *nizk.RangeProof, err error) {
+
+	shares, commitments, err = FixedRShareFrom(rnd, params, s, r)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	proof, err = nizk.ProveRange(rnd, secretRangeTranscript(), &commitments[0], k, s, r)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error generating range proof of the secret: %w", err)
+	}
+	return shares, commitments, proof, nil
+}
+
+// VerifySecretRange verifies a proof that commitments[0] commits to a secret in [0, 2^k)
+// (see RangeFixedRShareFrom)
+func VerifySecretRange(commitments []pedersen.Commitment, k int, proof *nizk.RangeProof) error {
+	if len(commitments) == 0 {
+		return fmt.Errorf("missing commitment of the secret")
+	}
+	if proof == nil {
+		return fmt.Errorf("missing range proof")
+	}
+	return nizk.VerifyRange(secretRangeTranscript(), &commitments[0], k, proof)
+}
@@ -0,0 +1,60 @@
+package vss
+
+import (
+	"testing"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangeFixedRShare(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	const k = 16
+
+	params, err := NewVSSParams(pedersen.GenerateParams(), 5, 2)
+	require.NoError(err)
+
+	s := curve25519.GetScalar(1234)
+	r := curve25519.RandomScalar()
+
+	shares, commitments, proof, err := RangeFixedRShareFrom(nil, params, s, r, k)
+	require.NoError(err)
+	assert.NoError(VerifySecretRange(commitments, k, proof))
+
+	// The sharing is the usual one
+	valid, err := VerifyCommitments(params, commitments)
+	require.NoError(err)
+	assert.True(valid)
+	for i := range shares {
+		valid, err = VerifyShare(params, &shares[i], commitments)
+		require.NoError(err)
+		assert.True(valid)
+	}
+
+	// The proof only depends on the commitment of the secret
+	// so it still holds for another sharing of the same secret with the same randomness
+	_, otherCommitments, err := FixedRShare(params, s, r)
+	require.NoError(err)
+	assert.NotEqual(commitments[1:], otherCommitments[1:])
+	assert.NoError(VerifySecretRange(otherCommitments, k, proof))
+
+	// Wrong number of bits
+	assert.Error(VerifySecretRange(commitments, k-1, proof))
+
+	// Different secret
+	_, otherCommitments, err = FixedRShare(params, curve25519.GetScalar(1235), r)
+	require.NoError(err)
+	assert.Error(VerifySecretRange(otherCommitments, k, proof))
+
+	// Missing proof or commitments
+	assert.Error(VerifySecretRange(commitments, k, nil))
+	assert.Error(VerifySecretRange(nil, k, proof))
+
+	// Secret out of range
+	_, _, _, err = RangeFixedRShareFrom(nil, params, curve25519.GetScalar(1<<k), r, k)
+	assert.Error(err)
+}
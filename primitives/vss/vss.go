@@ -6,6 +6,7 @@ package vss
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/shaih/go-yosovss/primitives/curve25519"
 	"github.com/shaih/go-yosovss/primitives/pedersen"
@@ -196,6 +197,13 @@ func ReconstructWithRFromValidShares(params *Params, validShares []Share) (
 // commitments[i] for i > 1 is the Pedersen commitment of the share of index i, that is shares[i-1]
 func FixedRShare(params *Params, s, r *curve25519.Scalar) (
 	shares []Share, commitments []pedersen.Commitment, err error) {
+	return FixedRShareFrom(nil, params, s, r)
+}
+
+// FixedRShareFrom is like FixedRShare but uses rnd as randomness source
+// If rnd is nil, system randomness is used
+func FixedRShareFrom(rnd io.Reader, params *Params, s, r *curve25519.Scalar) (
+	shares []Share, commitments []pedersen.Commitment, err error) {
 
 	n := params.N
 	d := params.D
@@ -235,7 +243,7 @@ func FixedRShare(params *Params, s, r *curve25519.Scalar) (
 	commitments[0] = *commitment
 
 	// Generate random values for remaining coefficients
-	chacha20Key, err := curve25519.RandomChacha20Key()
+	chacha20Key, err := curve25519.RandomChacha20KeyFrom(rnd)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -291,29 +299,12 @@ func VerifyShare(params *Params, share *Share, commitments []pedersen.Commitment
 // VerifyCommitments verifies that the commitments are consistent
 // i.e., they are on a polynomial of degree d
 func VerifyCommitments(params *Params, commitments []pedersen.Commitment) (bool, error) {
-	n := params.N
-	d := params.D
-
 	err := checkCommitmentsLength(params, commitments)
 	if err != nil {
 		return false, err
 	}
 
-	comVector := curve25519.PointXYMatrixFromEntries(1, n+1, commitments)
-	y, err := curve25519.PointXYMatrixScalarMatrixMul(comVector, &params.ParityMatrix)
-	if err != nil {
-		return false, err
-	}
-
-	// Checking that the vector y is zero
-	zero := true
-	for j := 0; j < n-d; j++ {
-		if !curve25519.PointXYEqual(&curve25519.PointXYInfinity, y.At(0, j)) {
-			zero = false
-			break
-		}
-	}
-	return zero, nil
+	return VerifyCommitmentsInCode(commitments, &params.ParityMatrix)
 }
 
 // VerifyCommitmentsRandomized is similar to VerifyCommitments
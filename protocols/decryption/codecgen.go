@@ -0,0 +1,7 @@
+//go:build generate
+// +build generate
+
+package decryption
+
+//go:generate codecgen -o gen-codecgen.go decryption_share.go
+//go:generate gofmt -w gen-codecgen.go
@@ -0,0 +1,149 @@
+package decryption
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/elgamal"
+	"github.com/shaih/go-yosovss/primitives/nizk"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+	log "github.com/sirupsen/logrus"
+)
+
+// DecryptionShareMessage is the message parties send during the decryption round
+// Notations below are for party j in [0,n-1] and R_{c,k} the chunk k of the ciphertext c
+// (see elgamal.Ciphertext)
+type DecryptionShareMessage struct {
+	_struct struct{}             `codec:",omitempty,omitemptyarray"`
+	D       []curve25519.PointXY `codec:"D"` // D[c*elgamal.NumChunks+k] = s_{j+1} R_{c,k}
+	Com     []curve25519.PointXY `codec:"c"` // Com and Resp are the proof for nizk.NewPedersenDLEqStatement
+	Resp    []curve25519.Scalar  `codec:"r"`
+}
+
+// decryptionShareTranscript returns the transcript for the proof of the decryption share of party j
+func decryptionShareTranscript(sessionID []byte, j int) *transcript.Transcript {
+	t := transcript.New("decryption_share")
+	t.AppendMessage("session", sessionID)
+	t.AppendUint64("j", uint64(j))
+	return t
+}
+
+// chunkRs returns the list of all the R_{c,k} (see DecryptionShareMessage)
+func chunkRs(ciphertexts []elgamal.Ciphertext) []curve25519.PointXY {
+	rs := make([]curve25519.PointXY, 0, len(ciphertexts)*elgamal.NumChunks)
+	for c := range ciphertexts {
+		rs = append(rs, ciphertexts[c].R...)
+	}
+	return rs
+}
+
+// PerformDecryptionShare executes what party j does in the decryption round:
+// it publishes its decryption shares with a proof that they are consistent
+// with the commitment to its share
+func PerformDecryptionShare(pub *PublicInput, prv *PrivateInput, j int) (*DecryptionShareMessage, error) {
+	rs := chunkRs(pub.Ciphertexts)
+	msg := &DecryptionShareMessage{
+		D: make([]curve25519.PointXY, len(rs)),
+	}
+	for l := range rs {
+		d, err := curve25519.MultPointXYScalar(&rs[l], &prv.Share.S)
+		if err != nil {
+			return nil, err
+		}
+		msg.D[l] = *d
+	}
+
+	stmt, err := nizk.NewPedersenDLEqStatement(&pub.Commitments[j+1], rs, msg.D)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := nizk.Prove(
+		prv.Rand, decryptionShareTranscript(pub.SessionID, j), stmt, nizk.LinearWitness{prv.Share.S, prv.Share.R},
+	)
+	if err != nil {
+		return nil, err
+	}
+	msg.Com = proof.Com
+	msg.Resp = proof.Resp
+	return msg, nil
+}
+
+// VerifyDecryptionShare verifies the decryption shares of party j
+func VerifyDecryptionShare(pub *PublicInput, j int, msg *DecryptionShareMessage) error {
+	rs := chunkRs(pub.Ciphertexts)
+	if len(msg.D) != len(rs) {
+		return fmt.Errorf("invalid number of decryption shares")
+	}
+	for l := range msg.D {
+		if !curve25519.IsOnCurveXY(&msg.D[l]) {
+			return fmt.Errorf("decryption share %d not on the curve", l)
+		}
+	}
+
+	stmt, err := nizk.NewPedersenDLEqStatement(&pub.Commitments[j+1], rs, msg.D)
+	if err != nil {
+		return err
+	}
+	return nizk.Verify(
+		decryptionShareTranscript(pub.SessionID, j), stmt, &nizk.LinearProof{Com: msg.Com, Resp: msg.Resp},
+	)
+}
+
+// CombineDecryptionShares decrypts all the ciphertexts using the decryption shares of t+1 parties
+// with valid shares (see VerifyDecryptionShare)
+// The masks s R_{c,k} are the Lagrange interpolations in the exponent of the decryption shares
+// plaintexts[c] is the plaintext of the ciphertext c, or nil if the ciphertext cannot be decrypted
+// (see elgamal.DecryptWithMasks)
+func CombineDecryptionShares(
+	pub *PublicInput, msgs []DecryptionShareMessage,
+) (
+	plaintexts []*curve25519.Scalar, err error,
+) {
+	var js []int
+	for j := range msgs {
+		err := VerifyDecryptionShare(pub, j, &msgs[j])
+		if err != nil {
+			log.Infof("invalid decryption share of party %d: %v", j, err)
+			continue
+		}
+		js = append(js, j)
+		if len(js) == pub.T+1 {
+			break
+		}
+	}
+	if len(js) < pub.T+1 {
+		return nil, fmt.Errorf("only %d valid decryption shares (less than t+1)", len(js))
+	}
+
+	indices := make([]curve25519.Scalar, len(js))
+	for q, j := range js {
+		curve25519.GetScalarC(&indices[q], uint64(j+1))
+	}
+	coeffs, err := curve25519.LagrangeCoeffs(indices, &curve25519.ScalarZero)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintexts = make([]*curve25519.Scalar, len(pub.Ciphertexts))
+	ds := make([]curve25519.PointXY, len(js))
+	for c := range pub.Ciphertexts {
+		masks := make([]curve25519.PointXY, elgamal.NumChunks)
+		for k := 0; k < elgamal.NumChunks; k++ {
+			for q, j := range js {
+				ds[q] = msgs[j].D[c*elgamal.NumChunks+k]
+			}
+			mask, err := curve25519.MultiMultPointXYScalarVarTime(ds, coeffs)
+			if err != nil {
+				return nil, err
+			}
+			masks[k] = *mask
+		}
+
+		plaintexts[c], err = elgamal.DecryptWithMasks(&pub.Ciphertexts[c], masks)
+		if err != nil {
+			log.Infof("ciphertext %d cannot be decrypted: %v", c, err)
+			plaintexts[c] = nil
+		}
+	}
+	return plaintexts, nil
+}
@@ -0,0 +1,483 @@
+// #nosec
+//go:build go1.6
+// +build go1.6
+
+// Code generated by codecgen - DO NOT EDIT.
+
+package decryption
+
+import (
+	"errors"
+	pkg1_curve25519 "github.com/shaih/go-yosovss/primitives/curve25519"
+	codec1978 "github.com/ugorji/go/codec"
+	"runtime"
+	"strconv"
+)
+
+const (
+	// ----- content types ----
+	codecSelferCcUTF8943 = 1
+	codecSelferCcRAW943  = 255
+	// ----- value types used ----
+	codecSelferValueTypeArray943     = 10
+	codecSelferValueTypeMap943       = 9
+	codecSelferValueTypeString943    = 6
+	codecSelferValueTypeInt943       = 2
+	codecSelferValueTypeUint943      = 3
+	codecSelferValueTypeFloat943     = 4
+	codecSelferValueTypeNil943       = 1
+	codecSelferBitsize943            = uint8(32 << (^uint(0) >> 63))
+	codecSelferDecContainerLenNil943 = -2147483648
+)
+
+var (
+	errCodecSelferOnlyMapOrArrayEncodeToStruct943 = errors.New(`only encoded map or array can be decoded into a struct`)
+)
+
+type codecSelfer943 struct{}
+
+func codecSelfer943False() bool { return false }
+func codecSelfer943True() bool  { return true }
+
+func init() {
+	if codec1978.GenVersion != 25 {
+		_, file, _, _ := runtime.Caller(0)
+		ver := strconv.FormatInt(int64(codec1978.GenVersion), 10)
+		panic(errors.New("codecgen version mismatch: current: 25, need " + ver + ". Re-generate file: " + file))
+	}
+	if false { // reference the types, but skip this branch at build/run time
+		var _ pkg1_curve25519.PointXY
+	}
+}
+
+func (DecryptionShareMessage) codecSelferViaCodecgen() {}
+func (x *DecryptionShareMessage) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
+		return
+	}
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyq2 = [3]bool{     // should field at this index be written?
+			len(x.D) != 0,    // D
+			len(x.Com) != 0,  // c
+			len(x.Resp) != 0, // r
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(3)
+			z.EncWriteArrayElem()
+			if yyq2[0] {
+				if x.D == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.D), e)
+				} // end block: if x.D slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[1] {
+				if x.Com == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.Com), e)
+				} // end block: if x.Com slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[2] {
+				if x.Resp == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_Scalar(([]pkg1_curve25519.Scalar)(x.Resp), e)
+				} // end block: if x.Resp slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
+				}
+			}
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"D\"")
+				} else {
+					r.EncodeString(`D`)
+				}
+				z.EncWriteMapElemValue()
+				if x.D == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.D), e)
+				} // end block: if x.D slice == nil
+			}
+			if yyq2[1] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"c\"")
+				} else {
+					r.EncodeString(`c`)
+				}
+				z.EncWriteMapElemValue()
+				if x.Com == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.Com), e)
+				} // end block: if x.Com slice == nil
+			}
+			if yyq2[2] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"r\"")
+				} else {
+					r.EncodeString(`r`)
+				}
+				z.EncWriteMapElemValue()
+				if x.Resp == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_Scalar(([]pkg1_curve25519.Scalar)(x.Resp), e)
+				} // end block: if x.Resp slice == nil
+			}
+			z.EncWriteMapEnd()
+		}
+	}
+}
+
+func (x *DecryptionShareMessage) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = DecryptionShareMessage{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
+		} else {
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
+		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
+	}
+}
+
+func (x *DecryptionShareMessage) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "D":
+			h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.D), d)
+		case "c":
+			h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.Com), d)
+		case "r":
+			h.decSlicecurve25519_Scalar((*[]pkg1_curve25519.Scalar)(&x.Resp), d)
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *DecryptionShareMessage) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj10 int
+	var yyb10 bool
+	var yyhl10 bool = l >= 0
+	yyj10++
+	if yyhl10 {
+		yyb10 = yyj10 > l
+	} else {
+		yyb10 = z.DecCheckBreak()
+	}
+	if yyb10 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.D), d)
+	yyj10++
+	if yyhl10 {
+		yyb10 = yyj10 > l
+	} else {
+		yyb10 = z.DecCheckBreak()
+	}
+	if yyb10 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.Com), d)
+	yyj10++
+	if yyhl10 {
+		yyb10 = yyj10 > l
+	} else {
+		yyb10 = z.DecCheckBreak()
+	}
+	if yyb10 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSlicecurve25519_Scalar((*[]pkg1_curve25519.Scalar)(&x.Resp), d)
+	for {
+		yyj10++
+		if yyhl10 {
+			yyb10 = yyj10 > l
+		} else {
+			yyb10 = z.DecCheckBreak()
+		}
+		if yyb10 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj10-1, "")
+	}
+}
+
+func (x *DecryptionShareMessage) IsCodecEmpty() bool {
+	return !(len(x.D) != 0 || len(x.Com) != 0 || len(x.Resp) != 0 || false)
+}
+
+func (x codecSelfer943) encSlicecurve25519_PointXY(v []pkg1_curve25519.PointXY, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	z.EncWriteArrayStart(len(v))
+	for yyv1 := range v {
+		z.EncWriteArrayElem()
+		yy2 := &v[yyv1]
+		if yyxt3 := z.Extension(yy2); yyxt3 != nil {
+			z.EncExtension(yy2, yyxt3)
+		} else {
+			z.F.EncSliceUint8V(([]uint8)(yy2[:]), e)
+		}
+	}
+	z.EncWriteArrayEnd()
+}
+
+func (x codecSelfer943) decSlicecurve25519_PointXY(v *[]pkg1_curve25519.PointXY, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyh1.IsNil {
+		if yyv1 != nil {
+			yyv1 = nil
+			yyc1 = true
+		}
+	} else if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = []pkg1_curve25519.PointXY{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else {
+		yyhl1 := yyl1 > 0
+		var yyrl1 int
+		_ = yyrl1
+		if yyhl1 {
+			if yyl1 > cap(yyv1) {
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 64)
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
+				} else {
+					yyv1 = make([]pkg1_curve25519.PointXY, yyrl1)
+				}
+				yyc1 = true
+			} else if yyl1 != len(yyv1) {
+				yyv1 = yyv1[:yyl1]
+				yyc1 = true
+			}
+		}
+		var yyj1 int
+		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
+			if yyj1 == 0 && yyv1 == nil {
+				if yyhl1 {
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 64)
+				} else {
+					yyrl1 = 8
+				}
+				yyv1 = make([]pkg1_curve25519.PointXY, yyrl1)
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			var yydb1 bool
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, pkg1_curve25519.PointXY{})
+				yyc1 = true
+			}
+			if yydb1 {
+				z.DecSwallow()
+			} else {
+				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
+					z.DecExtension(&yyv1[yyj1], yyxt3)
+				} else {
+					z.F.DecSliceUint8N(([]uint8)(yyv1[yyj1][:]), d)
+				}
+			}
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = make([]pkg1_curve25519.PointXY, 0)
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
+
+func (x codecSelfer943) encSlicecurve25519_Scalar(v []pkg1_curve25519.Scalar, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	z.EncWriteArrayStart(len(v))
+	for yyv1 := range v {
+		z.EncWriteArrayElem()
+		yy2 := &v[yyv1]
+		if yyxt3 := z.Extension(yy2); yyxt3 != nil {
+			z.EncExtension(yy2, yyxt3)
+		} else {
+			z.F.EncSliceUint8V(([]uint8)(yy2[:]), e)
+		}
+	}
+	z.EncWriteArrayEnd()
+}
+
+func (x codecSelfer943) decSlicecurve25519_Scalar(v *[]pkg1_curve25519.Scalar, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyh1.IsNil {
+		if yyv1 != nil {
+			yyv1 = nil
+			yyc1 = true
+		}
+	} else if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = []pkg1_curve25519.Scalar{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else {
+		yyhl1 := yyl1 > 0
+		var yyrl1 int
+		_ = yyrl1
+		if yyhl1 {
+			if yyl1 > cap(yyv1) {
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 32)
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
+				} else {
+					yyv1 = make([]pkg1_curve25519.Scalar, yyrl1)
+				}
+				yyc1 = true
+			} else if yyl1 != len(yyv1) {
+				yyv1 = yyv1[:yyl1]
+				yyc1 = true
+			}
+		}
+		var yyj1 int
+		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
+			if yyj1 == 0 && yyv1 == nil {
+				if yyhl1 {
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 32)
+				} else {
+					yyrl1 = 8
+				}
+				yyv1 = make([]pkg1_curve25519.Scalar, yyrl1)
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			var yydb1 bool
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, pkg1_curve25519.Scalar{})
+				yyc1 = true
+			}
+			if yydb1 {
+				z.DecSwallow()
+			} else {
+				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
+					z.DecExtension(&yyv1[yyj1], yyxt3)
+				} else {
+					z.F.DecSliceUint8N(([]uint8)(yyv1[yyj1][:]), d)
+				}
+			}
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = make([]pkg1_curve25519.Scalar, 0)
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
@@ -0,0 +1,35 @@
+// This file was automatically generated by genny.
+// Any changes will be lost if this file is regenerated.
+// see https://github.com/cheekybits/genny
+
+package decryption
+
+import (
+	"github.com/shaih/go-yosovss/communication"
+	"github.com/shaih/go-yosovss/msgpack"
+	log "github.com/sirupsen/logrus"
+)
+
+// This file (receive.go) is a template generating gen-receive.go
+
+// ReceiveDecryptionShareMessages receives and parse the messages sent by the parties in the round
+// parties is the list of parties in the round
+// A message that cannot be decoded (or that is missing) is not an error:
+// it is replaced by an empty message, which is treated as a message from a malicious party
+func ReceiveDecryptionShareMessages(bc communication.BroadcastChannel, parties []int) []DecryptionShareMessage {
+	messages := make([]DecryptionShareMessage, len(parties))
+
+	_, bm := bc.ReceiveRound()
+
+	for i, party := range parties {
+		var msg DecryptionShareMessage
+		err := msgpack.Decode(communication.PayloadFrom(bm, party), &msg)
+		if err != nil {
+			log.Infof("decoding message from party %d (id=%d) failed: %v", i, party, err)
+			continue
+		}
+		messages[i] = msg
+	}
+
+	return messages
+}
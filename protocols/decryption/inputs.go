@@ -0,0 +1,57 @@
+package decryption
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/shaih/go-yosovss/communication"
+	"github.com/shaih/go-yosovss/primitives/elgamal"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+)
+
+// PublicInput contain the public common inputs that are used in the decryption protocol
+type PublicInput struct {
+	T           int                   // max number of malicious parties
+	N           int                   // number of parties holding a share
+	Parties     []int                 // Parties[j] is the ID of the party holding the share of index j+1
+	Commitments []pedersen.Commitment // list of N+1 Pedersen commitments to the secret key s and its shares
+	// (output of the DKG or of the resharing protocol)
+	SessionID   []byte               // identifier of the session, bound to all the NIZK proofs
+	Ciphertexts []elgamal.Ciphertext // ciphertexts to decrypt, under the public key s G
+	// (see signing.StartPublicKeyParty)
+}
+
+// PrivateInput contains the private inputs of a party in the decryption protocol
+type PrivateInput struct {
+	BC    communication.BroadcastChannel
+	Share *vss.Share // share of the secret key, nil if the party is not in Parties
+	ID    int
+
+	// Rand is the randomness source used by the party (proofs)
+	// If nil, system randomness is used
+	Rand io.Reader
+}
+
+// checkInputs performs basic checks on the inputs to catch most common errors
+func checkInputs(pub *PublicInput, prv *PrivateInput, j int) error {
+	if pub.T >= pub.N {
+		return fmt.Errorf("T must be < N")
+	}
+	if len(pub.Parties) != pub.N {
+		return fmt.Errorf("number of parties must be N")
+	}
+	if len(pub.Commitments) != pub.N+1 {
+		return fmt.Errorf("number of commitments must be N+1")
+	}
+	if j >= 0 && (prv.Share == nil || prv.Share.Index != j+1) {
+		return fmt.Errorf("party %d must have the share of index %d", prv.ID, j+1)
+	}
+	for c := range pub.Ciphertexts {
+		err := pub.Ciphertexts[c].CheckShape()
+		if err != nil {
+			return fmt.Errorf("invalid ciphertext %d: %w", c, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package decryption
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/protocols/resharing/common"
+)
+
+const numRounds = 1 // number of rounds of messaging required for the protocol
+
+// StartDecryptionParty initiates the protocol for a party decrypting pub.Ciphertexts
+// with the secret key s committed in pub.Commitments, without reconstructing s
+// plaintexts[c] is the plaintext of the ciphertext c, or nil if the ciphertext cannot be decrypted
+// (which can only happen if the encryptor is malicious, see elgamal.Ciphertext)
+func StartDecryptionParty(
+	pub *PublicInput,
+	prv *PrivateInput,
+) (
+	plaintexts []*curve25519.Scalar,
+	err error,
+) {
+	// index of the party in pub.Parties, -1 if the party does not hold a share
+	j := common.IntIndexOf(pub.Parties, prv.ID)
+	err = checkInputs(pub, prv, j)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each party holding a share publishes its decryption shares
+	if j >= 0 {
+		msg, err := PerformDecryptionShare(pub, prv, j)
+		if err != nil {
+			return nil, fmt.Errorf("party %d failed to compute decryption shares: %w", prv.ID, err)
+		}
+		prv.BC.Send(msgpack.Encode(msg))
+	} else {
+		prv.BC.Send([]byte{}) // an empty message
+	}
+
+	msgs := ReceiveDecryptionShareMessages(prv.BC, pub.Parties)
+
+	plaintexts, err = CombineDecryptionShares(pub, msgs)
+	if err != nil {
+		return nil, fmt.Errorf("party %d failed to decrypt: %w", prv.ID, err)
+	}
+	return plaintexts, nil
+}
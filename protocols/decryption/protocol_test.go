@@ -0,0 +1,186 @@
+package decryption
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/shaih/go-yosovss/communication/fake"
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/elgamal"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupDecryption setup the decryption protocol for n parties holding a share of a random secret key
+// with threshold t, and numCiphertexts encryptions of random messages under the corresponding public key
+// numParties-n additional parties only observe the protocol
+func setupDecryption(
+	t testing.TB,
+	n int,
+	tt int,
+	numParties int,
+	numCiphertexts int,
+) (
+	pub *PublicInput,
+	prvs []PrivateInput,
+	o fake.Orchestrator,
+	messages []curve25519.Scalar,
+) {
+	require := require.New(t)
+
+	o = fake.NewOrchestrator()
+
+	vssParams, err := vss.NewVSSParams(pedersen.GenerateParams(), n, tt)
+	require.NoError(err)
+	secret := curve25519.RandomScalar()
+	shares, commitments, err := vss.FixedRShare(vssParams, secret, curve25519.RandomScalar())
+	require.NoError(err)
+	pk, err := curve25519.MultBaseGPointXYScalar(secret)
+	require.NoError(err)
+
+	messages = make([]curve25519.Scalar, numCiphertexts)
+	ciphertexts := make([]elgamal.Ciphertext, numCiphertexts)
+	for c := range ciphertexts {
+		messages[c] = *curve25519.RandomScalar()
+		ct, _, err := elgamal.EncryptFrom(nil, pk, &messages[c])
+		require.NoError(err)
+		ciphertexts[c] = *ct
+	}
+
+	parties := make([]int, n)
+	for j := range parties {
+		parties[j] = j
+	}
+
+	pub = &PublicInput{
+		T:           tt,
+		N:           n,
+		Parties:     parties,
+		Commitments: commitments,
+		SessionID:   []byte("test session"),
+		Ciphertexts: ciphertexts,
+	}
+
+	prvs = make([]PrivateInput, numParties)
+	for party := 0; party < numParties; party++ {
+		channel := fake.NewPartyBroadcastChannel(party)
+		o.AddChannel(channel)
+		prvs[party] = PrivateInput{
+			BC: channel,
+			ID: party,
+		}
+		if party < n {
+			prvs[party].Share = &shares[party]
+		}
+	}
+
+	return pub, prvs, o, messages
+}
+
+// runDecryption runs the decryption protocol with all the parties
+// party is run with startParty[party] if it exists, and StartDecryptionParty otherwise
+func runDecryption(
+	t *testing.T,
+	pub *PublicInput,
+	prvs []PrivateInput,
+	o fake.Orchestrator,
+	startParty map[int]func(pub *PublicInput, prv *PrivateInput) ([]*curve25519.Scalar, error),
+) [][]*curve25519.Scalar {
+	require := require.New(t)
+
+	outputs := make([][]*curve25519.Scalar, len(prvs))
+	errs := make([]error, len(prvs))
+
+	var wg sync.WaitGroup
+	for party := range prvs {
+		start, ok := startParty[party]
+		if !ok {
+			start = StartDecryptionParty
+		}
+		wg.Add(1)
+		go func(party int) {
+			defer wg.Done()
+			outputs[party], errs[party] = start(pub, &prvs[party])
+		}(party)
+	}
+
+	for o.Round < numRounds {
+		require.NoError(o.ReceiveMessages())
+		require.NoError(o.Broadcast())
+		o.Round++
+	}
+
+	wg.Wait()
+
+	for party := range prvs {
+		require.NoError(errs[party])
+	}
+	return outputs
+}
+
+func TestDecryptionProtocol(t *testing.T) {
+	assert := assert.New(t)
+
+	const (
+		n              = 5
+		tt             = 2
+		numCiphertexts = 3
+	)
+
+	pub, prvs, o, messages := setupDecryption(t, n, tt, n+1, numCiphertexts) // party n is an observer
+	outputs := runDecryption(t, pub, prvs, o, nil)
+
+	for party := range prvs {
+		for c := range messages {
+			if assert.NotNil(outputs[party][c]) {
+				assert.Equal(messages[c], *outputs[party][c])
+			}
+		}
+	}
+}
+
+func TestDecryptionProtocolMalicious(t *testing.T) {
+	// Parties 0 and 1 send invalid decryption shares
+	// and ciphertext 1 cannot be decrypted
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n              = 5
+		tt             = 2
+		numCiphertexts = 3
+	)
+
+	pub, prvs, o, messages := setupDecryption(t, n, tt, n, numCiphertexts)
+	pub.Ciphertexts[1].E[5] = *curve25519.RandomPointXY()
+
+	cheatingParty := func(pub *PublicInput, prv *PrivateInput) ([]*curve25519.Scalar, error) {
+		msg, err := PerformDecryptionShare(pub, prv, prv.ID)
+		require.NoError(err)
+		msg.D[7] = *curve25519.RandomPointXY()
+		prv.BC.Send(msgpack.Encode(msg))
+		msgs := ReceiveDecryptionShareMessages(prv.BC, pub.Parties)
+		return CombineDecryptionShares(pub, msgs)
+	}
+	outputs := runDecryption(t, pub, prvs, o,
+		map[int]func(*PublicInput, *PrivateInput) ([]*curve25519.Scalar, error){
+			0: cheatingParty,
+			1: cheatingParty,
+		},
+	)
+
+	for party := range prvs {
+		for c := range messages {
+			if c == 1 {
+				assert.Nil(outputs[party][c])
+				continue
+			}
+			if assert.NotNil(outputs[party][c]) {
+				assert.Equal(messages[c], *outputs[party][c])
+			}
+		}
+	}
+}
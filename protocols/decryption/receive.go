@@ -0,0 +1,36 @@
+package decryption
+
+// This file (receive.go) is a template generating gen-receive.go
+
+import (
+	"github.com/cheekybits/genny/generic"
+	"github.com/shaih/go-yosovss/communication"
+	"github.com/shaih/go-yosovss/msgpack"
+	log "github.com/sirupsen/logrus"
+)
+
+//go:generate genny -in=$GOFILE -out=gen-$GOFILE gen "MessageType=DecryptionShareMessage"
+
+type MessageType generic.Type
+
+// ReceiveMessageTypes receives and parse the messages sent by the parties in the round
+// parties is the list of parties in the round
+// A message that cannot be decoded (or that is missing) is not an error:
+// it is replaced by an empty message, which is treated as a message from a malicious party
+func ReceiveMessageTypes(bc communication.BroadcastChannel, parties []int) []MessageType {
+	messages := make([]MessageType, len(parties))
+
+	_, bm := bc.ReceiveRound()
+
+	for i, party := range parties {
+		var msg MessageType
+		err := msgpack.Decode(communication.PayloadFrom(bm, party), &msg)
+		if err != nil {
+			log.Infof("decoding message from party %d (id=%d) failed: %v", i, party, err)
+			continue
+		}
+		messages[i] = msg
+	}
+
+	return messages
+}
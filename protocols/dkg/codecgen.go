@@ -0,0 +1,7 @@
+//go:build generate
+// +build generate
+
+package dkg
+
+//go:generate codecgen -o gen-codecgen.go step1_dealing.go step2_complaint.go step3_answer.go
+//go:generate gofmt -w gen-codecgen.go
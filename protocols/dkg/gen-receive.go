@@ -0,0 +1,83 @@
+// This file was automatically generated by genny.
+// Any changes will be lost if this file is regenerated.
+// see https://github.com/cheekybits/genny
+
+package dkg
+
+import (
+	"github.com/shaih/go-yosovss/communication"
+	"github.com/shaih/go-yosovss/msgpack"
+	log "github.com/sirupsen/logrus"
+)
+
+// This file (receive.go) is a template generating gen-receive.go
+
+// ReceiveDealingMessages receives and parse the messages sent by the parties in the round
+// parties is the list of parties in the round
+// A message that cannot be decoded (or that is missing) is not an error:
+// it is replaced by an empty message, which is treated as a message from a malicious party
+func ReceiveDealingMessages(bc communication.BroadcastChannel, parties []int) []DealingMessage {
+	messages := make([]DealingMessage, len(parties))
+
+	_, bm := bc.ReceiveRound()
+
+	for i, party := range parties {
+		var msg DealingMessage
+		err := msgpack.Decode(communication.PayloadFrom(bm, party), &msg)
+		if err != nil {
+			log.Infof("decoding message from party %d (id=%d) failed: %v", i, party, err)
+			continue
+		}
+		messages[i] = msg
+	}
+
+	return messages
+}
+
+// This file (receive.go) is a template generating gen-receive.go
+
+// ReceiveComplaintMessages receives and parse the messages sent by the parties in the round
+// parties is the list of parties in the round
+// A message that cannot be decoded (or that is missing) is not an error:
+// it is replaced by an empty message, which is treated as a message from a malicious party
+func ReceiveComplaintMessages(bc communication.BroadcastChannel, parties []int) []ComplaintMessage {
+	messages := make([]ComplaintMessage, len(parties))
+
+	_, bm := bc.ReceiveRound()
+
+	for i, party := range parties {
+		var msg ComplaintMessage
+		err := msgpack.Decode(communication.PayloadFrom(bm, party), &msg)
+		if err != nil {
+			log.Infof("decoding message from party %d (id=%d) failed: %v", i, party, err)
+			continue
+		}
+		messages[i] = msg
+	}
+
+	return messages
+}
+
+// This file (receive.go) is a template generating gen-receive.go
+
+// ReceiveAnswerMessages receives and parse the messages sent by the parties in the round
+// parties is the list of parties in the round
+// A message that cannot be decoded (or that is missing) is not an error:
+// it is replaced by an empty message, which is treated as a message from a malicious party
+func ReceiveAnswerMessages(bc communication.BroadcastChannel, parties []int) []AnswerMessage {
+	messages := make([]AnswerMessage, len(parties))
+
+	_, bm := bc.ReceiveRound()
+
+	for i, party := range parties {
+		var msg AnswerMessage
+		err := msgpack.Decode(communication.PayloadFrom(bm, party), &msg)
+		if err != nil {
+			log.Infof("decoding message from party %d (id=%d) failed: %v", i, party, err)
+			continue
+		}
+		messages[i] = msg
+	}
+
+	return messages
+}
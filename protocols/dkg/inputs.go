@@ -0,0 +1,50 @@
+package dkg
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/shaih/go-yosovss/communication"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/vss"
+)
+
+// PublicInput contain the public common inputs that are used in the DKG protocol
+type PublicInput struct {
+	VSSParams vss.Params             // parameters for the VSS
+	EncPKs    []curve25519.PublicKey // encryption public keys (indexed by party ID)
+	T         int                    // max number of malicious parties (=VSSParams.D)
+	N         int                    // number of parties sharing the secret (=VSSParams.N)
+	Parties   []int                  // Parties[i] is the ID of the party getting the share of index i+1
+	// it should be the holding committee of the first resharing (see resharing.Committees)
+}
+
+// PrivateInput contains the private inputs of a party in the DKG protocol
+type PrivateInput struct {
+	BC    communication.BroadcastChannel
+	EncSK curve25519.PrivateKey
+	ID    int
+
+	// Rand is the randomness source used by the party (secret, shares, encryption)
+	// If nil, system randomness is used
+	Rand io.Reader
+}
+
+// checkInputs performs basic checks on the inputs to catch most common errors
+func checkInputs(pub *PublicInput, prv *PrivateInput) error {
+	if pub.T >= pub.N {
+		return fmt.Errorf("T must be < N")
+	}
+	if pub.VSSParams.N != pub.N || pub.VSSParams.D != pub.T {
+		return fmt.Errorf("VSS parameters do not match N and T")
+	}
+	if len(pub.Parties) != pub.N {
+		return fmt.Errorf("number of parties must be N")
+	}
+	for _, party := range pub.Parties {
+		if party < 0 || party >= len(pub.EncPKs) {
+			return fmt.Errorf("no encryption key for party %d", party)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package dkg
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/shaih/go-yosovss/protocols/resharing/common"
+)
+
+const numRounds = 3 // number of rounds of messaging required for the protocol
+
+// StartParty initiates the protocol for a party participating in the DKG protocol
+// It returns the commitments to the shared secret and (if the party is in pub.Parties) its share
+// (or nil otherwise)
+// The outputs can be used directly as PublicInput.Commitments and PrivateInput.Share
+// of resharing.StartCommitteeParty, with pub.Parties as the holding committee
+func StartParty(
+	pub *PublicInput,
+	prv *PrivateInput,
+) (
+	share *vss.Share,
+	commitments []pedersen.Commitment,
+	err error,
+) {
+	err = checkInputs(pub, prv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// index of the party in pub.Parties, -1 if the party does not participate
+	j := common.IntIndexOf(pub.Parties, prv.ID)
+
+	// Dealing
+	// =======
+
+	// Each party shares a random secret to all the parties
+	var dealtShares []DealtShare
+	if j >= 0 {
+		var msg *DealingMessage
+		msg, dealtShares, err = PerformDealing(pub, prv)
+		if err != nil {
+			return nil, nil, fmt.Errorf("party %d failed to perform dealing: %w", prv.ID, err)
+		}
+		prv.BC.Send(msgpack.Encode(msg))
+	} else {
+		prv.BC.Send([]byte{}) // an empty message
+	}
+
+	dealingMessages := ReceiveDealingMessages(prv.BC, pub.Parties)
+
+	// Complaint
+	// =========
+
+	// Each party verifies the shares it received and complains against invalid dealers
+	var receivedShares []*vss.Share
+	if j >= 0 {
+		var msg *ComplaintMessage
+		msg, receivedShares = PerformComplaint(pub, prv, j, dealingMessages)
+		prv.BC.Send(msgpack.Encode(msg))
+	} else {
+		prv.BC.Send([]byte{})
+	}
+
+	complaintMessages := ReceiveComplaintMessages(prv.BC, pub.Parties)
+
+	// Answer
+	// ======
+
+	// Each dealer publishes the shares of the parties complaining against it
+	if j >= 0 {
+		msg, err := PerformAnswer(pub, j, dealtShares, complaintMessages)
+		if err != nil {
+			return nil, nil, fmt.Errorf("party %d failed to perform answer: %w", prv.ID, err)
+		}
+		prv.BC.Send(msgpack.Encode(msg))
+	} else {
+		prv.BC.Send([]byte{})
+	}
+
+	answerMessages := ReceiveAnswerMessages(prv.BC, pub.Parties)
+
+	// Output
+	// ======
+
+	share, commitments, err = PerformOutput(
+		pub, j, dealingMessages, complaintMessages, answerMessages, receivedShares,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("party %d failed to compute output: %w", prv.ID, err)
+	}
+	return share, commitments, nil
+}
@@ -0,0 +1,330 @@
+package dkg
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/shaih/go-yosovss/communication/fake"
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupDKG setup the DKG protocol for n parties and threshold t
+// numParties-n additional parties only observe the protocol
+func setupDKG(
+	t testing.TB,
+	n int,
+	tt int,
+	numParties int,
+) (
+	pub *PublicInput,
+	prvs []PrivateInput,
+	o fake.Orchestrator,
+) {
+	require := require.New(t)
+
+	o = fake.NewOrchestrator()
+
+	encPKs, encSKs := curve25519.SetupKeys(numParties)
+	vssParams, err := vss.NewVSSParams(pedersen.GenerateParams(), n, tt)
+	require.NoError(err)
+
+	parties := make([]int, n)
+	for j := range parties {
+		parties[j] = j
+	}
+
+	pub = &PublicInput{
+		VSSParams: *vssParams,
+		EncPKs:    encPKs,
+		T:         tt,
+		N:         n,
+		Parties:   parties,
+	}
+
+	prvs = make([]PrivateInput, numParties)
+	for party := 0; party < numParties; party++ {
+		channel := fake.NewPartyBroadcastChannel(party)
+		o.AddChannel(channel)
+		prvs[party] = PrivateInput{
+			BC:    channel,
+			EncSK: encSKs[party],
+			ID:    party,
+		}
+	}
+
+	return pub, prvs, o
+}
+
+// runDKG runs the DKG protocol with all the parties
+// party is run with startParty[party] if it exists, and StartParty otherwise
+func runDKG(
+	t *testing.T,
+	pub *PublicInput,
+	prvs []PrivateInput,
+	o fake.Orchestrator,
+	startParty map[int]func(pub *PublicInput, prv *PrivateInput) (*vss.Share, []pedersen.Commitment, error),
+) (
+	outputShares []*vss.Share,
+	outputCommitments [][]pedersen.Commitment,
+) {
+	require := require.New(t)
+
+	outputShares = make([]*vss.Share, len(prvs))
+	outputCommitments = make([][]pedersen.Commitment, len(prvs))
+	errs := make([]error, len(prvs))
+
+	var wg sync.WaitGroup
+	for party := range prvs {
+		start, ok := startParty[party]
+		if !ok {
+			start = StartParty
+		}
+		wg.Add(1)
+		go func(party int) {
+			defer wg.Done()
+			outputShares[party], outputCommitments[party], errs[party] = start(pub, &prvs[party])
+		}(party)
+	}
+
+	for o.Round < numRounds {
+		require.NoError(o.ReceiveMessages())
+		require.NoError(o.Broadcast())
+		o.Round++
+	}
+
+	wg.Wait()
+
+	for party := range prvs {
+		require.NoError(errs[party])
+	}
+	return outputShares, outputCommitments
+}
+
+// checkDKGResults checks that all the parties output the same valid commitments
+// and that the parties of pub.Parties (except the ones in skip) output valid shares
+// of the committed secret
+func checkDKGResults(
+	t *testing.T,
+	pub *PublicInput,
+	outputShares []*vss.Share,
+	outputCommitments [][]pedersen.Commitment,
+	skip map[int]bool,
+) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	var commitments []pedersen.Commitment
+	for party := range outputCommitments {
+		if skip[party] {
+			continue
+		}
+		if commitments == nil {
+			commitments = outputCommitments[party]
+		}
+		assert.Equal(commitments, outputCommitments[party], "all output commitments must be the same")
+	}
+
+	valid, err := vss.VerifyCommitments(&pub.VSSParams, commitments)
+	require.NoError(err)
+	assert.True(valid, "commitments must be valid")
+
+	var shares []vss.Share
+	for party := range outputShares {
+		if skip[party] {
+			continue
+		}
+		if j := indexOf(pub.Parties, party); j < 0 {
+			assert.Nil(outputShares[party], "non participating parties must output nil shares")
+			continue
+		}
+		require.NotNil(outputShares[party])
+		valid, err := vss.VerifyShare(&pub.VSSParams, outputShares[party], commitments)
+		require.NoError(err)
+		assert.True(valid, "share of party %d must be valid", party)
+		shares = append(shares, *outputShares[party])
+	}
+
+	// the shares are a sharing of the committed secret
+	require.GreaterOrEqual(len(shares), pub.T+1)
+	s, r, err := vss.ReconstructWithRFromValidShares(&pub.VSSParams, shares)
+	require.NoError(err)
+	valid, err = pedersen.VerifyCommitment(pub.VSSParams.PedersenParams, &commitments[0], s, r)
+	require.NoError(err)
+	assert.True(valid)
+}
+
+func indexOf(list []int, val int) int {
+	for i, v := range list {
+		if v == val {
+			return i
+		}
+	}
+	return -1
+}
+
+// startCheatingParty runs the DKG protocol as a cheating dealer
+// cheatDealing modifies the dealing message before it is sent
+// if noAnswer is true, the dealer does not answer complaints
+func startCheatingParty(
+	cheatDealing func(pub *PublicInput, msg *DealingMessage),
+	noAnswer bool,
+) func(pub *PublicInput, prv *PrivateInput) (*vss.Share, []pedersen.Commitment, error) {
+	return func(pub *PublicInput, prv *PrivateInput) (*vss.Share, []pedersen.Commitment, error) {
+		j := indexOf(pub.Parties, prv.ID)
+
+		msg, dealtShares, err := PerformDealing(pub, prv)
+		if err != nil {
+			return nil, nil, err
+		}
+		cheatDealing(pub, msg)
+		prv.BC.Send(msgpack.Encode(msg))
+		dealingMessages := ReceiveDealingMessages(prv.BC, pub.Parties)
+
+		complaintMsg, receivedShares := PerformComplaint(pub, prv, j, dealingMessages)
+		prv.BC.Send(msgpack.Encode(complaintMsg))
+		complaintMessages := ReceiveComplaintMessages(prv.BC, pub.Parties)
+
+		if noAnswer {
+			prv.BC.Send([]byte{})
+		} else {
+			answerMsg, err := PerformAnswer(pub, j, dealtShares, complaintMessages)
+			if err != nil {
+				return nil, nil, err
+			}
+			prv.BC.Send(msgpack.Encode(answerMsg))
+		}
+		answerMessages := ReceiveAnswerMessages(prv.BC, pub.Parties)
+
+		qualified, err := ComputeQualifiedDealers(pub, dealingMessages, complaintMessages, answerMessages)
+		if err != nil {
+			return nil, nil, err
+		}
+		if indexOf(qualified, j) < 0 {
+			// the cheating party does not know its share as it was disqualified
+			return nil, nil, nil
+		}
+		return PerformOutput(pub, j, dealingMessages, complaintMessages, answerMessages, receivedShares)
+	}
+}
+
+// wrongShareTo replaces the encrypted share of party j by an encryption of a random share
+func wrongShareTo(j int) func(pub *PublicInput, msg *DealingMessage) {
+	return func(pub *PublicInput, msg *DealingMessage) {
+		ds := DealtShare{S: *curve25519.RandomScalar(), R: *curve25519.RandomScalar()}
+		var err error
+		msg.EncShares[j], err = curve25519.Encrypt(pub.EncPKs[pub.Parties[j]], msgpack.Encode(ds))
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+func TestDKGProtocol(t *testing.T) {
+	const (
+		n  = 5
+		tt = 2
+	)
+
+	pub, prvs, o := setupDKG(t, n, tt, n+1) // party n is an observer
+	outputShares, outputCommitments := runDKG(t, pub, prvs, o, nil)
+	checkDKGResults(t, pub, outputShares, outputCommitments, nil)
+}
+
+func TestDKGProtocolAnsweredComplaint(t *testing.T) {
+	// Dealer 0 sends an invalid share to party 1 but answers the complaint,
+	// so it stays qualified
+	const (
+		n  = 5
+		tt = 2
+	)
+
+	pub, prvs, o := setupDKG(t, n, tt, n)
+	outputShares, outputCommitments := runDKG(t, pub, prvs, o,
+		map[int]func(*PublicInput, *PrivateInput) (*vss.Share, []pedersen.Commitment, error){
+			0: startCheatingParty(wrongShareTo(1), false),
+		},
+	)
+	checkDKGResults(t, pub, outputShares, outputCommitments, nil)
+}
+
+func TestDKGProtocolDisqualifiedDealer(t *testing.T) {
+	// Dealer 0 sends an invalid share to party 1 and does not answer the complaint
+	// Dealer 2 sends invalid commitments
+	// Both are disqualified
+	const (
+		n  = 5
+		tt = 2
+	)
+	assert := assert.New(t)
+
+	pub, prvs, o := setupDKG(t, n, tt, n)
+	outputShares, outputCommitments := runDKG(t, pub, prvs, o,
+		map[int]func(*PublicInput, *PrivateInput) (*vss.Share, []pedersen.Commitment, error){
+			0: startCheatingParty(wrongShareTo(1), true),
+			2: startCheatingParty(func(pub *PublicInput, msg *DealingMessage) {
+				msg.Commitments[3] = *curve25519.RandomPointXY()
+			}, false),
+		},
+	)
+
+	assert.Nil(outputShares[0])
+	assert.Nil(outputShares[2])
+	checkDKGResults(t, pub, outputShares, outputCommitments, map[int]bool{0: true, 2: true})
+}
+
+func TestComputeQualifiedDealersTooManyComplaints(t *testing.T) {
+	// A dealer with more than t complaints is disqualified, even if it answers them
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n  = 5
+		tt = 2
+	)
+	pub, prvs, _ := setupDKG(t, n, tt, n)
+
+	dealingMessages := make([]DealingMessage, n)
+	dealtShares := make([][]DealtShare, n)
+	for i := 0; i < n; i++ {
+		msg, shares, err := PerformDealing(pub, &prvs[i])
+		require.NoError(err)
+		dealingMessages[i] = *msg
+		dealtShares[i] = shares
+	}
+
+	// parties 0, 1, 2 complain against dealer 4
+	complaintMessages := make([]ComplaintMessage, n)
+	for j := 0; j < n; j++ {
+		complaintMessages[j] = ComplaintMessage{Complaints: make([]bool, n)}
+	}
+	for j := 0; j <= tt; j++ {
+		complaintMessages[j].Complaints[4] = true
+	}
+
+	answerMessages := make([]AnswerMessage, n)
+	for i := 0; i < n; i++ {
+		msg, err := PerformAnswer(pub, i, dealtShares[i], complaintMessages)
+		require.NoError(err)
+		answerMessages[i] = *msg
+	}
+
+	qualified, err := ComputeQualifiedDealers(pub, dealingMessages, complaintMessages, answerMessages)
+	require.NoError(err)
+	assert.Equal([]int{0, 1, 2, 3}, qualified)
+
+	// with only t complaints, the dealer is qualified
+	complaintMessages[0].Complaints[4] = false
+	for i := 0; i < n; i++ {
+		msg, err := PerformAnswer(pub, i, dealtShares[i], complaintMessages)
+		require.NoError(err)
+		answerMessages[i] = *msg
+	}
+	qualified, err = ComputeQualifiedDealers(pub, dealingMessages, complaintMessages, answerMessages)
+	require.NoError(err)
+	assert.Equal([]int{0, 1, 2, 3, 4}, qualified)
+}
@@ -0,0 +1,101 @@
+package dkg
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+)
+
+// DealingMessage is the message parties send during dealing round
+// Notations below are for dealer i in [0,n-1]
+type DealingMessage struct {
+	_struct     struct{}              `codec:",omitempty,omitemptyarray"`
+	Commitments []pedersen.Commitment `codec:"C"` // Commitments[j] is the Pedersen commitment to
+	// the share of index j of a fresh random secret s_i (see vss.FixedRShare)
+	// j in 0,...,n
+	EncShares []curve25519.Ciphertext `codec:"E"` // EncShares[j] is an encryption under the key of party j
+	// of its share (type DealtShare)
+	// j in 0,...,n-1
+}
+
+// DealtShare is the share sent by a dealer to party j (share of index j+1)
+type DealtShare struct {
+	_struct struct{}          `codec:",omitempty,omitemptyarray"`
+	S       curve25519.Scalar `codec:"s"` // sigma_{i,j+1}
+	R       curve25519.Scalar `codec:"r"` // rho_{i,j+1}
+}
+
+// toVSSShare converts the share of index j+1 into a vss.Share
+func (ds *DealtShare) toVSSShare(j int) *vss.Share {
+	share := &vss.Share{
+		Index: j + 1,
+		S:     ds.S,
+		R:     ds.R,
+	}
+	curve25519.GetScalarC(&share.IndexScalar, uint64(j+1))
+	return share
+}
+
+// PerformDealing executes what a party does in the dealing round
+// and returns the message it should broadcast together with the shares it dealt
+// (which are required to answer complaints, see PerformAnswer)
+// The party shares a fresh random secret s_i with a fresh random decommitment r_i
+// The final secret is the sum of the secrets of the qualified dealers (see ComputeQualifiedDealers)
+func PerformDealing(pub *PublicInput, prv *PrivateInput) (*DealingMessage, []DealtShare, error) {
+	s, err := curve25519.RandomScalarFrom(prv.Rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := curve25519.RandomScalarFrom(prv.Rand)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shares, commitments, err := vss.FixedRShareFrom(prv.Rand, &pub.VSSParams, s, r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while sharing the secret: %w", err)
+	}
+
+	msg := &DealingMessage{
+		Commitments: commitments,
+		EncShares:   make([]curve25519.Ciphertext, pub.N),
+	}
+	dealtShares := make([]DealtShare, pub.N)
+
+	// Encrypt the share of index j+1 for party j
+	for j := 0; j < pub.N; j++ {
+		dealtShares[j] = DealtShare{S: shares[j].S, R: shares[j].R}
+		msg.EncShares[j], err = curve25519.EncryptFrom(
+			prv.Rand, pub.EncPKs[pub.Parties[j]], msgpack.Encode(dealtShares[j]),
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return msg, dealtShares, nil
+}
+
+// checkDealingMessage checks everything about the message of a dealer that can be checked publicly:
+// lengths, commitments on the curve, and commitments on a polynomial of degree t
+func checkDealingMessage(pub *PublicInput, msg *DealingMessage) error {
+	if len(msg.Commitments) != pub.N+1 || len(msg.EncShares) != pub.N {
+		return fmt.Errorf("commitments or encrypted shares of incorrect length")
+	}
+	for k := range msg.Commitments {
+		if !curve25519.IsOnCurveXY(&msg.Commitments[k]) {
+			return fmt.Errorf("commitment %d not on the curve", k)
+		}
+	}
+	valid, err := vss.VerifyCommitments(&pub.VSSParams, msg.Commitments)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("commitments are not on a polynomial of degree t")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package dkg
+
+import (
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	log "github.com/sirupsen/logrus"
+)
+
+// ComplaintMessage is the message parties send during the complaint round
+// Below we assume the party is j
+type ComplaintMessage struct {
+	_struct    struct{} `codec:",omitempty,omitemptyarray"`
+	Complaints []bool   `codec:"C"` // Complaints[i] == true iff complaint against dealer i
+}
+
+// PerformComplaint executes what party j does in the complaint round
+// It decrypts and verifies all the shares sent to it
+// and returns the message it should broadcast together with the received shares
+// receivedShares[i] is the share sent by dealer i, or nil if party j complains against dealer i
+// or if the dealing message of dealer i is publicly invalid (see checkDealingMessage)
+// j is in [0,n-1]
+func PerformComplaint(
+	pub *PublicInput, prv *PrivateInput, j int,
+	dealingMessages []DealingMessage,
+) (
+	msg *ComplaintMessage, receivedShares []*vss.Share,
+) {
+	myLog := log.WithFields(log.Fields{
+		"party": prv.ID,
+		"j":     j,
+	})
+
+	msg = &ComplaintMessage{
+		Complaints: make([]bool, pub.N),
+	}
+	receivedShares = make([]*vss.Share, pub.N)
+
+	for i := 0; i < pub.N; i++ {
+		if err := checkDealingMessage(pub, &dealingMessages[i]); err != nil {
+			// no need to complain, everybody disqualifies dealer i
+			myLog.Infof("dealer %d is publicly invalid: %v", i, err)
+			continue
+		}
+
+		share := getShare(pub, prv, j, &dealingMessages[i])
+		if share == nil {
+			myLog.Infof("complain against dealer %d", i)
+			msg.Complaints[i] = true
+			continue
+		}
+		receivedShares[i] = share
+	}
+
+	return msg, receivedShares
+}
+
+// getShare decrypts and verifies the share sent to party j in the dealing message msg
+// The message must have been checked with checkDealingMessage
+// It returns nil if the share is invalid
+func getShare(pub *PublicInput, prv *PrivateInput, j int, msg *DealingMessage) *vss.Share {
+	dsMsg, err := curve25519.Decrypt(pub.EncPKs[prv.ID], prv.EncSK, msg.EncShares[j])
+	if err != nil {
+		return nil
+	}
+	var ds DealtShare
+	err = msgpack.Decode(dsMsg, &ds)
+	if err != nil {
+		return nil
+	}
+
+	share := ds.toVSSShare(j)
+	valid, err := vss.VerifyShare(&pub.VSSParams, share, msg.Commitments)
+	if err != nil || !valid {
+		return nil
+	}
+	return share
+}
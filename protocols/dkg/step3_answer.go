@@ -0,0 +1,42 @@
+package dkg
+
+import (
+	"fmt"
+)
+
+// AnswerMessage is the message dealers send during the answer round
+// Below we assume the dealer is i
+type AnswerMessage struct {
+	_struct struct{}      `codec:",omitempty,omitemptyarray"`
+	Shares  []*DealtShare `codec:"S"` // Shares[j] is the share of party j in clear if party j complained
+	// against dealer i, and nil otherwise
+	// j in 0,...,n-1
+}
+
+// PerformAnswer executes what dealer i does in the answer round:
+// it publishes the shares of all the parties that complained against it
+// shares are the shares it dealt (in the same order as in PerformDealing)
+func PerformAnswer(
+	pub *PublicInput, i int,
+	shares []DealtShare,
+	complaintMessages []ComplaintMessage,
+) (*AnswerMessage, error) {
+	if len(shares) != pub.N {
+		return nil, fmt.Errorf("invalid number of shares")
+	}
+
+	msg := &AnswerMessage{
+		Shares: make([]*DealtShare, pub.N),
+	}
+	for j := 0; j < pub.N; j++ {
+		if isComplaining(&complaintMessages[j], i) {
+			msg.Shares[j] = &shares[j]
+		}
+	}
+	return msg, nil
+}
+
+// isComplaining returns true if the complaint message contains a complaint against dealer i
+func isComplaining(msg *ComplaintMessage, i int) bool {
+	return i < len(msg.Complaints) && msg.Complaints[i]
+}
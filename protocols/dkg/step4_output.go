@@ -0,0 +1,138 @@
+package dkg
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	log "github.com/sirupsen/logrus"
+)
+
+// checkDealerQualified returns nil if dealer i is qualified, and the reason of its disqualification otherwise
+// Dealer i is disqualified if its dealing message is publicly invalid (see checkDealingMessage),
+// if more than t parties complained against it, or if it did not answer a complaint with a valid share
+func checkDealerQualified(
+	pub *PublicInput, i int,
+	dealingMessages []DealingMessage,
+	complaintMessages []ComplaintMessage,
+	answerMessages []AnswerMessage,
+) error {
+	err := checkDealingMessage(pub, &dealingMessages[i])
+	if err != nil {
+		return err
+	}
+
+	var complaints []int
+	for j := 0; j < pub.N; j++ {
+		if isComplaining(&complaintMessages[j], i) {
+			complaints = append(complaints, j)
+		}
+	}
+	if len(complaints) == 0 {
+		return nil
+	}
+	if len(complaints) > pub.T {
+		// honest dealers get at most t complaints
+		return fmt.Errorf("%d complaints (more than t)", len(complaints))
+	}
+
+	answer := &answerMessages[i]
+	if len(answer.Shares) != pub.N {
+		return fmt.Errorf("answer of incorrect length")
+	}
+	for _, j := range complaints {
+		if answer.Shares[j] == nil {
+			return fmt.Errorf("no answer to the complaint of party %d", j)
+		}
+		valid, err := vss.VerifyShare(&pub.VSSParams, answer.Shares[j].toVSSShare(j), dealingMessages[i].Commitments)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return fmt.Errorf("invalid answer to the complaint of party %d", j)
+		}
+	}
+	return nil
+}
+
+// ComputeQualifiedDealers returns the list of qualified dealers, in increasing order
+// It depends only on broadcast messages, so all the parties compute the same list
+// It returns an error if there are less than t+1 qualified dealers,
+// as then the secret may be known by the malicious parties
+func ComputeQualifiedDealers(
+	pub *PublicInput,
+	dealingMessages []DealingMessage,
+	complaintMessages []ComplaintMessage,
+	answerMessages []AnswerMessage,
+) ([]int, error) {
+	var qualified []int
+	for i := 0; i < pub.N; i++ {
+		err := checkDealerQualified(pub, i, dealingMessages, complaintMessages, answerMessages)
+		if err != nil {
+			log.Infof("dealer %d disqualified: %v", i, err)
+			continue
+		}
+		qualified = append(qualified, i)
+	}
+	if len(qualified) < pub.T+1 {
+		return nil, fmt.Errorf("only %d qualified dealers (less than t+1)", len(qualified))
+	}
+	return qualified, nil
+}
+
+// PerformOutput computes the commitments to the shared secret and, if j >= 0, the share of party j
+// The secret (resp. decommitment) is the sum of the secrets (resp. decommitments) of the qualified dealers
+// so the commitments (resp. shares) are the sums of the commitments (resp. shares) of the qualified dealers
+// receivedShares are the shares received by party j (see PerformComplaint), nil if j < 0
+func PerformOutput(
+	pub *PublicInput, j int,
+	dealingMessages []DealingMessage,
+	complaintMessages []ComplaintMessage,
+	answerMessages []AnswerMessage,
+	receivedShares []*vss.Share,
+) (
+	share *vss.Share,
+	commitments []pedersen.Commitment,
+	err error,
+) {
+	qualified, err := ComputeQualifiedDealers(pub, dealingMessages, complaintMessages, answerMessages)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Commitments
+	commitments = make([]pedersen.Commitment, pub.N+1)
+	for k := 0; k <= pub.N; k++ {
+		points := make([]curve25519.PointXY, len(qualified))
+		for q, i := range qualified {
+			points[q] = dealingMessages[i].Commitments[k]
+		}
+		ck, err := curve25519.AddPointsXY(points)
+		if err != nil {
+			return nil, nil, err
+		}
+		commitments[k] = *ck
+	}
+
+	if j < 0 {
+		return nil, commitments, nil
+	}
+
+	// Share
+	share = &vss.Share{
+		Index: j + 1,
+	}
+	curve25519.GetScalarC(&share.IndexScalar, uint64(j+1))
+	for _, i := range qualified {
+		si := receivedShares[i]
+		if si == nil {
+			// party j complained against the qualified dealer i, so dealer i answered with a valid share
+			si = answerMessages[i].Shares[j].toVSSShare(j)
+		}
+		share.S = *curve25519.AddScalar(&share.S, &si.S)
+		share.R = *curve25519.AddScalar(&share.R, &si.R)
+	}
+
+	return share, commitments, nil
+}
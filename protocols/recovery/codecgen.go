@@ -0,0 +1,7 @@
+//go:build generate
+// +build generate
+
+package recovery
+
+//go:generate codecgen -o gen-codecgen.go step1_blinding.go step2_complaint.go step3_answer.go step4_reply.go
+//go:generate gofmt -w gen-codecgen.go
@@ -0,0 +1,1443 @@
+// #nosec
+//go:build go1.6
+// +build go1.6
+
+// Code generated by codecgen - DO NOT EDIT.
+
+package recovery
+
+import (
+	"errors"
+	pkg1_curve25519 "github.com/shaih/go-yosovss/primitives/curve25519"
+	codec1978 "github.com/ugorji/go/codec"
+	"runtime"
+	"strconv"
+)
+
+const (
+	// ----- content types ----
+	codecSelferCcUTF8943 = 1
+	codecSelferCcRAW943  = 255
+	// ----- value types used ----
+	codecSelferValueTypeArray943     = 10
+	codecSelferValueTypeMap943       = 9
+	codecSelferValueTypeString943    = 6
+	codecSelferValueTypeInt943       = 2
+	codecSelferValueTypeUint943      = 3
+	codecSelferValueTypeFloat943     = 4
+	codecSelferValueTypeNil943       = 1
+	codecSelferBitsize943            = uint8(32 << (^uint(0) >> 63))
+	codecSelferDecContainerLenNil943 = -2147483648
+)
+
+var (
+	errCodecSelferOnlyMapOrArrayEncodeToStruct943 = errors.New(`only encoded map or array can be decoded into a struct`)
+)
+
+type codecSelfer943 struct{}
+
+func codecSelfer943False() bool { return false }
+func codecSelfer943True() bool  { return true }
+
+func init() {
+	if codec1978.GenVersion != 25 {
+		_, file, _, _ := runtime.Caller(0)
+		ver := strconv.FormatInt(int64(codec1978.GenVersion), 10)
+		panic(errors.New("codecgen version mismatch: current: 25, need " + ver + ". Re-generate file: " + file))
+	}
+	if false { // reference the types, but skip this branch at build/run time
+		var _ pkg1_curve25519.PointXY
+	}
+}
+
+func (BlindingMessage) codecSelferViaCodecgen() {}
+func (x *BlindingMessage) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
+		return
+	}
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyq2 = [2]bool{     // should field at this index be written?
+			len(x.Commitments) != 0, // C
+			len(x.EncShares) != 0,   // E
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(2)
+			z.EncWriteArrayElem()
+			if yyq2[0] {
+				if x.Commitments == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.Commitments), e)
+				} // end block: if x.Commitments slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[1] {
+				if x.EncShares == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_Ciphertext(([]pkg1_curve25519.Ciphertext)(x.EncShares), e)
+				} // end block: if x.EncShares slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
+				}
+			}
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"C\"")
+				} else {
+					r.EncodeString(`C`)
+				}
+				z.EncWriteMapElemValue()
+				if x.Commitments == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.Commitments), e)
+				} // end block: if x.Commitments slice == nil
+			}
+			if yyq2[1] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"E\"")
+				} else {
+					r.EncodeString(`E`)
+				}
+				z.EncWriteMapElemValue()
+				if x.EncShares == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_Ciphertext(([]pkg1_curve25519.Ciphertext)(x.EncShares), e)
+				} // end block: if x.EncShares slice == nil
+			}
+			z.EncWriteMapEnd()
+		}
+	}
+}
+
+func (x *BlindingMessage) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = BlindingMessage{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
+		} else {
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
+		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
+	}
+}
+
+func (x *BlindingMessage) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "C":
+			h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.Commitments), d)
+		case "E":
+			h.decSlicecurve25519_Ciphertext((*[]pkg1_curve25519.Ciphertext)(&x.EncShares), d)
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *BlindingMessage) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj8 int
+	var yyb8 bool
+	var yyhl8 bool = l >= 0
+	yyj8++
+	if yyhl8 {
+		yyb8 = yyj8 > l
+	} else {
+		yyb8 = z.DecCheckBreak()
+	}
+	if yyb8 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.Commitments), d)
+	yyj8++
+	if yyhl8 {
+		yyb8 = yyj8 > l
+	} else {
+		yyb8 = z.DecCheckBreak()
+	}
+	if yyb8 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSlicecurve25519_Ciphertext((*[]pkg1_curve25519.Ciphertext)(&x.EncShares), d)
+	for {
+		yyj8++
+		if yyhl8 {
+			yyb8 = yyj8 > l
+		} else {
+			yyb8 = z.DecCheckBreak()
+		}
+		if yyb8 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj8-1, "")
+	}
+}
+
+func (x *BlindingMessage) IsCodecEmpty() bool {
+	return !(len(x.Commitments) != 0 || len(x.EncShares) != 0 || false)
+}
+
+func (DealtShare) codecSelferViaCodecgen() {}
+func (x *DealtShare) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
+		return
+	}
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyq2 = [2]bool{     // should field at this index be written?
+			len(x.S) != 0, // s
+			len(x.R) != 0, // r
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(2)
+			z.EncWriteArrayElem()
+			if yyq2[0] {
+				yy5 := &x.S
+				if yyxt6 := z.Extension(yy5); yyxt6 != nil {
+					z.EncExtension(yy5, yyxt6)
+				} else {
+					z.F.EncSliceUint8V(([]uint8)(yy5[:]), e)
+				}
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[1] {
+				yy7 := &x.R
+				if yyxt8 := z.Extension(yy7); yyxt8 != nil {
+					z.EncExtension(yy7, yyxt8)
+				} else {
+					z.F.EncSliceUint8V(([]uint8)(yy7[:]), e)
+				}
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
+				}
+			}
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"s\"")
+				} else {
+					r.EncodeString(`s`)
+				}
+				z.EncWriteMapElemValue()
+				yy9 := &x.S
+				if yyxt10 := z.Extension(yy9); yyxt10 != nil {
+					z.EncExtension(yy9, yyxt10)
+				} else {
+					z.F.EncSliceUint8V(([]uint8)(yy9[:]), e)
+				}
+			}
+			if yyq2[1] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"r\"")
+				} else {
+					r.EncodeString(`r`)
+				}
+				z.EncWriteMapElemValue()
+				yy11 := &x.R
+				if yyxt12 := z.Extension(yy11); yyxt12 != nil {
+					z.EncExtension(yy11, yyxt12)
+				} else {
+					z.F.EncSliceUint8V(([]uint8)(yy11[:]), e)
+				}
+			}
+			z.EncWriteMapEnd()
+		}
+	}
+}
+
+func (x *DealtShare) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = DealtShare{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
+		} else {
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
+		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
+	}
+}
+
+func (x *DealtShare) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "s":
+			if yyxt5 := z.Extension(x.S); yyxt5 != nil {
+				z.DecExtension(&x.S, yyxt5)
+			} else {
+				z.F.DecSliceUint8N(([]uint8)(x.S[:]), d)
+			}
+		case "r":
+			if yyxt7 := z.Extension(x.R); yyxt7 != nil {
+				z.DecExtension(&x.R, yyxt7)
+			} else {
+				z.F.DecSliceUint8N(([]uint8)(x.R[:]), d)
+			}
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *DealtShare) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj8 int
+	var yyb8 bool
+	var yyhl8 bool = l >= 0
+	yyj8++
+	if yyhl8 {
+		yyb8 = yyj8 > l
+	} else {
+		yyb8 = z.DecCheckBreak()
+	}
+	if yyb8 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if yyxt10 := z.Extension(x.S); yyxt10 != nil {
+		z.DecExtension(&x.S, yyxt10)
+	} else {
+		z.F.DecSliceUint8N(([]uint8)(x.S[:]), d)
+	}
+	yyj8++
+	if yyhl8 {
+		yyb8 = yyj8 > l
+	} else {
+		yyb8 = z.DecCheckBreak()
+	}
+	if yyb8 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if yyxt12 := z.Extension(x.R); yyxt12 != nil {
+		z.DecExtension(&x.R, yyxt12)
+	} else {
+		z.F.DecSliceUint8N(([]uint8)(x.R[:]), d)
+	}
+	for {
+		yyj8++
+		if yyhl8 {
+			yyb8 = yyj8 > l
+		} else {
+			yyb8 = z.DecCheckBreak()
+		}
+		if yyb8 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj8-1, "")
+	}
+}
+
+func (x *DealtShare) IsCodecEmpty() bool {
+	return !(len(x.S) != 0 || len(x.R) != 0 || false)
+}
+
+func (ComplaintMessage) codecSelferViaCodecgen() {}
+func (x *ComplaintMessage) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
+		return
+	}
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyq2 = [1]bool{     // should field at this index be written?
+			len(x.Complaints) != 0, // C
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(1)
+			z.EncWriteArrayElem()
+			if yyq2[0] {
+				if x.Complaints == nil {
+					r.EncodeNil()
+				} else {
+					z.F.EncSliceBoolV(x.Complaints, e)
+				} // end block: if x.Complaints slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
+				}
+			}
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"C\"")
+				} else {
+					r.EncodeString(`C`)
+				}
+				z.EncWriteMapElemValue()
+				if x.Complaints == nil {
+					r.EncodeNil()
+				} else {
+					z.F.EncSliceBoolV(x.Complaints, e)
+				} // end block: if x.Complaints slice == nil
+			}
+			z.EncWriteMapEnd()
+		}
+	}
+}
+
+func (x *ComplaintMessage) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = ComplaintMessage{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
+		} else {
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
+		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
+	}
+}
+
+func (x *ComplaintMessage) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "C":
+			z.F.DecSliceBoolX(&x.Complaints, d)
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *ComplaintMessage) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj6 int
+	var yyb6 bool
+	var yyhl6 bool = l >= 0
+	yyj6++
+	if yyhl6 {
+		yyb6 = yyj6 > l
+	} else {
+		yyb6 = z.DecCheckBreak()
+	}
+	if yyb6 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	z.F.DecSliceBoolX(&x.Complaints, d)
+	for {
+		yyj6++
+		if yyhl6 {
+			yyb6 = yyj6 > l
+		} else {
+			yyb6 = z.DecCheckBreak()
+		}
+		if yyb6 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj6-1, "")
+	}
+}
+
+func (x *ComplaintMessage) IsCodecEmpty() bool {
+	return !(len(x.Complaints) != 0 || false)
+}
+
+func (AnswerMessage) codecSelferViaCodecgen() {}
+func (x *AnswerMessage) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
+		return
+	}
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyq2 = [1]bool{     // should field at this index be written?
+			len(x.Shares) != 0, // S
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(1)
+			z.EncWriteArrayElem()
+			if yyq2[0] {
+				if x.Shares == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicePtrtoDealtShare(([]*DealtShare)(x.Shares), e)
+				} // end block: if x.Shares slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
+				}
+			}
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"S\"")
+				} else {
+					r.EncodeString(`S`)
+				}
+				z.EncWriteMapElemValue()
+				if x.Shares == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicePtrtoDealtShare(([]*DealtShare)(x.Shares), e)
+				} // end block: if x.Shares slice == nil
+			}
+			z.EncWriteMapEnd()
+		}
+	}
+}
+
+func (x *AnswerMessage) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = AnswerMessage{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
+		} else {
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
+		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
+	}
+}
+
+func (x *AnswerMessage) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "S":
+			h.decSlicePtrtoDealtShare((*[]*DealtShare)(&x.Shares), d)
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *AnswerMessage) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj6 int
+	var yyb6 bool
+	var yyhl6 bool = l >= 0
+	yyj6++
+	if yyhl6 {
+		yyb6 = yyj6 > l
+	} else {
+		yyb6 = z.DecCheckBreak()
+	}
+	if yyb6 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSlicePtrtoDealtShare((*[]*DealtShare)(&x.Shares), d)
+	for {
+		yyj6++
+		if yyhl6 {
+			yyb6 = yyj6 > l
+		} else {
+			yyb6 = z.DecCheckBreak()
+		}
+		if yyb6 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj6-1, "")
+	}
+}
+
+func (x *AnswerMessage) IsCodecEmpty() bool {
+	return !(len(x.Shares) != 0 || false)
+}
+
+func (ReplyMessage) codecSelferViaCodecgen() {}
+func (x *ReplyMessage) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
+		return
+	}
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyq2 = [1]bool{     // should field at this index be written?
+			len(x.EncShare) != 0, // E
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(1)
+			z.EncWriteArrayElem()
+			if yyq2[0] {
+				if yyxt4 := z.Extension(x.EncShare); yyxt4 != nil {
+					z.EncExtension(x.EncShare, yyxt4)
+				} else {
+					if x.EncShare == nil {
+						r.EncodeNil()
+					} else {
+						z.F.EncSliceUint8V(([]uint8)(x.EncShare), e)
+					} // end block: if x.EncShare slice == nil
+				}
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
+				}
+			}
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"E\"")
+				} else {
+					r.EncodeString(`E`)
+				}
+				z.EncWriteMapElemValue()
+				if yyxt5 := z.Extension(x.EncShare); yyxt5 != nil {
+					z.EncExtension(x.EncShare, yyxt5)
+				} else {
+					if x.EncShare == nil {
+						r.EncodeNil()
+					} else {
+						z.F.EncSliceUint8V(([]uint8)(x.EncShare), e)
+					} // end block: if x.EncShare slice == nil
+				}
+			}
+			z.EncWriteMapEnd()
+		}
+	}
+}
+
+func (x *ReplyMessage) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = ReplyMessage{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
+		} else {
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
+		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
+	}
+}
+
+func (x *ReplyMessage) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "E":
+			if yyxt5 := z.Extension(x.EncShare); yyxt5 != nil {
+				z.DecExtension(&x.EncShare, yyxt5)
+			} else {
+				z.F.DecSliceUint8X((*[]uint8)(&x.EncShare), d)
+			}
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *ReplyMessage) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj6 int
+	var yyb6 bool
+	var yyhl6 bool = l >= 0
+	yyj6++
+	if yyhl6 {
+		yyb6 = yyj6 > l
+	} else {
+		yyb6 = z.DecCheckBreak()
+	}
+	if yyb6 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if yyxt8 := z.Extension(x.EncShare); yyxt8 != nil {
+		z.DecExtension(&x.EncShare, yyxt8)
+	} else {
+		z.F.DecSliceUint8X((*[]uint8)(&x.EncShare), d)
+	}
+	for {
+		yyj6++
+		if yyhl6 {
+			yyb6 = yyj6 > l
+		} else {
+			yyb6 = z.DecCheckBreak()
+		}
+		if yyb6 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj6-1, "")
+	}
+}
+
+func (x *ReplyMessage) IsCodecEmpty() bool {
+	return !(len(x.EncShare) != 0 || false)
+}
+
+func (BlindedShare) codecSelferViaCodecgen() {}
+func (x *BlindedShare) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
+		return
+	}
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyq2 = [2]bool{     // should field at this index be written?
+			len(x.S) != 0, // s
+			len(x.R) != 0, // r
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(2)
+			z.EncWriteArrayElem()
+			if yyq2[0] {
+				yy5 := &x.S
+				if yyxt6 := z.Extension(yy5); yyxt6 != nil {
+					z.EncExtension(yy5, yyxt6)
+				} else {
+					z.F.EncSliceUint8V(([]uint8)(yy5[:]), e)
+				}
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[1] {
+				yy7 := &x.R
+				if yyxt8 := z.Extension(yy7); yyxt8 != nil {
+					z.EncExtension(yy7, yyxt8)
+				} else {
+					z.F.EncSliceUint8V(([]uint8)(yy7[:]), e)
+				}
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
+				}
+			}
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"s\"")
+				} else {
+					r.EncodeString(`s`)
+				}
+				z.EncWriteMapElemValue()
+				yy9 := &x.S
+				if yyxt10 := z.Extension(yy9); yyxt10 != nil {
+					z.EncExtension(yy9, yyxt10)
+				} else {
+					z.F.EncSliceUint8V(([]uint8)(yy9[:]), e)
+				}
+			}
+			if yyq2[1] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"r\"")
+				} else {
+					r.EncodeString(`r`)
+				}
+				z.EncWriteMapElemValue()
+				yy11 := &x.R
+				if yyxt12 := z.Extension(yy11); yyxt12 != nil {
+					z.EncExtension(yy11, yyxt12)
+				} else {
+					z.F.EncSliceUint8V(([]uint8)(yy11[:]), e)
+				}
+			}
+			z.EncWriteMapEnd()
+		}
+	}
+}
+
+func (x *BlindedShare) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = BlindedShare{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
+		} else {
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
+		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
+	}
+}
+
+func (x *BlindedShare) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "s":
+			if yyxt5 := z.Extension(x.S); yyxt5 != nil {
+				z.DecExtension(&x.S, yyxt5)
+			} else {
+				z.F.DecSliceUint8N(([]uint8)(x.S[:]), d)
+			}
+		case "r":
+			if yyxt7 := z.Extension(x.R); yyxt7 != nil {
+				z.DecExtension(&x.R, yyxt7)
+			} else {
+				z.F.DecSliceUint8N(([]uint8)(x.R[:]), d)
+			}
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *BlindedShare) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj8 int
+	var yyb8 bool
+	var yyhl8 bool = l >= 0
+	yyj8++
+	if yyhl8 {
+		yyb8 = yyj8 > l
+	} else {
+		yyb8 = z.DecCheckBreak()
+	}
+	if yyb8 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if yyxt10 := z.Extension(x.S); yyxt10 != nil {
+		z.DecExtension(&x.S, yyxt10)
+	} else {
+		z.F.DecSliceUint8N(([]uint8)(x.S[:]), d)
+	}
+	yyj8++
+	if yyhl8 {
+		yyb8 = yyj8 > l
+	} else {
+		yyb8 = z.DecCheckBreak()
+	}
+	if yyb8 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if yyxt12 := z.Extension(x.R); yyxt12 != nil {
+		z.DecExtension(&x.R, yyxt12)
+	} else {
+		z.F.DecSliceUint8N(([]uint8)(x.R[:]), d)
+	}
+	for {
+		yyj8++
+		if yyhl8 {
+			yyb8 = yyj8 > l
+		} else {
+			yyb8 = z.DecCheckBreak()
+		}
+		if yyb8 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj8-1, "")
+	}
+}
+
+func (x *BlindedShare) IsCodecEmpty() bool {
+	return !(len(x.S) != 0 || len(x.R) != 0 || false)
+}
+
+func (x codecSelfer943) encSlicecurve25519_PointXY(v []pkg1_curve25519.PointXY, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	z.EncWriteArrayStart(len(v))
+	for yyv1 := range v {
+		z.EncWriteArrayElem()
+		yy2 := &v[yyv1]
+		if yyxt3 := z.Extension(yy2); yyxt3 != nil {
+			z.EncExtension(yy2, yyxt3)
+		} else {
+			z.F.EncSliceUint8V(([]uint8)(yy2[:]), e)
+		}
+	}
+	z.EncWriteArrayEnd()
+}
+
+func (x codecSelfer943) decSlicecurve25519_PointXY(v *[]pkg1_curve25519.PointXY, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyh1.IsNil {
+		if yyv1 != nil {
+			yyv1 = nil
+			yyc1 = true
+		}
+	} else if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = []pkg1_curve25519.PointXY{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else {
+		yyhl1 := yyl1 > 0
+		var yyrl1 int
+		_ = yyrl1
+		if yyhl1 {
+			if yyl1 > cap(yyv1) {
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 64)
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
+				} else {
+					yyv1 = make([]pkg1_curve25519.PointXY, yyrl1)
+				}
+				yyc1 = true
+			} else if yyl1 != len(yyv1) {
+				yyv1 = yyv1[:yyl1]
+				yyc1 = true
+			}
+		}
+		var yyj1 int
+		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
+			if yyj1 == 0 && yyv1 == nil {
+				if yyhl1 {
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 64)
+				} else {
+					yyrl1 = 8
+				}
+				yyv1 = make([]pkg1_curve25519.PointXY, yyrl1)
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			var yydb1 bool
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, pkg1_curve25519.PointXY{})
+				yyc1 = true
+			}
+			if yydb1 {
+				z.DecSwallow()
+			} else {
+				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
+					z.DecExtension(&yyv1[yyj1], yyxt3)
+				} else {
+					z.F.DecSliceUint8N(([]uint8)(yyv1[yyj1][:]), d)
+				}
+			}
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = make([]pkg1_curve25519.PointXY, 0)
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
+
+func (x codecSelfer943) encSlicecurve25519_Ciphertext(v []pkg1_curve25519.Ciphertext, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	z.EncWriteArrayStart(len(v))
+	for yyv1 := range v {
+		z.EncWriteArrayElem()
+		if yyxt2 := z.Extension(v[yyv1]); yyxt2 != nil {
+			z.EncExtension(v[yyv1], yyxt2)
+		} else {
+			if v[yyv1] == nil {
+				r.EncodeNil()
+			} else {
+				z.F.EncSliceUint8V(([]uint8)(v[yyv1]), e)
+			} // end block: if v[yyv1] slice == nil
+		}
+	}
+	z.EncWriteArrayEnd()
+}
+
+func (x codecSelfer943) decSlicecurve25519_Ciphertext(v *[]pkg1_curve25519.Ciphertext, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyh1.IsNil {
+		if yyv1 != nil {
+			yyv1 = nil
+			yyc1 = true
+		}
+	} else if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = []pkg1_curve25519.Ciphertext{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else {
+		yyhl1 := yyl1 > 0
+		var yyrl1 int
+		_ = yyrl1
+		if yyhl1 {
+			if yyl1 > cap(yyv1) {
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 24)
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
+				} else {
+					yyv1 = make([]pkg1_curve25519.Ciphertext, yyrl1)
+				}
+				yyc1 = true
+			} else if yyl1 != len(yyv1) {
+				yyv1 = yyv1[:yyl1]
+				yyc1 = true
+			}
+		}
+		var yyj1 int
+		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
+			if yyj1 == 0 && yyv1 == nil {
+				if yyhl1 {
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 24)
+				} else {
+					yyrl1 = 8
+				}
+				yyv1 = make([]pkg1_curve25519.Ciphertext, yyrl1)
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			var yydb1 bool
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, nil)
+				yyc1 = true
+			}
+			if yydb1 {
+				z.DecSwallow()
+			} else {
+				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
+					z.DecExtension(&yyv1[yyj1], yyxt3)
+				} else {
+					z.F.DecSliceUint8X((*[]uint8)(&yyv1[yyj1]), d)
+				}
+			}
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = make([]pkg1_curve25519.Ciphertext, 0)
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
+
+func (x codecSelfer943) encSlicePtrtoDealtShare(v []*DealtShare, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	z.EncWriteArrayStart(len(v))
+	for yyv1 := range v {
+		z.EncWriteArrayElem()
+		if v[yyv1] == nil {
+			r.EncodeNil()
+		} else {
+			if yyxt2 := z.Extension(v[yyv1]); yyxt2 != nil {
+				z.EncExtension(v[yyv1], yyxt2)
+			} else {
+				v[yyv1].CodecEncodeSelf(e)
+			}
+		}
+	}
+	z.EncWriteArrayEnd()
+}
+
+func (x codecSelfer943) decSlicePtrtoDealtShare(v *[]*DealtShare, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyh1.IsNil {
+		if yyv1 != nil {
+			yyv1 = nil
+			yyc1 = true
+		}
+	} else if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = []*DealtShare{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else {
+		yyhl1 := yyl1 > 0
+		var yyrl1 int
+		_ = yyrl1
+		if yyhl1 {
+			if yyl1 > cap(yyv1) {
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 8)
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
+				} else {
+					yyv1 = make([]*DealtShare, yyrl1)
+				}
+				yyc1 = true
+			} else if yyl1 != len(yyv1) {
+				yyv1 = yyv1[:yyl1]
+				yyc1 = true
+			}
+		}
+		var yyj1 int
+		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
+			if yyj1 == 0 && yyv1 == nil {
+				if yyhl1 {
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 8)
+				} else {
+					yyrl1 = 8
+				}
+				yyv1 = make([]*DealtShare, yyrl1)
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			var yydb1 bool
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, nil)
+				yyc1 = true
+			}
+			if yydb1 {
+				z.DecSwallow()
+			} else {
+				if r.TryNil() {
+					yyv1[yyj1] = nil
+				} else {
+					if yyv1[yyj1] == nil {
+						yyv1[yyj1] = new(DealtShare)
+					}
+					if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
+						z.DecExtension(yyv1[yyj1], yyxt3)
+					} else {
+						yyv1[yyj1].CodecDecodeSelf(d)
+					}
+				}
+			}
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = make([]*DealtShare, 0)
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
@@ -0,0 +1,102 @@
+package recovery
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/shaih/go-yosovss/communication"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+)
+
+// PublicInput contain the public common inputs that are used in the recovery protocol
+type PublicInput struct {
+	VSSParams   vss.Params             // parameters for the VSS
+	EncPKs      []curve25519.PublicKey // encryption public keys (indexed by party ID)
+	T           int                    // max number of malicious parties (=VSSParams.D)
+	N           int                    // number of parties holding a share (=VSSParams.N)
+	Parties     []int                  // Parties[i] is the ID of the party holding the share of index i+1
+	Commitments []pedersen.Commitment  // commitments to the shares (see resharing.PublicInput.Commitments)
+	Recipient   int                    // index in Parties of the party recovering its share
+	Helpers     []int                  // indices in Parties of the parties helping the recipient
+	// there must be at least t+1 helpers, and the recipient cannot be a helper
+}
+
+// PrivateInput contains the private inputs of a party in the recovery protocol
+type PrivateInput struct {
+	BC    communication.BroadcastChannel
+	EncSK curve25519.PrivateKey
+	Share *vss.Share // share of the party, required for helpers only
+	ID    int
+
+	// Rand is the randomness source used by the party (blinding polynomials and encryption)
+	// If nil, system randomness is used
+	Rand io.Reader
+}
+
+// helperParties returns the IDs of the helpers (in the order of pub.Helpers)
+func helperParties(pub *PublicInput) []int {
+	ids := make([]int, len(pub.Helpers))
+	for k, h := range pub.Helpers {
+		ids[k] = pub.Parties[h]
+	}
+	return ids
+}
+
+// checkInputs performs basic checks on the inputs to catch most common errors
+func checkInputs(pub *PublicInput, prv *PrivateInput) error {
+	if pub.T >= pub.N {
+		return fmt.Errorf("T must be < N")
+	}
+	if pub.VSSParams.N != pub.N || pub.VSSParams.D != pub.T {
+		return fmt.Errorf("VSS parameters do not match N and T")
+	}
+	if len(pub.Parties) != pub.N {
+		return fmt.Errorf("number of parties must be N")
+	}
+	for _, party := range pub.Parties {
+		if party < 0 || party >= len(pub.EncPKs) {
+			return fmt.Errorf("no encryption key for party %d", party)
+		}
+	}
+	if len(pub.Commitments) != pub.N+1 {
+		return fmt.Errorf("there must be N+1 commitments")
+	}
+	if pub.Recipient < 0 || pub.Recipient >= pub.N {
+		return fmt.Errorf("invalid recipient %d", pub.Recipient)
+	}
+	if len(pub.Helpers) < pub.T+1 {
+		return fmt.Errorf("there must be at least T+1 helpers")
+	}
+	isHelper := make(map[int]bool, len(pub.Helpers))
+	for _, h := range pub.Helpers {
+		if h < 0 || h >= pub.N {
+			return fmt.Errorf("invalid helper %d", h)
+		}
+		if h == pub.Recipient {
+			return fmt.Errorf("the recipient cannot be a helper")
+		}
+		if isHelper[h] {
+			return fmt.Errorf("duplicate helper %d", h)
+		}
+		isHelper[h] = true
+	}
+
+	for _, h := range pub.Helpers {
+		if pub.Parties[h] != prv.ID {
+			continue
+		}
+		if prv.Share == nil || prv.Share.Index != h+1 {
+			return fmt.Errorf("helper %d must have the share of index %d", h, h+1)
+		}
+		valid, err := vss.VerifyShare(&pub.VSSParams, prv.Share, pub.Commitments)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return fmt.Errorf("share of helper %d does not match its commitment", h)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package recovery
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/shaih/go-yosovss/protocols/resharing/common"
+)
+
+const numRounds = 4 // number of rounds of messaging required for the protocol
+
+// StartParty initiates the protocol for a party participating in the recovery protocol
+// It returns the recovered share if the party is the recipient (or nil otherwise)
+// The recovered share can be used directly as PrivateInput.Share of resharing.StartCommitteeParty
+func StartParty(
+	pub *PublicInput,
+	prv *PrivateInput,
+) (
+	share *vss.Share,
+	err error,
+) {
+	err = checkInputs(pub, prv)
+	if err != nil {
+		return nil, err
+	}
+
+	helpers := helperParties(pub)
+
+	// index of the party in pub.Helpers, -1 if the party is not a helper
+	k := common.IntIndexOf(helpers, prv.ID)
+
+	// Blinding
+	// ========
+
+	// Each helper shares random blinding polynomials that are zero at the index of the recipient
+	var dealtShares []DealtShare
+	if k >= 0 {
+		var msg *BlindingMessage
+		msg, dealtShares, err = PerformBlinding(pub, prv)
+		if err != nil {
+			return nil, fmt.Errorf("party %d failed to perform blinding: %w", prv.ID, err)
+		}
+		prv.BC.Send(msgpack.Encode(msg))
+	} else {
+		prv.BC.Send([]byte{}) // an empty message
+	}
+
+	blindingMessages := ReceiveBlindingMessages(prv.BC, helpers)
+
+	// Complaint
+	// =========
+
+	// Each helper verifies the blinding shares it received and complains against invalid helpers
+	var receivedShares []*vss.Share
+	if k >= 0 {
+		var msg *ComplaintMessage
+		msg, receivedShares = PerformComplaint(pub, prv, k, blindingMessages)
+		prv.BC.Send(msgpack.Encode(msg))
+	} else {
+		prv.BC.Send([]byte{})
+	}
+
+	complaintMessages := ReceiveComplaintMessages(prv.BC, helpers)
+
+	// Answer
+	// ======
+
+	// Each helper publishes the blinding shares of the helpers complaining against it
+	if k >= 0 {
+		msg, err := PerformAnswer(pub, k, dealtShares, complaintMessages)
+		if err != nil {
+			return nil, fmt.Errorf("party %d failed to perform answer: %w", prv.ID, err)
+		}
+		prv.BC.Send(msgpack.Encode(msg))
+	} else {
+		prv.BC.Send([]byte{})
+	}
+
+	answerMessages := ReceiveAnswerMessages(prv.BC, helpers)
+
+	// Reply
+	// =====
+
+	// Each helper sends its blinded share to the recipient
+	if k >= 0 {
+		msg, err := PerformReply(pub, prv, k, blindingMessages, complaintMessages, answerMessages, receivedShares)
+		if err != nil {
+			return nil, fmt.Errorf("party %d failed to perform reply: %w", prv.ID, err)
+		}
+		prv.BC.Send(msgpack.Encode(msg))
+	} else {
+		prv.BC.Send([]byte{})
+	}
+
+	replyMessages := ReceiveReplyMessages(prv.BC, helpers)
+
+	// Output
+	// ======
+
+	if prv.ID != pub.Parties[pub.Recipient] {
+		return nil, nil
+	}
+	share, err = PerformOutput(pub, prv, blindingMessages, complaintMessages, answerMessages, replyMessages)
+	if err != nil {
+		return nil, fmt.Errorf("party %d failed to recover its share: %w", prv.ID, err)
+	}
+	return share, nil
+}
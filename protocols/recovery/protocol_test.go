@@ -0,0 +1,434 @@
+package recovery
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/shaih/go-yosovss/communication/fake"
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupRecovery setup the recovery protocol for n parties and threshold t
+// where the party recipient lost its share and is helped by the parties helpers
+// numParties-n additional parties only observe the protocol
+// It also returns the lost share of the recipient
+func setupRecovery(
+	t testing.TB,
+	n int,
+	tt int,
+	numParties int,
+	recipient int,
+	helpers []int,
+) (
+	pub *PublicInput,
+	prvs []PrivateInput,
+	o fake.Orchestrator,
+	lostShare *vss.Share,
+) {
+	require := require.New(t)
+
+	o = fake.NewOrchestrator()
+
+	encPKs, encSKs := curve25519.SetupKeys(numParties)
+	vssParams, err := vss.NewVSSParams(pedersen.GenerateParams(), n, tt)
+	require.NoError(err)
+
+	shares, commitments, err := vss.FixedRShare(vssParams, curve25519.RandomScalar(), curve25519.RandomScalar())
+	require.NoError(err)
+
+	parties := make([]int, n)
+	for i := range parties {
+		parties[i] = i
+	}
+
+	pub = &PublicInput{
+		VSSParams:   *vssParams,
+		EncPKs:      encPKs,
+		T:           tt,
+		N:           n,
+		Parties:     parties,
+		Commitments: commitments,
+		Recipient:   recipient,
+		Helpers:     helpers,
+	}
+
+	prvs = make([]PrivateInput, numParties)
+	for party := 0; party < numParties; party++ {
+		channel := fake.NewPartyBroadcastChannel(party)
+		o.AddChannel(channel)
+		prvs[party] = PrivateInput{
+			BC:    channel,
+			EncSK: encSKs[party],
+			ID:    party,
+		}
+		if party < n && party != recipient {
+			prvs[party].Share = &shares[party]
+		}
+	}
+
+	return pub, prvs, o, &shares[recipient]
+}
+
+// allHelpers returns the indices of all the parties but the recipient
+func allHelpers(n int, recipient int) []int {
+	var helpers []int
+	for i := 0; i < n; i++ {
+		if i != recipient {
+			helpers = append(helpers, i)
+		}
+	}
+	return helpers
+}
+
+// runRecovery runs the recovery protocol with all the parties
+// party is run with startParty[party] if it exists, and StartParty otherwise
+func runRecovery(
+	t *testing.T,
+	prvs []PrivateInput,
+	pub *PublicInput,
+	o fake.Orchestrator,
+	startParty map[int]func(pub *PublicInput, prv *PrivateInput) (*vss.Share, error),
+) (
+	outputShares []*vss.Share,
+	errs []error,
+) {
+	require := require.New(t)
+
+	outputShares = make([]*vss.Share, len(prvs))
+	errs = make([]error, len(prvs))
+
+	var wg sync.WaitGroup
+	for party := range prvs {
+		start, ok := startParty[party]
+		if !ok {
+			start = StartParty
+		}
+		wg.Add(1)
+		go func(party int) {
+			defer wg.Done()
+			outputShares[party], errs[party] = start(pub, &prvs[party])
+		}(party)
+	}
+
+	for o.Round < numRounds {
+		require.NoError(o.ReceiveMessages())
+		require.NoError(o.Broadcast())
+		o.Round++
+	}
+
+	wg.Wait()
+
+	return outputShares, errs
+}
+
+// checkRecoveryResults checks that the recipient recovered its lost share
+// and that the other parties (except the ones in skip) output nil shares without error
+func checkRecoveryResults(
+	t *testing.T,
+	pub *PublicInput,
+	lostShare *vss.Share,
+	outputShares []*vss.Share,
+	errs []error,
+	skip map[int]bool,
+) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	recipient := pub.Parties[pub.Recipient]
+	for party := range outputShares {
+		if skip[party] {
+			continue
+		}
+		require.NoError(errs[party], "party %d", party)
+		if party != recipient {
+			assert.Nil(outputShares[party], "only the recipient outputs a share")
+		}
+	}
+
+	require.NotNil(outputShares[recipient])
+	assert.Equal(*lostShare, *outputShares[recipient])
+}
+
+// startCheatingHelper runs the recovery protocol as a cheating helper
+// cheatBlinding (if not nil) modifies the blinding message before it is sent
+// if noAnswer is true, the helper does not answer complaints
+// cheatReply (if not nil) modifies the reply message before it is sent
+func startCheatingHelper(
+	cheatBlinding func(pub *PublicInput, msg *BlindingMessage),
+	noAnswer bool,
+	cheatReply func(pub *PublicInput, msg *ReplyMessage),
+) func(pub *PublicInput, prv *PrivateInput) (*vss.Share, error) {
+	return func(pub *PublicInput, prv *PrivateInput) (*vss.Share, error) {
+		helpers := helperParties(pub)
+		k := indexOf(helpers, prv.ID)
+
+		msg, dealtShares, err := PerformBlinding(pub, prv)
+		if err != nil {
+			return nil, err
+		}
+		if cheatBlinding != nil {
+			cheatBlinding(pub, msg)
+		}
+		prv.BC.Send(msgpack.Encode(msg))
+		blindingMessages := ReceiveBlindingMessages(prv.BC, helpers)
+
+		complaintMsg, receivedShares := PerformComplaint(pub, prv, k, blindingMessages)
+		prv.BC.Send(msgpack.Encode(complaintMsg))
+		complaintMessages := ReceiveComplaintMessages(prv.BC, helpers)
+
+		if noAnswer {
+			prv.BC.Send([]byte{})
+		} else {
+			answerMsg, err := PerformAnswer(pub, k, dealtShares, complaintMessages)
+			if err != nil {
+				return nil, err
+			}
+			prv.BC.Send(msgpack.Encode(answerMsg))
+		}
+		answerMessages := ReceiveAnswerMessages(prv.BC, helpers)
+
+		replyMsg, err := PerformReply(
+			pub, prv, k, blindingMessages, complaintMessages, answerMessages, receivedShares,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if cheatReply != nil {
+			cheatReply(pub, replyMsg)
+		}
+		prv.BC.Send(msgpack.Encode(replyMsg))
+		ReceiveReplyMessages(prv.BC, helpers)
+
+		return nil, nil
+	}
+}
+
+func indexOf(list []int, val int) int {
+	for i, v := range list {
+		if v == val {
+			return i
+		}
+	}
+	return -1
+}
+
+// wrongShareTo replaces the encrypted blinding share of helper kp by an encryption of a random share
+func wrongShareTo(kp int) func(pub *PublicInput, msg *BlindingMessage) {
+	return func(pub *PublicInput, msg *BlindingMessage) {
+		ds := DealtShare{S: *curve25519.RandomScalar(), R: *curve25519.RandomScalar()}
+		var err error
+		msg.EncShares[kp], err = curve25519.Encrypt(pub.EncPKs[pub.Parties[pub.Helpers[kp]]], msgpack.Encode(ds))
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// wrongReply replaces the reply by an encryption of a random blinded share
+func wrongReply(pub *PublicInput, msg *ReplyMessage) {
+	bs := BlindedShare{S: *curve25519.RandomScalar(), R: *curve25519.RandomScalar()}
+	var err error
+	msg.EncShare, err = curve25519.Encrypt(pub.EncPKs[pub.Parties[pub.Recipient]], msgpack.Encode(bs))
+	if err != nil {
+		panic(err)
+	}
+}
+
+func TestRecoveryProtocol(t *testing.T) {
+	const (
+		n         = 5
+		tt        = 2
+		recipient = 1
+	)
+
+	pub, prvs, o, lostShare := setupRecovery(t, n, tt, n+1, recipient, allHelpers(n, recipient)) // party n observes
+	outputShares, errs := runRecovery(t, prvs, pub, o, nil)
+	checkRecoveryResults(t, pub, lostShare, outputShares, errs, nil)
+}
+
+func TestRecoveryProtocolMinimalHelpers(t *testing.T) {
+	// Only t+1 helpers, the other parties only observe
+	const (
+		n         = 5
+		tt        = 2
+		recipient = 4
+	)
+
+	pub, prvs, o, lostShare := setupRecovery(t, n, tt, n, recipient, []int{3, 0, 2})
+	outputShares, errs := runRecovery(t, prvs, pub, o, nil)
+	checkRecoveryResults(t, pub, lostShare, outputShares, errs, nil)
+}
+
+func TestRecoveryProtocolCheatingHelpers(t *testing.T) {
+	// Helper 0 sends an invalid blinding share to helper 1 but answers the complaint, so it stays qualified
+	// Helper 2 sends an invalid blinding share to helper 1 and does not answer the complaint
+	// Helper 3 sends a sharing that is not zero at the index of the recipient
+	// Helper 4 sends an invalid blinded share to the recipient
+	// Helpers 2 and 3 are disqualified, and the recipient ignores the reply of helper 4
+	const (
+		n         = 9
+		tt        = 4
+		recipient = 8
+	)
+
+	pub, prvs, o, lostShare := setupRecovery(t, n, tt, n, recipient, allHelpers(n, recipient))
+	outputShares, errs := runRecovery(t, prvs, pub, o,
+		map[int]func(*PublicInput, *PrivateInput) (*vss.Share, error){
+			0: startCheatingHelper(wrongShareTo(1), false, nil),
+			2: startCheatingHelper(wrongShareTo(1), true, nil),
+			3: startCheatingHelper(func(pub *PublicInput, msg *BlindingMessage) {
+				_, commitments, err := vss.FixedRShare(
+					&pub.VSSParams, curve25519.RandomScalar(), curve25519.RandomScalar())
+				if err != nil {
+					panic(err)
+				}
+				msg.Commitments = commitments
+			}, false, nil),
+			4: startCheatingHelper(nil, false, wrongReply),
+		},
+	)
+	checkRecoveryResults(t, pub, lostShare, outputShares, errs, nil)
+}
+
+func TestRecoveryProtocolTooManyInvalidReplies(t *testing.T) {
+	// With only t+1 helpers, the recipient cannot recover its share if a helper sends an invalid reply
+	const (
+		n         = 5
+		tt        = 2
+		recipient = 0
+	)
+	require := require.New(t)
+
+	pub, prvs, o, _ := setupRecovery(t, n, tt, n, recipient, []int{1, 2, 3})
+	outputShares, errs := runRecovery(t, prvs, pub, o,
+		map[int]func(*PublicInput, *PrivateInput) (*vss.Share, error){
+			2: startCheatingHelper(nil, false, wrongReply),
+		},
+	)
+	require.Error(errs[recipient])
+	require.Nil(outputShares[recipient])
+}
+
+func TestBlindedSharesHideShares(t *testing.T) {
+	// The blinded shares received by the recipient are on a polynomial that matches the lost share
+	// at the index of the recipient, but not the shares of the helpers nor the secret
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n         = 5
+		tt        = 2
+		recipient = 2
+	)
+
+	pub, prvs, _, lostShare := setupRecovery(t, n, tt, n, recipient, []int{0, 1, 3})
+
+	blindingMessages := make([]BlindingMessage, len(pub.Helpers))
+	dealtShares := make([][]DealtShare, len(pub.Helpers))
+	for k, h := range pub.Helpers {
+		msg, shares, err := PerformBlinding(pub, &prvs[h])
+		require.NoError(err)
+		require.NoError(checkBlindingMessage(pub, msg))
+		blindingMessages[k] = *msg
+		dealtShares[k] = shares
+	}
+
+	complaintMessages := make([]ComplaintMessage, len(pub.Helpers))
+	receivedShares := make([][]*vss.Share, len(pub.Helpers))
+	for kp, h := range pub.Helpers {
+		msg, shares := PerformComplaint(pub, &prvs[h], kp, blindingMessages)
+		assert.Equal(make([]bool, len(pub.Helpers)), msg.Complaints)
+		complaintMessages[kp] = *msg
+		receivedShares[kp] = shares
+	}
+
+	answerMessages := make([]AnswerMessage, len(pub.Helpers))
+	for k := range pub.Helpers {
+		msg, err := PerformAnswer(pub, k, dealtShares[k], complaintMessages)
+		require.NoError(err)
+		answerMessages[k] = *msg
+	}
+
+	qualified, err := ComputeQualifiedHelpers(pub, blindingMessages, complaintMessages, answerMessages)
+	require.NoError(err)
+	assert.Equal([]int{0, 1, 2}, qualified)
+
+	replyMessages := make([]ReplyMessage, len(pub.Helpers))
+	for kp, h := range pub.Helpers {
+		msg, err := PerformReply(
+			pub, &prvs[h], kp, blindingMessages, complaintMessages, answerMessages, receivedShares[kp])
+		require.NoError(err)
+		replyMessages[kp] = *msg
+	}
+
+	blindedCommitments, err := ComputeBlindedCommitments(pub, blindingMessages, qualified)
+	require.NoError(err)
+	assert.Equal(pub.Commitments[recipient+1], blindedCommitments[recipient+1])
+
+	blindedShares := make([]vss.Share, len(pub.Helpers))
+	for kp, h := range pub.Helpers {
+		share := getBlindedShare(pub, &prvs[pub.Parties[recipient]], kp, blindedCommitments, &replyMessages[kp])
+		require.NotNil(share)
+		assert.NotEqual(prvs[h].Share.S, share.S, "the share of helper %d must be blinded", h)
+		blindedShares[kp] = *share
+	}
+
+	// the secret is hidden
+	s, _, err := vss.ReconstructWithRFromValidShares(&pub.VSSParams, blindedShares)
+	require.NoError(err)
+	valid, err := pedersen.VerifyCommitment(pub.VSSParams.PedersenParams, &pub.Commitments[0], s, &lostShare.R)
+	require.NoError(err)
+	assert.False(valid)
+	assert.NotEqual(pub.Commitments[0], blindedCommitments[0])
+
+	share, err := PerformOutput(
+		pub, &prvs[pub.Parties[recipient]], blindingMessages, complaintMessages, answerMessages, replyMessages)
+	require.NoError(err)
+	assert.Equal(*lostShare, *share)
+}
+
+func TestCheckInputs(t *testing.T) {
+	assert := assert.New(t)
+
+	const (
+		n         = 5
+		tt        = 2
+		recipient = 1
+	)
+
+	pub, prvs, _, _ := setupRecovery(t, n, tt, n, recipient, allHelpers(n, recipient))
+	assert.NoError(checkInputs(pub, &prvs[0]))
+	assert.NoError(checkInputs(pub, &prvs[recipient]))
+
+	invalid := *pub
+	invalid.Helpers = []int{0, 2}
+	assert.Error(checkInputs(&invalid, &prvs[0]), "less than t+1 helpers")
+
+	invalid = *pub
+	invalid.Helpers = []int{0, 1, 2}
+	assert.Error(checkInputs(&invalid, &prvs[0]), "recipient is a helper")
+
+	invalid = *pub
+	invalid.Helpers = []int{0, 2, 2}
+	assert.Error(checkInputs(&invalid, &prvs[0]), "duplicate helper")
+
+	invalid = *pub
+	invalid.Recipient = n
+	assert.Error(checkInputs(&invalid, &prvs[0]), "invalid recipient")
+
+	invalid = *pub
+	invalid.Commitments = pub.Commitments[:n]
+	assert.Error(checkInputs(&invalid, &prvs[0]), "missing commitment")
+
+	// helper with a wrong share
+	prv := prvs[0]
+	prv.Share = prvs[2].Share
+	assert.Error(checkInputs(pub, &prv))
+	prv.Share = nil
+	assert.Error(checkInputs(pub, &prv))
+}
@@ -0,0 +1,173 @@
+package recovery
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+)
+
+// BlindingMessage is the message helpers send during the blinding round
+// Notations below are for helper k in [0,len(Helpers)-1]
+type BlindingMessage struct {
+	_struct     struct{}              `codec:",omitempty,omitemptyarray"`
+	Commitments []pedersen.Commitment `codec:"C"` // Commitments[x] is the Pedersen commitment to
+	// the evaluation at x of the blinding polynomials (delta_k, rho_k), which are zero at Recipient+1
+	// x in 0,...,n
+	EncShares []curve25519.Ciphertext `codec:"E"` // EncShares[k'] is an encryption under the key of helper k'
+	// of its blinding share (type DealtShare) of index Helpers[k']+1
+	// k' in 0,...,len(Helpers)-1
+}
+
+// DealtShare is the blinding share sent by helper k to helper k' (share of index Helpers[k']+1)
+type DealtShare struct {
+	_struct struct{}          `codec:",omitempty,omitemptyarray"`
+	S       curve25519.Scalar `codec:"s"` // delta_k(Helpers[k']+1)
+	R       curve25519.Scalar `codec:"r"` // rho_k(Helpers[k']+1)
+}
+
+// toVSSShare converts the share of index i+1 into a vss.Share
+func (ds *DealtShare) toVSSShare(i int) *vss.Share {
+	share := &vss.Share{
+		Index: i + 1,
+		S:     ds.S,
+		R:     ds.R,
+	}
+	curve25519.GetScalarC(&share.IndexScalar, uint64(i+1))
+	return share
+}
+
+// zeroShareFrom is like vss.FixedRShareFrom but shares the zero polynomials of degree d
+// that are zero at the index zeroIndex (instead of the polynomials with a given constant term)
+// The polynomials are f(x) = (x - zeroIndex) * a(x) and g(x) = (x - zeroIndex) * b(x)
+// with a and b random of degree d-1, so that f and g are uniformly random among
+// the polynomials of degree d that are zero at zeroIndex
+func zeroShareFrom(rnd io.Reader, params *vss.Params, zeroIndex int) (
+	shares []vss.Share, commitments []pedersen.Commitment, err error) {
+
+	n := params.N
+	d := params.D
+
+	z := curve25519.GetScalar(uint64(zeroIndex))
+
+	// vanishing returns the coefficients of (x - z) * a(x) for a random a of degree d-1
+	vanishing := func() (*curve25519.Polynomial, error) {
+		a := make([]curve25519.Scalar, d)
+		for i := range a {
+			ai, err := curve25519.RandomScalarFrom(rnd)
+			if err != nil {
+				return nil, err
+			}
+			a[i] = *ai
+		}
+		p := curve25519.Polynomial{
+			Coefficients: make([]curve25519.Scalar, d+1),
+		}
+		for i := 0; i <= d; i++ {
+			// coefficient of x^i is a_{i-1} - z * a_i
+			if i < d {
+				p.Coefficients[i] = *curve25519.NegateScalar(curve25519.MultScalar(z, &a[i]))
+			}
+			if i > 0 {
+				p.Coefficients[i] = *curve25519.AddScalar(&p.Coefficients[i], &a[i-1])
+			}
+		}
+		return &p, nil
+	}
+
+	f, err := vanishing()
+	if err != nil {
+		return nil, nil, err
+	}
+	g, err := vanishing()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shares = make([]vss.Share, n)
+	commitments = make([]pedersen.Commitment, n+1)
+	for i := 0; i <= n; i++ {
+		var x, s, r curve25519.Scalar
+		curve25519.GetScalarC(&x, uint64(i))
+		f.EvaluateC(&s, &x)
+		g.EvaluateC(&r, &x)
+
+		commitment, err := pedersen.GenerateCommitmentFixedR(params.PedersenParams, &s, &r)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error generating commitment of share %d: %w", i, err)
+		}
+		commitments[i] = *commitment
+
+		if i > 0 {
+			shares[i-1] = vss.Share{Index: i, IndexScalar: x, S: s, R: r}
+		}
+	}
+
+	return shares, commitments, nil
+}
+
+// PerformBlinding executes what a helper does in the blinding round
+// and returns the message it should broadcast together with the blinding shares it dealt
+// (which are required to answer complaints, see PerformAnswer)
+// The helper shares fresh random blinding polynomials that are zero at the index of the recipient
+// The final blinding polynomials are the sums of the ones of the qualified helpers (see ComputeQualifiedHelpers)
+func PerformBlinding(pub *PublicInput, prv *PrivateInput) (*BlindingMessage, []DealtShare, error) {
+	shares, commitments, err := zeroShareFrom(prv.Rand, &pub.VSSParams, pub.Recipient+1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while sharing the blinding polynomials: %w", err)
+	}
+
+	msg := &BlindingMessage{
+		Commitments: commitments,
+		EncShares:   make([]curve25519.Ciphertext, len(pub.Helpers)),
+	}
+	dealtShares := make([]DealtShare, len(pub.Helpers))
+
+	// Encrypt the share of index Helpers[k']+1 for helper k'
+	for k, h := range pub.Helpers {
+		dealtShares[k] = DealtShare{S: shares[h].S, R: shares[h].R}
+		msg.EncShares[k], err = curve25519.EncryptFrom(
+			prv.Rand, pub.EncPKs[pub.Parties[h]], msgpack.Encode(dealtShares[k]),
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return msg, dealtShares, nil
+}
+
+// checkBlindingMessage checks everything about the message of a helper that can be checked publicly:
+// lengths, commitments on the curve, commitments on a polynomial of degree t,
+// and commitment at the index of the recipient being a commitment to (0,0)
+func checkBlindingMessage(pub *PublicInput, msg *BlindingMessage) error {
+	if len(msg.Commitments) != pub.N+1 || len(msg.EncShares) != len(pub.Helpers) {
+		return fmt.Errorf("commitments or encrypted shares of incorrect length")
+	}
+	for x := range msg.Commitments {
+		if !curve25519.IsOnCurveXY(&msg.Commitments[x]) {
+			return fmt.Errorf("commitment %d not on the curve", x)
+		}
+	}
+	valid, err := vss.VerifyCommitments(&pub.VSSParams, msg.Commitments)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("commitments are not on a polynomial of degree t")
+	}
+	valid, err = pedersen.VerifyCommitment(
+		pub.VSSParams.PedersenParams, &msg.Commitments[pub.Recipient+1],
+		&curve25519.ScalarZero, &curve25519.ScalarZero,
+	)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("blinding polynomials are not zero at the index of the recipient")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package recovery
+
+import (
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	log "github.com/sirupsen/logrus"
+)
+
+// ComplaintMessage is the message helpers send during the complaint round
+// Below we assume the helper is k'
+type ComplaintMessage struct {
+	_struct    struct{} `codec:",omitempty,omitemptyarray"`
+	Complaints []bool   `codec:"C"` // Complaints[k] == true iff complaint against helper k
+}
+
+// PerformComplaint executes what helper k' does in the complaint round
+// It decrypts and verifies all the blinding shares sent to it
+// and returns the message it should broadcast together with the received shares
+// receivedShares[k] is the share sent by helper k, or nil if helper k' complains against helper k
+// or if the blinding message of helper k is publicly invalid (see checkBlindingMessage)
+// k' is in [0,len(Helpers)-1]
+func PerformComplaint(
+	pub *PublicInput, prv *PrivateInput, kp int,
+	blindingMessages []BlindingMessage,
+) (
+	msg *ComplaintMessage, receivedShares []*vss.Share,
+) {
+	myLog := log.WithFields(log.Fields{
+		"party": prv.ID,
+		"k":     kp,
+	})
+
+	msg = &ComplaintMessage{
+		Complaints: make([]bool, len(pub.Helpers)),
+	}
+	receivedShares = make([]*vss.Share, len(pub.Helpers))
+
+	for k := range pub.Helpers {
+		if err := checkBlindingMessage(pub, &blindingMessages[k]); err != nil {
+			// no need to complain, everybody disqualifies helper k
+			myLog.Infof("helper %d is publicly invalid: %v", k, err)
+			continue
+		}
+
+		share := getShare(pub, prv, kp, &blindingMessages[k])
+		if share == nil {
+			myLog.Infof("complain against helper %d", k)
+			msg.Complaints[k] = true
+			continue
+		}
+		receivedShares[k] = share
+	}
+
+	return msg, receivedShares
+}
+
+// getShare decrypts and verifies the blinding share sent to helper k' in the blinding message msg
+// The message must have been checked with checkBlindingMessage
+// It returns nil if the share is invalid
+func getShare(pub *PublicInput, prv *PrivateInput, kp int, msg *BlindingMessage) *vss.Share {
+	dsMsg, err := curve25519.Decrypt(pub.EncPKs[prv.ID], prv.EncSK, msg.EncShares[kp])
+	if err != nil {
+		return nil
+	}
+	var ds DealtShare
+	err = msgpack.Decode(dsMsg, &ds)
+	if err != nil {
+		return nil
+	}
+
+	share := ds.toVSSShare(pub.Helpers[kp])
+	valid, err := vss.VerifyShare(&pub.VSSParams, share, msg.Commitments)
+	if err != nil || !valid {
+		return nil
+	}
+	return share
+}
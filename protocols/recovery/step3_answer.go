@@ -0,0 +1,44 @@
+package recovery
+
+import (
+	"fmt"
+)
+
+// AnswerMessage is the message helpers send during the answer round
+// Below we assume the helper is k
+type AnswerMessage struct {
+	_struct struct{}      `codec:",omitempty,omitemptyarray"`
+	Shares  []*DealtShare `codec:"S"` // Shares[k'] is the blinding share of helper k' in clear if helper k'
+	// complained against helper k, and nil otherwise
+	// k' in 0,...,len(Helpers)-1
+}
+
+// PerformAnswer executes what helper k does in the answer round:
+// it publishes the blinding shares of all the helpers that complained against it
+// shares are the shares it dealt (in the same order as in PerformBlinding)
+// As an honest helper never complains against an honest helper, the blinding of the reply
+// of an honest helper (see PerformReply) always contains a blinding share that is not published
+func PerformAnswer(
+	pub *PublicInput, k int,
+	shares []DealtShare,
+	complaintMessages []ComplaintMessage,
+) (*AnswerMessage, error) {
+	if len(shares) != len(pub.Helpers) {
+		return nil, fmt.Errorf("invalid number of shares")
+	}
+
+	msg := &AnswerMessage{
+		Shares: make([]*DealtShare, len(pub.Helpers)),
+	}
+	for kp := range pub.Helpers {
+		if isComplaining(&complaintMessages[kp], k) {
+			msg.Shares[kp] = &shares[kp]
+		}
+	}
+	return msg, nil
+}
+
+// isComplaining returns true if the complaint message contains a complaint against helper k
+func isComplaining(msg *ComplaintMessage, k int) bool {
+	return k < len(msg.Complaints) && msg.Complaints[k]
+}
@@ -0,0 +1,137 @@
+package recovery
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	log "github.com/sirupsen/logrus"
+)
+
+// ReplyMessage is the message helpers send during the reply round
+// Below we assume the helper is k'
+type ReplyMessage struct {
+	_struct  struct{}              `codec:",omitempty,omitemptyarray"`
+	EncShare curve25519.Ciphertext `codec:"E"` // encryption under the key of the recipient
+	// of the blinded share of index Helpers[k']+1 (type BlindedShare)
+}
+
+// BlindedShare is the share of helper k' blinded by the blinding polynomials of the qualified helpers
+type BlindedShare struct {
+	_struct struct{}          `codec:",omitempty,omitemptyarray"`
+	S       curve25519.Scalar `codec:"s"` // sigma_{h'+1} + sum_k delta_k(h'+1) where h' = Helpers[k']
+	R       curve25519.Scalar `codec:"r"` // rho_{h'+1} + sum_k rho_k(h'+1)
+}
+
+// checkHelperQualified returns nil if helper k is qualified, and the reason of its disqualification otherwise
+// Helper k is disqualified if its blinding message is publicly invalid (see checkBlindingMessage),
+// if more than t helpers complained against it, or if it did not answer a complaint with a valid share
+func checkHelperQualified(
+	pub *PublicInput, k int,
+	blindingMessages []BlindingMessage,
+	complaintMessages []ComplaintMessage,
+	answerMessages []AnswerMessage,
+) error {
+	err := checkBlindingMessage(pub, &blindingMessages[k])
+	if err != nil {
+		return err
+	}
+
+	var complaints []int
+	for kp := range pub.Helpers {
+		if isComplaining(&complaintMessages[kp], k) {
+			complaints = append(complaints, kp)
+		}
+	}
+	if len(complaints) == 0 {
+		return nil
+	}
+	if len(complaints) > pub.T {
+		// honest helpers get at most t complaints
+		return fmt.Errorf("%d complaints (more than t)", len(complaints))
+	}
+
+	answer := &answerMessages[k]
+	if len(answer.Shares) != len(pub.Helpers) {
+		return fmt.Errorf("answer of incorrect length")
+	}
+	for _, kp := range complaints {
+		if answer.Shares[kp] == nil {
+			return fmt.Errorf("no answer to the complaint of helper %d", kp)
+		}
+		valid, err := vss.VerifyShare(
+			&pub.VSSParams, answer.Shares[kp].toVSSShare(pub.Helpers[kp]), blindingMessages[k].Commitments,
+		)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return fmt.Errorf("invalid answer to the complaint of helper %d", kp)
+		}
+	}
+	return nil
+}
+
+// ComputeQualifiedHelpers returns the list of qualified helpers, in increasing order
+// whose blinding polynomials are used to blind the replies
+// It depends only on broadcast messages, so all the parties compute the same list
+// It returns an error if more than t helpers are disqualified,
+// as honest helpers are never disqualified, so that there are more than t malicious helpers
+func ComputeQualifiedHelpers(
+	pub *PublicInput,
+	blindingMessages []BlindingMessage,
+	complaintMessages []ComplaintMessage,
+	answerMessages []AnswerMessage,
+) ([]int, error) {
+	var qualified []int
+	for k := range pub.Helpers {
+		err := checkHelperQualified(pub, k, blindingMessages, complaintMessages, answerMessages)
+		if err != nil {
+			log.Infof("helper %d disqualified: %v", k, err)
+			continue
+		}
+		qualified = append(qualified, k)
+	}
+	if len(qualified) < len(pub.Helpers)-pub.T {
+		return nil, fmt.Errorf("only %d qualified helpers (less than the number of helpers minus t)", len(qualified))
+	}
+	return qualified, nil
+}
+
+// PerformReply executes what helper k' does in the reply round:
+// it blinds its share with the blinding shares of the qualified helpers
+// and encrypts it under the key of the recipient
+// receivedShares are the shares received by helper k' (see PerformComplaint)
+func PerformReply(
+	pub *PublicInput, prv *PrivateInput, kp int,
+	blindingMessages []BlindingMessage,
+	complaintMessages []ComplaintMessage,
+	answerMessages []AnswerMessage,
+	receivedShares []*vss.Share,
+) (*ReplyMessage, error) {
+	qualified, err := ComputeQualifiedHelpers(pub, blindingMessages, complaintMessages, answerMessages)
+	if err != nil {
+		return nil, err
+	}
+
+	bs := BlindedShare{
+		S: prv.Share.S,
+		R: prv.Share.R,
+	}
+	for _, k := range qualified {
+		sk := receivedShares[k]
+		if sk == nil {
+			// helper k' complained against the qualified helper k, so helper k answered with a valid share
+			sk = answerMessages[k].Shares[kp].toVSSShare(pub.Helpers[kp])
+		}
+		bs.S = *curve25519.AddScalar(&bs.S, &sk.S)
+		bs.R = *curve25519.AddScalar(&bs.R, &sk.R)
+	}
+
+	encShare, err := curve25519.EncryptFrom(prv.Rand, pub.EncPKs[pub.Parties[pub.Recipient]], msgpack.Encode(bs))
+	if err != nil {
+		return nil, err
+	}
+	return &ReplyMessage{EncShare: encShare}, nil
+}
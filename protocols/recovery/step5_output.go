@@ -0,0 +1,134 @@
+package recovery
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	log "github.com/sirupsen/logrus"
+)
+
+// ComputeBlindedCommitments returns the commitments to the blinded shares
+// blindedCommitments[x] = Commitments[x] + sum_k BlindingMessage[k].Commitments[x] for qualified helpers k
+// As the blinding polynomials are zero at Recipient+1,
+// blindedCommitments[Recipient+1] = Commitments[Recipient+1]
+func ComputeBlindedCommitments(
+	pub *PublicInput,
+	blindingMessages []BlindingMessage,
+	qualified []int,
+) ([]pedersen.Commitment, error) {
+	blindedCommitments := make([]pedersen.Commitment, pub.N+1)
+	for x := 0; x <= pub.N; x++ {
+		points := make([]curve25519.PointXY, 0, len(qualified)+1)
+		points = append(points, pub.Commitments[x])
+		for _, k := range qualified {
+			points = append(points, blindingMessages[k].Commitments[x])
+		}
+		cx, err := curve25519.AddPointsXY(points)
+		if err != nil {
+			return nil, err
+		}
+		blindedCommitments[x] = *cx
+	}
+	return blindedCommitments, nil
+}
+
+// getBlindedShare decrypts and verifies the blinded share sent by helper k' in the reply message msg
+// It returns nil if the share is invalid
+func getBlindedShare(
+	pub *PublicInput, prv *PrivateInput, kp int,
+	blindedCommitments []pedersen.Commitment,
+	msg *ReplyMessage,
+) *vss.Share {
+	bsMsg, err := curve25519.Decrypt(pub.EncPKs[prv.ID], prv.EncSK, msg.EncShare)
+	if err != nil {
+		return nil
+	}
+	var bs BlindedShare
+	err = msgpack.Decode(bsMsg, &bs)
+	if err != nil {
+		return nil
+	}
+
+	h := pub.Helpers[kp]
+	share := &vss.Share{
+		Index: h + 1,
+		S:     bs.S,
+		R:     bs.R,
+	}
+	curve25519.GetScalarC(&share.IndexScalar, uint64(h+1))
+	valid, err := vss.VerifyShare(&pub.VSSParams, share, blindedCommitments)
+	if err != nil || !valid {
+		return nil
+	}
+	return share
+}
+
+// PerformOutput executes what the recipient does at the end of the protocol:
+// it verifies the blinded shares of the helpers and interpolates t+1 valid ones at Recipient+1
+// The blinded shares are evaluations of the sharing polynomials plus the blinding polynomials,
+// which are random polynomials of degree t except at Recipient+1, where they are zero
+// so the recipient does not learn anything besides its share
+// It returns an error if there are less than t+1 valid blinded shares
+func PerformOutput(
+	pub *PublicInput, prv *PrivateInput,
+	blindingMessages []BlindingMessage,
+	complaintMessages []ComplaintMessage,
+	answerMessages []AnswerMessage,
+	replyMessages []ReplyMessage,
+) (*vss.Share, error) {
+	qualified, err := ComputeQualifiedHelpers(pub, blindingMessages, complaintMessages, answerMessages)
+	if err != nil {
+		return nil, err
+	}
+	blindedCommitments, err := ComputeBlindedCommitments(pub, blindingMessages, qualified)
+	if err != nil {
+		return nil, err
+	}
+
+	var validShares []*vss.Share
+	for kp := range pub.Helpers {
+		share := getBlindedShare(pub, prv, kp, blindedCommitments, &replyMessages[kp])
+		if share == nil {
+			log.Infof("invalid blinded share from helper %d", kp)
+			continue
+		}
+		validShares = append(validShares, share)
+		if len(validShares) == pub.T+1 {
+			break
+		}
+	}
+	if len(validShares) < pub.T+1 {
+		return nil, fmt.Errorf("only %d valid blinded shares (less than t+1)", len(validShares))
+	}
+
+	// Polynomial interpolation evaluated at Recipient+1
+	share := &vss.Share{
+		Index: pub.Recipient + 1,
+	}
+	curve25519.GetScalarC(&share.IndexScalar, uint64(pub.Recipient+1))
+
+	indices := make([]curve25519.Scalar, len(validShares))
+	for q, s := range validShares {
+		indices[q] = s.IndexScalar
+	}
+	lambdas, err := curve25519.LagrangeCoeffs(indices, &share.IndexScalar)
+	if err != nil {
+		return nil, fmt.Errorf("error in polynomial interpolation: %w", err)
+	}
+	for q, s := range validShares {
+		share.S = *curve25519.AddScalar(&share.S, curve25519.MultScalar(&lambdas[q], &s.S))
+		share.R = *curve25519.AddScalar(&share.R, curve25519.MultScalar(&lambdas[q], &s.R))
+	}
+
+	valid, err := vss.VerifyShare(&pub.VSSParams, share, pub.Commitments)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, fmt.Errorf("recovered share does not match its commitment")
+	}
+	return share, nil
+}
@@ -0,0 +1,183 @@
+// Package auditor allows any observer to check a completed refresh of the resharing protocol
+// from its public transcript, i.e., without any secret key (see Audit)
+package auditor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shaih/go-yosovss/communication"
+	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/protocols/resharing"
+)
+
+// Verdict is the verdict of the audit of a refresh
+type Verdict int
+
+const (
+	// VerdictValid means that the refresh succeeded: the next commitments are defined
+	// and every member of the next holding committee can compute its share
+	// from the messages of publicly valid verifiers and from the resolved complaints
+	VerdictValid Verdict = iota
+	// VerdictFailed means that the refresh failed (see Report.Reason)
+	VerdictFailed
+)
+
+// String returns the verdict in a human-readable form
+func (v Verdict) String() string {
+	switch v {
+	case VerdictValid:
+		return "valid"
+	case VerdictFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("Verdict(%d)", int(v))
+	}
+}
+
+// Report is the result of the audit of a refresh
+// Dealers (resp. verifiers) are indices in the holding (resp. verification) committee
+type Report struct {
+	Verdict Verdict
+	Reason  string // reason of the failure (empty if the refresh is valid)
+
+	DisqualifiedDealers    []int         // dealers disqualified by complaints or by sending a malformed message (sorted)
+	QualifiedDealers       []int         // dealers whose sharings are used for refreshing (see ComputeQualifiedDealers)
+	QualifiedDealersDigest []byte        // public commitment to QualifiedDealers (see resharing.QualifiedDealersDigest)
+	InvalidVerifiers       map[int]error // InvalidVerifiers[j] is the reason why verifier j is invalid
+	// (see resharing.CheckVerifiers)
+	Blame *resharing.BlameReport // evidence of the disqualifications of the dealers and of the invalid verifiers
+
+	NextCommitments    []pedersen.Commitment // commitments of the next holding committee (nil if no qualified dealers)
+	FeldmanCommitments []feldman.GCommitment // only if pub.FeldmanConversion (see ComputeFeldmanCommitments)
+
+	// BatchNextCommitments[u] are the commitments of the secret u of the next holding committee
+	// only for a batched refresh (see resharing.PublicInput.BatchCommitments), NextCommitments is then nil
+	BatchNextCommitments [][]pedersen.Commitment
+}
+
+// Audit runs the public part of the refresh of pub from the messages broadcast in the rounds
+// (messages of parties that are not members of the committee sending in the round are ignored,
+// and resolution is empty with verifiable encryption, see resharing.PublicInput.NumRounds):
+// it resolves the complaints, computes the qualified dealers and the next commitments,
+// and verifies publicly the proofs of the verifiers
+// Malicious messages do not cause an error but are reported (see Report)
+// An error is only returned if the audit could not be performed (e.g., if pub is invalid)
+func Audit(
+	pub *resharing.PublicInput,
+	dealing, verification, resolution []communication.BroadcastMessage,
+) (*Report, error) {
+	return auditPayloads(
+		pub,
+		communication.PayloadsFrom(dealing, pub.Committees.Hold),
+		communication.PayloadsFrom(verification, pub.Committees.Ver),
+		communication.PayloadsFrom(resolution, pub.Committees.Res),
+	)
+}
+
+// AuditTranscript is the same as Audit for a transcript (see Transcript)
+func AuditTranscript(tr *Transcript) (*Report, error) {
+	if tr.Pub == nil {
+		return nil, fmt.Errorf("missing public input in transcript")
+	}
+	pub, err := tr.Pub.PublicInput()
+	if err != nil {
+		return nil, fmt.Errorf("invalid public input in transcript: %w", err)
+	}
+	return auditPayloads(pub, tr.Dealing, tr.Verification, tr.Resolution)
+}
+
+// auditPayloads is the same as Audit where the messages are given as payloads (see Transcript)
+func auditPayloads(pub *resharing.PublicInput, dealing, verification, resolution [][]byte) (*Report, error) {
+	err := resharing.CheckPublicInput(pub)
+	if err != nil {
+		return nil, err
+	}
+	if pub.BatchCommitments == nil && len(pub.Commitments) != pub.N+1 {
+		return nil, fmt.Errorf("there must be N+1 commitments")
+	}
+	if len(dealing) != len(pub.Committees.Hold) ||
+		len(verification) != len(pub.Committees.Ver) ||
+		len(resolution) != len(pub.Committees.Res) {
+		return nil, fmt.Errorf("the number of messages does not match the size of the committees")
+	}
+
+	dealingMessages := resharing.DecodeDealingMessages(dealing, pub.Committees.Hold)
+	verificationMessages := resharing.DecodeVerificationMessages(verification, pub.Committees.Ver)
+	resolutionMessages := resharing.DecodeResolutionMessages(resolution, pub.Committees.Res)
+
+	report := &Report{}
+
+	resolvedSharesSR, disqualifiedDealersByComplaints, blame, err := resharing.ResolveComplaints(
+		pub, dealingMessages, verificationMessages, resolutionMessages, &resharing.PartyDebugParams{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve complaints: %w", err)
+	}
+	report.DisqualifiedDealers = make([]int, 0, len(disqualifiedDealersByComplaints))
+	for i := range disqualifiedDealersByComplaints {
+		report.DisqualifiedDealers = append(report.DisqualifiedDealers, i)
+	}
+	sort.Ints(report.DisqualifiedDealers)
+	report.Blame = blame
+
+	report.InvalidVerifiers, err = resharing.CheckVerifiers(pub, dealingMessages, verificationMessages, blame)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check verifiers: %w", err)
+	}
+
+	qualifiedDealers, lagrangeCoefs, err := resharing.ComputeQualifiedDealers(
+		pub, disqualifiedDealersByComplaints, dealingMessages, blame)
+	if err != nil {
+		report.Verdict = VerdictFailed
+		report.Reason = err.Error()
+		return report, nil
+	}
+	report.QualifiedDealers = qualifiedDealers
+	report.QualifiedDealersDigest = resharing.QualifiedDealersDigest(pub, qualifiedDealers)
+
+	if pub.BatchCommitments != nil {
+		report.BatchNextCommitments, err = resharing.ComputeRefreshedBatchCommitments(
+			pub, dealingMessages, qualifiedDealers, lagrangeCoefs)
+	} else {
+		report.NextCommitments, err = resharing.ComputeRefreshedCommitments(
+			pub, dealingMessages, qualifiedDealers, lagrangeCoefs)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute refreshed commitments: %w", err)
+	}
+
+	if pub.FeldmanConversion {
+		report.FeldmanCommitments, err = resharing.ComputeFeldmanCommitments(pub, dealingMessages, qualifiedDealers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute Feldman commitments: %w", err)
+		}
+	}
+
+	// The next holding committee reconstructs the shares of each qualified dealer i from d+1 verifiers j
+	// that either forwarded them (no complaint and valid message) or complained and got them resolved
+	// (see ComputeShareIL)
+	verParams := &pub.VSSParams
+	if pub.VerVSSParams != nil {
+		verParams = pub.VerVSSParams
+	}
+	for _, i := range qualifiedDealers {
+		available := 0
+		for j := range verificationMessages {
+			_, invalid := report.InvalidVerifiers[j]
+			if resharing.IsResolved(resolvedSharesSR, i, j) || (!invalid && !verificationMessages[j].Complaints[i]) {
+				available++
+			}
+		}
+		if available < verParams.D+1 {
+			report.Verdict = VerdictFailed
+			report.Reason = fmt.Sprintf(
+				"not enough shares of dealer %d for the next holding committee: got %d but need %d",
+				i, available, verParams.D+1)
+			return report, nil
+		}
+	}
+
+	report.Verdict = VerdictValid
+	return report, nil
+}
@@ -0,0 +1,422 @@
+package auditor
+
+import (
+	"testing"
+
+	"github.com/shaih/go-yosovss/communication"
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/shaih/go-yosovss/protocols/resharing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	numRounds     = 3 // number of rounds of the resharing protocol
+	numCommittees = 4 // number of committees of the resharing protocol
+)
+
+// setupAuditor setups a refresh where each committee has n different parties taken in order
+// and max tt malicious parties
+func setupAuditor(t *testing.T, n, tt int) (pub *resharing.PublicInput, prvs []resharing.PrivateInput) {
+	require := require.New(t)
+
+	numParties := n * numCommittees
+
+	vcParams, err := feldman.GenerateVCParams(2 * n)
+	require.NoError(err)
+	encPKs, encSKs := curve25519.SetupKeys(numParties)
+	vssParams, err := vss.NewVSSParams(pedersen.GenerateParams(), n, tt)
+	require.NoError(err)
+
+	shares, commitments, err := vss.FixedRShare(vssParams, curve25519.RandomScalar(), curve25519.RandomScalar())
+	require.NoError(err)
+
+	committees := make([][]int, numCommittees)
+	for c := range committees {
+		committees[c] = make([]int, n)
+		for x := range committees[c] {
+			committees[c][x] = c*n + x
+		}
+	}
+
+	pub = &resharing.PublicInput{
+		VCParams:  *vcParams,
+		EncPKs:    encPKs,
+		VSSParams: *vssParams,
+		T:         tt,
+		N:         n,
+		Committees: resharing.Committees{
+			Hold: committees[0],
+			Ver:  committees[1],
+			Res:  committees[2],
+			Next: committees[3],
+		},
+		Commitments:       commitments,
+		SessionID:         []byte("test session"),
+		Epoch:             1,
+		FeldmanConversion: true,
+	}
+
+	prvs = make([]resharing.PrivateInput, numParties)
+	for party := range prvs {
+		prvs[party] = resharing.PrivateInput{
+			EncSK: encSKs[party],
+			ID:    party,
+		}
+	}
+	for i, party := range pub.Committees.Hold {
+		prvs[party].Share = &shares[i]
+	}
+	return pub, prvs
+}
+
+// runRefresh runs the refresh with all the parties driven step by step (see resharing.Party)
+// and returns the messages broadcast in each round and the parties
+// tamper (if not nil) may replace the payload broadcast by a party in a round
+func runRefresh(
+	t *testing.T,
+	pub *resharing.PublicInput,
+	prvs []resharing.PrivateInput,
+	tamper func(round int, party int, payload []byte) []byte,
+) (
+	messages [][]communication.BroadcastMessage,
+	parties []*resharing.Party,
+) {
+	require := require.New(t)
+	dbg := &resharing.PartyDebugParams{}
+
+	parties = make([]*resharing.Party, len(prvs))
+	for party := range parties {
+		var err error
+		parties[party], err = resharing.NewParty(pub, &prvs[party], dbg)
+		require.NoError(err)
+	}
+
+	messages = make([][]communication.BroadcastMessage, numRounds)
+	for round := 0; round < numRounds; round++ {
+		messages[round] = make([]communication.BroadcastMessage, len(parties))
+		for party := range parties {
+			payload := parties[party].Payload()
+			if tamper != nil {
+				payload = tamper(round, party, payload)
+			}
+			messages[round][party] = communication.BroadcastMessage{
+				Payload:  payload,
+				SenderID: party,
+			}
+		}
+
+		for party := range parties {
+			// the refresh may fail in the last round
+			_, _, err := parties[party].Step(messages[round])
+			if round < numRounds-1 {
+				require.NoError(err)
+			}
+		}
+	}
+	return messages, parties
+}
+
+func TestAudit(t *testing.T) {
+	const (
+		n  = 3
+		tt = 1
+	)
+
+	testCases := []struct {
+		name                string
+		allQualifiedDealers bool
+		qualifiedDealers    []int
+	}{
+		{"first-qualified-dealers", false, []int{0, 1}},
+		{"all-qualified-dealers", true, []int{0, 1, 2}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			pub, prvs := setupAuditor(t, n, tt)
+			pub.AllQualifiedDealers = tc.allQualifiedDealers
+			messages, parties := runRefresh(t, pub, prvs, nil)
+
+			report, err := Audit(pub, messages[0], messages[1], messages[2])
+			require.NoError(err)
+			assert.Equal(VerdictValid, report.Verdict)
+			assert.Empty(report.Reason)
+			assert.Empty(report.DisqualifiedDealers)
+			assert.Empty(report.InvalidVerifiers)
+			assert.Equal(tc.qualifiedDealers, report.QualifiedDealers)
+
+			// The auditor computes the same commitments and qualified dealers as the parties
+			for party := range parties {
+				_, nextCommitments, feldmanCommitments, err := parties[party].Output()
+				require.NoError(err)
+				assert.Equal(nextCommitments, report.NextCommitments)
+				assert.Equal(feldmanCommitments, report.FeldmanCommitments)
+				assert.Equal(report.QualifiedDealers, parties[party].QualifiedDealers())
+				assert.Equal(report.QualifiedDealersDigest, parties[party].QualifiedDealersDigest())
+			}
+
+			// Same result from an encoded transcript
+			var tr Transcript
+			require.NoError(msgpack.Decode(
+				msgpack.Encode(NewTranscript(pub, messages[0], messages[1], messages[2])), &tr))
+			trReport, err := AuditTranscript(&tr)
+			require.NoError(err)
+			assert.Equal(report, trReport)
+		})
+	}
+}
+
+func TestAuditBatch(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n          = 3
+		tt         = 1
+		numSecrets = 2
+	)
+
+	pub, prvs := setupAuditor(t, n, tt)
+	pub.FeldmanConversion = false
+
+	// Replace the sharing by numSecrets sharings (see resharing.PublicInput.BatchCommitments)
+	vssParams := &pub.VSSParams
+	pub.Commitments = nil
+	pub.BatchCommitments = make([][]pedersen.Commitment, numSecrets)
+	for _, party := range pub.Committees.Hold {
+		prvs[party].Share = nil
+		prvs[party].BatchShares = make([]*vss.Share, numSecrets)
+	}
+	for u := range pub.BatchCommitments {
+		shares, commitments, err := vss.FixedRShare(vssParams, curve25519.RandomScalar(), curve25519.RandomScalar())
+		require.NoError(err)
+		pub.BatchCommitments[u] = commitments
+		for i, party := range pub.Committees.Hold {
+			prvs[party].BatchShares[u] = &shares[i]
+		}
+	}
+
+	messages, parties := runRefresh(t, pub, prvs, nil)
+
+	report, err := Audit(pub, messages[0], messages[1], messages[2])
+	require.NoError(err)
+	assert.Equal(VerdictValid, report.Verdict)
+	assert.Nil(report.NextCommitments)
+	require.Len(report.BatchNextCommitments, numSecrets)
+
+	// The auditor computes the same commitments as the parties
+	for party := range parties {
+		_, nextCommitments, err := parties[party].BatchOutput()
+		require.NoError(err)
+		assert.Equal(nextCommitments, report.BatchNextCommitments)
+	}
+
+	// Same result from an encoded transcript
+	var tr Transcript
+	require.NoError(msgpack.Decode(
+		msgpack.Encode(NewTranscript(pub, messages[0], messages[1], messages[2])), &tr))
+	assert.Equal(pub.BatchCommitments, tr.Pub.BatchCommitments)
+	trReport, err := AuditTranscript(&tr)
+	require.NoError(err)
+	assert.Equal(report, trReport)
+}
+
+func TestAuditMaliciousParties(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n  = 3
+		tt = 1
+	)
+
+	pub, prvs := setupAuditor(t, n, tt)
+
+	badDealer := pub.Committees.Hold[0]
+	badVerifier := pub.Committees.Ver[1]
+
+	messages, parties := runRefresh(t, pub, prvs, func(round int, party int, payload []byte) []byte {
+		switch {
+		case round == 0 && party == badDealer:
+			return []byte{0xde, 0xad}
+		case round == 1 && party == badVerifier:
+			// invalid proof of the verifier
+			var msg resharing.VerificationMessage
+			require.NoError(msgpack.Decode(payload, &msg))
+			msg.VPComProof.HashL[0][0] ^= 1
+			return msgpack.Encode(&msg)
+		default:
+			return payload
+		}
+	})
+
+	report, err := Audit(pub, messages[0], messages[1], messages[2])
+	require.NoError(err)
+	assert.Equal(VerdictValid, report.Verdict)
+	assert.Equal([]int{0}, report.DisqualifiedDealers)
+	assert.Equal([]int{1, 2}, report.QualifiedDealers)
+	require.Len(report.InvalidVerifiers, 1)
+	assert.Contains(report.InvalidVerifiers, 1)
+
+	require.Len(report.Blame.Dealers, 1)
+	assert.Equal(0, report.Blame.Dealers[0].Dealer)
+	assert.Equal(resharing.BlameMalformedDealing, report.Blame.Dealers[0].Reason)
+	require.Len(report.Blame.Verifiers, 1)
+	assert.Equal(1, report.Blame.Verifiers[0].Verifier)
+	assert.Equal(resharing.BlameInvalidVerification, report.Blame.Verifiers[0].Reason)
+	assert.Equal(-1, report.Blame.Verifiers[0].NextHolder)
+
+	for _, party := range pub.Committees.Next {
+		nextShare, nextCommitments, _, err := parties[party].Output()
+		require.NoError(err)
+		require.NotNil(nextShare)
+		assert.Equal(nextCommitments, report.NextCommitments)
+	}
+}
+
+func TestAuditFailed(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n  = 3
+		tt = 1
+	)
+
+	pub, prvs := setupAuditor(t, n, tt)
+
+	// Not enough qualified dealers
+	messages, _ := runRefresh(t, pub, prvs, func(round int, party int, payload []byte) []byte {
+		if round == 0 && party != pub.Committees.Hold[0] {
+			return nil
+		}
+		return payload
+	})
+
+	report, err := Audit(pub, messages[0], messages[1], messages[2])
+	require.NoError(err)
+	assert.Equal(VerdictFailed, report.Verdict)
+	assert.NotEmpty(report.Reason)
+	assert.Equal([]int{1, 2}, report.DisqualifiedDealers)
+	assert.Nil(report.NextCommitments)
+
+	// Not enough valid verifiers
+	messages, _ = runRefresh(t, pub, prvs, func(round int, party int, payload []byte) []byte {
+		if round == 1 && party != pub.Committees.Ver[0] {
+			return nil
+		}
+		return payload
+	})
+
+	report, err = Audit(pub, messages[0], messages[1], messages[2])
+	require.NoError(err)
+	assert.Equal(VerdictFailed, report.Verdict)
+	assert.NotEmpty(report.Reason)
+	assert.Len(report.InvalidVerifiers, n-1)
+}
+
+func TestAuditErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	const (
+		n  = 3
+		tt = 1
+	)
+
+	pub, prvs := setupAuditor(t, n, tt)
+	messages, _ := runRefresh(t, pub, prvs, nil)
+	tr := NewTranscript(pub, messages[0], messages[1], messages[2])
+
+	// Missing public input
+	_, err := AuditTranscript(&Transcript{})
+	assert.Error(err)
+
+	// Missing messages
+	truncated := *tr
+	truncated.Dealing = truncated.Dealing[:n-1]
+	_, err = AuditTranscript(&truncated)
+	assert.Error(err)
+
+	// Invalid public input
+	invalidPub := *tr.Pub
+	invalidPub.T = n
+	_, err = AuditTranscript(&Transcript{Pub: &invalidPub})
+	assert.Error(err)
+
+	invalidPub = *tr.Pub
+	invalidPub.Commitments = invalidPub.Commitments[:n]
+	_, err = AuditTranscript(&Transcript{
+		Pub:          &invalidPub,
+		Dealing:      tr.Dealing,
+		Verification: tr.Verification,
+		Resolution:   tr.Resolution,
+	})
+	assert.Error(err)
+
+	// Range proof of the secret of another sharing
+	_, _, proof, err := vss.RangeFixedRShareFrom(
+		nil, &pub.VSSParams, curve25519.GetScalar(1234), curve25519.RandomScalar(), 16)
+	require.NoError(t, err)
+	invalidPub = *tr.Pub
+	invalidPub.SecretRangeProof = proof
+	invalidPub.SecretRangeBits = 16
+	_, err = AuditTranscript(&Transcript{
+		Pub:          &invalidPub,
+		Dealing:      tr.Dealing,
+		Verification: tr.Verification,
+		Resolution:   tr.Resolution,
+	})
+	assert.Error(err)
+}
+
+func TestPublicParams(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n  = 3
+		tt = 1
+	)
+
+	pub, _ := setupAuditor(t, n, tt)
+	pub.AllQualifiedDealers = true
+	pub.Committees.Next = append(pub.Committees.Next, 4*n)
+	pub.EncPKs = append(pub.EncPKs, curve25519.PublicKey{})
+	var err error
+	pub.NextVSSParams, err = vss.NewVSSParams(pub.VSSParams.PedersenParams, n+1, tt+1)
+	require.NoError(err)
+	vcParams, err := feldman.GenerateVCParams(2 * (n + 1))
+	require.NoError(err)
+	pub.VCParams = *vcParams
+	_, pub.Commitments, pub.SecretRangeProof, err = vss.RangeFixedRShareFrom(
+		nil, &pub.VSSParams, curve25519.GetScalar(1234), curve25519.RandomScalar(), 16)
+	require.NoError(err)
+	pub.SecretRangeBits = 16
+
+	var pp PublicParams
+	require.NoError(msgpack.Decode(msgpack.Encode(NewPublicParams(pub)), &pp))
+	decoded, err := pp.PublicInput()
+	require.NoError(err)
+	require.NoError(resharing.CheckPublicInput(decoded))
+
+	assert.Equal(pub.Committees, decoded.Committees)
+	assert.Equal(pub.Commitments, decoded.Commitments)
+	assert.Equal(pub.VCParams, decoded.VCParams)
+	assert.Nil(decoded.VerVSSParams)
+	assert.Nil(decoded.ResVSSParams)
+	require.NotNil(decoded.NextVSSParams)
+	assert.Equal(n+1, decoded.NextVSSParams.N)
+	assert.Equal(tt+1, decoded.NextVSSParams.D)
+	assert.Equal(pub.FeldmanConversion, decoded.FeldmanConversion)
+	assert.Equal(pub.AllQualifiedDealers, decoded.AllQualifiedDealers)
+	assert.Equal(pub.SecretRangeProof, decoded.SecretRangeProof)
+	assert.Equal(pub.SecretRangeBits, decoded.SecretRangeBits)
+}
@@ -0,0 +1,7 @@
+//go:build generate
+// +build generate
+
+package auditor
+
+//go:generate codecgen -o gen-codecgen.go transcript.go
+//go:generate gofmt -w gen-codecgen.go
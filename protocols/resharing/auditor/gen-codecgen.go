@@ -0,0 +1,1538 @@
+// #nosec
+//go:build go1.6
+// +build go1.6
+
+// Code generated by codecgen - DO NOT EDIT.
+
+package auditor
+
+import (
+	"errors"
+	pkg1_curve25519 "github.com/shaih/go-yosovss/primitives/curve25519"
+	pkg3_nizk "github.com/shaih/go-yosovss/primitives/nizk"
+	pkg2_resharing "github.com/shaih/go-yosovss/protocols/resharing"
+	codec1978 "github.com/ugorji/go/codec"
+	"runtime"
+	"strconv"
+)
+
+const (
+	// ----- content types ----
+	codecSelferCcUTF8943 = 1
+	codecSelferCcRAW943  = 255
+	// ----- value types used ----
+	codecSelferValueTypeArray943     = 10
+	codecSelferValueTypeMap943       = 9
+	codecSelferValueTypeString943    = 6
+	codecSelferValueTypeInt943       = 2
+	codecSelferValueTypeUint943      = 3
+	codecSelferValueTypeFloat943     = 4
+	codecSelferValueTypeNil943       = 1
+	codecSelferBitsize943            = uint8(32 << (^uint(0) >> 63))
+	codecSelferDecContainerLenNil943 = -2147483648
+)
+
+var (
+	errCodecSelferOnlyMapOrArrayEncodeToStruct943 = errors.New(`only encoded map or array can be decoded into a struct`)
+)
+
+type codecSelfer943 struct{}
+
+func codecSelfer943False() bool { return false }
+func codecSelfer943True() bool  { return true }
+
+func init() {
+	if codec1978.GenVersion != 25 {
+		_, file, _, _ := runtime.Caller(0)
+		ver := strconv.FormatInt(int64(codec1978.GenVersion), 10)
+		panic(errors.New("codecgen version mismatch: current: 25, need " + ver + ". Re-generate file: " + file))
+	}
+	if false { // reference the types, but skip this branch at build/run time
+		var _ pkg1_curve25519.PublicKey
+		var _ pkg3_nizk.RangeProof
+		var _ pkg2_resharing.ProofFormat
+	}
+}
+
+func (PublicParams) codecSelferViaCodecgen() {}
+func (x *PublicParams) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
+		return
+	}
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyn21 bool = x.SecretRangeProof == nil
+		var yyq2 = [20]bool{ // should field at this index be written?
+			x.T != 0,                       // t
+			len(x.Hold) != 0,               // hold
+			len(x.Ver) != 0,                // ver
+			len(x.Res) != 0,                // res
+			len(x.Next) != 0,               // next
+			x.VerT != 0,                    // vt
+			x.ResT != 0,                    // rt
+			x.NextT != 0,                   // nt
+			len(x.EncPKs) != 0,             // epk
+			len(x.Commitments) != 0,        // com
+			len(x.SessionID) != 0,          // sid
+			x.Epoch != 0,                   // epoch
+			x.DealingProofFormat != 0,      // dpf
+			x.VerificationProofFormat != 0, // vpf
+			len(x.VEncPKs) != 0,            // vepk
+			bool(x.FeldmanConversion),      // feldman
+			bool(x.AllQualifiedDealers),    // aqd
+			len(x.BatchCommitments) != 0,   // bcom
+			x.SecretRangeProof != nil,      // srp
+			x.SecretRangeBits != 0,         // srb
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(20)
+			z.EncWriteArrayElem()
+			if yyq2[0] {
+				r.EncodeInt(int64(x.T))
+			} else {
+				r.EncodeInt(0)
+			}
+			z.EncWriteArrayElem()
+			if yyq2[1] {
+				if x.Hold == nil {
+					r.EncodeNil()
+				} else {
+					z.F.EncSliceIntV(x.Hold, e)
+				} // end block: if x.Hold slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[2] {
+				if x.Ver == nil {
+					r.EncodeNil()
+				} else {
+					z.F.EncSliceIntV(x.Ver, e)
+				} // end block: if x.Ver slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[3] {
+				if x.Res == nil {
+					r.EncodeNil()
+				} else {
+					z.F.EncSliceIntV(x.Res, e)
+				} // end block: if x.Res slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[4] {
+				if x.Next == nil {
+					r.EncodeNil()
+				} else {
+					z.F.EncSliceIntV(x.Next, e)
+				} // end block: if x.Next slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[5] {
+				r.EncodeInt(int64(x.VerT))
+			} else {
+				r.EncodeInt(0)
+			}
+			z.EncWriteArrayElem()
+			if yyq2[6] {
+				r.EncodeInt(int64(x.ResT))
+			} else {
+				r.EncodeInt(0)
+			}
+			z.EncWriteArrayElem()
+			if yyq2[7] {
+				r.EncodeInt(int64(x.NextT))
+			} else {
+				r.EncodeInt(0)
+			}
+			z.EncWriteArrayElem()
+			if yyq2[8] {
+				if x.EncPKs == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_PublicKey(([]pkg1_curve25519.PublicKey)(x.EncPKs), e)
+				} // end block: if x.EncPKs slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[9] {
+				if x.Commitments == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.Commitments), e)
+				} // end block: if x.Commitments slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[10] {
+				if x.SessionID == nil {
+					r.EncodeNil()
+				} else {
+					r.EncodeStringBytesRaw([]byte(x.SessionID))
+				} // end block: if x.SessionID slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[11] {
+				r.EncodeUint(uint64(x.Epoch))
+			} else {
+				r.EncodeUint(0)
+			}
+			z.EncWriteArrayElem()
+			if yyq2[12] {
+				if yyxt35 := z.Extension(x.DealingProofFormat); yyxt35 != nil {
+					z.EncExtension(x.DealingProofFormat, yyxt35)
+				} else {
+					r.EncodeInt(int64(x.DealingProofFormat))
+				}
+			} else {
+				r.EncodeInt(0)
+			}
+			z.EncWriteArrayElem()
+			if yyq2[13] {
+				if yyxt36 := z.Extension(x.VerificationProofFormat); yyxt36 != nil {
+					z.EncExtension(x.VerificationProofFormat, yyxt36)
+				} else {
+					r.EncodeInt(int64(x.VerificationProofFormat))
+				}
+			} else {
+				r.EncodeInt(0)
+			}
+			z.EncWriteArrayElem()
+			if yyq2[14] {
+				if x.VEncPKs == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.VEncPKs), e)
+				} // end block: if x.VEncPKs slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[15] {
+				r.EncodeBool(bool(x.FeldmanConversion))
+			} else {
+				r.EncodeBool(false)
+			}
+			z.EncWriteArrayElem()
+			if yyq2[16] {
+				r.EncodeBool(bool(x.AllQualifiedDealers))
+			} else {
+				r.EncodeBool(false)
+			}
+			z.EncWriteArrayElem()
+			if yyq2[17] {
+				if x.BatchCommitments == nil {
+					r.EncodeNil()
+				} else {
+					h.encSliceSlicecurve25519_PointXY(([][]pkg1_curve25519.PointXY)(x.BatchCommitments), e)
+				} // end block: if x.BatchCommitments slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			if yyn21 {
+				z.EncWriteArrayElem()
+				r.EncodeNil()
+			} else {
+				z.EncWriteArrayElem()
+				if yyq2[18] {
+					if yyxt41 := z.Extension(x.SecretRangeProof); yyxt41 != nil {
+						z.EncExtension(x.SecretRangeProof, yyxt41)
+					} else {
+						z.EncFallback(x.SecretRangeProof)
+					}
+				} else {
+					r.EncodeNil()
+				}
+			}
+			z.EncWriteArrayElem()
+			if yyq2[19] {
+				r.EncodeInt(int64(x.SecretRangeBits))
+			} else {
+				r.EncodeInt(0)
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
+				}
+			}
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"t\"")
+				} else {
+					r.EncodeString(`t`)
+				}
+				z.EncWriteMapElemValue()
+				r.EncodeInt(int64(x.T))
+			}
+			if yyq2[1] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"hold\"")
+				} else {
+					r.EncodeString(`hold`)
+				}
+				z.EncWriteMapElemValue()
+				if x.Hold == nil {
+					r.EncodeNil()
+				} else {
+					z.F.EncSliceIntV(x.Hold, e)
+				} // end block: if x.Hold slice == nil
+			}
+			if yyq2[2] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"ver\"")
+				} else {
+					r.EncodeString(`ver`)
+				}
+				z.EncWriteMapElemValue()
+				if x.Ver == nil {
+					r.EncodeNil()
+				} else {
+					z.F.EncSliceIntV(x.Ver, e)
+				} // end block: if x.Ver slice == nil
+			}
+			if yyq2[3] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"res\"")
+				} else {
+					r.EncodeString(`res`)
+				}
+				z.EncWriteMapElemValue()
+				if x.Res == nil {
+					r.EncodeNil()
+				} else {
+					z.F.EncSliceIntV(x.Res, e)
+				} // end block: if x.Res slice == nil
+			}
+			if yyq2[4] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"next\"")
+				} else {
+					r.EncodeString(`next`)
+				}
+				z.EncWriteMapElemValue()
+				if x.Next == nil {
+					r.EncodeNil()
+				} else {
+					z.F.EncSliceIntV(x.Next, e)
+				} // end block: if x.Next slice == nil
+			}
+			if yyq2[5] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"vt\"")
+				} else {
+					r.EncodeString(`vt`)
+				}
+				z.EncWriteMapElemValue()
+				r.EncodeInt(int64(x.VerT))
+			}
+			if yyq2[6] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"rt\"")
+				} else {
+					r.EncodeString(`rt`)
+				}
+				z.EncWriteMapElemValue()
+				r.EncodeInt(int64(x.ResT))
+			}
+			if yyq2[7] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"nt\"")
+				} else {
+					r.EncodeString(`nt`)
+				}
+				z.EncWriteMapElemValue()
+				r.EncodeInt(int64(x.NextT))
+			}
+			if yyq2[8] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"epk\"")
+				} else {
+					r.EncodeString(`epk`)
+				}
+				z.EncWriteMapElemValue()
+				if x.EncPKs == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_PublicKey(([]pkg1_curve25519.PublicKey)(x.EncPKs), e)
+				} // end block: if x.EncPKs slice == nil
+			}
+			if yyq2[9] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"com\"")
+				} else {
+					r.EncodeString(`com`)
+				}
+				z.EncWriteMapElemValue()
+				if x.Commitments == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.Commitments), e)
+				} // end block: if x.Commitments slice == nil
+			}
+			if yyq2[10] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"sid\"")
+				} else {
+					r.EncodeString(`sid`)
+				}
+				z.EncWriteMapElemValue()
+				if x.SessionID == nil {
+					r.EncodeNil()
+				} else {
+					r.EncodeStringBytesRaw([]byte(x.SessionID))
+				} // end block: if x.SessionID slice == nil
+			}
+			if yyq2[11] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"epoch\"")
+				} else {
+					r.EncodeString(`epoch`)
+				}
+				z.EncWriteMapElemValue()
+				r.EncodeUint(uint64(x.Epoch))
+			}
+			if yyq2[12] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"dpf\"")
+				} else {
+					r.EncodeString(`dpf`)
+				}
+				z.EncWriteMapElemValue()
+				if yyxt55 := z.Extension(x.DealingProofFormat); yyxt55 != nil {
+					z.EncExtension(x.DealingProofFormat, yyxt55)
+				} else {
+					r.EncodeInt(int64(x.DealingProofFormat))
+				}
+			}
+			if yyq2[13] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"vpf\"")
+				} else {
+					r.EncodeString(`vpf`)
+				}
+				z.EncWriteMapElemValue()
+				if yyxt56 := z.Extension(x.VerificationProofFormat); yyxt56 != nil {
+					z.EncExtension(x.VerificationProofFormat, yyxt56)
+				} else {
+					r.EncodeInt(int64(x.VerificationProofFormat))
+				}
+			}
+			if yyq2[14] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"vepk\"")
+				} else {
+					r.EncodeString(`vepk`)
+				}
+				z.EncWriteMapElemValue()
+				if x.VEncPKs == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.VEncPKs), e)
+				} // end block: if x.VEncPKs slice == nil
+			}
+			if yyq2[15] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"feldman\"")
+				} else {
+					r.EncodeString(`feldman`)
+				}
+				z.EncWriteMapElemValue()
+				r.EncodeBool(bool(x.FeldmanConversion))
+			}
+			if yyq2[16] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"aqd\"")
+				} else {
+					r.EncodeString(`aqd`)
+				}
+				z.EncWriteMapElemValue()
+				r.EncodeBool(bool(x.AllQualifiedDealers))
+			}
+			if yyq2[17] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"bcom\"")
+				} else {
+					r.EncodeString(`bcom`)
+				}
+				z.EncWriteMapElemValue()
+				if x.BatchCommitments == nil {
+					r.EncodeNil()
+				} else {
+					h.encSliceSlicecurve25519_PointXY(([][]pkg1_curve25519.PointXY)(x.BatchCommitments), e)
+				} // end block: if x.BatchCommitments slice == nil
+			}
+			if yyq2[18] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"srp\"")
+				} else {
+					r.EncodeString(`srp`)
+				}
+				z.EncWriteMapElemValue()
+				if yyn21 {
+					r.EncodeNil()
+				} else {
+					if yyxt61 := z.Extension(x.SecretRangeProof); yyxt61 != nil {
+						z.EncExtension(x.SecretRangeProof, yyxt61)
+					} else {
+						z.EncFallback(x.SecretRangeProof)
+					}
+				}
+			}
+			if yyq2[19] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"srb\"")
+				} else {
+					r.EncodeString(`srb`)
+				}
+				z.EncWriteMapElemValue()
+				r.EncodeInt(int64(x.SecretRangeBits))
+			}
+			z.EncWriteMapEnd()
+		}
+	}
+}
+
+func (x *PublicParams) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = PublicParams{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
+		} else {
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
+		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
+	}
+}
+
+func (x *PublicParams) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "t":
+			x.T = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+		case "hold":
+			z.F.DecSliceIntX(&x.Hold, d)
+		case "ver":
+			z.F.DecSliceIntX(&x.Ver, d)
+		case "res":
+			z.F.DecSliceIntX(&x.Res, d)
+		case "next":
+			z.F.DecSliceIntX(&x.Next, d)
+		case "vt":
+			x.VerT = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+		case "rt":
+			x.ResT = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+		case "nt":
+			x.NextT = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+		case "epk":
+			h.decSlicecurve25519_PublicKey((*[]pkg1_curve25519.PublicKey)(&x.EncPKs), d)
+		case "com":
+			h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.Commitments), d)
+		case "sid":
+			x.SessionID = z.DecodeBytesInto(([]byte)(x.SessionID))
+		case "epoch":
+			x.Epoch = (uint64)(r.DecodeUint64())
+		case "dpf":
+			if yyxt24 := z.Extension(x.DealingProofFormat); yyxt24 != nil {
+				z.DecExtension(&x.DealingProofFormat, yyxt24)
+			} else {
+				x.DealingProofFormat = (pkg2_resharing.ProofFormat)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+			}
+		case "vpf":
+			if yyxt26 := z.Extension(x.VerificationProofFormat); yyxt26 != nil {
+				z.DecExtension(&x.VerificationProofFormat, yyxt26)
+			} else {
+				x.VerificationProofFormat = (pkg2_resharing.ProofFormat)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+			}
+		case "vepk":
+			h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.VEncPKs), d)
+		case "feldman":
+			x.FeldmanConversion = (bool)(r.DecodeBool())
+		case "aqd":
+			x.AllQualifiedDealers = (bool)(r.DecodeBool())
+		case "bcom":
+			h.decSliceSlicecurve25519_PointXY((*[][]pkg1_curve25519.PointXY)(&x.BatchCommitments), d)
+		case "srp":
+			if r.TryNil() {
+				if x.SecretRangeProof != nil { // remove the if-true
+					x.SecretRangeProof = nil
+				}
+			} else {
+				if x.SecretRangeProof == nil {
+					x.SecretRangeProof = new(pkg3_nizk.RangeProof)
+				}
+				if yyxt34 := z.Extension(x.SecretRangeProof); yyxt34 != nil {
+					z.DecExtension(x.SecretRangeProof, yyxt34)
+				} else {
+					z.DecFallback(x.SecretRangeProof, false)
+				}
+			}
+		case "srb":
+			x.SecretRangeBits = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *PublicParams) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj36 int
+	var yyb36 bool
+	var yyhl36 bool = l >= 0
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.T = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	z.F.DecSliceIntX(&x.Hold, d)
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	z.F.DecSliceIntX(&x.Ver, d)
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	z.F.DecSliceIntX(&x.Res, d)
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	z.F.DecSliceIntX(&x.Next, d)
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.VerT = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.ResT = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.NextT = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSlicecurve25519_PublicKey((*[]pkg1_curve25519.PublicKey)(&x.EncPKs), d)
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.Commitments), d)
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.SessionID = z.DecodeBytesInto(([]byte)(x.SessionID))
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.Epoch = (uint64)(r.DecodeUint64())
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if yyxt57 := z.Extension(x.DealingProofFormat); yyxt57 != nil {
+		z.DecExtension(&x.DealingProofFormat, yyxt57)
+	} else {
+		x.DealingProofFormat = (pkg2_resharing.ProofFormat)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+	}
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if yyxt59 := z.Extension(x.VerificationProofFormat); yyxt59 != nil {
+		z.DecExtension(&x.VerificationProofFormat, yyxt59)
+	} else {
+		x.VerificationProofFormat = (pkg2_resharing.ProofFormat)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+	}
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.VEncPKs), d)
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.FeldmanConversion = (bool)(r.DecodeBool())
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.AllQualifiedDealers = (bool)(r.DecodeBool())
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSliceSlicecurve25519_PointXY((*[][]pkg1_curve25519.PointXY)(&x.BatchCommitments), d)
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if r.TryNil() {
+		if x.SecretRangeProof != nil { // remove the if-true
+			x.SecretRangeProof = nil
+		}
+	} else {
+		if x.SecretRangeProof == nil {
+			x.SecretRangeProof = new(pkg3_nizk.RangeProof)
+		}
+		if yyxt67 := z.Extension(x.SecretRangeProof); yyxt67 != nil {
+			z.DecExtension(x.SecretRangeProof, yyxt67)
+		} else {
+			z.DecFallback(x.SecretRangeProof, false)
+		}
+	}
+	yyj36++
+	if yyhl36 {
+		yyb36 = yyj36 > l
+	} else {
+		yyb36 = z.DecCheckBreak()
+	}
+	if yyb36 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.SecretRangeBits = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+	for {
+		yyj36++
+		if yyhl36 {
+			yyb36 = yyj36 > l
+		} else {
+			yyb36 = z.DecCheckBreak()
+		}
+		if yyb36 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj36-1, "")
+	}
+}
+
+func (x *PublicParams) IsCodecEmpty() bool {
+	return !(x.T != 0 || len(x.Hold) != 0 || len(x.Ver) != 0 || len(x.Res) != 0 || len(x.Next) != 0 || x.VerT != 0 || x.ResT != 0 || x.NextT != 0 || len(x.EncPKs) != 0 || len(x.Commitments) != 0 || len(x.SessionID) != 0 || x.Epoch != 0 || x.DealingProofFormat != 0 || x.VerificationProofFormat != 0 || len(x.VEncPKs) != 0 || bool(x.FeldmanConversion) || bool(x.AllQualifiedDealers) || len(x.BatchCommitments) != 0 || x.SecretRangeBits != 0 || false)
+}
+
+func (Transcript) codecSelferViaCodecgen() {}
+func (x *Transcript) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
+		return
+	}
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyn3 bool = x.Pub == nil
+		var yyq2 = [4]bool{ // should field at this index be written?
+			x.Pub != nil,             // pub
+			len(x.Dealing) != 0,      // d
+			len(x.Verification) != 0, // v
+			len(x.Resolution) != 0,   // r
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(4)
+			if yyn3 {
+				z.EncWriteArrayElem()
+				r.EncodeNil()
+			} else {
+				z.EncWriteArrayElem()
+				if yyq2[0] {
+					if yyxt7 := z.Extension(x.Pub); yyxt7 != nil {
+						z.EncExtension(x.Pub, yyxt7)
+					} else {
+						x.Pub.CodecEncodeSelf(e)
+					}
+				} else {
+					r.EncodeNil()
+				}
+			}
+			z.EncWriteArrayElem()
+			if yyq2[1] {
+				if x.Dealing == nil {
+					r.EncodeNil()
+				} else {
+					z.F.EncSliceBytesV(x.Dealing, e)
+				} // end block: if x.Dealing slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[2] {
+				if x.Verification == nil {
+					r.EncodeNil()
+				} else {
+					z.F.EncSliceBytesV(x.Verification, e)
+				} // end block: if x.Verification slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[3] {
+				if x.Resolution == nil {
+					r.EncodeNil()
+				} else {
+					z.F.EncSliceBytesV(x.Resolution, e)
+				} // end block: if x.Resolution slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
+				}
+			}
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"pub\"")
+				} else {
+					r.EncodeString(`pub`)
+				}
+				z.EncWriteMapElemValue()
+				if yyn3 {
+					r.EncodeNil()
+				} else {
+					if yyxt11 := z.Extension(x.Pub); yyxt11 != nil {
+						z.EncExtension(x.Pub, yyxt11)
+					} else {
+						x.Pub.CodecEncodeSelf(e)
+					}
+				}
+			}
+			if yyq2[1] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"d\"")
+				} else {
+					r.EncodeString(`d`)
+				}
+				z.EncWriteMapElemValue()
+				if x.Dealing == nil {
+					r.EncodeNil()
+				} else {
+					z.F.EncSliceBytesV(x.Dealing, e)
+				} // end block: if x.Dealing slice == nil
+			}
+			if yyq2[2] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"v\"")
+				} else {
+					r.EncodeString(`v`)
+				}
+				z.EncWriteMapElemValue()
+				if x.Verification == nil {
+					r.EncodeNil()
+				} else {
+					z.F.EncSliceBytesV(x.Verification, e)
+				} // end block: if x.Verification slice == nil
+			}
+			if yyq2[3] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"r\"")
+				} else {
+					r.EncodeString(`r`)
+				}
+				z.EncWriteMapElemValue()
+				if x.Resolution == nil {
+					r.EncodeNil()
+				} else {
+					z.F.EncSliceBytesV(x.Resolution, e)
+				} // end block: if x.Resolution slice == nil
+			}
+			z.EncWriteMapEnd()
+		}
+	}
+}
+
+func (x *Transcript) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = Transcript{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
+		} else {
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
+		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
+	}
+}
+
+func (x *Transcript) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "pub":
+			if r.TryNil() {
+				if x.Pub != nil { // remove the if-true
+					x.Pub = nil
+				}
+			} else {
+				if x.Pub == nil {
+					x.Pub = new(PublicParams)
+				}
+				if yyxt5 := z.Extension(x.Pub); yyxt5 != nil {
+					z.DecExtension(x.Pub, yyxt5)
+				} else {
+					x.Pub.CodecDecodeSelf(d)
+				}
+			}
+		case "d":
+			z.F.DecSliceBytesX(&x.Dealing, d)
+		case "v":
+			z.F.DecSliceBytesX(&x.Verification, d)
+		case "r":
+			z.F.DecSliceBytesX(&x.Resolution, d)
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *Transcript) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj12 int
+	var yyb12 bool
+	var yyhl12 bool = l >= 0
+	yyj12++
+	if yyhl12 {
+		yyb12 = yyj12 > l
+	} else {
+		yyb12 = z.DecCheckBreak()
+	}
+	if yyb12 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if r.TryNil() {
+		if x.Pub != nil { // remove the if-true
+			x.Pub = nil
+		}
+	} else {
+		if x.Pub == nil {
+			x.Pub = new(PublicParams)
+		}
+		if yyxt14 := z.Extension(x.Pub); yyxt14 != nil {
+			z.DecExtension(x.Pub, yyxt14)
+		} else {
+			x.Pub.CodecDecodeSelf(d)
+		}
+	}
+	yyj12++
+	if yyhl12 {
+		yyb12 = yyj12 > l
+	} else {
+		yyb12 = z.DecCheckBreak()
+	}
+	if yyb12 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	z.F.DecSliceBytesX(&x.Dealing, d)
+	yyj12++
+	if yyhl12 {
+		yyb12 = yyj12 > l
+	} else {
+		yyb12 = z.DecCheckBreak()
+	}
+	if yyb12 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	z.F.DecSliceBytesX(&x.Verification, d)
+	yyj12++
+	if yyhl12 {
+		yyb12 = yyj12 > l
+	} else {
+		yyb12 = z.DecCheckBreak()
+	}
+	if yyb12 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	z.F.DecSliceBytesX(&x.Resolution, d)
+	for {
+		yyj12++
+		if yyhl12 {
+			yyb12 = yyj12 > l
+		} else {
+			yyb12 = z.DecCheckBreak()
+		}
+		if yyb12 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj12-1, "")
+	}
+}
+
+func (x *Transcript) IsCodecEmpty() bool {
+	return !(len(x.Dealing) != 0 || len(x.Verification) != 0 || len(x.Resolution) != 0 || false)
+}
+
+func (x codecSelfer943) encSlicecurve25519_PublicKey(v []pkg1_curve25519.PublicKey, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	z.EncWriteArrayStart(len(v))
+	for yyv1 := range v {
+		z.EncWriteArrayElem()
+		yy2 := &v[yyv1]
+		if yyxt3 := z.Extension(yy2); yyxt3 != nil {
+			z.EncExtension(yy2, yyxt3)
+		} else {
+			z.F.EncSliceUint8V(([]uint8)(yy2[:]), e)
+		}
+	}
+	z.EncWriteArrayEnd()
+}
+
+func (x codecSelfer943) decSlicecurve25519_PublicKey(v *[]pkg1_curve25519.PublicKey, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyh1.IsNil {
+		if yyv1 != nil {
+			yyv1 = nil
+			yyc1 = true
+		}
+	} else if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = []pkg1_curve25519.PublicKey{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else {
+		yyhl1 := yyl1 > 0
+		var yyrl1 int
+		_ = yyrl1
+		if yyhl1 {
+			if yyl1 > cap(yyv1) {
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 32)
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
+				} else {
+					yyv1 = make([]pkg1_curve25519.PublicKey, yyrl1)
+				}
+				yyc1 = true
+			} else if yyl1 != len(yyv1) {
+				yyv1 = yyv1[:yyl1]
+				yyc1 = true
+			}
+		}
+		var yyj1 int
+		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
+			if yyj1 == 0 && yyv1 == nil {
+				if yyhl1 {
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 32)
+				} else {
+					yyrl1 = 8
+				}
+				yyv1 = make([]pkg1_curve25519.PublicKey, yyrl1)
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			var yydb1 bool
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, pkg1_curve25519.PublicKey{})
+				yyc1 = true
+			}
+			if yydb1 {
+				z.DecSwallow()
+			} else {
+				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
+					z.DecExtension(&yyv1[yyj1], yyxt3)
+				} else {
+					z.F.DecSliceUint8N(([]uint8)(yyv1[yyj1][:]), d)
+				}
+			}
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = make([]pkg1_curve25519.PublicKey, 0)
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
+
+func (x codecSelfer943) encSlicecurve25519_PointXY(v []pkg1_curve25519.PointXY, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	z.EncWriteArrayStart(len(v))
+	for yyv1 := range v {
+		z.EncWriteArrayElem()
+		yy2 := &v[yyv1]
+		if yyxt3 := z.Extension(yy2); yyxt3 != nil {
+			z.EncExtension(yy2, yyxt3)
+		} else {
+			z.F.EncSliceUint8V(([]uint8)(yy2[:]), e)
+		}
+	}
+	z.EncWriteArrayEnd()
+}
+
+func (x codecSelfer943) decSlicecurve25519_PointXY(v *[]pkg1_curve25519.PointXY, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyh1.IsNil {
+		if yyv1 != nil {
+			yyv1 = nil
+			yyc1 = true
+		}
+	} else if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = []pkg1_curve25519.PointXY{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else {
+		yyhl1 := yyl1 > 0
+		var yyrl1 int
+		_ = yyrl1
+		if yyhl1 {
+			if yyl1 > cap(yyv1) {
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 64)
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
+				} else {
+					yyv1 = make([]pkg1_curve25519.PointXY, yyrl1)
+				}
+				yyc1 = true
+			} else if yyl1 != len(yyv1) {
+				yyv1 = yyv1[:yyl1]
+				yyc1 = true
+			}
+		}
+		var yyj1 int
+		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
+			if yyj1 == 0 && yyv1 == nil {
+				if yyhl1 {
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 64)
+				} else {
+					yyrl1 = 8
+				}
+				yyv1 = make([]pkg1_curve25519.PointXY, yyrl1)
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			var yydb1 bool
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, pkg1_curve25519.PointXY{})
+				yyc1 = true
+			}
+			if yydb1 {
+				z.DecSwallow()
+			} else {
+				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
+					z.DecExtension(&yyv1[yyj1], yyxt3)
+				} else {
+					z.F.DecSliceUint8N(([]uint8)(yyv1[yyj1][:]), d)
+				}
+			}
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = make([]pkg1_curve25519.PointXY, 0)
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
+
+func (x codecSelfer943) encSliceSlicecurve25519_PointXY(v [][]pkg1_curve25519.PointXY, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	z.EncWriteArrayStart(len(v))
+	for yyv1 := range v {
+		z.EncWriteArrayElem()
+		if v[yyv1] == nil {
+			r.EncodeNil()
+		} else {
+			h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(v[yyv1]), e)
+		} // end block: if v[yyv1] slice == nil
+	}
+	z.EncWriteArrayEnd()
+}
+
+func (x codecSelfer943) decSliceSlicecurve25519_PointXY(v *[][]pkg1_curve25519.PointXY, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyh1.IsNil {
+		if yyv1 != nil {
+			yyv1 = nil
+			yyc1 = true
+		}
+	} else if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = [][]pkg1_curve25519.PointXY{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else {
+		yyhl1 := yyl1 > 0
+		var yyrl1 int
+		_ = yyrl1
+		if yyhl1 {
+			if yyl1 > cap(yyv1) {
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 24)
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
+				} else {
+					yyv1 = make([][]pkg1_curve25519.PointXY, yyrl1)
+				}
+				yyc1 = true
+			} else if yyl1 != len(yyv1) {
+				yyv1 = yyv1[:yyl1]
+				yyc1 = true
+			}
+		}
+		var yyj1 int
+		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
+			if yyj1 == 0 && yyv1 == nil {
+				if yyhl1 {
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 24)
+				} else {
+					yyrl1 = 8
+				}
+				yyv1 = make([][]pkg1_curve25519.PointXY, yyrl1)
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			var yydb1 bool
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, nil)
+				yyc1 = true
+			}
+			if yydb1 {
+				z.DecSwallow()
+			} else {
+				h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&yyv1[yyj1]), d)
+			}
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = make([][]pkg1_curve25519.PointXY, 0)
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
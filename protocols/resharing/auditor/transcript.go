@@ -0,0 +1,173 @@
+package auditor
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/communication"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/elgamal"
+	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/nizk"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/shaih/go-yosovss/protocols/resharing"
+)
+
+// PublicParams is the public input of a refresh in an encodable form (see resharing.PublicInput)
+// The parameters that only depend on the sizes of the committees (vector commitment and VSS parameters)
+// are not included, as they are generated again by PublicInput
+type PublicParams struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	T     int   `codec:"t"`    // max number of malicious parties in the holding committee
+	Hold  []int `codec:"hold"` // holding committee (of size N)
+	Ver   []int `codec:"ver"`  // verification committee
+	Res   []int `codec:"res"`  // resolution committee
+	Next  []int `codec:"next"` // next holding committee
+	VerT  int   `codec:"vt"`   // max number of malicious parties in the verification committee
+	ResT  int   `codec:"rt"`   // max number of malicious parties in the resolution committee
+	NextT int   `codec:"nt"`   // max number of malicious parties in the next holding committee
+
+	EncPKs      []curve25519.PublicKey `codec:"epk"`
+	Commitments []pedersen.Commitment  `codec:"com"`
+	SessionID   []byte                 `codec:"sid"`
+	Epoch       uint64                 `codec:"epoch"`
+
+	DealingProofFormat      resharing.ProofFormat   `codec:"dpf"`
+	VerificationProofFormat resharing.ProofFormat   `codec:"vpf"`
+	VEncPKs                 []elgamal.PublicKey     `codec:"vepk"`
+	FeldmanConversion       bool                    `codec:"feldman"`
+	AllQualifiedDealers     bool                    `codec:"aqd"`
+	BatchCommitments        [][]pedersen.Commitment `codec:"bcom"`
+	SecretRangeProof        *nizk.RangeProof        `codec:"srp"`
+	SecretRangeBits         int                     `codec:"srb"`
+}
+
+// NewPublicParams returns the encodable form of pub
+func NewPublicParams(pub *resharing.PublicInput) *PublicParams {
+	pp := &PublicParams{
+		T:                       pub.T,
+		Hold:                    pub.Committees.Hold,
+		Ver:                     pub.Committees.Ver,
+		Res:                     pub.Committees.Res,
+		Next:                    pub.Committees.Next,
+		VerT:                    pub.T,
+		ResT:                    pub.T,
+		NextT:                   pub.T,
+		EncPKs:                  pub.EncPKs,
+		Commitments:             pub.Commitments,
+		SessionID:               pub.SessionID,
+		Epoch:                   pub.Epoch,
+		DealingProofFormat:      pub.ProofFormats.Dealing,
+		VerificationProofFormat: pub.ProofFormats.Verification,
+		VEncPKs:                 pub.VEncPKs,
+		FeldmanConversion:       pub.FeldmanConversion,
+		AllQualifiedDealers:     pub.AllQualifiedDealers,
+		BatchCommitments:        pub.BatchCommitments,
+		SecretRangeProof:        pub.SecretRangeProof,
+		SecretRangeBits:         pub.SecretRangeBits,
+	}
+	if pub.VerVSSParams != nil {
+		pp.VerT = pub.VerVSSParams.D
+	}
+	if pub.ResVSSParams != nil {
+		pp.ResT = pub.ResVSSParams.D
+	}
+	if pub.NextVSSParams != nil {
+		pp.NextT = pub.NextVSSParams.D
+	}
+	return pp
+}
+
+// PublicInput returns the public input of the refresh
+// The committees that have the same size and threshold as the holding committee use its VSS parameters
+func (pp *PublicParams) PublicInput() (*resharing.PublicInput, error) {
+	n := len(pp.Hold)
+
+	vssParams, err := vss.NewVSSParams(pedersen.GenerateParams(), n, pp.T)
+	if err != nil {
+		return nil, err
+	}
+
+	// committeeParams returns the VSS parameters of a committee, or nil if they are the ones of the holding committee
+	committeeParams := func(members []int, t int) (*vss.Params, error) {
+		if len(members) == n && t == pp.T {
+			return nil, nil
+		}
+		return vss.NewVSSParams(pedersen.GenerateParams(), len(members), t)
+	}
+
+	verVSSParams, err := committeeParams(pp.Ver, pp.VerT)
+	if err != nil {
+		return nil, fmt.Errorf("invalid verification committee: %w", err)
+	}
+	resVSSParams, err := committeeParams(pp.Res, pp.ResT)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resolution committee: %w", err)
+	}
+	nextVSSParams, err := committeeParams(pp.Next, pp.NextT)
+	if err != nil {
+		return nil, fmt.Errorf("invalid next holding committee: %w", err)
+	}
+
+	vcParams, err := feldman.GenerateVCParams(2 * len(pp.Next))
+	if err != nil {
+		return nil, err
+	}
+
+	return &resharing.PublicInput{
+		VCParams:  *vcParams,
+		EncPKs:    pp.EncPKs,
+		VSSParams: *vssParams,
+		T:         pp.T,
+		N:         n,
+		Committees: resharing.Committees{
+			Hold: pp.Hold,
+			Ver:  pp.Ver,
+			Res:  pp.Res,
+			Next: pp.Next,
+		},
+		Commitments: pp.Commitments,
+		SessionID:   pp.SessionID,
+		Epoch:       pp.Epoch,
+		ProofFormats: resharing.ProofFormats{
+			Dealing:      pp.DealingProofFormat,
+			Verification: pp.VerificationProofFormat,
+		},
+		VEncPKs:             pp.VEncPKs,
+		FeldmanConversion:   pp.FeldmanConversion,
+		AllQualifiedDealers: pp.AllQualifiedDealers,
+		BatchCommitments:    pp.BatchCommitments,
+		SecretRangeProof:    pp.SecretRangeProof,
+		SecretRangeBits:     pp.SecretRangeBits,
+		VerVSSParams:        verVSSParams,
+		ResVSSParams:        resVSSParams,
+		NextVSSParams:       nextVSSParams,
+	}, nil
+}
+
+// Transcript is the public transcript of a refresh: its public input and the messages broadcast in the rounds
+// Dealing[x] (resp. Verification[x], Resolution[x]) is the payload broadcast by the x-th member
+// of the holding (resp. verification, resolution) committee (nil if it did not send any)
+// There are no resolution messages with verifiable encryption (see resharing.PublicInput.NumRounds)
+type Transcript struct {
+	_struct      struct{}      `codec:",omitempty,omitemptyarray"`
+	Pub          *PublicParams `codec:"pub"`
+	Dealing      [][]byte      `codec:"d"`
+	Verification [][]byte      `codec:"v"`
+	Resolution   [][]byte      `codec:"r"`
+}
+
+// NewTranscript returns the transcript of a refresh from the messages broadcast in the rounds
+// (messages of parties that are not members of the committee sending in the round are ignored)
+func NewTranscript(
+	pub *resharing.PublicInput,
+	dealing, verification, resolution []communication.BroadcastMessage,
+) *Transcript {
+	return &Transcript{
+		Pub:          NewPublicParams(pub),
+		Dealing:      communication.PayloadsFrom(dealing, pub.Committees.Hold),
+		Verification: communication.PayloadsFrom(verification, pub.Committees.Ver),
+		Resolution:   communication.PayloadsFrom(resolution, pub.Committees.Res),
+	}
+}
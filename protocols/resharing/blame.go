@@ -0,0 +1,101 @@
+package resharing
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	log "github.com/sirupsen/logrus"
+)
+
+// BlameReason is the reason why a dealer is disqualified or a verifier is invalid (see BlameReport)
+type BlameReason int
+
+const (
+	// BlameMalformedDealing means that the dealing message is missing or has fields of incorrect length
+	// (see CheckDealingMessages)
+	BlameMalformedDealing BlameReason = iota + 1
+	// BlameUnresolvedComplaint means that a verifier complained against the dealer
+	// and that the shares the dealer sent to this verifier cannot be recovered from the eps shares revealed
+	// by the resolution committee: the eps key does not match HashEps, EncResM cannot be decrypted,
+	// or the decrypted shares do not match ComC
+	BlameUnresolvedComplaint
+	// BlameInvalidDealing means that the proofs or the commitments of the dealing message are invalid
+	// (see ComputeQualifiedDealers)
+	BlameInvalidDealing
+	// BlameInvalidVerification means that the verification message is malformed, that VPComProof is invalid
+	// (see CheckVerifiers), or that the shares the verifier sent to a next holder do not match VPComProof
+	BlameInvalidVerification
+)
+
+// String returns the reason in a human-readable form
+func (r BlameReason) String() string {
+	switch r {
+	case BlameMalformedDealing:
+		return "malformed dealing"
+	case BlameUnresolvedComplaint:
+		return "unresolved complaint"
+	case BlameInvalidDealing:
+		return "invalid dealing"
+	case BlameInvalidVerification:
+		return "invalid verification"
+	default:
+		return fmt.Sprintf("BlameReason(%d)", int(r))
+	}
+}
+
+// DealerBlame is the evidence of the disqualification of a dealer
+// The message of the dealer is the message Dealer of the dealing round in the public transcript
+type DealerBlame struct {
+	_struct struct{}    `codec:",omitempty,omitemptyarray"`
+	Dealer  int         `codec:"i"` // index of the dealer in the holding committee
+	Reason  BlameReason `codec:"r"`
+	Detail  string      `codec:"d"` // description of the failed check
+
+	// For BlameUnresolvedComplaint only
+	Verifier  int                  `codec:"j"` // index of the verifier whose complaint is not resolved
+	EpsShares []*curve25519.Scalar `codec:"e"` // EpsShares[k] = eps_{i+1,j+1,k+1} revealed by the resolution
+	// committee member k (nil if not revealed), from which the eps key is reconstructed (see ReconstructEpsKey)
+}
+
+// VerifierBlame is the evidence of the invalidity of a verifier
+// The message of the verifier is the message Verifier of the verification round in the public transcript
+type VerifierBlame struct {
+	_struct  struct{}    `codec:",omitempty,omitemptyarray"`
+	Verifier int         `codec:"j"` // index of the verifier in the verification committee
+	Reason   BlameReason `codec:"r"`
+	Detail   string      `codec:"d"` // description of the failed check
+
+	// When the verifier is blamed by a member of the next holding committee,
+	// which may be the only one able to decrypt the shares it received
+	NextHolder int            `codec:"l"` // index of the next holder (-1 if the blame only uses public information)
+	Shares     *VerSentShares `codec:"s"` // shares decrypted by the next holder (nil if decryption failed)
+	// As the encryption is not verifiable, a third party can only confirm this blame
+	// if the next holder reveals its decryption key (or if the blame also holds publicly, see CheckVerifiers)
+}
+
+// BlameReport lists the dealers disqualified and the verifiers found invalid during a refresh
+// with the minimal evidence allowing a third party to confirm the blame from the public transcript
+// It is an accountable record of the misbehaviors, e.g., for slashing or reputation systems
+type BlameReport struct {
+	_struct   struct{}        `codec:",omitempty,omitemptyarray"`
+	Dealers   []DealerBlame   `codec:"D"`
+	Verifiers []VerifierBlame `codec:"V"`
+}
+
+// blameDealer adds b to the report (if not nil)
+func (r *BlameReport) blameDealer(b DealerBlame) {
+	log.Infof("dealer %d disqualified because of %v: %s", b.Dealer, b.Reason, b.Detail)
+	if r == nil {
+		return
+	}
+	r.Dealers = append(r.Dealers, b)
+}
+
+// blameVerifier adds b to the report (if not nil)
+func (r *BlameReport) blameVerifier(b VerifierBlame) {
+	log.Infof("verifier %d disqualified because of %v: %s", b.Verifier, b.Reason, b.Detail)
+	if r == nil {
+		return
+	}
+	r.Verifiers = append(r.Verifiers, b)
+}
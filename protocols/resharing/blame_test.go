@@ -0,0 +1,117 @@
+package resharing
+
+import (
+	"testing"
+
+	"github.com/shaih/go-yosovss/communication"
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlameReport(t *testing.T) {
+	// Test that the blame report of the next holders contains the evidence of all the misbehaviors
+	// and that a third party can confirm the blame of an unresolved complaint
+
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n          = 5                 // number of parties per committee
+		numParties = n * numCommittees // total number of parties
+		tt         = 2                 // threshold of malicious parties
+	)
+
+	pub, prvs, _, _, _ := setupResharingSeq(t, n, tt)
+	dbg := &PartyDebugParams{}
+
+	// Dealer 0 sends nothing
+	// Dealer 1 sends an invalid encryption of M[0] to verifier 0 and to the resolution committee
+	// Verifier 1 sends an invalid proof
+	tamper := func(round int, party int, payload []byte) []byte {
+		switch {
+		case round == 0 && party == pub.Committees.Hold[0]:
+			return nil
+		case round == 0 && party == pub.Committees.Hold[1]:
+			var msg DealingMessage
+			require.NoError(msgpack.Decode(payload, &msg))
+			msg.EncVerM[0][len(msg.EncVerM[0])-1] ^= 1
+			msg.EncResM[0][len(msg.EncResM[0])-1] ^= 1
+			return msgpack.Encode(&msg)
+		case round == 1 && party == pub.Committees.Ver[1]:
+			var msg VerificationMessage
+			require.NoError(msgpack.Decode(payload, &msg))
+			msg.VPComProof.HashL[0][0] ^= 1
+			return msgpack.Encode(&msg)
+		default:
+			return payload
+		}
+	}
+
+	parties := make([]*Party, numParties)
+	for party := range parties {
+		var err error
+		parties[party], err = NewParty(pub, &prvs[party], dbg)
+		require.NoError(err)
+	}
+
+	var dealingMessages []DealingMessage
+	for round := 0; round < numRounds; round++ {
+		messages := make([]communication.BroadcastMessage, numParties)
+		for party := range parties {
+			messages[party] = communication.BroadcastMessage{
+				Payload:  tamper(round, party, parties[party].Payload()),
+				SenderID: party,
+			}
+		}
+		if round == 0 {
+			dealingMessages = DecodeDealingMessages(
+				communication.PayloadsFrom(messages, pub.Committees.Hold), pub.Committees.Hold)
+		}
+		for party := range parties {
+			_, _, err := parties[party].Step(messages)
+			require.NoError(err)
+		}
+	}
+
+	for l, party := range pub.Committees.Next {
+		blame := parties[party].Blame()
+		require.NotNil(blame)
+
+		// The blame report can be encoded
+		var decoded BlameReport
+		require.NoError(msgpack.Decode(msgpack.Encode(blame), &decoded))
+		assert.Equal(blame.Dealers, decoded.Dealers)
+		assert.Equal(len(blame.Verifiers), len(decoded.Verifiers))
+
+		require.Len(blame.Dealers, 2)
+		assert.Equal(0, blame.Dealers[0].Dealer)
+		assert.Equal(BlameMalformedDealing, blame.Dealers[0].Reason)
+
+		unresolved := blame.Dealers[1]
+		assert.Equal(1, unresolved.Dealer)
+		assert.Equal(BlameUnresolvedComplaint, unresolved.Reason)
+		assert.Equal(0, unresolved.Verifier)
+		require.Len(unresolved.EpsShares, n)
+		for k := range unresolved.EpsShares {
+			assert.NotNil(unresolved.EpsShares[k], "eps share %d", k)
+		}
+
+		// A third party confirms that the revealed eps key does not decrypt EncResM[0]
+		msg := dealingMessages[unresolved.Dealer]
+		epsKey, err := ReconstructEpsKey(n, tt, unresolved.EpsShares, msg.HashEps[unresolved.Verifier])
+		require.NoError(err)
+		_, err = curve25519.SymmetricDecrypt(epsKey, curve25519.Nonce{}, msg.EncResM[unresolved.Verifier])
+		assert.Error(err)
+
+		require.Len(blame.Verifiers, 1)
+		assert.Equal(1, blame.Verifiers[0].Verifier)
+		assert.Equal(BlameInvalidVerification, blame.Verifiers[0].Reason)
+		assert.Equal(l, blame.Verifiers[0].NextHolder)
+		assert.NotNil(blame.Verifiers[0].Shares)
+
+		_, _, _, err = parties[party].Output()
+		require.NoError(err)
+	}
+}
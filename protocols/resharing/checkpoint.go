@@ -0,0 +1,163 @@
+package resharing
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	"golang.org/x/crypto/hkdf"
+)
+
+// checkpointSalt is the salt used to derive the key encrypting the checkpoints from EncSK
+const checkpointSalt = "yosovss resharing checkpoint"
+
+// checkpointKey derives the key encrypting the checkpoints of the party from its EncSK
+// The key is bound to the session, the epoch, and the party,
+// so that a checkpoint of another refresh cannot be restored
+func checkpointKey(pub *PublicInput, prv *PrivateInput) (key curve25519.Key, err error) {
+	info := make([]byte, 0, len(pub.SessionID)+16)
+	info = append(info, pub.SessionID...)
+	info = append(info, make([]byte, 16)...)
+	binary.LittleEndian.PutUint64(info[len(pub.SessionID):], pub.Epoch)
+	binary.LittleEndian.PutUint64(info[len(pub.SessionID)+8:], uint64(prv.ID))
+
+	kdf := hkdf.New(sha256.New, prv.EncSK[:], []byte(checkpointSalt), info)
+	_, err = io.ReadFull(kdf, key[:])
+	if err != nil {
+		return curve25519.Key{}, err
+	}
+	return key, nil
+}
+
+// SaveCheckpoint writes the state of the party (see Party.State) to the file path,
+// encrypted under a key derived from prv.EncSK (see RestorePartyFromCheckpoint)
+// The file is replaced atomically, so that a crash leaves either the previous or the new checkpoint
+func (p *Party) SaveCheckpoint(path string) error {
+	key, err := checkpointKey(p.pub, p.prv)
+	if err != nil {
+		return err
+	}
+
+	// The nonce is always taken from the system randomness, even if prv.Rand is set,
+	// as a deterministic prv.Rand would reuse nonces for different states
+	nonce := curve25519.GenerateNonce()
+	c, err := curve25519.SymmetricEncrypt(key, nonce, p.State())
+	if err != nil {
+		return fmt.Errorf("party %d failed to encrypt its checkpoint: %w", p.prv.ID, err)
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("party %d failed to create its checkpoint: %w", p.prv.ID, err)
+	}
+	defer os.Remove(f.Name()) // no-op if renamed
+
+	_, err = f.Write(append(nonce[:], c...))
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("party %d failed to write its checkpoint: %w", p.prv.ID, err)
+	}
+
+	err = os.Rename(f.Name(), path)
+	if err != nil {
+		return fmt.Errorf("party %d failed to write its checkpoint: %w", p.prv.ID, err)
+	}
+	return nil
+}
+
+// RestorePartyFromCheckpoint restores a party from the checkpoint written by SaveCheckpoint
+// pub, prv, and dbg must be the same as the ones given to NewParty (see RestoreParty)
+// It fails if the checkpoint was not written by the same party for the same session and epoch
+func RestorePartyFromCheckpoint(
+	pub *PublicInput, prv *PrivateInput, dbg *PartyDebugParams, path string,
+) (*Party, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("party %d failed to read its checkpoint: %w", prv.ID, err)
+	}
+
+	var nonce curve25519.Nonce
+	if len(b) < len(nonce) {
+		return nil, fmt.Errorf("party %d failed to decrypt its checkpoint: checkpoint too short", prv.ID)
+	}
+	copy(nonce[:], b)
+
+	key, err := checkpointKey(pub, prv)
+	if err != nil {
+		return nil, err
+	}
+	state, err := curve25519.SymmetricDecrypt(key, nonce, b[len(nonce):])
+	if err != nil {
+		return nil, fmt.Errorf("party %d failed to decrypt its checkpoint: %w", prv.ID, err)
+	}
+
+	return RestoreParty(pub, prv, dbg, state)
+}
+
+// StartCommitteePartyWithCheckpoint is the same as StartCommitteePartyWithFeldman
+// but checkpoints the state of the party in the file path before broadcasting each message
+// If the file already exists, the party resumes from it, broadcasting again the message of the current round
+// (which is exactly the message it broadcast or was about to broadcast before stopping)
+// so that the party never deals twice with different randomness
+func StartCommitteePartyWithCheckpoint(
+	pub *PublicInput,
+	prv *PrivateInput,
+	dbg *PartyDebugParams,
+	path string,
+) (
+	nextShare *vss.Share,
+	nextCommitments []pedersen.Commitment,
+	feldmanCommitments []feldman.GCommitment,
+	err error,
+) {
+	var party *Party
+
+	_, err = os.Stat(path)
+	switch {
+	case err == nil:
+		party, err = RestorePartyFromCheckpoint(pub, prv, dbg, path)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	case os.IsNotExist(err):
+		party, err = NewParty(pub, prv, dbg)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		err = party.SaveCheckpoint(path)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	default:
+		return nil, nil, nil, fmt.Errorf("party %d failed to access its checkpoint: %w", prv.ID, err)
+	}
+
+	payload, status := party.Payload(), party.Status()
+	for status == PartyWaiting {
+		prv.BC.Send(payload)
+		_, bm := prv.BC.ReceiveRound()
+		payload, status, err = party.Step(bm)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		err = party.SaveCheckpoint(path)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return party.Output()
+}
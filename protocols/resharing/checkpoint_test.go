@@ -0,0 +1,169 @@
+package resharing
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/shaih/go-yosovss/communication"
+	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpoint(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n  = 3
+		tt = 1
+	)
+
+	pub, prvs, _, _, _ := setupResharingSeq(t, n, tt)
+	dbg := &PartyDebugParams{}
+	path := filepath.Join(t.TempDir(), "checkpoint")
+
+	party, err := NewParty(pub, &prvs[0], dbg)
+	require.NoError(err)
+	require.NoError(party.SaveCheckpoint(path))
+
+	restored, err := RestorePartyFromCheckpoint(pub, &prvs[0], dbg, path)
+	require.NoError(err)
+	assert.Equal(party.Round(), restored.Round())
+	assert.Equal(party.Payload(), restored.Payload())
+
+	// Checkpoint of another party
+	_, err = RestorePartyFromCheckpoint(pub, &prvs[1], dbg, path)
+	assert.Error(err)
+
+	// Checkpoint of another epoch
+	otherPub := *pub
+	otherPub.Epoch++
+	_, err = RestorePartyFromCheckpoint(&otherPub, &prvs[0], dbg, path)
+	assert.Error(err)
+
+	// Modified checkpoint
+	b, err := ioutil.ReadFile(path)
+	require.NoError(err)
+	b[len(b)-1] ^= 1
+	require.NoError(ioutil.WriteFile(path, b, 0600))
+	_, err = RestorePartyFromCheckpoint(pub, &prvs[0], dbg, path)
+	assert.Error(err)
+
+	// Truncated checkpoint
+	require.NoError(ioutil.WriteFile(path, b[:10], 0600))
+	_, err = RestorePartyFromCheckpoint(pub, &prvs[0], dbg, path)
+	assert.Error(err)
+
+	// Missing checkpoint
+	_, err = RestorePartyFromCheckpoint(pub, &prvs[0], dbg, path+"-missing")
+	assert.Error(err)
+}
+
+// recordingChannel is a broadcast channel that records the messages sent by the party
+type recordingChannel struct {
+	communication.BroadcastChannel
+	sent [][]byte
+}
+
+func (c *recordingChannel) Send(msg []byte) {
+	c.sent = append(c.sent, msg)
+	c.BroadcastChannel.Send(msg)
+}
+
+func TestResharingProtocolCheckpointCrash(t *testing.T) {
+	// Test the resharing protocol when some parties crash just before broadcasting their message
+	// and restart from their checkpoint
+
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n          = 3                 // number of parties per committee
+		numParties = n * numCommittees // total number of parties
+		tt         = 1                 // threshold of malicious parties
+	)
+
+	pub, prvs, o, secret, rnd := setupResharingSeq(t, n, tt)
+	dbg := &PartyDebugParams{}
+	dir := t.TempDir()
+
+	// crashRounds[party] is the round in which the party crashes (if any)
+	// A dealer, a verifier, and a resolution committee member crash before broadcasting their message,
+	// and a next holding committee member crashes before computing its share
+	crashRounds := map[int]int{
+		pub.Committees.Hold[0]: 0,
+		pub.Committees.Ver[1]:  1,
+		pub.Committees.Res[2]:  2,
+		pub.Committees.Next[0]: numRounds,
+	}
+
+	// Output of all parties
+	outputCommitments := make([][]feldman.GCommitment, numParties)
+	outputShares := make([]*vss.Share, numParties)
+	outputErrs := make([]error, numParties)
+
+	// crashPayloads[party] is the payload the party was about to broadcast when it crashed
+	crashPayloads := make([][]byte, numParties)
+	channels := make([]*recordingChannel, numParties)
+
+	var wg sync.WaitGroup
+	for party := 0; party < numParties; party++ {
+		channels[party] = &recordingChannel{BroadcastChannel: prvs[party].BC}
+		prvs[party].BC = channels[party]
+		path := filepath.Join(dir, strconv.Itoa(party))
+
+		wg.Add(1)
+		go func(party int, wg *sync.WaitGroup) {
+			defer wg.Done()
+			prv := &prvs[party]
+
+			if crashRound, ok := crashRounds[party]; ok {
+				// Run the party until it crashes
+				p, err := NewParty(pub, prv, dbg)
+				require.NoError(err)
+				require.NoError(p.SaveCheckpoint(path))
+				for p.Round() < crashRound {
+					prv.BC.Send(p.Payload())
+					_, bm := prv.BC.ReceiveRound()
+					_, _, err = p.Step(bm)
+					require.NoError(err)
+					require.NoError(p.SaveCheckpoint(path))
+				}
+				crashPayloads[party] = p.Payload()
+			}
+
+			// Start or restart the party
+			outputShares[party], outputCommitments[party], _, outputErrs[party] =
+				StartCommitteePartyWithCheckpoint(pub, prv, dbg, path)
+		}(party, &wg)
+	}
+
+	for o.Round < numRounds {
+		err := o.ReceiveMessages()
+		require.NoError(err)
+		err = o.Broadcast()
+		require.NoError(err)
+		o.Round++
+	}
+
+	wg.Wait()
+
+	for party := 0; party < numParties; party++ {
+		require.NoError(outputErrs[party])
+	}
+
+	// Restarted parties broadcast exactly the payload they were about to broadcast
+	for party, crashRound := range crashRounds {
+		require.Len(channels[party].sent, numRounds)
+		if crashRound < numRounds {
+			assert.Equal(crashPayloads[party], channels[party].sent[crashRound])
+		}
+	}
+
+	checkProtocolResults(t, pub, secret, rnd, outputCommitments, outputShares, false)
+}
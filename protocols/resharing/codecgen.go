@@ -3,5 +3,5 @@
 
 package resharing
 
-//go:generate codecgen -o gen-codecgen.go nizk_dl.go nizk_dbl_dleq.go step1_dealing.go step2_verification.go step3_resolution.go step4_resolution.go verifier_proof.go
+//go:generate codecgen -o gen-codecgen.go nizk_dl.go nizk_dbl_dleq.go step1_dealing.go step2_verification.go step3_resolution.go step4_resolution.go verifier_proof.go party_state.go blame.go
 //go:generate gofmt -w gen-codecgen.go
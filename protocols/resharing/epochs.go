@@ -0,0 +1,100 @@
+package resharing
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+)
+
+// StartEpochsParty initiates the protocol for a party participating in several consecutive refreshes
+// committees[e] are the committees of the e-th refresh, which is run with epoch pub.Epoch+e
+// All the committees must have size N and threshold T
+// The holding committee of each refresh must be the next holding committee of the previous refresh
+// and committees[0].Hold must be pub.Committees.Hold
+// The next commitments and the next shares of each refresh are used as commitments and shares of the following one
+// It returns the commitments after the last refresh and (if the party is a member of the last next holding committee)
+// its share (or nil otherwise)
+// Every party must call StartEpochsParty with the same committees, even if it is not a member of some of them,
+// as the parties are expected to send a message at each round (see StartCommitteeParty)
+func StartEpochsParty(
+	pub *PublicInput,
+	prv *PrivateInput,
+	committees []Committees,
+	dbg *PartyDebugParams,
+) (
+	nextShare *vss.Share,
+	nextCommitments []pedersen.Commitment,
+	err error,
+) {
+	err = checkEpochCommittees(pub, committees)
+	if err != nil {
+		return nil, nil, err
+	}
+	if dbg.SkipRefreshing {
+		return nil, nil, fmt.Errorf("cannot chain refreshes when refreshing is skipped")
+	}
+
+	// Copies of the inputs updated at each epoch, so that pub and prv are not modified
+	epochPub := *pub
+	epochPrv := *prv
+
+	for e := range committees {
+		epochPub.Committees = committees[e]
+		epochPub.Epoch = pub.Epoch + uint64(e)
+
+		nextShare, nextCommitments, err = StartCommitteeParty(&epochPub, &epochPrv, dbg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("party %d failed at epoch %d: %w", prv.ID, epochPub.Epoch, err)
+		}
+
+		// The commitment to the secret must never change
+		if len(nextCommitments) != pub.N+1 ||
+			!curve25519.PointXYEqual(&nextCommitments[0], &pub.Commitments[0]) {
+			return nil, nil, fmt.Errorf("commitment to the secret changed at epoch %d", epochPub.Epoch)
+		}
+
+		epochPub.Commitments = nextCommitments
+		epochPrv.Share = nextShare
+	}
+
+	return nextShare, nextCommitments, nil
+}
+
+// checkEpochCommittees checks that the committees of consecutive refreshes are chained
+// (see StartEpochsParty)
+func checkEpochCommittees(pub *PublicInput, committees []Committees) error {
+	if len(committees) == 0 {
+		return fmt.Errorf("no refresh to perform")
+	}
+	if pub.VerVSSParams != nil || pub.ResVSSParams != nil || pub.NextVSSParams != nil {
+		return fmt.Errorf("all the committees of all the refreshes must have size N and threshold T")
+	}
+	if !equalCommittee(committees[0].Hold, pub.Committees.Hold) {
+		return fmt.Errorf("first holding committee does not match pub.Committees.Hold")
+	}
+	for e := range committees {
+		c := &committees[e]
+		if len(c.Hold) != pub.N || len(c.Ver) != pub.N || len(c.Res) != pub.N || len(c.Next) != pub.N {
+			return fmt.Errorf("committees of refresh %d do not have size N", e)
+		}
+		if e > 0 && !equalCommittee(c.Hold, committees[e-1].Next) {
+			return fmt.Errorf("holding committee of refresh %d is not the next holding committee of refresh %d", e, e-1)
+		}
+	}
+	return nil
+}
+
+// equalCommittee returns true if the two committees contain the same parties in the same order
+func equalCommittee(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
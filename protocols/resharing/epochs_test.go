@@ -0,0 +1,110 @@
+package resharing
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/shaih/go-yosovss/protocols/resharing/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResharingProtocolEpochs(t *testing.T) {
+	// Test many consecutive refreshes when everybody is honest
+	var err error
+
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n          = 3                 // number of parties per committee
+		numParties = n * numCommittees // total number of parties
+		tt         = 1                 // threshold of malicious parties
+		numEpochs  = 24                // number of refreshes
+	)
+
+	pub, prvs, o, secret, _ := setupResharingSeq(t, n, tt)
+	committees := rotatingCommittees(n, numEpochs)
+
+	// Output of all parties
+	outputCommitments := make([][]feldman.GCommitment, numParties)
+	outputShares := make([]*vss.Share, numParties)
+
+	var wg sync.WaitGroup
+
+	// Start protocol
+	for party := 0; party < numParties; party++ {
+		wg.Add(1)
+		go func(party int, wg *sync.WaitGroup) {
+			defer wg.Done()
+			outputShares[party], outputCommitments[party], err =
+				StartEpochsParty(pub, &prvs[party], committees, &PartyDebugParams{})
+			require.NoError(err)
+		}(party, &wg)
+	}
+
+	for o.Round < numRounds*numEpochs {
+		err := o.ReceiveMessages()
+		require.NoError(err)
+		err = o.Broadcast()
+		require.NoError(err)
+		o.Round++
+	}
+
+	wg.Wait()
+
+	// Check all the parties agree on the last commitments
+	lastCommitments := outputCommitments[0]
+	for party := 0; party < numParties; party++ {
+		assert.Equal(lastCommitments, outputCommitments[party])
+	}
+	assert.Equal(pub.Commitments[0], lastCommitments[0])
+
+	// Check only the last next holding committee has shares and that they are valid
+	lastNext := committees[numEpochs-1].Next
+	lastShares := make([]vss.Share, 0, n)
+	for party := 0; party < numParties; party++ {
+		if common.IntIndexOf(lastNext, party) < 0 {
+			assert.Nil(outputShares[party])
+			continue
+		}
+		require.NotNil(outputShares[party])
+		valid, err := vss.VerifyShare(&pub.VSSParams, outputShares[party], lastCommitments)
+		require.NoError(err)
+		assert.True(valid)
+		lastShares = append(lastShares, *outputShares[party])
+	}
+	require.Len(lastShares, n)
+
+	reconsSecret, err := vss.Reconstruct(&pub.VSSParams, lastShares[:tt+1], lastCommitments)
+	require.NoError(err)
+	assert.Equal(*secret, *reconsSecret)
+}
+
+func TestCheckEpochCommittees(t *testing.T) {
+	assert := assert.New(t)
+
+	const (
+		n  = 3
+		tt = 1
+	)
+
+	pub, _, _, _, _ := setupResharingSeq(t, n, tt)
+
+	committees := rotatingCommittees(n, 3)
+	assert.NoError(checkEpochCommittees(pub, committees))
+
+	assert.Error(checkEpochCommittees(pub, nil))
+	assert.Error(checkEpochCommittees(pub, committees[1:]))
+
+	// Refreshes not chained
+	committees[1].Hold = committees[0].Hold
+	assert.Error(checkEpochCommittees(pub, committees))
+
+	// Committee of the wrong size
+	committees = rotatingCommittees(n, 3)
+	committees[2].Ver = committees[2].Ver[:n-1]
+	assert.Error(checkEpochCommittees(pub, committees))
+}
@@ -21,34 +21,39 @@ const (
 
 // GenerateAllEps generate all the epsKeys, epsL structures, and corresponding hashes
 // for all resolution committee members
-func GenerateAllEps(n int, t int) (epsKeys []curve25519.Key, epsK []EpsK, hashEps [][][HashLength]byte, err error) {
+// There is one key for each of the nVer verification committee members,
+// shared among the nRes resolution committee members with degree tRes
+// rnd is the randomness source (system randomness if nil)
+func GenerateAllEps(rnd io.Reader, nVer int, nRes int, tRes int) (
+	epsKeys []curve25519.Key, epsK []EpsK, hashEps [][][HashLength]byte, err error,
+) {
 	// Initialization
-	epsKeys = make([]curve25519.Key, n)
-	epsK = make([]EpsK, n)
-	hashEps = make([][][HashLength]byte, n)
-	for k := 0; k < n; k++ {
-		epsK[k].Eps = make([]curve25519.Scalar, n)
+	epsKeys = make([]curve25519.Key, nVer)
+	epsK = make([]EpsK, nRes)
+	hashEps = make([][][HashLength]byte, nVer)
+	for k := 0; k < nRes; k++ {
+		epsK[k].Eps = make([]curve25519.Scalar, nVer)
 	}
 
-	chacha20Key, err := curve25519.RandomChacha20Key()
+	chacha20Key, err := curve25519.RandomChacha20KeyFrom(rnd)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 	eps := curve25519.Scalar{}
 
-	for j := 0; j < n; j++ {
+	for j := 0; j < nVer; j++ {
 		// Generate random secret seed eps for Vk
 		curve25519.RandomScalarChacha20C(&eps, &chacha20Key, uint64(j))
 
 		// Secret share it and derive the symmetric encryption key epsKey
-		epsKey, epsShares, err := GenerateEpsKeyShares(n, t, &eps)
+		epsKey, epsShares, err := GenerateEpsKeyShares(rnd, nRes, tRes, &eps)
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("failed to generate eps keys for j=%d: %w", j, err)
 		}
 
 		epsKeys[j] = epsKey
-		hashEps[j] = make([][HashLength]byte, n)
-		for k := 0; k < n; k++ {
+		hashEps[j] = make([][HashLength]byte, nRes)
+		for k := 0; k < nRes; k++ {
 			hashEps[j][k] = sha256.Sum256(epsShares[k][:])
 			epsK[k].Eps[j] = epsShares[k]
 		}
@@ -61,6 +66,7 @@ func GenerateAllEps(n int, t int) (epsKeys []curve25519.Key, epsK []EpsK, hashEp
 // and make the key epsKey be HKDF(eps) using KeyFromEps function
 // d is the degree of Shamir's polynomial
 func GenerateEpsKeyShares(
+	rnd io.Reader,
 	n int,
 	d int,
 	eps *curve25519.Scalar,
@@ -76,7 +82,7 @@ func GenerateEpsKeyShares(
 	}
 
 	// Generate the shares of epsKey
-	shares, err := shamir.GenerateShares(shamir.Message(*eps), d+1, n)
+	shares, err := shamir.GenerateSharesFrom(rnd, shamir.Message(*eps), d+1, n)
 	if err != nil {
 		return [32]byte{}, nil, err
 	}
@@ -117,6 +123,11 @@ func ReconstructEpsKey(
 	epsKey curve25519.Key,
 	err error,
 ) {
+	if len(epsShares) != n || len(hashEps) != n {
+		return [32]byte{}, fmt.Errorf("reconstruction of eps key failed: expected %d shares and hashes, got %d and %d",
+			n, len(epsShares), len(hashEps))
+	}
+
 	// Find d+1 valid shares
 	validShares := make([]shamir.Share, 0, d+1) // prepare an array of capacity d+1
 	for i := 0; i < n && len(validShares) < d+1; i++ {
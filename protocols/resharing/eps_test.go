@@ -27,7 +27,7 @@ func TestReconstructEpsKey(t *testing.T) {
 			n := tc.n
 			d := tc.d
 
-			epsKey, epsL, hashEps, err := GenerateAllEps(n, d)
+			epsKey, epsL, hashEps, err := GenerateAllEps(nil, n, n, d)
 			require.NoError(err)
 
 			for k := 0; k < n; k++ {
@@ -9,6 +9,9 @@ package resharing
 import (
 	"errors"
 	pkg1_curve25519 "github.com/shaih/go-yosovss/primitives/curve25519"
+	pkg2_elgamal "github.com/shaih/go-yosovss/primitives/elgamal"
+	pkg4_feldman "github.com/shaih/go-yosovss/primitives/feldman"
+	pkg3_nizk "github.com/shaih/go-yosovss/primitives/nizk"
 	codec1978 "github.com/ugorji/go/codec"
 	"runtime"
 	"strconv"
@@ -47,6 +50,9 @@ func init() {
 	}
 	if false { // reference the types, but skip this branch at build/run time
 		var _ pkg1_curve25519.PointXY
+		var _ pkg2_elgamal.Ciphertext
+		var _ pkg4_feldman.ExpShare
+		var _ pkg3_nizk.LinearProof
 	}
 }
 
@@ -348,181 +354,6 @@ func (x *DLWitness) IsCodecEmpty() bool {
 	return !(len(x.XLog) != 0 || false)
 }
 
-func (DLChHashIn) codecSelferViaCodecgen() {}
-func (x *DLChHashIn) CodecEncodeSelf(e *codec1978.Encoder) {
-	var h codecSelfer943
-	z, r := codec1978.GenHelper().Encoder(e)
-	_, _, _ = h, z, r
-	if z.EncBasicHandle().CheckCircularRef {
-		z.EncEncode(x)
-		return
-	}
-	if x == nil {
-		r.EncodeNil()
-	} else {
-		yy2arr2 := z.EncBasicHandle().StructToArray
-		_ = yy2arr2
-		const yyr2 bool = false // struct tag has 'toArray'
-		if yyr2 || yy2arr2 {
-			z.EncWriteArrayStart(2)
-			z.EncWriteArrayElem()
-			yy5 := &x.Stmt
-			if yyxt6 := z.Extension(yy5); yyxt6 != nil {
-				z.EncExtension(yy5, yyxt6)
-			} else {
-				yy5.CodecEncodeSelf(e)
-			}
-			z.EncWriteArrayElem()
-			if x.Com == nil {
-				r.EncodeNil()
-			} else {
-				h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.Com), e)
-			} // end block: if x.Com slice == nil
-			z.EncWriteArrayEnd()
-		} else {
-			z.EncWriteMapStart(2)
-			z.EncWriteMapElemKey()
-			if z.IsJSONHandle() {
-				z.WriteStr("\"s\"")
-			} else {
-				r.EncodeString(`s`)
-			}
-			z.EncWriteMapElemValue()
-			yy8 := &x.Stmt
-			if yyxt9 := z.Extension(yy8); yyxt9 != nil {
-				z.EncExtension(yy8, yyxt9)
-			} else {
-				yy8.CodecEncodeSelf(e)
-			}
-			z.EncWriteMapElemKey()
-			if z.IsJSONHandle() {
-				z.WriteStr("\"c\"")
-			} else {
-				r.EncodeString(`c`)
-			}
-			z.EncWriteMapElemValue()
-			if x.Com == nil {
-				r.EncodeNil()
-			} else {
-				h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.Com), e)
-			} // end block: if x.Com slice == nil
-			z.EncWriteMapEnd()
-		}
-	}
-}
-
-func (x *DLChHashIn) CodecDecodeSelf(d *codec1978.Decoder) {
-	var h codecSelfer943
-	z, r := codec1978.GenHelper().Decoder(d)
-	_, _, _ = h, z, r
-	yyct2 := r.ContainerType()
-	if yyct2 == codecSelferValueTypeNil943 {
-		*(x) = DLChHashIn{}
-	} else if yyct2 == codecSelferValueTypeMap943 {
-		yyl2 := z.DecReadMapStart()
-		if yyl2 == 0 {
-		} else {
-			x.codecDecodeSelfFromMap(yyl2, d)
-		}
-		z.DecReadMapEnd()
-	} else if yyct2 == codecSelferValueTypeArray943 {
-		yyl2 := z.DecReadArrayStart()
-		if yyl2 != 0 {
-			x.codecDecodeSelfFromArray(yyl2, d)
-		}
-		z.DecReadArrayEnd()
-	} else {
-		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
-	}
-}
-
-func (x *DLChHashIn) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
-	var h codecSelfer943
-	z, r := codec1978.GenHelper().Decoder(d)
-	_, _, _ = h, z, r
-	var yyhl3 bool = l >= 0
-	for yyj3 := 0; ; yyj3++ {
-		if yyhl3 {
-			if yyj3 >= l {
-				break
-			}
-		} else {
-			if z.DecCheckBreak() {
-				break
-			}
-		}
-		z.DecReadMapElemKey()
-		yys3 := r.DecodeStringAsBytes()
-		z.DecReadMapElemValue()
-		switch string(yys3) {
-		case "s":
-			if yyxt5 := z.Extension(x.Stmt); yyxt5 != nil {
-				z.DecExtension(&x.Stmt, yyxt5)
-			} else {
-				x.Stmt.CodecDecodeSelf(d)
-			}
-		case "c":
-			h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.Com), d)
-		default:
-			z.DecStructFieldNotFound(-1, string(yys3))
-		} // end switch yys3
-	} // end for yyj3
-}
-
-func (x *DLChHashIn) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
-	var h codecSelfer943
-	z, r := codec1978.GenHelper().Decoder(d)
-	_, _, _ = h, z, r
-	var yyj8 int
-	var yyb8 bool
-	var yyhl8 bool = l >= 0
-	yyj8++
-	if yyhl8 {
-		yyb8 = yyj8 > l
-	} else {
-		yyb8 = z.DecCheckBreak()
-	}
-	if yyb8 {
-		z.DecReadArrayEnd()
-		return
-	}
-	z.DecReadArrayElem()
-	if yyxt10 := z.Extension(x.Stmt); yyxt10 != nil {
-		z.DecExtension(&x.Stmt, yyxt10)
-	} else {
-		x.Stmt.CodecDecodeSelf(d)
-	}
-	yyj8++
-	if yyhl8 {
-		yyb8 = yyj8 > l
-	} else {
-		yyb8 = z.DecCheckBreak()
-	}
-	if yyb8 {
-		z.DecReadArrayEnd()
-		return
-	}
-	z.DecReadArrayElem()
-	h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.Com), d)
-	for {
-		yyj8++
-		if yyhl8 {
-			yyb8 = yyj8 > l
-		} else {
-			yyb8 = z.DecCheckBreak()
-		}
-		if yyb8 {
-			break
-		}
-		z.DecReadArrayElem()
-		z.DecStructFieldNotFound(yyj8-1, "")
-	}
-}
-
-func (x *DLChHashIn) IsCodecEmpty() bool {
-	return !(!(x.Stmt.IsCodecEmpty()) || len(x.Com) != 0 || false)
-}
-
 func (DLProof) codecSelferViaCodecgen() {}
 func (x *DLProof) CodecEncodeSelf(e *codec1978.Encoder) {
 	var h codecSelfer943
@@ -538,8 +369,9 @@ func (x *DLProof) CodecEncodeSelf(e *codec1978.Encoder) {
 		yy2arr2 := z.EncBasicHandle().StructToArray
 		_ = yy2arr2
 		const yyr2 bool = false // struct tag has 'toArray'
+		var yyn5 bool = x.Chal == nil
 		if yyr2 || yy2arr2 {
-			z.EncWriteArrayStart(2)
+			z.EncWriteArrayStart(3)
 			z.EncWriteArrayElem()
 			if x.Com == nil {
 				r.EncodeNil()
@@ -552,9 +384,20 @@ func (x *DLProof) CodecEncodeSelf(e *codec1978.Encoder) {
 			} else {
 				h.encSlicecurve25519_Scalar(([]pkg1_curve25519.Scalar)(x.Resp), e)
 			} // end block: if x.Resp slice == nil
+			if yyn5 {
+				z.EncWriteArrayElem()
+				r.EncodeNil()
+			} else {
+				z.EncWriteArrayElem()
+				if yyxt8 := z.Extension(x.Chal); yyxt8 != nil {
+					z.EncExtension(x.Chal, yyxt8)
+				} else {
+					z.F.EncSliceUint8V(([]uint8)(x.Chal[:]), e)
+				}
+			}
 			z.EncWriteArrayEnd()
 		} else {
-			z.EncWriteMapStart(2)
+			z.EncWriteMapStart(3)
 			z.EncWriteMapElemKey()
 			if z.IsJSONHandle() {
 				z.WriteStr("\"c\"")
@@ -579,6 +422,22 @@ func (x *DLProof) CodecEncodeSelf(e *codec1978.Encoder) {
 			} else {
 				h.encSlicecurve25519_Scalar(([]pkg1_curve25519.Scalar)(x.Resp), e)
 			} // end block: if x.Resp slice == nil
+			z.EncWriteMapElemKey()
+			if z.IsJSONHandle() {
+				z.WriteStr("\"e\"")
+			} else {
+				r.EncodeString(`e`)
+			}
+			z.EncWriteMapElemValue()
+			if yyn5 {
+				r.EncodeNil()
+			} else {
+				if yyxt11 := z.Extension(x.Chal); yyxt11 != nil {
+					z.EncExtension(x.Chal, yyxt11)
+				} else {
+					z.F.EncSliceUint8V(([]uint8)(x.Chal[:]), e)
+				}
+			}
 			z.EncWriteMapEnd()
 		}
 	}
@@ -632,6 +491,21 @@ func (x *DLProof) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
 			h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.Com), d)
 		case "r":
 			h.decSlicecurve25519_Scalar((*[]pkg1_curve25519.Scalar)(&x.Resp), d)
+		case "e":
+			if r.TryNil() {
+				if x.Chal != nil { // remove the if-true
+					x.Chal = nil
+				}
+			} else {
+				if x.Chal == nil {
+					x.Chal = new(pkg1_curve25519.Scalar)
+				}
+				if yyxt9 := z.Extension(x.Chal); yyxt9 != nil {
+					z.DecExtension(x.Chal, yyxt9)
+				} else {
+					z.F.DecSliceUint8N(([]uint8)(x.Chal[:]), d)
+				}
+			}
 		default:
 			z.DecStructFieldNotFound(-1, string(yys3))
 		} // end switch yys3
@@ -642,45 +516,70 @@ func (x *DLProof) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Decoder(d)
 	_, _, _ = h, z, r
-	var yyj8 int
-	var yyb8 bool
-	var yyhl8 bool = l >= 0
-	yyj8++
-	if yyhl8 {
-		yyb8 = yyj8 > l
+	var yyj10 int
+	var yyb10 bool
+	var yyhl10 bool = l >= 0
+	yyj10++
+	if yyhl10 {
+		yyb10 = yyj10 > l
 	} else {
-		yyb8 = z.DecCheckBreak()
+		yyb10 = z.DecCheckBreak()
 	}
-	if yyb8 {
+	if yyb10 {
 		z.DecReadArrayEnd()
 		return
 	}
 	z.DecReadArrayElem()
 	h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.Com), d)
-	yyj8++
-	if yyhl8 {
-		yyb8 = yyj8 > l
+	yyj10++
+	if yyhl10 {
+		yyb10 = yyj10 > l
 	} else {
-		yyb8 = z.DecCheckBreak()
+		yyb10 = z.DecCheckBreak()
 	}
-	if yyb8 {
+	if yyb10 {
 		z.DecReadArrayEnd()
 		return
 	}
 	z.DecReadArrayElem()
 	h.decSlicecurve25519_Scalar((*[]pkg1_curve25519.Scalar)(&x.Resp), d)
+	yyj10++
+	if yyhl10 {
+		yyb10 = yyj10 > l
+	} else {
+		yyb10 = z.DecCheckBreak()
+	}
+	if yyb10 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if r.TryNil() {
+		if x.Chal != nil { // remove the if-true
+			x.Chal = nil
+		}
+	} else {
+		if x.Chal == nil {
+			x.Chal = new(pkg1_curve25519.Scalar)
+		}
+		if yyxt16 := z.Extension(x.Chal); yyxt16 != nil {
+			z.DecExtension(x.Chal, yyxt16)
+		} else {
+			z.F.DecSliceUint8N(([]uint8)(x.Chal[:]), d)
+		}
+	}
 	for {
-		yyj8++
-		if yyhl8 {
-			yyb8 = yyj8 > l
+		yyj10++
+		if yyhl10 {
+			yyb10 = yyj10 > l
 		} else {
-			yyb8 = z.DecCheckBreak()
+			yyb10 = z.DecCheckBreak()
 		}
-		if yyb8 {
+		if yyb10 {
 			break
 		}
 		z.DecReadArrayElem()
-		z.DecStructFieldNotFound(yyj8-1, "")
+		z.DecStructFieldNotFound(yyj10-1, "")
 	}
 }
 
@@ -1050,8 +949,8 @@ func (x *DblDLEqWitness) IsCodecEmpty() bool {
 	return !(len(x.X) != 0 || len(x.Y) != 0 || false)
 }
 
-func (DblDLEqHashIn) codecSelferViaCodecgen() {}
-func (x *DblDLEqHashIn) CodecEncodeSelf(e *codec1978.Encoder) {
+func (DblDLEqProof) codecSelferViaCodecgen() {}
+func (x *DblDLEqProof) CodecEncodeSelf(e *codec1978.Encoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Encoder(e)
 	_, _, _ = h, z, r
@@ -1065,15 +964,9 @@ func (x *DblDLEqHashIn) CodecEncodeSelf(e *codec1978.Encoder) {
 		yy2arr2 := z.EncBasicHandle().StructToArray
 		_ = yy2arr2
 		const yyr2 bool = false // struct tag has 'toArray'
+		var yyn7 bool = x.Chal == nil
 		if yyr2 || yy2arr2 {
-			z.EncWriteArrayStart(3)
-			z.EncWriteArrayElem()
-			yy6 := &x.Stmt
-			if yyxt7 := z.Extension(yy6); yyxt7 != nil {
-				z.EncExtension(yy6, yyxt7)
-			} else {
-				yy6.CodecEncodeSelf(e)
-			}
+			z.EncWriteArrayStart(5)
 			z.EncWriteArrayElem()
 			if x.Com == nil {
 				r.EncodeNil()
@@ -1086,209 +979,9 @@ func (x *DblDLEqHashIn) CodecEncodeSelf(e *codec1978.Encoder) {
 			} else {
 				h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.ComPrime), e)
 			} // end block: if x.ComPrime slice == nil
-			z.EncWriteArrayEnd()
-		} else {
-			z.EncWriteMapStart(3)
-			z.EncWriteMapElemKey()
-			if z.IsJSONHandle() {
-				z.WriteStr("\"s\"")
-			} else {
-				r.EncodeString(`s`)
-			}
-			z.EncWriteMapElemValue()
-			yy10 := &x.Stmt
-			if yyxt11 := z.Extension(yy10); yyxt11 != nil {
-				z.EncExtension(yy10, yyxt11)
-			} else {
-				yy10.CodecEncodeSelf(e)
-			}
-			z.EncWriteMapElemKey()
-			if z.IsJSONHandle() {
-				z.WriteStr("\"g\"")
-			} else {
-				r.EncodeString(`g`)
-			}
-			z.EncWriteMapElemValue()
-			if x.Com == nil {
-				r.EncodeNil()
-			} else {
-				h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.Com), e)
-			} // end block: if x.Com slice == nil
-			z.EncWriteMapElemKey()
-			if z.IsJSONHandle() {
-				z.WriteStr("\"h\"")
-			} else {
-				r.EncodeString(`h`)
-			}
-			z.EncWriteMapElemValue()
-			if x.ComPrime == nil {
-				r.EncodeNil()
-			} else {
-				h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.ComPrime), e)
-			} // end block: if x.ComPrime slice == nil
-			z.EncWriteMapEnd()
-		}
-	}
-}
-
-func (x *DblDLEqHashIn) CodecDecodeSelf(d *codec1978.Decoder) {
-	var h codecSelfer943
-	z, r := codec1978.GenHelper().Decoder(d)
-	_, _, _ = h, z, r
-	yyct2 := r.ContainerType()
-	if yyct2 == codecSelferValueTypeNil943 {
-		*(x) = DblDLEqHashIn{}
-	} else if yyct2 == codecSelferValueTypeMap943 {
-		yyl2 := z.DecReadMapStart()
-		if yyl2 == 0 {
-		} else {
-			x.codecDecodeSelfFromMap(yyl2, d)
-		}
-		z.DecReadMapEnd()
-	} else if yyct2 == codecSelferValueTypeArray943 {
-		yyl2 := z.DecReadArrayStart()
-		if yyl2 != 0 {
-			x.codecDecodeSelfFromArray(yyl2, d)
-		}
-		z.DecReadArrayEnd()
-	} else {
-		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
-	}
-}
-
-func (x *DblDLEqHashIn) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
-	var h codecSelfer943
-	z, r := codec1978.GenHelper().Decoder(d)
-	_, _, _ = h, z, r
-	var yyhl3 bool = l >= 0
-	for yyj3 := 0; ; yyj3++ {
-		if yyhl3 {
-			if yyj3 >= l {
-				break
-			}
-		} else {
-			if z.DecCheckBreak() {
-				break
-			}
-		}
-		z.DecReadMapElemKey()
-		yys3 := r.DecodeStringAsBytes()
-		z.DecReadMapElemValue()
-		switch string(yys3) {
-		case "s":
-			if yyxt5 := z.Extension(x.Stmt); yyxt5 != nil {
-				z.DecExtension(&x.Stmt, yyxt5)
-			} else {
-				x.Stmt.CodecDecodeSelf(d)
-			}
-		case "g":
-			h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.Com), d)
-		case "h":
-			h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.ComPrime), d)
-		default:
-			z.DecStructFieldNotFound(-1, string(yys3))
-		} // end switch yys3
-	} // end for yyj3
-}
-
-func (x *DblDLEqHashIn) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
-	var h codecSelfer943
-	z, r := codec1978.GenHelper().Decoder(d)
-	_, _, _ = h, z, r
-	var yyj10 int
-	var yyb10 bool
-	var yyhl10 bool = l >= 0
-	yyj10++
-	if yyhl10 {
-		yyb10 = yyj10 > l
-	} else {
-		yyb10 = z.DecCheckBreak()
-	}
-	if yyb10 {
-		z.DecReadArrayEnd()
-		return
-	}
-	z.DecReadArrayElem()
-	if yyxt12 := z.Extension(x.Stmt); yyxt12 != nil {
-		z.DecExtension(&x.Stmt, yyxt12)
-	} else {
-		x.Stmt.CodecDecodeSelf(d)
-	}
-	yyj10++
-	if yyhl10 {
-		yyb10 = yyj10 > l
-	} else {
-		yyb10 = z.DecCheckBreak()
-	}
-	if yyb10 {
-		z.DecReadArrayEnd()
-		return
-	}
-	z.DecReadArrayElem()
-	h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.Com), d)
-	yyj10++
-	if yyhl10 {
-		yyb10 = yyj10 > l
-	} else {
-		yyb10 = z.DecCheckBreak()
-	}
-	if yyb10 {
-		z.DecReadArrayEnd()
-		return
-	}
-	z.DecReadArrayElem()
-	h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.ComPrime), d)
-	for {
-		yyj10++
-		if yyhl10 {
-			yyb10 = yyj10 > l
-		} else {
-			yyb10 = z.DecCheckBreak()
-		}
-		if yyb10 {
-			break
-		}
-		z.DecReadArrayElem()
-		z.DecStructFieldNotFound(yyj10-1, "")
-	}
-}
-
-func (x *DblDLEqHashIn) IsCodecEmpty() bool {
-	return !(!(x.Stmt.IsCodecEmpty()) || len(x.Com) != 0 || len(x.ComPrime) != 0 || false)
-}
-
-func (DblDLEqProof) codecSelferViaCodecgen() {}
-func (x *DblDLEqProof) CodecEncodeSelf(e *codec1978.Encoder) {
-	var h codecSelfer943
-	z, r := codec1978.GenHelper().Encoder(e)
-	_, _, _ = h, z, r
-	if z.EncBasicHandle().CheckCircularRef {
-		z.EncEncode(x)
-		return
-	}
-	if x == nil {
-		r.EncodeNil()
-	} else {
-		yy2arr2 := z.EncBasicHandle().StructToArray
-		_ = yy2arr2
-		const yyr2 bool = false // struct tag has 'toArray'
-		if yyr2 || yy2arr2 {
-			z.EncWriteArrayStart(4)
-			z.EncWriteArrayElem()
-			if x.Com == nil {
-				r.EncodeNil()
-			} else {
-				h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.Com), e)
-			} // end block: if x.Com slice == nil
-			z.EncWriteArrayElem()
-			if x.ComPrime == nil {
-				r.EncodeNil()
-			} else {
-				h.encSlicecurve25519_PointXY(([]pkg1_curve25519.PointXY)(x.ComPrime), e)
-			} // end block: if x.ComPrime slice == nil
-			z.EncWriteArrayElem()
-			if x.RespG == nil {
-				r.EncodeNil()
+			z.EncWriteArrayElem()
+			if x.RespG == nil {
+				r.EncodeNil()
 			} else {
 				h.encSlicecurve25519_Scalar(([]pkg1_curve25519.Scalar)(x.RespG), e)
 			} // end block: if x.RespG slice == nil
@@ -1298,9 +991,20 @@ func (x *DblDLEqProof) CodecEncodeSelf(e *codec1978.Encoder) {
 			} else {
 				h.encSlicecurve25519_Scalar(([]pkg1_curve25519.Scalar)(x.RespH), e)
 			} // end block: if x.RespH slice == nil
+			if yyn7 {
+				z.EncWriteArrayElem()
+				r.EncodeNil()
+			} else {
+				z.EncWriteArrayElem()
+				if yyxt12 := z.Extension(x.Chal); yyxt12 != nil {
+					z.EncExtension(x.Chal, yyxt12)
+				} else {
+					z.F.EncSliceUint8V(([]uint8)(x.Chal[:]), e)
+				}
+			}
 			z.EncWriteArrayEnd()
 		} else {
-			z.EncWriteMapStart(4)
+			z.EncWriteMapStart(5)
 			z.EncWriteMapElemKey()
 			if z.IsJSONHandle() {
 				z.WriteStr("\"g\"")
@@ -1349,6 +1053,22 @@ func (x *DblDLEqProof) CodecEncodeSelf(e *codec1978.Encoder) {
 			} else {
 				h.encSlicecurve25519_Scalar(([]pkg1_curve25519.Scalar)(x.RespH), e)
 			} // end block: if x.RespH slice == nil
+			z.EncWriteMapElemKey()
+			if z.IsJSONHandle() {
+				z.WriteStr("\"e\"")
+			} else {
+				r.EncodeString(`e`)
+			}
+			z.EncWriteMapElemValue()
+			if yyn7 {
+				r.EncodeNil()
+			} else {
+				if yyxt17 := z.Extension(x.Chal); yyxt17 != nil {
+					z.EncExtension(x.Chal, yyxt17)
+				} else {
+					z.F.EncSliceUint8V(([]uint8)(x.Chal[:]), e)
+				}
+			}
 			z.EncWriteMapEnd()
 		}
 	}
@@ -1406,6 +1126,21 @@ func (x *DblDLEqProof) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
 			h.decSlicecurve25519_Scalar((*[]pkg1_curve25519.Scalar)(&x.RespG), d)
 		case "H":
 			h.decSlicecurve25519_Scalar((*[]pkg1_curve25519.Scalar)(&x.RespH), d)
+		case "e":
+			if r.TryNil() {
+				if x.Chal != nil { // remove the if-true
+					x.Chal = nil
+				}
+			} else {
+				if x.Chal == nil {
+					x.Chal = new(pkg1_curve25519.Scalar)
+				}
+				if yyxt13 := z.Extension(x.Chal); yyxt13 != nil {
+					z.DecExtension(x.Chal, yyxt13)
+				} else {
+					z.F.DecSliceUint8N(([]uint8)(x.Chal[:]), d)
+				}
+			}
 		default:
 			z.DecStructFieldNotFound(-1, string(yys3))
 		} // end switch yys3
@@ -1416,69 +1151,94 @@ func (x *DblDLEqProof) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Decoder(d)
 	_, _, _ = h, z, r
-	var yyj12 int
-	var yyb12 bool
-	var yyhl12 bool = l >= 0
-	yyj12++
-	if yyhl12 {
-		yyb12 = yyj12 > l
+	var yyj14 int
+	var yyb14 bool
+	var yyhl14 bool = l >= 0
+	yyj14++
+	if yyhl14 {
+		yyb14 = yyj14 > l
 	} else {
-		yyb12 = z.DecCheckBreak()
+		yyb14 = z.DecCheckBreak()
 	}
-	if yyb12 {
+	if yyb14 {
 		z.DecReadArrayEnd()
 		return
 	}
 	z.DecReadArrayElem()
 	h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.Com), d)
-	yyj12++
-	if yyhl12 {
-		yyb12 = yyj12 > l
+	yyj14++
+	if yyhl14 {
+		yyb14 = yyj14 > l
 	} else {
-		yyb12 = z.DecCheckBreak()
+		yyb14 = z.DecCheckBreak()
 	}
-	if yyb12 {
+	if yyb14 {
 		z.DecReadArrayEnd()
 		return
 	}
 	z.DecReadArrayElem()
 	h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.ComPrime), d)
-	yyj12++
-	if yyhl12 {
-		yyb12 = yyj12 > l
+	yyj14++
+	if yyhl14 {
+		yyb14 = yyj14 > l
 	} else {
-		yyb12 = z.DecCheckBreak()
+		yyb14 = z.DecCheckBreak()
 	}
-	if yyb12 {
+	if yyb14 {
 		z.DecReadArrayEnd()
 		return
 	}
 	z.DecReadArrayElem()
 	h.decSlicecurve25519_Scalar((*[]pkg1_curve25519.Scalar)(&x.RespG), d)
-	yyj12++
-	if yyhl12 {
-		yyb12 = yyj12 > l
+	yyj14++
+	if yyhl14 {
+		yyb14 = yyj14 > l
 	} else {
-		yyb12 = z.DecCheckBreak()
+		yyb14 = z.DecCheckBreak()
 	}
-	if yyb12 {
+	if yyb14 {
 		z.DecReadArrayEnd()
 		return
 	}
 	z.DecReadArrayElem()
 	h.decSlicecurve25519_Scalar((*[]pkg1_curve25519.Scalar)(&x.RespH), d)
+	yyj14++
+	if yyhl14 {
+		yyb14 = yyj14 > l
+	} else {
+		yyb14 = z.DecCheckBreak()
+	}
+	if yyb14 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if r.TryNil() {
+		if x.Chal != nil { // remove the if-true
+			x.Chal = nil
+		}
+	} else {
+		if x.Chal == nil {
+			x.Chal = new(pkg1_curve25519.Scalar)
+		}
+		if yyxt24 := z.Extension(x.Chal); yyxt24 != nil {
+			z.DecExtension(x.Chal, yyxt24)
+		} else {
+			z.F.DecSliceUint8N(([]uint8)(x.Chal[:]), d)
+		}
+	}
 	for {
-		yyj12++
-		if yyhl12 {
-			yyb12 = yyj12 > l
+		yyj14++
+		if yyhl14 {
+			yyb14 = yyj14 > l
 		} else {
-			yyb12 = z.DecCheckBreak()
+			yyb14 = z.DecCheckBreak()
 		}
-		if yyb12 {
+		if yyb14 {
 			break
 		}
 		z.DecReadArrayElem()
-		z.DecStructFieldNotFound(yyj12-1, "")
+		z.DecStructFieldNotFound(yyj14-1, "")
 	}
 }
 
@@ -1501,19 +1261,22 @@ func (x *DealingMessage) CodecEncodeSelf(e *codec1978.Encoder) {
 		yy2arr2 := z.EncBasicHandle().StructToArray
 		_ = yy2arr2
 		const yyr2 bool = false // struct tag has 'toArray'
-		var yyq2 = [8]bool{     // should field at this index be written?
+		var yyn12 bool = x.FeldmanShare == nil
+		var yyq2 = [10]bool{ // should field at this index be written?
 			len(x.ComC) != 0,                 // C
 			len(x.ComZ) != 0,                 // Z
 			len(x.ComZPrime) != 0,            // z
 			!(x.DblDLEqProof.IsCodecEmpty()), // p
 			len(x.EncVerM) != 0,              // V
+			len(x.VEncVerM) != 0,             // v
 			len(x.EncResM) != 0,              // R
 			len(x.EncEpsK) != 0,              // e
 			len(x.HashEps) != 0,              // h
+			x.FeldmanShare != nil,            // f
 		}
 		_ = yyq2
 		if yyr2 || yy2arr2 {
-			z.EncWriteArrayStart(8)
+			z.EncWriteArrayStart(10)
 			z.EncWriteArrayElem()
 			if yyq2[0] {
 				if x.ComC == nil {
@@ -1546,11 +1309,11 @@ func (x *DealingMessage) CodecEncodeSelf(e *codec1978.Encoder) {
 			}
 			z.EncWriteArrayElem()
 			if yyq2[3] {
-				yy14 := &x.DblDLEqProof
-				if yyxt15 := z.Extension(yy14); yyxt15 != nil {
-					z.EncExtension(yy14, yyxt15)
+				yy16 := &x.DblDLEqProof
+				if yyxt17 := z.Extension(yy16); yyxt17 != nil {
+					z.EncExtension(yy16, yyxt17)
 				} else {
-					yy14.CodecEncodeSelf(e)
+					yy16.CodecEncodeSelf(e)
 				}
 			} else {
 				r.EncodeNil()
@@ -1567,6 +1330,16 @@ func (x *DealingMessage) CodecEncodeSelf(e *codec1978.Encoder) {
 			}
 			z.EncWriteArrayElem()
 			if yyq2[5] {
+				if x.VEncVerM == nil {
+					r.EncodeNil()
+				} else {
+					h.encSliceVerifiableEncryption(([]VerifiableEncryption)(x.VEncVerM), e)
+				} // end block: if x.VEncVerM slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[6] {
 				if x.EncResM == nil {
 					r.EncodeNil()
 				} else {
@@ -1576,7 +1349,7 @@ func (x *DealingMessage) CodecEncodeSelf(e *codec1978.Encoder) {
 				r.EncodeNil()
 			}
 			z.EncWriteArrayElem()
-			if yyq2[6] {
+			if yyq2[7] {
 				if x.EncEpsK == nil {
 					r.EncodeNil()
 				} else {
@@ -1586,7 +1359,7 @@ func (x *DealingMessage) CodecEncodeSelf(e *codec1978.Encoder) {
 				r.EncodeNil()
 			}
 			z.EncWriteArrayElem()
-			if yyq2[7] {
+			if yyq2[8] {
 				if x.HashEps == nil {
 					r.EncodeNil()
 				} else {
@@ -1595,6 +1368,21 @@ func (x *DealingMessage) CodecEncodeSelf(e *codec1978.Encoder) {
 			} else {
 				r.EncodeNil()
 			}
+			if yyn12 {
+				z.EncWriteArrayElem()
+				r.EncodeNil()
+			} else {
+				z.EncWriteArrayElem()
+				if yyq2[9] {
+					if yyxt23 := z.Extension(x.FeldmanShare); yyxt23 != nil {
+						z.EncExtension(x.FeldmanShare, yyxt23)
+					} else {
+						z.EncFallback(x.FeldmanShare)
+					}
+				} else {
+					r.EncodeNil()
+				}
+			}
 			z.EncWriteArrayEnd()
 		} else {
 			var yynn2 int
@@ -1655,11 +1443,11 @@ func (x *DealingMessage) CodecEncodeSelf(e *codec1978.Encoder) {
 					r.EncodeString(`p`)
 				}
 				z.EncWriteMapElemValue()
-				yy23 := &x.DblDLEqProof
-				if yyxt24 := z.Extension(yy23); yyxt24 != nil {
-					z.EncExtension(yy23, yyxt24)
+				yy27 := &x.DblDLEqProof
+				if yyxt28 := z.Extension(yy27); yyxt28 != nil {
+					z.EncExtension(yy27, yyxt28)
 				} else {
-					yy23.CodecEncodeSelf(e)
+					yy27.CodecEncodeSelf(e)
 				}
 			}
 			if yyq2[4] {
@@ -1677,6 +1465,20 @@ func (x *DealingMessage) CodecEncodeSelf(e *codec1978.Encoder) {
 				} // end block: if x.EncVerM slice == nil
 			}
 			if yyq2[5] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"v\"")
+				} else {
+					r.EncodeString(`v`)
+				}
+				z.EncWriteMapElemValue()
+				if x.VEncVerM == nil {
+					r.EncodeNil()
+				} else {
+					h.encSliceVerifiableEncryption(([]VerifiableEncryption)(x.VEncVerM), e)
+				} // end block: if x.VEncVerM slice == nil
+			}
+			if yyq2[6] {
 				z.EncWriteMapElemKey()
 				if z.IsJSONHandle() {
 					z.WriteStr("\"R\"")
@@ -1690,7 +1492,7 @@ func (x *DealingMessage) CodecEncodeSelf(e *codec1978.Encoder) {
 					h.encSlicecurve25519_SymmetricCiphertext(([]pkg1_curve25519.SymmetricCiphertext)(x.EncResM), e)
 				} // end block: if x.EncResM slice == nil
 			}
-			if yyq2[6] {
+			if yyq2[7] {
 				z.EncWriteMapElemKey()
 				if z.IsJSONHandle() {
 					z.WriteStr("\"e\"")
@@ -1704,7 +1506,7 @@ func (x *DealingMessage) CodecEncodeSelf(e *codec1978.Encoder) {
 					h.encSlicecurve25519_Ciphertext(([]pkg1_curve25519.Ciphertext)(x.EncEpsK), e)
 				} // end block: if x.EncEpsK slice == nil
 			}
-			if yyq2[7] {
+			if yyq2[8] {
 				z.EncWriteMapElemKey()
 				if z.IsJSONHandle() {
 					z.WriteStr("\"h\"")
@@ -1718,6 +1520,24 @@ func (x *DealingMessage) CodecEncodeSelf(e *codec1978.Encoder) {
 					h.encSliceSliceArray32uint8(([][][32]uint8)(x.HashEps), e)
 				} // end block: if x.HashEps slice == nil
 			}
+			if yyq2[9] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"f\"")
+				} else {
+					r.EncodeString(`f`)
+				}
+				z.EncWriteMapElemValue()
+				if yyn12 {
+					r.EncodeNil()
+				} else {
+					if yyxt34 := z.Extension(x.FeldmanShare); yyxt34 != nil {
+						z.EncExtension(x.FeldmanShare, yyxt34)
+					} else {
+						z.EncFallback(x.FeldmanShare)
+					}
+				}
+			}
 			z.EncWriteMapEnd()
 		}
 	}
@@ -1781,12 +1601,29 @@ func (x *DealingMessage) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
 			}
 		case "V":
 			h.decSlicecurve25519_Ciphertext((*[]pkg1_curve25519.Ciphertext)(&x.EncVerM), d)
+		case "v":
+			h.decSliceVerifiableEncryption((*[]VerifiableEncryption)(&x.VEncVerM), d)
 		case "R":
 			h.decSlicecurve25519_SymmetricCiphertext((*[]pkg1_curve25519.SymmetricCiphertext)(&x.EncResM), d)
 		case "e":
 			h.decSlicecurve25519_Ciphertext((*[]pkg1_curve25519.Ciphertext)(&x.EncEpsK), d)
 		case "h":
 			h.decSliceSliceArray32uint8((*[][][32]uint8)(&x.HashEps), d)
+		case "f":
+			if r.TryNil() {
+				if x.FeldmanShare != nil { // remove the if-true
+					x.FeldmanShare = nil
+				}
+			} else {
+				if x.FeldmanShare == nil {
+					x.FeldmanShare = new(pkg4_feldman.ExpShare)
+				}
+				if yyxt23 := z.Extension(x.FeldmanShare); yyxt23 != nil {
+					z.DecExtension(x.FeldmanShare, yyxt23)
+				} else {
+					z.DecFallback(x.FeldmanShare, false)
+				}
+			}
 		default:
 			z.DecStructFieldNotFound(-1, string(yys3))
 		} // end switch yys3
@@ -1797,126 +1634,163 @@ func (x *DealingMessage) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Decoder(d)
 	_, _, _ = h, z, r
-	var yyj20 int
-	var yyb20 bool
-	var yyhl20 bool = l >= 0
-	yyj20++
-	if yyhl20 {
-		yyb20 = yyj20 > l
+	var yyj24 int
+	var yyb24 bool
+	var yyhl24 bool = l >= 0
+	yyj24++
+	if yyhl24 {
+		yyb24 = yyj24 > l
 	} else {
-		yyb20 = z.DecCheckBreak()
+		yyb24 = z.DecCheckBreak()
 	}
-	if yyb20 {
+	if yyb24 {
 		z.DecReadArrayEnd()
 		return
 	}
 	z.DecReadArrayElem()
 	h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.ComC), d)
-	yyj20++
-	if yyhl20 {
-		yyb20 = yyj20 > l
+	yyj24++
+	if yyhl24 {
+		yyb24 = yyj24 > l
 	} else {
-		yyb20 = z.DecCheckBreak()
+		yyb24 = z.DecCheckBreak()
 	}
-	if yyb20 {
+	if yyb24 {
 		z.DecReadArrayEnd()
 		return
 	}
 	z.DecReadArrayElem()
 	h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.ComZ), d)
-	yyj20++
-	if yyhl20 {
-		yyb20 = yyj20 > l
+	yyj24++
+	if yyhl24 {
+		yyb24 = yyj24 > l
 	} else {
-		yyb20 = z.DecCheckBreak()
+		yyb24 = z.DecCheckBreak()
 	}
-	if yyb20 {
+	if yyb24 {
 		z.DecReadArrayEnd()
 		return
 	}
 	z.DecReadArrayElem()
 	h.decSlicecurve25519_PointXY((*[]pkg1_curve25519.PointXY)(&x.ComZPrime), d)
-	yyj20++
-	if yyhl20 {
-		yyb20 = yyj20 > l
+	yyj24++
+	if yyhl24 {
+		yyb24 = yyj24 > l
 	} else {
-		yyb20 = z.DecCheckBreak()
+		yyb24 = z.DecCheckBreak()
 	}
-	if yyb20 {
+	if yyb24 {
 		z.DecReadArrayEnd()
 		return
 	}
 	z.DecReadArrayElem()
-	if yyxt28 := z.Extension(x.DblDLEqProof); yyxt28 != nil {
-		z.DecExtension(&x.DblDLEqProof, yyxt28)
+	if yyxt32 := z.Extension(x.DblDLEqProof); yyxt32 != nil {
+		z.DecExtension(&x.DblDLEqProof, yyxt32)
 	} else {
 		x.DblDLEqProof.CodecDecodeSelf(d)
 	}
-	yyj20++
-	if yyhl20 {
-		yyb20 = yyj20 > l
+	yyj24++
+	if yyhl24 {
+		yyb24 = yyj24 > l
 	} else {
-		yyb20 = z.DecCheckBreak()
+		yyb24 = z.DecCheckBreak()
 	}
-	if yyb20 {
+	if yyb24 {
 		z.DecReadArrayEnd()
 		return
 	}
 	z.DecReadArrayElem()
 	h.decSlicecurve25519_Ciphertext((*[]pkg1_curve25519.Ciphertext)(&x.EncVerM), d)
-	yyj20++
-	if yyhl20 {
-		yyb20 = yyj20 > l
+	yyj24++
+	if yyhl24 {
+		yyb24 = yyj24 > l
 	} else {
-		yyb20 = z.DecCheckBreak()
+		yyb24 = z.DecCheckBreak()
 	}
-	if yyb20 {
+	if yyb24 {
 		z.DecReadArrayEnd()
 		return
 	}
 	z.DecReadArrayElem()
-	h.decSlicecurve25519_SymmetricCiphertext((*[]pkg1_curve25519.SymmetricCiphertext)(&x.EncResM), d)
-	yyj20++
-	if yyhl20 {
-		yyb20 = yyj20 > l
+	h.decSliceVerifiableEncryption((*[]VerifiableEncryption)(&x.VEncVerM), d)
+	yyj24++
+	if yyhl24 {
+		yyb24 = yyj24 > l
 	} else {
-		yyb20 = z.DecCheckBreak()
+		yyb24 = z.DecCheckBreak()
 	}
-	if yyb20 {
+	if yyb24 {
 		z.DecReadArrayEnd()
 		return
 	}
 	z.DecReadArrayElem()
-	h.decSlicecurve25519_Ciphertext((*[]pkg1_curve25519.Ciphertext)(&x.EncEpsK), d)
-	yyj20++
-	if yyhl20 {
-		yyb20 = yyj20 > l
+	h.decSlicecurve25519_SymmetricCiphertext((*[]pkg1_curve25519.SymmetricCiphertext)(&x.EncResM), d)
+	yyj24++
+	if yyhl24 {
+		yyb24 = yyj24 > l
+	} else {
+		yyb24 = z.DecCheckBreak()
+	}
+	if yyb24 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSlicecurve25519_Ciphertext((*[]pkg1_curve25519.Ciphertext)(&x.EncEpsK), d)
+	yyj24++
+	if yyhl24 {
+		yyb24 = yyj24 > l
 	} else {
-		yyb20 = z.DecCheckBreak()
+		yyb24 = z.DecCheckBreak()
 	}
-	if yyb20 {
+	if yyb24 {
 		z.DecReadArrayEnd()
 		return
 	}
 	z.DecReadArrayElem()
 	h.decSliceSliceArray32uint8((*[][][32]uint8)(&x.HashEps), d)
+	yyj24++
+	if yyhl24 {
+		yyb24 = yyj24 > l
+	} else {
+		yyb24 = z.DecCheckBreak()
+	}
+	if yyb24 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if r.TryNil() {
+		if x.FeldmanShare != nil { // remove the if-true
+			x.FeldmanShare = nil
+		}
+	} else {
+		if x.FeldmanShare == nil {
+			x.FeldmanShare = new(pkg4_feldman.ExpShare)
+		}
+		if yyxt44 := z.Extension(x.FeldmanShare); yyxt44 != nil {
+			z.DecExtension(x.FeldmanShare, yyxt44)
+		} else {
+			z.DecFallback(x.FeldmanShare, false)
+		}
+	}
 	for {
-		yyj20++
-		if yyhl20 {
-			yyb20 = yyj20 > l
+		yyj24++
+		if yyhl24 {
+			yyb24 = yyj24 > l
 		} else {
-			yyb20 = z.DecCheckBreak()
+			yyb24 = z.DecCheckBreak()
 		}
-		if yyb20 {
+		if yyb24 {
 			break
 		}
 		z.DecReadArrayElem()
-		z.DecStructFieldNotFound(yyj20-1, "")
+		z.DecStructFieldNotFound(yyj24-1, "")
 	}
 }
 
 func (x *DealingMessage) IsCodecEmpty() bool {
-	return !(len(x.ComC) != 0 || len(x.ComZ) != 0 || len(x.ComZPrime) != 0 || !(x.DblDLEqProof.IsCodecEmpty()) || len(x.EncVerM) != 0 || len(x.EncResM) != 0 || len(x.EncEpsK) != 0 || len(x.HashEps) != 0 || false)
+	return !(len(x.ComC) != 0 || len(x.ComZ) != 0 || len(x.ComZPrime) != 0 || !(x.DblDLEqProof.IsCodecEmpty()) || len(x.EncVerM) != 0 || len(x.VEncVerM) != 0 || len(x.EncResM) != 0 || len(x.EncEpsK) != 0 || len(x.HashEps) != 0 || false)
 }
 
 func (VerificationMJ) codecSelferViaCodecgen() {}
@@ -2604,10 +2478,30 @@ func (x *PairIJ) CodecEncodeSelf(e *codec1978.Encoder) {
 		_ = yy2arr2
 		const yyr2 bool = false // struct tag has 'toArray'
 		if yyr2 || yy2arr2 {
-			z.EncWriteArrayStart(0)
+			z.EncWriteArrayStart(2)
+			z.EncWriteArrayElem()
+			r.EncodeInt(int64(x.I))
+			z.EncWriteArrayElem()
+			r.EncodeInt(int64(x.J))
 			z.EncWriteArrayEnd()
 		} else {
-			z.EncWriteMapStart(0)
+			z.EncWriteMapStart(2)
+			z.EncWriteMapElemKey()
+			if z.IsJSONHandle() {
+				z.WriteStr("\"i\"")
+			} else {
+				r.EncodeString(`i`)
+			}
+			z.EncWriteMapElemValue()
+			r.EncodeInt(int64(x.I))
+			z.EncWriteMapElemKey()
+			if z.IsJSONHandle() {
+				z.WriteStr("\"j\"")
+			} else {
+				r.EncodeString(`j`)
+			}
+			z.EncWriteMapElemValue()
+			r.EncodeInt(int64(x.J))
 			z.EncWriteMapEnd()
 		}
 	}
@@ -2657,6 +2551,10 @@ func (x *PairIJ) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
 		yys3 := r.DecodeStringAsBytes()
 		z.DecReadMapElemValue()
 		switch string(yys3) {
+		case "i":
+			x.I = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+		case "j":
+			x.J = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
 		default:
 			z.DecStructFieldNotFound(-1, string(yys3))
 		} // end switch yys3
@@ -2667,26 +2565,50 @@ func (x *PairIJ) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Decoder(d)
 	_, _, _ = h, z, r
-	var yyj4 int
-	var yyb4 bool
-	var yyhl4 bool = l >= 0
+	var yyj6 int
+	var yyb6 bool
+	var yyhl6 bool = l >= 0
+	yyj6++
+	if yyhl6 {
+		yyb6 = yyj6 > l
+	} else {
+		yyb6 = z.DecCheckBreak()
+	}
+	if yyb6 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.I = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+	yyj6++
+	if yyhl6 {
+		yyb6 = yyj6 > l
+	} else {
+		yyb6 = z.DecCheckBreak()
+	}
+	if yyb6 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.J = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
 	for {
-		yyj4++
-		if yyhl4 {
-			yyb4 = yyj4 > l
+		yyj6++
+		if yyhl6 {
+			yyb6 = yyj6 > l
 		} else {
-			yyb4 = z.DecCheckBreak()
+			yyb6 = z.DecCheckBreak()
 		}
-		if yyb4 {
+		if yyb6 {
 			break
 		}
 		z.DecReadArrayElem()
-		z.DecStructFieldNotFound(yyj4-1, "")
+		z.DecStructFieldNotFound(yyj6-1, "")
 	}
 }
 
 func (x *PairIJ) IsCodecEmpty() bool {
-	return !(false)
+	return !(x.I != 0 || x.J != 0 || false)
 }
 
 func (ResolutionMessage) codecSelferViaCodecgen() {}
@@ -3413,146 +3335,1498 @@ func (x *VPHashEIn) IsCodecEmpty() bool {
 	return !(len(x.HashL) != 0 || false)
 }
 
-func (x codecSelfer943) encSlicecurve25519_PointXY(v []pkg1_curve25519.PointXY, e *codec1978.Encoder) {
+func (PartyState) codecSelferViaCodecgen() {}
+func (x *PartyState) CodecEncodeSelf(e *codec1978.Encoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Encoder(e)
 	_, _, _ = h, z, r
-	if v == nil {
-		r.EncodeNil()
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
 		return
 	}
-	z.EncWriteArrayStart(len(v))
-	for yyv1 := range v {
-		z.EncWriteArrayElem()
-		yy2 := &v[yyv1]
-		if yyxt3 := z.Extension(yy2); yyxt3 != nil {
-			z.EncExtension(yy2, yyxt3)
-		} else {
-			z.F.EncSliceUint8V(([]uint8)(yy2[:]), e)
-		}
-	}
-	z.EncWriteArrayEnd()
-}
-
-func (x codecSelfer943) decSlicecurve25519_PointXY(v *[]pkg1_curve25519.PointXY, d *codec1978.Decoder) {
-	var h codecSelfer943
-	z, r := codec1978.GenHelper().Decoder(d)
-	_, _, _ = h, z, r
-
-	yyv1 := *v
-	yyh1, yyl1 := z.DecSliceHelperStart()
-	var yyc1 bool
-	_ = yyc1
-	if yyh1.IsNil {
-		if yyv1 != nil {
-			yyv1 = nil
-			yyc1 = true
-		}
-	} else if yyl1 == 0 {
-		if yyv1 == nil {
-			yyv1 = []pkg1_curve25519.PointXY{}
-			yyc1 = true
-		} else if len(yyv1) != 0 {
-			yyv1 = yyv1[:0]
-			yyc1 = true
-		}
+	if x == nil {
+		r.EncodeNil()
 	} else {
-		yyhl1 := yyl1 > 0
-		var yyrl1 int
-		_ = yyrl1
-		if yyhl1 {
-			if yyl1 > cap(yyv1) {
-				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 64)
-				if yyrl1 <= cap(yyv1) {
-					yyv1 = yyv1[:yyrl1]
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyq2 = [3]bool{     // should field at this index be written?
+			x.Round != 0,         // r
+			len(x.Payload) != 0,  // p
+			len(x.Received) != 0, // m
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(3)
+			z.EncWriteArrayElem()
+			if yyq2[0] {
+				r.EncodeInt(int64(x.Round))
+			} else {
+				r.EncodeInt(0)
+			}
+			z.EncWriteArrayElem()
+			if yyq2[1] {
+				if x.Payload == nil {
+					r.EncodeNil()
 				} else {
-					yyv1 = make([]pkg1_curve25519.PointXY, yyrl1)
+					r.EncodeStringBytesRaw([]byte(x.Payload))
+				} // end block: if x.Payload slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[2] {
+				if x.Received == nil {
+					r.EncodeNil()
+				} else {
+					h.encSliceSliceSliceuint8(([][][]uint8)(x.Received), e)
+				} // end block: if x.Received slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
 				}
-				yyc1 = true
-			} else if yyl1 != len(yyv1) {
-				yyv1 = yyv1[:yyl1]
-				yyc1 = true
 			}
-		}
-		var yyj1 int
-		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
-			if yyj1 == 0 && yyv1 == nil {
-				if yyhl1 {
-					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 64)
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"r\"")
 				} else {
-					yyrl1 = 8
+					r.EncodeString(`r`)
 				}
-				yyv1 = make([]pkg1_curve25519.PointXY, yyrl1)
-				yyc1 = true
+				z.EncWriteMapElemValue()
+				r.EncodeInt(int64(x.Round))
 			}
-			yyh1.ElemContainerState(yyj1)
-			var yydb1 bool
-			if yyj1 >= len(yyv1) {
-				yyv1 = append(yyv1, pkg1_curve25519.PointXY{})
-				yyc1 = true
+			if yyq2[1] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"p\"")
+				} else {
+					r.EncodeString(`p`)
+				}
+				z.EncWriteMapElemValue()
+				if x.Payload == nil {
+					r.EncodeNil()
+				} else {
+					r.EncodeStringBytesRaw([]byte(x.Payload))
+				} // end block: if x.Payload slice == nil
 			}
-			if yydb1 {
-				z.DecSwallow()
-			} else {
-				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
-					z.DecExtension(&yyv1[yyj1], yyxt3)
+			if yyq2[2] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"m\"")
 				} else {
-					z.F.DecSliceUint8N(([]uint8)(yyv1[yyj1][:]), d)
+					r.EncodeString(`m`)
 				}
+				z.EncWriteMapElemValue()
+				if x.Received == nil {
+					r.EncodeNil()
+				} else {
+					h.encSliceSliceSliceuint8(([][][]uint8)(x.Received), e)
+				} // end block: if x.Received slice == nil
 			}
+			z.EncWriteMapEnd()
 		}
-		if yyj1 < len(yyv1) {
-			yyv1 = yyv1[:yyj1]
-			yyc1 = true
-		} else if yyj1 == 0 && yyv1 == nil {
-			yyv1 = make([]pkg1_curve25519.PointXY, 0)
-			yyc1 = true
-		}
-	}
-	yyh1.End()
-	if yyc1 {
-		*v = yyv1
 	}
 }
 
-func (x codecSelfer943) encSlicecurve25519_Scalar(v []pkg1_curve25519.Scalar, e *codec1978.Encoder) {
+func (x *PartyState) CodecDecodeSelf(d *codec1978.Decoder) {
 	var h codecSelfer943
-	z, r := codec1978.GenHelper().Encoder(e)
+	z, r := codec1978.GenHelper().Decoder(d)
 	_, _, _ = h, z, r
-	if v == nil {
-		r.EncodeNil()
-		return
-	}
-	z.EncWriteArrayStart(len(v))
-	for yyv1 := range v {
-		z.EncWriteArrayElem()
-		yy2 := &v[yyv1]
-		if yyxt3 := z.Extension(yy2); yyxt3 != nil {
-			z.EncExtension(yy2, yyxt3)
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = PartyState{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
 		} else {
-			z.F.EncSliceUint8V(([]uint8)(yy2[:]), e)
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
 		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
 	}
-	z.EncWriteArrayEnd()
 }
 
-func (x codecSelfer943) decSlicecurve25519_Scalar(v *[]pkg1_curve25519.Scalar, d *codec1978.Decoder) {
+func (x *PartyState) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Decoder(d)
 	_, _, _ = h, z, r
-
-	yyv1 := *v
-	yyh1, yyl1 := z.DecSliceHelperStart()
-	var yyc1 bool
-	_ = yyc1
-	if yyh1.IsNil {
-		if yyv1 != nil {
-			yyv1 = nil
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "r":
+			x.Round = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+		case "p":
+			x.Payload = z.DecodeBytesInto(([]byte)(x.Payload))
+		case "m":
+			h.decSliceSliceSliceuint8((*[][][]uint8)(&x.Received), d)
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *PartyState) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj9 int
+	var yyb9 bool
+	var yyhl9 bool = l >= 0
+	yyj9++
+	if yyhl9 {
+		yyb9 = yyj9 > l
+	} else {
+		yyb9 = z.DecCheckBreak()
+	}
+	if yyb9 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.Round = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+	yyj9++
+	if yyhl9 {
+		yyb9 = yyj9 > l
+	} else {
+		yyb9 = z.DecCheckBreak()
+	}
+	if yyb9 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.Payload = z.DecodeBytesInto(([]byte)(x.Payload))
+	yyj9++
+	if yyhl9 {
+		yyb9 = yyj9 > l
+	} else {
+		yyb9 = z.DecCheckBreak()
+	}
+	if yyb9 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSliceSliceSliceuint8((*[][][]uint8)(&x.Received), d)
+	for {
+		yyj9++
+		if yyhl9 {
+			yyb9 = yyj9 > l
+		} else {
+			yyb9 = z.DecCheckBreak()
+		}
+		if yyb9 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj9-1, "")
+	}
+}
+
+func (x *PartyState) IsCodecEmpty() bool {
+	return !(x.Round != 0 || len(x.Payload) != 0 || len(x.Received) != 0 || false)
+}
+
+func (BlameReason) codecSelferViaCodecgen() {}
+func (x BlameReason) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	r.EncodeInt(int64(x))
+}
+
+func (x *BlameReason) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	*x = (BlameReason)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+}
+
+func (DealerBlame) codecSelferViaCodecgen() {}
+func (x *DealerBlame) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
+		return
+	}
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyq2 = [5]bool{     // should field at this index be written?
+			x.Dealer != 0,         // i
+			x.Reason != 0,         // r
+			x.Detail != "",        // d
+			x.Verifier != 0,       // j
+			len(x.EpsShares) != 0, // e
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(5)
+			z.EncWriteArrayElem()
+			if yyq2[0] {
+				r.EncodeInt(int64(x.Dealer))
+			} else {
+				r.EncodeInt(0)
+			}
+			z.EncWriteArrayElem()
+			if yyq2[1] {
+				if yyxt9 := z.Extension(x.Reason); yyxt9 != nil {
+					z.EncExtension(x.Reason, yyxt9)
+				} else {
+					x.Reason.CodecEncodeSelf(e)
+				}
+			} else {
+				r.EncodeInt(0)
+			}
+			z.EncWriteArrayElem()
+			if yyq2[2] {
+				r.EncodeString(string(x.Detail))
+			} else {
+				r.EncodeString("")
+			}
+			z.EncWriteArrayElem()
+			if yyq2[3] {
+				r.EncodeInt(int64(x.Verifier))
+			} else {
+				r.EncodeInt(0)
+			}
+			z.EncWriteArrayElem()
+			if yyq2[4] {
+				if x.EpsShares == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicePtrtocurve25519_Scalar(([]*pkg1_curve25519.Scalar)(x.EpsShares), e)
+				} // end block: if x.EpsShares slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
+				}
+			}
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"i\"")
+				} else {
+					r.EncodeString(`i`)
+				}
+				z.EncWriteMapElemValue()
+				r.EncodeInt(int64(x.Dealer))
+			}
+			if yyq2[1] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"r\"")
+				} else {
+					r.EncodeString(`r`)
+				}
+				z.EncWriteMapElemValue()
+				if yyxt14 := z.Extension(x.Reason); yyxt14 != nil {
+					z.EncExtension(x.Reason, yyxt14)
+				} else {
+					x.Reason.CodecEncodeSelf(e)
+				}
+			}
+			if yyq2[2] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"d\"")
+				} else {
+					r.EncodeString(`d`)
+				}
+				z.EncWriteMapElemValue()
+				r.EncodeString(string(x.Detail))
+			}
+			if yyq2[3] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"j\"")
+				} else {
+					r.EncodeString(`j`)
+				}
+				z.EncWriteMapElemValue()
+				r.EncodeInt(int64(x.Verifier))
+			}
+			if yyq2[4] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"e\"")
+				} else {
+					r.EncodeString(`e`)
+				}
+				z.EncWriteMapElemValue()
+				if x.EpsShares == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicePtrtocurve25519_Scalar(([]*pkg1_curve25519.Scalar)(x.EpsShares), e)
+				} // end block: if x.EpsShares slice == nil
+			}
+			z.EncWriteMapEnd()
+		}
+	}
+}
+
+func (x *DealerBlame) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = DealerBlame{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
+		} else {
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
+		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
+	}
+}
+
+func (x *DealerBlame) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "i":
+			x.Dealer = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+		case "r":
+			if yyxt6 := z.Extension(x.Reason); yyxt6 != nil {
+				z.DecExtension(&x.Reason, yyxt6)
+			} else {
+				x.Reason.CodecDecodeSelf(d)
+			}
+		case "d":
+			x.Detail = (string)(z.DecStringZC(r.DecodeStringAsBytes()))
+		case "j":
+			x.Verifier = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+		case "e":
+			h.decSlicePtrtocurve25519_Scalar((*[]*pkg1_curve25519.Scalar)(&x.EpsShares), d)
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *DealerBlame) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj11 int
+	var yyb11 bool
+	var yyhl11 bool = l >= 0
+	yyj11++
+	if yyhl11 {
+		yyb11 = yyj11 > l
+	} else {
+		yyb11 = z.DecCheckBreak()
+	}
+	if yyb11 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.Dealer = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+	yyj11++
+	if yyhl11 {
+		yyb11 = yyj11 > l
+	} else {
+		yyb11 = z.DecCheckBreak()
+	}
+	if yyb11 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if yyxt14 := z.Extension(x.Reason); yyxt14 != nil {
+		z.DecExtension(&x.Reason, yyxt14)
+	} else {
+		x.Reason.CodecDecodeSelf(d)
+	}
+	yyj11++
+	if yyhl11 {
+		yyb11 = yyj11 > l
+	} else {
+		yyb11 = z.DecCheckBreak()
+	}
+	if yyb11 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.Detail = (string)(z.DecStringZC(r.DecodeStringAsBytes()))
+	yyj11++
+	if yyhl11 {
+		yyb11 = yyj11 > l
+	} else {
+		yyb11 = z.DecCheckBreak()
+	}
+	if yyb11 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.Verifier = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+	yyj11++
+	if yyhl11 {
+		yyb11 = yyj11 > l
+	} else {
+		yyb11 = z.DecCheckBreak()
+	}
+	if yyb11 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSlicePtrtocurve25519_Scalar((*[]*pkg1_curve25519.Scalar)(&x.EpsShares), d)
+	for {
+		yyj11++
+		if yyhl11 {
+			yyb11 = yyj11 > l
+		} else {
+			yyb11 = z.DecCheckBreak()
+		}
+		if yyb11 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj11-1, "")
+	}
+}
+
+func (x *DealerBlame) IsCodecEmpty() bool {
+	return !(x.Dealer != 0 || x.Reason != 0 || x.Detail != "" || x.Verifier != 0 || len(x.EpsShares) != 0 || false)
+}
+
+func (VerifierBlame) codecSelferViaCodecgen() {}
+func (x *VerifierBlame) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
+		return
+	}
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyn7 bool = x.Shares == nil
+		var yyq2 = [5]bool{ // should field at this index be written?
+			x.Verifier != 0,   // j
+			x.Reason != 0,     // r
+			x.Detail != "",    // d
+			x.NextHolder != 0, // l
+			x.Shares != nil,   // s
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(5)
+			z.EncWriteArrayElem()
+			if yyq2[0] {
+				r.EncodeInt(int64(x.Verifier))
+			} else {
+				r.EncodeInt(0)
+			}
+			z.EncWriteArrayElem()
+			if yyq2[1] {
+				if yyxt9 := z.Extension(x.Reason); yyxt9 != nil {
+					z.EncExtension(x.Reason, yyxt9)
+				} else {
+					x.Reason.CodecEncodeSelf(e)
+				}
+			} else {
+				r.EncodeInt(0)
+			}
+			z.EncWriteArrayElem()
+			if yyq2[2] {
+				r.EncodeString(string(x.Detail))
+			} else {
+				r.EncodeString("")
+			}
+			z.EncWriteArrayElem()
+			if yyq2[3] {
+				r.EncodeInt(int64(x.NextHolder))
+			} else {
+				r.EncodeInt(0)
+			}
+			if yyn7 {
+				z.EncWriteArrayElem()
+				r.EncodeNil()
+			} else {
+				z.EncWriteArrayElem()
+				if yyq2[4] {
+					if yyxt12 := z.Extension(x.Shares); yyxt12 != nil {
+						z.EncExtension(x.Shares, yyxt12)
+					} else {
+						x.Shares.CodecEncodeSelf(e)
+					}
+				} else {
+					r.EncodeNil()
+				}
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
+				}
+			}
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"j\"")
+				} else {
+					r.EncodeString(`j`)
+				}
+				z.EncWriteMapElemValue()
+				r.EncodeInt(int64(x.Verifier))
+			}
+			if yyq2[1] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"r\"")
+				} else {
+					r.EncodeString(`r`)
+				}
+				z.EncWriteMapElemValue()
+				if yyxt14 := z.Extension(x.Reason); yyxt14 != nil {
+					z.EncExtension(x.Reason, yyxt14)
+				} else {
+					x.Reason.CodecEncodeSelf(e)
+				}
+			}
+			if yyq2[2] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"d\"")
+				} else {
+					r.EncodeString(`d`)
+				}
+				z.EncWriteMapElemValue()
+				r.EncodeString(string(x.Detail))
+			}
+			if yyq2[3] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"l\"")
+				} else {
+					r.EncodeString(`l`)
+				}
+				z.EncWriteMapElemValue()
+				r.EncodeInt(int64(x.NextHolder))
+			}
+			if yyq2[4] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"s\"")
+				} else {
+					r.EncodeString(`s`)
+				}
+				z.EncWriteMapElemValue()
+				if yyn7 {
+					r.EncodeNil()
+				} else {
+					if yyxt17 := z.Extension(x.Shares); yyxt17 != nil {
+						z.EncExtension(x.Shares, yyxt17)
+					} else {
+						x.Shares.CodecEncodeSelf(e)
+					}
+				}
+			}
+			z.EncWriteMapEnd()
+		}
+	}
+}
+
+func (x *VerifierBlame) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = VerifierBlame{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
+		} else {
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
+		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
+	}
+}
+
+func (x *VerifierBlame) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "j":
+			x.Verifier = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+		case "r":
+			if yyxt6 := z.Extension(x.Reason); yyxt6 != nil {
+				z.DecExtension(&x.Reason, yyxt6)
+			} else {
+				x.Reason.CodecDecodeSelf(d)
+			}
+		case "d":
+			x.Detail = (string)(z.DecStringZC(r.DecodeStringAsBytes()))
+		case "l":
+			x.NextHolder = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+		case "s":
+			if r.TryNil() {
+				if x.Shares != nil { // remove the if-true
+					x.Shares = nil
+				}
+			} else {
+				if x.Shares == nil {
+					x.Shares = new(VerSentShares)
+				}
+				if yyxt10 := z.Extension(x.Shares); yyxt10 != nil {
+					z.DecExtension(x.Shares, yyxt10)
+				} else {
+					x.Shares.CodecDecodeSelf(d)
+				}
+			}
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *VerifierBlame) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj11 int
+	var yyb11 bool
+	var yyhl11 bool = l >= 0
+	yyj11++
+	if yyhl11 {
+		yyb11 = yyj11 > l
+	} else {
+		yyb11 = z.DecCheckBreak()
+	}
+	if yyb11 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.Verifier = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+	yyj11++
+	if yyhl11 {
+		yyb11 = yyj11 > l
+	} else {
+		yyb11 = z.DecCheckBreak()
+	}
+	if yyb11 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if yyxt14 := z.Extension(x.Reason); yyxt14 != nil {
+		z.DecExtension(&x.Reason, yyxt14)
+	} else {
+		x.Reason.CodecDecodeSelf(d)
+	}
+	yyj11++
+	if yyhl11 {
+		yyb11 = yyj11 > l
+	} else {
+		yyb11 = z.DecCheckBreak()
+	}
+	if yyb11 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.Detail = (string)(z.DecStringZC(r.DecodeStringAsBytes()))
+	yyj11++
+	if yyhl11 {
+		yyb11 = yyj11 > l
+	} else {
+		yyb11 = z.DecCheckBreak()
+	}
+	if yyb11 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	x.NextHolder = (int)(z.C.IntV(r.DecodeInt64(), codecSelferBitsize943))
+	yyj11++
+	if yyhl11 {
+		yyb11 = yyj11 > l
+	} else {
+		yyb11 = z.DecCheckBreak()
+	}
+	if yyb11 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if r.TryNil() {
+		if x.Shares != nil { // remove the if-true
+			x.Shares = nil
+		}
+	} else {
+		if x.Shares == nil {
+			x.Shares = new(VerSentShares)
+		}
+		if yyxt18 := z.Extension(x.Shares); yyxt18 != nil {
+			z.DecExtension(x.Shares, yyxt18)
+		} else {
+			x.Shares.CodecDecodeSelf(d)
+		}
+	}
+	for {
+		yyj11++
+		if yyhl11 {
+			yyb11 = yyj11 > l
+		} else {
+			yyb11 = z.DecCheckBreak()
+		}
+		if yyb11 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj11-1, "")
+	}
+}
+
+func (x *VerifierBlame) IsCodecEmpty() bool {
+	return !(x.Verifier != 0 || x.Reason != 0 || x.Detail != "" || x.NextHolder != 0 || false)
+}
+
+func (BlameReport) codecSelferViaCodecgen() {}
+func (x *BlameReport) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
+		return
+	}
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyq2 = [2]bool{     // should field at this index be written?
+			len(x.Dealers) != 0,   // D
+			len(x.Verifiers) != 0, // V
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(2)
+			z.EncWriteArrayElem()
+			if yyq2[0] {
+				if x.Dealers == nil {
+					r.EncodeNil()
+				} else {
+					h.encSliceDealerBlame(([]DealerBlame)(x.Dealers), e)
+				} // end block: if x.Dealers slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[1] {
+				if x.Verifiers == nil {
+					r.EncodeNil()
+				} else {
+					h.encSliceVerifierBlame(([]VerifierBlame)(x.Verifiers), e)
+				} // end block: if x.Verifiers slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
+				}
+			}
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"D\"")
+				} else {
+					r.EncodeString(`D`)
+				}
+				z.EncWriteMapElemValue()
+				if x.Dealers == nil {
+					r.EncodeNil()
+				} else {
+					h.encSliceDealerBlame(([]DealerBlame)(x.Dealers), e)
+				} // end block: if x.Dealers slice == nil
+			}
+			if yyq2[1] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"V\"")
+				} else {
+					r.EncodeString(`V`)
+				}
+				z.EncWriteMapElemValue()
+				if x.Verifiers == nil {
+					r.EncodeNil()
+				} else {
+					h.encSliceVerifierBlame(([]VerifierBlame)(x.Verifiers), e)
+				} // end block: if x.Verifiers slice == nil
+			}
+			z.EncWriteMapEnd()
+		}
+	}
+}
+
+func (x *BlameReport) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = BlameReport{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
+		} else {
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
+		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
+	}
+}
+
+func (x *BlameReport) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "D":
+			h.decSliceDealerBlame((*[]DealerBlame)(&x.Dealers), d)
+		case "V":
+			h.decSliceVerifierBlame((*[]VerifierBlame)(&x.Verifiers), d)
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *BlameReport) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj8 int
+	var yyb8 bool
+	var yyhl8 bool = l >= 0
+	yyj8++
+	if yyhl8 {
+		yyb8 = yyj8 > l
+	} else {
+		yyb8 = z.DecCheckBreak()
+	}
+	if yyb8 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSliceDealerBlame((*[]DealerBlame)(&x.Dealers), d)
+	yyj8++
+	if yyhl8 {
+		yyb8 = yyj8 > l
+	} else {
+		yyb8 = z.DecCheckBreak()
+	}
+	if yyb8 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSliceVerifierBlame((*[]VerifierBlame)(&x.Verifiers), d)
+	for {
+		yyj8++
+		if yyhl8 {
+			yyb8 = yyj8 > l
+		} else {
+			yyb8 = z.DecCheckBreak()
+		}
+		if yyb8 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj8-1, "")
+	}
+}
+
+func (x *BlameReport) IsCodecEmpty() bool {
+	return !(len(x.Dealers) != 0 || len(x.Verifiers) != 0 || false)
+}
+
+func (x codecSelfer943) encSlicecurve25519_PointXY(v []pkg1_curve25519.PointXY, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	z.EncWriteArrayStart(len(v))
+	for yyv1 := range v {
+		z.EncWriteArrayElem()
+		yy2 := &v[yyv1]
+		if yyxt3 := z.Extension(yy2); yyxt3 != nil {
+			z.EncExtension(yy2, yyxt3)
+		} else {
+			z.F.EncSliceUint8V(([]uint8)(yy2[:]), e)
+		}
+	}
+	z.EncWriteArrayEnd()
+}
+
+func (x codecSelfer943) decSlicecurve25519_PointXY(v *[]pkg1_curve25519.PointXY, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyh1.IsNil {
+		if yyv1 != nil {
+			yyv1 = nil
+			yyc1 = true
+		}
+	} else if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = []pkg1_curve25519.PointXY{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else {
+		yyhl1 := yyl1 > 0
+		var yyrl1 int
+		_ = yyrl1
+		if yyhl1 {
+			if yyl1 > cap(yyv1) {
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 64)
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
+				} else {
+					yyv1 = make([]pkg1_curve25519.PointXY, yyrl1)
+				}
+				yyc1 = true
+			} else if yyl1 != len(yyv1) {
+				yyv1 = yyv1[:yyl1]
+				yyc1 = true
+			}
+		}
+		var yyj1 int
+		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
+			if yyj1 == 0 && yyv1 == nil {
+				if yyhl1 {
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 64)
+				} else {
+					yyrl1 = 8
+				}
+				yyv1 = make([]pkg1_curve25519.PointXY, yyrl1)
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			var yydb1 bool
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, pkg1_curve25519.PointXY{})
+				yyc1 = true
+			}
+			if yydb1 {
+				z.DecSwallow()
+			} else {
+				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
+					z.DecExtension(&yyv1[yyj1], yyxt3)
+				} else {
+					z.F.DecSliceUint8N(([]uint8)(yyv1[yyj1][:]), d)
+				}
+			}
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = make([]pkg1_curve25519.PointXY, 0)
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
+
+func (x codecSelfer943) encSlicecurve25519_Scalar(v []pkg1_curve25519.Scalar, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	z.EncWriteArrayStart(len(v))
+	for yyv1 := range v {
+		z.EncWriteArrayElem()
+		yy2 := &v[yyv1]
+		if yyxt3 := z.Extension(yy2); yyxt3 != nil {
+			z.EncExtension(yy2, yyxt3)
+		} else {
+			z.F.EncSliceUint8V(([]uint8)(yy2[:]), e)
+		}
+	}
+	z.EncWriteArrayEnd()
+}
+
+func (x codecSelfer943) decSlicecurve25519_Scalar(v *[]pkg1_curve25519.Scalar, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyh1.IsNil {
+		if yyv1 != nil {
+			yyv1 = nil
+			yyc1 = true
+		}
+	} else if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = []pkg1_curve25519.Scalar{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else {
+		yyhl1 := yyl1 > 0
+		var yyrl1 int
+		_ = yyrl1
+		if yyhl1 {
+			if yyl1 > cap(yyv1) {
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 32)
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
+				} else {
+					yyv1 = make([]pkg1_curve25519.Scalar, yyrl1)
+				}
+				yyc1 = true
+			} else if yyl1 != len(yyv1) {
+				yyv1 = yyv1[:yyl1]
+				yyc1 = true
+			}
+		}
+		var yyj1 int
+		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
+			if yyj1 == 0 && yyv1 == nil {
+				if yyhl1 {
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 32)
+				} else {
+					yyrl1 = 8
+				}
+				yyv1 = make([]pkg1_curve25519.Scalar, yyrl1)
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			var yydb1 bool
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, pkg1_curve25519.Scalar{})
+				yyc1 = true
+			}
+			if yydb1 {
+				z.DecSwallow()
+			} else {
+				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
+					z.DecExtension(&yyv1[yyj1], yyxt3)
+				} else {
+					z.F.DecSliceUint8N(([]uint8)(yyv1[yyj1][:]), d)
+				}
+			}
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = make([]pkg1_curve25519.Scalar, 0)
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
+
+func (x codecSelfer943) encSlicecurve25519_Ciphertext(v []pkg1_curve25519.Ciphertext, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	z.EncWriteArrayStart(len(v))
+	for yyv1 := range v {
+		z.EncWriteArrayElem()
+		if yyxt2 := z.Extension(v[yyv1]); yyxt2 != nil {
+			z.EncExtension(v[yyv1], yyxt2)
+		} else {
+			if v[yyv1] == nil {
+				r.EncodeNil()
+			} else {
+				z.F.EncSliceUint8V(([]uint8)(v[yyv1]), e)
+			} // end block: if v[yyv1] slice == nil
+		}
+	}
+	z.EncWriteArrayEnd()
+}
+
+func (x codecSelfer943) decSlicecurve25519_Ciphertext(v *[]pkg1_curve25519.Ciphertext, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyh1.IsNil {
+		if yyv1 != nil {
+			yyv1 = nil
+			yyc1 = true
+		}
+	} else if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = []pkg1_curve25519.Ciphertext{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else {
+		yyhl1 := yyl1 > 0
+		var yyrl1 int
+		_ = yyrl1
+		if yyhl1 {
+			if yyl1 > cap(yyv1) {
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 24)
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
+				} else {
+					yyv1 = make([]pkg1_curve25519.Ciphertext, yyrl1)
+				}
+				yyc1 = true
+			} else if yyl1 != len(yyv1) {
+				yyv1 = yyv1[:yyl1]
+				yyc1 = true
+			}
+		}
+		var yyj1 int
+		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
+			if yyj1 == 0 && yyv1 == nil {
+				if yyhl1 {
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 24)
+				} else {
+					yyrl1 = 8
+				}
+				yyv1 = make([]pkg1_curve25519.Ciphertext, yyrl1)
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			var yydb1 bool
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, nil)
+				yyc1 = true
+			}
+			if yydb1 {
+				z.DecSwallow()
+			} else {
+				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
+					z.DecExtension(&yyv1[yyj1], yyxt3)
+				} else {
+					z.F.DecSliceUint8X((*[]uint8)(&yyv1[yyj1]), d)
+				}
+			}
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = make([]pkg1_curve25519.Ciphertext, 0)
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
+
+func (x codecSelfer943) encSliceVerifiableEncryption(v []VerifiableEncryption, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	z.EncWriteArrayStart(len(v))
+	for yyv1 := range v {
+		z.EncWriteArrayElem()
+		yy2 := &v[yyv1]
+		if yyxt3 := z.Extension(yy2); yyxt3 != nil {
+			z.EncExtension(yy2, yyxt3)
+		} else {
+			z.EncFallback(yy2)
+		}
+	}
+	z.EncWriteArrayEnd()
+}
+
+func (x codecSelfer943) decSliceVerifiableEncryption(v *[]VerifiableEncryption, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyh1.IsNil {
+		if yyv1 != nil {
+			yyv1 = nil
+			yyc1 = true
+		}
+	} else if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = []VerifiableEncryption{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else {
+		yyhl1 := yyl1 > 0
+		var yyrl1 int
+		_ = yyrl1
+		if yyhl1 {
+			if yyl1 > cap(yyv1) {
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 104)
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
+				} else {
+					yyv1 = make([]VerifiableEncryption, yyrl1)
+				}
+				yyc1 = true
+			} else if yyl1 != len(yyv1) {
+				yyv1 = yyv1[:yyl1]
+				yyc1 = true
+			}
+		}
+		var yyj1 int
+		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
+			if yyj1 == 0 && yyv1 == nil {
+				if yyhl1 {
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 104)
+				} else {
+					yyrl1 = 8
+				}
+				yyv1 = make([]VerifiableEncryption, yyrl1)
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			var yydb1 bool
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, VerifiableEncryption{})
+				yyc1 = true
+			}
+			if yydb1 {
+				z.DecSwallow()
+			} else {
+				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
+					z.DecExtension(&yyv1[yyj1], yyxt3)
+				} else {
+					z.DecFallback(&yyv1[yyj1], false)
+				}
+			}
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = make([]VerifiableEncryption, 0)
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
+
+func (x codecSelfer943) encSlicecurve25519_SymmetricCiphertext(v []pkg1_curve25519.SymmetricCiphertext, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	z.EncWriteArrayStart(len(v))
+	for yyv1 := range v {
+		z.EncWriteArrayElem()
+		if yyxt2 := z.Extension(v[yyv1]); yyxt2 != nil {
+			z.EncExtension(v[yyv1], yyxt2)
+		} else {
+			if v[yyv1] == nil {
+				r.EncodeNil()
+			} else {
+				z.F.EncSliceUint8V(([]uint8)(v[yyv1]), e)
+			} // end block: if v[yyv1] slice == nil
+		}
+	}
+	z.EncWriteArrayEnd()
+}
+
+func (x codecSelfer943) decSlicecurve25519_SymmetricCiphertext(v *[]pkg1_curve25519.SymmetricCiphertext, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyh1.IsNil {
+		if yyv1 != nil {
+			yyv1 = nil
 			yyc1 = true
 		}
 	} else if yyl1 == 0 {
 		if yyv1 == nil {
-			yyv1 = []pkg1_curve25519.Scalar{}
+			yyv1 = []pkg1_curve25519.SymmetricCiphertext{}
 			yyc1 = true
 		} else if len(yyv1) != 0 {
 			yyv1 = yyv1[:0]
@@ -3564,11 +4838,11 @@ func (x codecSelfer943) decSlicecurve25519_Scalar(v *[]pkg1_curve25519.Scalar, d
 		_ = yyrl1
 		if yyhl1 {
 			if yyl1 > cap(yyv1) {
-				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 32)
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 24)
 				if yyrl1 <= cap(yyv1) {
 					yyv1 = yyv1[:yyrl1]
 				} else {
-					yyv1 = make([]pkg1_curve25519.Scalar, yyrl1)
+					yyv1 = make([]pkg1_curve25519.SymmetricCiphertext, yyrl1)
 				}
 				yyc1 = true
 			} else if yyl1 != len(yyv1) {
@@ -3580,17 +4854,17 @@ func (x codecSelfer943) decSlicecurve25519_Scalar(v *[]pkg1_curve25519.Scalar, d
 		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
 			if yyj1 == 0 && yyv1 == nil {
 				if yyhl1 {
-					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 32)
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 24)
 				} else {
 					yyrl1 = 8
 				}
-				yyv1 = make([]pkg1_curve25519.Scalar, yyrl1)
+				yyv1 = make([]pkg1_curve25519.SymmetricCiphertext, yyrl1)
 				yyc1 = true
 			}
 			yyh1.ElemContainerState(yyj1)
 			var yydb1 bool
 			if yyj1 >= len(yyv1) {
-				yyv1 = append(yyv1, pkg1_curve25519.Scalar{})
+				yyv1 = append(yyv1, nil)
 				yyc1 = true
 			}
 			if yydb1 {
@@ -3599,7 +4873,7 @@ func (x codecSelfer943) decSlicecurve25519_Scalar(v *[]pkg1_curve25519.Scalar, d
 				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
 					z.DecExtension(&yyv1[yyj1], yyxt3)
 				} else {
-					z.F.DecSliceUint8N(([]uint8)(yyv1[yyj1][:]), d)
+					z.F.DecSliceUint8X((*[]uint8)(&yyv1[yyj1]), d)
 				}
 			}
 		}
@@ -3607,7 +4881,7 @@ func (x codecSelfer943) decSlicecurve25519_Scalar(v *[]pkg1_curve25519.Scalar, d
 			yyv1 = yyv1[:yyj1]
 			yyc1 = true
 		} else if yyj1 == 0 && yyv1 == nil {
-			yyv1 = make([]pkg1_curve25519.Scalar, 0)
+			yyv1 = make([]pkg1_curve25519.SymmetricCiphertext, 0)
 			yyc1 = true
 		}
 	}
@@ -3617,7 +4891,7 @@ func (x codecSelfer943) decSlicecurve25519_Scalar(v *[]pkg1_curve25519.Scalar, d
 	}
 }
 
-func (x codecSelfer943) encSlicecurve25519_Ciphertext(v []pkg1_curve25519.Ciphertext, e *codec1978.Encoder) {
+func (x codecSelfer943) encSliceSliceArray32uint8(v [][][32]uint8, e *codec1978.Encoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Encoder(e)
 	_, _, _ = h, z, r
@@ -3628,20 +4902,16 @@ func (x codecSelfer943) encSlicecurve25519_Ciphertext(v []pkg1_curve25519.Cipher
 	z.EncWriteArrayStart(len(v))
 	for yyv1 := range v {
 		z.EncWriteArrayElem()
-		if yyxt2 := z.Extension(v[yyv1]); yyxt2 != nil {
-			z.EncExtension(v[yyv1], yyxt2)
+		if v[yyv1] == nil {
+			r.EncodeNil()
 		} else {
-			if v[yyv1] == nil {
-				r.EncodeNil()
-			} else {
-				z.F.EncSliceUint8V(([]uint8)(v[yyv1]), e)
-			} // end block: if v[yyv1] slice == nil
-		}
+			h.encSliceArray32uint8(([][32]uint8)(v[yyv1]), e)
+		} // end block: if v[yyv1] slice == nil
 	}
 	z.EncWriteArrayEnd()
 }
 
-func (x codecSelfer943) decSlicecurve25519_Ciphertext(v *[]pkg1_curve25519.Ciphertext, d *codec1978.Decoder) {
+func (x codecSelfer943) decSliceSliceArray32uint8(v *[][][32]uint8, d *codec1978.Decoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Decoder(d)
 	_, _, _ = h, z, r
@@ -3657,7 +4927,7 @@ func (x codecSelfer943) decSlicecurve25519_Ciphertext(v *[]pkg1_curve25519.Ciphe
 		}
 	} else if yyl1 == 0 {
 		if yyv1 == nil {
-			yyv1 = []pkg1_curve25519.Ciphertext{}
+			yyv1 = [][][32]uint8{}
 			yyc1 = true
 		} else if len(yyv1) != 0 {
 			yyv1 = yyv1[:0]
@@ -3673,7 +4943,7 @@ func (x codecSelfer943) decSlicecurve25519_Ciphertext(v *[]pkg1_curve25519.Ciphe
 				if yyrl1 <= cap(yyv1) {
 					yyv1 = yyv1[:yyrl1]
 				} else {
-					yyv1 = make([]pkg1_curve25519.Ciphertext, yyrl1)
+					yyv1 = make([][][32]uint8, yyrl1)
 				}
 				yyc1 = true
 			} else if yyl1 != len(yyv1) {
@@ -3689,7 +4959,7 @@ func (x codecSelfer943) decSlicecurve25519_Ciphertext(v *[]pkg1_curve25519.Ciphe
 				} else {
 					yyrl1 = 8
 				}
-				yyv1 = make([]pkg1_curve25519.Ciphertext, yyrl1)
+				yyv1 = make([][][32]uint8, yyrl1)
 				yyc1 = true
 			}
 			yyh1.ElemContainerState(yyj1)
@@ -3701,18 +4971,108 @@ func (x codecSelfer943) decSlicecurve25519_Ciphertext(v *[]pkg1_curve25519.Ciphe
 			if yydb1 {
 				z.DecSwallow()
 			} else {
-				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
-					z.DecExtension(&yyv1[yyj1], yyxt3)
+				h.decSliceArray32uint8((*[][32]uint8)(&yyv1[yyj1]), d)
+			}
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = make([][][32]uint8, 0)
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
+
+func (x codecSelfer943) encSliceArray32uint8(v [][32]uint8, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	z.EncWriteArrayStart(len(v))
+	for yyv1 := range v {
+		z.EncWriteArrayElem()
+		yy2 := &v[yyv1]
+		h.encArray32uint8((*[32]uint8)(yy2), e)
+	}
+	z.EncWriteArrayEnd()
+}
+
+func (x codecSelfer943) decSliceArray32uint8(v *[][32]uint8, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyh1.IsNil {
+		if yyv1 != nil {
+			yyv1 = nil
+			yyc1 = true
+		}
+	} else if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = [][32]uint8{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else {
+		yyhl1 := yyl1 > 0
+		var yyrl1 int
+		_ = yyrl1
+		if yyhl1 {
+			if yyl1 > cap(yyv1) {
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 32)
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
 				} else {
-					z.F.DecSliceUint8X((*[]uint8)(&yyv1[yyj1]), d)
+					yyv1 = make([][32]uint8, yyrl1)
+				}
+				yyc1 = true
+			} else if yyl1 != len(yyv1) {
+				yyv1 = yyv1[:yyl1]
+				yyc1 = true
+			}
+		}
+		var yyj1 int
+		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
+			if yyj1 == 0 && yyv1 == nil {
+				if yyhl1 {
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 32)
+				} else {
+					yyrl1 = 8
 				}
+				yyv1 = make([][32]uint8, yyrl1)
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			var yydb1 bool
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, [32]uint8{})
+				yyc1 = true
+			}
+			if yydb1 {
+				z.DecSwallow()
+			} else {
+				h.decArray32uint8((*[32]uint8)(&yyv1[yyj1]), d)
 			}
 		}
 		if yyj1 < len(yyv1) {
 			yyv1 = yyv1[:yyj1]
 			yyc1 = true
 		} else if yyj1 == 0 && yyv1 == nil {
-			yyv1 = make([]pkg1_curve25519.Ciphertext, 0)
+			yyv1 = make([][32]uint8, 0)
 			yyc1 = true
 		}
 	}
@@ -3722,7 +5082,25 @@ func (x codecSelfer943) decSlicecurve25519_Ciphertext(v *[]pkg1_curve25519.Ciphe
 	}
 }
 
-func (x codecSelfer943) encSlicecurve25519_SymmetricCiphertext(v []pkg1_curve25519.SymmetricCiphertext, e *codec1978.Encoder) {
+func (x codecSelfer943) encArray32uint8(v *[32]uint8, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	r.EncodeStringBytesRaw(((*[32]byte)(v))[:])
+}
+
+func (x codecSelfer943) decArray32uint8(v *[32]uint8, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	r.DecodeBytes(((*[32]byte)(v))[:])
+}
+
+func (x codecSelfer943) encSlicePtrtocurve25519_Scalar(v []*pkg1_curve25519.Scalar, e *codec1978.Encoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Encoder(e)
 	_, _, _ = h, z, r
@@ -3733,20 +5111,20 @@ func (x codecSelfer943) encSlicecurve25519_SymmetricCiphertext(v []pkg1_curve255
 	z.EncWriteArrayStart(len(v))
 	for yyv1 := range v {
 		z.EncWriteArrayElem()
-		if yyxt2 := z.Extension(v[yyv1]); yyxt2 != nil {
-			z.EncExtension(v[yyv1], yyxt2)
+		if v[yyv1] == nil {
+			r.EncodeNil()
 		} else {
-			if v[yyv1] == nil {
-				r.EncodeNil()
+			if yyxt2 := z.Extension(v[yyv1]); yyxt2 != nil {
+				z.EncExtension(v[yyv1], yyxt2)
 			} else {
-				z.F.EncSliceUint8V(([]uint8)(v[yyv1]), e)
-			} // end block: if v[yyv1] slice == nil
+				z.F.EncSliceUint8V(([]uint8)(v[yyv1][:]), e)
+			}
 		}
 	}
 	z.EncWriteArrayEnd()
 }
 
-func (x codecSelfer943) decSlicecurve25519_SymmetricCiphertext(v *[]pkg1_curve25519.SymmetricCiphertext, d *codec1978.Decoder) {
+func (x codecSelfer943) decSlicePtrtocurve25519_Scalar(v *[]*pkg1_curve25519.Scalar, d *codec1978.Decoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Decoder(d)
 	_, _, _ = h, z, r
@@ -3762,7 +5140,7 @@ func (x codecSelfer943) decSlicecurve25519_SymmetricCiphertext(v *[]pkg1_curve25
 		}
 	} else if yyl1 == 0 {
 		if yyv1 == nil {
-			yyv1 = []pkg1_curve25519.SymmetricCiphertext{}
+			yyv1 = []*pkg1_curve25519.Scalar{}
 			yyc1 = true
 		} else if len(yyv1) != 0 {
 			yyv1 = yyv1[:0]
@@ -3774,11 +5152,11 @@ func (x codecSelfer943) decSlicecurve25519_SymmetricCiphertext(v *[]pkg1_curve25
 		_ = yyrl1
 		if yyhl1 {
 			if yyl1 > cap(yyv1) {
-				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 24)
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 8)
 				if yyrl1 <= cap(yyv1) {
 					yyv1 = yyv1[:yyrl1]
 				} else {
-					yyv1 = make([]pkg1_curve25519.SymmetricCiphertext, yyrl1)
+					yyv1 = make([]*pkg1_curve25519.Scalar, yyrl1)
 				}
 				yyc1 = true
 			} else if yyl1 != len(yyv1) {
@@ -3790,11 +5168,11 @@ func (x codecSelfer943) decSlicecurve25519_SymmetricCiphertext(v *[]pkg1_curve25
 		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
 			if yyj1 == 0 && yyv1 == nil {
 				if yyhl1 {
-					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 24)
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 8)
 				} else {
 					yyrl1 = 8
 				}
-				yyv1 = make([]pkg1_curve25519.SymmetricCiphertext, yyrl1)
+				yyv1 = make([]*pkg1_curve25519.Scalar, yyrl1)
 				yyc1 = true
 			}
 			yyh1.ElemContainerState(yyj1)
@@ -3806,10 +5184,17 @@ func (x codecSelfer943) decSlicecurve25519_SymmetricCiphertext(v *[]pkg1_curve25
 			if yydb1 {
 				z.DecSwallow()
 			} else {
-				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
-					z.DecExtension(&yyv1[yyj1], yyxt3)
+				if r.TryNil() {
+					yyv1[yyj1] = nil
 				} else {
-					z.F.DecSliceUint8X((*[]uint8)(&yyv1[yyj1]), d)
+					if yyv1[yyj1] == nil {
+						yyv1[yyj1] = new(pkg1_curve25519.Scalar)
+					}
+					if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
+						z.DecExtension(yyv1[yyj1], yyxt3)
+					} else {
+						z.F.DecSliceUint8N(([]uint8)(yyv1[yyj1][:]), d)
+					}
 				}
 			}
 		}
@@ -3817,7 +5202,7 @@ func (x codecSelfer943) decSlicecurve25519_SymmetricCiphertext(v *[]pkg1_curve25
 			yyv1 = yyv1[:yyj1]
 			yyc1 = true
 		} else if yyj1 == 0 && yyv1 == nil {
-			yyv1 = make([]pkg1_curve25519.SymmetricCiphertext, 0)
+			yyv1 = make([]*pkg1_curve25519.Scalar, 0)
 			yyc1 = true
 		}
 	}
@@ -3827,7 +5212,90 @@ func (x codecSelfer943) decSlicecurve25519_SymmetricCiphertext(v *[]pkg1_curve25
 	}
 }
 
-func (x codecSelfer943) encSliceSliceArray32uint8(v [][][32]uint8, e *codec1978.Encoder) {
+func (x codecSelfer943) encMapPairIJcurve25519_Scalar(v map[PairIJ]pkg1_curve25519.Scalar, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+	} else if z.EncBasicHandle().Canonical {
+		z.EncEncodeMapNonNil(v)
+	} else {
+		z.EncWriteMapStart(len(v))
+		for yyk1, yyv1 := range v {
+			z.EncWriteMapElemKey()
+			yy2 := &yyk1
+			if yyxt3 := z.Extension(yy2); yyxt3 != nil {
+				z.EncExtension(yy2, yyxt3)
+			} else {
+				yy2.CodecEncodeSelf(e)
+			}
+			z.EncWriteMapElemValue()
+			yy4 := &yyv1
+			if yyxt5 := z.Extension(yy4); yyxt5 != nil {
+				z.EncExtension(yy4, yyxt5)
+			} else {
+				z.F.EncSliceUint8V(([]uint8)(yy4[:]), e)
+			}
+		}
+		z.EncWriteMapEnd()
+	}
+}
+
+func (x codecSelfer943) decMapPairIJcurve25519_Scalar(v *map[PairIJ]pkg1_curve25519.Scalar, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyl1 := z.DecReadMapStart()
+	if yyl1 == codecSelferDecContainerLenNil943 {
+		*v = nil
+	} else {
+		if yyv1 == nil {
+			yyrl1 := z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 48)
+			yyv1 = make(map[PairIJ]pkg1_curve25519.Scalar, yyrl1)
+			*v = yyv1
+		}
+		var yymk1 PairIJ
+		var yymv1 pkg1_curve25519.Scalar
+		var yymg1, yymdn1 bool
+		if z.DecBasicHandle().MapValueReset {
+			yymg1 = true
+		}
+		if yyl1 != 0 {
+			yyhl1 := yyl1 > 0
+			for yyj1 := 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ {
+				z.DecReadMapElemKey()
+				if yyxt3 := z.Extension(yymk1); yyxt3 != nil {
+					z.DecExtension(&yymk1, yyxt3)
+				} else {
+					yymk1.CodecDecodeSelf(d)
+				}
+				if yymg1 {
+					yymv1 = yyv1[yymk1]
+				} else {
+					yymv1 = pkg1_curve25519.Scalar{}
+				}
+				z.DecReadMapElemValue()
+				yymdn1 = false
+				if yyxt5 := z.Extension(yymv1); yyxt5 != nil {
+					z.DecExtension(&yymv1, yyxt5)
+				} else {
+					z.F.DecSliceUint8N(([]uint8)(yymv1[:]), d)
+				}
+				if yymdn1 {
+					yyv1[yymk1] = pkg1_curve25519.Scalar{}
+				} else {
+					yyv1[yymk1] = yymv1
+				}
+			}
+		} // else len==0: leave as-is (do not clear map entries)
+		z.DecReadMapEnd()
+	}
+}
+
+func (x codecSelfer943) encSliceSliceSliceuint8(v [][][]uint8, e *codec1978.Encoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Encoder(e)
 	_, _, _ = h, z, r
@@ -3841,13 +5309,13 @@ func (x codecSelfer943) encSliceSliceArray32uint8(v [][][32]uint8, e *codec1978.
 		if v[yyv1] == nil {
 			r.EncodeNil()
 		} else {
-			h.encSliceArray32uint8(([][32]uint8)(v[yyv1]), e)
+			z.F.EncSliceBytesV(v[yyv1], e)
 		} // end block: if v[yyv1] slice == nil
 	}
 	z.EncWriteArrayEnd()
 }
 
-func (x codecSelfer943) decSliceSliceArray32uint8(v *[][][32]uint8, d *codec1978.Decoder) {
+func (x codecSelfer943) decSliceSliceSliceuint8(v *[][][]uint8, d *codec1978.Decoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Decoder(d)
 	_, _, _ = h, z, r
@@ -3863,7 +5331,7 @@ func (x codecSelfer943) decSliceSliceArray32uint8(v *[][][32]uint8, d *codec1978
 		}
 	} else if yyl1 == 0 {
 		if yyv1 == nil {
-			yyv1 = [][][32]uint8{}
+			yyv1 = [][][]uint8{}
 			yyc1 = true
 		} else if len(yyv1) != 0 {
 			yyv1 = yyv1[:0]
@@ -3879,7 +5347,7 @@ func (x codecSelfer943) decSliceSliceArray32uint8(v *[][][32]uint8, d *codec1978
 				if yyrl1 <= cap(yyv1) {
 					yyv1 = yyv1[:yyrl1]
 				} else {
-					yyv1 = make([][][32]uint8, yyrl1)
+					yyv1 = make([][][]uint8, yyrl1)
 				}
 				yyc1 = true
 			} else if yyl1 != len(yyv1) {
@@ -3895,7 +5363,7 @@ func (x codecSelfer943) decSliceSliceArray32uint8(v *[][][32]uint8, d *codec1978
 				} else {
 					yyrl1 = 8
 				}
-				yyv1 = make([][][32]uint8, yyrl1)
+				yyv1 = make([][][]uint8, yyrl1)
 				yyc1 = true
 			}
 			yyh1.ElemContainerState(yyj1)
@@ -3907,14 +5375,14 @@ func (x codecSelfer943) decSliceSliceArray32uint8(v *[][][32]uint8, d *codec1978
 			if yydb1 {
 				z.DecSwallow()
 			} else {
-				h.decSliceArray32uint8((*[][32]uint8)(&yyv1[yyj1]), d)
+				z.F.DecSliceBytesX(&yyv1[yyj1], d)
 			}
 		}
 		if yyj1 < len(yyv1) {
 			yyv1 = yyv1[:yyj1]
 			yyc1 = true
 		} else if yyj1 == 0 && yyv1 == nil {
-			yyv1 = make([][][32]uint8, 0)
+			yyv1 = make([][][]uint8, 0)
 			yyc1 = true
 		}
 	}
@@ -3924,7 +5392,7 @@ func (x codecSelfer943) decSliceSliceArray32uint8(v *[][][32]uint8, d *codec1978
 	}
 }
 
-func (x codecSelfer943) encSliceArray32uint8(v [][32]uint8, e *codec1978.Encoder) {
+func (x codecSelfer943) encSliceDealerBlame(v []DealerBlame, e *codec1978.Encoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Encoder(e)
 	_, _, _ = h, z, r
@@ -3936,12 +5404,16 @@ func (x codecSelfer943) encSliceArray32uint8(v [][32]uint8, e *codec1978.Encoder
 	for yyv1 := range v {
 		z.EncWriteArrayElem()
 		yy2 := &v[yyv1]
-		h.encArray32uint8((*[32]uint8)(yy2), e)
+		if yyxt3 := z.Extension(yy2); yyxt3 != nil {
+			z.EncExtension(yy2, yyxt3)
+		} else {
+			yy2.CodecEncodeSelf(e)
+		}
 	}
 	z.EncWriteArrayEnd()
 }
 
-func (x codecSelfer943) decSliceArray32uint8(v *[][32]uint8, d *codec1978.Decoder) {
+func (x codecSelfer943) decSliceDealerBlame(v *[]DealerBlame, d *codec1978.Decoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Decoder(d)
 	_, _, _ = h, z, r
@@ -3957,7 +5429,7 @@ func (x codecSelfer943) decSliceArray32uint8(v *[][32]uint8, d *codec1978.Decode
 		}
 	} else if yyl1 == 0 {
 		if yyv1 == nil {
-			yyv1 = [][32]uint8{}
+			yyv1 = []DealerBlame{}
 			yyc1 = true
 		} else if len(yyv1) != 0 {
 			yyv1 = yyv1[:0]
@@ -3969,11 +5441,11 @@ func (x codecSelfer943) decSliceArray32uint8(v *[][32]uint8, d *codec1978.Decode
 		_ = yyrl1
 		if yyhl1 {
 			if yyl1 > cap(yyv1) {
-				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 32)
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 64)
 				if yyrl1 <= cap(yyv1) {
 					yyv1 = yyv1[:yyrl1]
 				} else {
-					yyv1 = make([][32]uint8, yyrl1)
+					yyv1 = make([]DealerBlame, yyrl1)
 				}
 				yyc1 = true
 			} else if yyl1 != len(yyv1) {
@@ -3985,30 +5457,34 @@ func (x codecSelfer943) decSliceArray32uint8(v *[][32]uint8, d *codec1978.Decode
 		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
 			if yyj1 == 0 && yyv1 == nil {
 				if yyhl1 {
-					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 32)
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 64)
 				} else {
 					yyrl1 = 8
 				}
-				yyv1 = make([][32]uint8, yyrl1)
+				yyv1 = make([]DealerBlame, yyrl1)
 				yyc1 = true
 			}
 			yyh1.ElemContainerState(yyj1)
 			var yydb1 bool
 			if yyj1 >= len(yyv1) {
-				yyv1 = append(yyv1, [32]uint8{})
+				yyv1 = append(yyv1, DealerBlame{})
 				yyc1 = true
 			}
 			if yydb1 {
 				z.DecSwallow()
 			} else {
-				h.decArray32uint8((*[32]uint8)(&yyv1[yyj1]), d)
+				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
+					z.DecExtension(&yyv1[yyj1], yyxt3)
+				} else {
+					yyv1[yyj1].CodecDecodeSelf(d)
+				}
 			}
 		}
 		if yyj1 < len(yyv1) {
 			yyv1 = yyv1[:yyj1]
 			yyc1 = true
 		} else if yyj1 == 0 && yyv1 == nil {
-			yyv1 = make([][32]uint8, 0)
+			yyv1 = make([]DealerBlame, 0)
 			yyc1 = true
 		}
 	}
@@ -4018,25 +5494,7 @@ func (x codecSelfer943) decSliceArray32uint8(v *[][32]uint8, d *codec1978.Decode
 	}
 }
 
-func (x codecSelfer943) encArray32uint8(v *[32]uint8, e *codec1978.Encoder) {
-	var h codecSelfer943
-	z, r := codec1978.GenHelper().Encoder(e)
-	_, _, _ = h, z, r
-	if v == nil {
-		r.EncodeNil()
-		return
-	}
-	r.EncodeStringBytesRaw(((*[32]byte)(v))[:])
-}
-
-func (x codecSelfer943) decArray32uint8(v *[32]uint8, d *codec1978.Decoder) {
-	var h codecSelfer943
-	z, r := codec1978.GenHelper().Decoder(d)
-	_, _, _ = h, z, r
-	r.DecodeBytes(((*[32]byte)(v))[:])
-}
-
-func (x codecSelfer943) encSlicePtrtocurve25519_Scalar(v []*pkg1_curve25519.Scalar, e *codec1978.Encoder) {
+func (x codecSelfer943) encSliceVerifierBlame(v []VerifierBlame, e *codec1978.Encoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Encoder(e)
 	_, _, _ = h, z, r
@@ -4047,20 +5505,17 @@ func (x codecSelfer943) encSlicePtrtocurve25519_Scalar(v []*pkg1_curve25519.Scal
 	z.EncWriteArrayStart(len(v))
 	for yyv1 := range v {
 		z.EncWriteArrayElem()
-		if v[yyv1] == nil {
-			r.EncodeNil()
+		yy2 := &v[yyv1]
+		if yyxt3 := z.Extension(yy2); yyxt3 != nil {
+			z.EncExtension(yy2, yyxt3)
 		} else {
-			if yyxt2 := z.Extension(v[yyv1]); yyxt2 != nil {
-				z.EncExtension(v[yyv1], yyxt2)
-			} else {
-				z.F.EncSliceUint8V(([]uint8)(v[yyv1][:]), e)
-			}
+			yy2.CodecEncodeSelf(e)
 		}
 	}
 	z.EncWriteArrayEnd()
 }
 
-func (x codecSelfer943) decSlicePtrtocurve25519_Scalar(v *[]*pkg1_curve25519.Scalar, d *codec1978.Decoder) {
+func (x codecSelfer943) decSliceVerifierBlame(v *[]VerifierBlame, d *codec1978.Decoder) {
 	var h codecSelfer943
 	z, r := codec1978.GenHelper().Decoder(d)
 	_, _, _ = h, z, r
@@ -4076,7 +5531,7 @@ func (x codecSelfer943) decSlicePtrtocurve25519_Scalar(v *[]*pkg1_curve25519.Sca
 		}
 	} else if yyl1 == 0 {
 		if yyv1 == nil {
-			yyv1 = []*pkg1_curve25519.Scalar{}
+			yyv1 = []VerifierBlame{}
 			yyc1 = true
 		} else if len(yyv1) != 0 {
 			yyv1 = yyv1[:0]
@@ -4088,11 +5543,11 @@ func (x codecSelfer943) decSlicePtrtocurve25519_Scalar(v *[]*pkg1_curve25519.Sca
 		_ = yyrl1
 		if yyhl1 {
 			if yyl1 > cap(yyv1) {
-				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 8)
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 48)
 				if yyrl1 <= cap(yyv1) {
 					yyv1 = yyv1[:yyrl1]
 				} else {
-					yyv1 = make([]*pkg1_curve25519.Scalar, yyrl1)
+					yyv1 = make([]VerifierBlame, yyrl1)
 				}
 				yyc1 = true
 			} else if yyl1 != len(yyv1) {
@@ -4104,33 +5559,26 @@ func (x codecSelfer943) decSlicePtrtocurve25519_Scalar(v *[]*pkg1_curve25519.Sca
 		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
 			if yyj1 == 0 && yyv1 == nil {
 				if yyhl1 {
-					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 8)
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 48)
 				} else {
 					yyrl1 = 8
 				}
-				yyv1 = make([]*pkg1_curve25519.Scalar, yyrl1)
+				yyv1 = make([]VerifierBlame, yyrl1)
 				yyc1 = true
 			}
 			yyh1.ElemContainerState(yyj1)
 			var yydb1 bool
 			if yyj1 >= len(yyv1) {
-				yyv1 = append(yyv1, nil)
+				yyv1 = append(yyv1, VerifierBlame{})
 				yyc1 = true
 			}
 			if yydb1 {
 				z.DecSwallow()
 			} else {
-				if r.TryNil() {
-					yyv1[yyj1] = nil
+				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
+					z.DecExtension(&yyv1[yyj1], yyxt3)
 				} else {
-					if yyv1[yyj1] == nil {
-						yyv1[yyj1] = new(pkg1_curve25519.Scalar)
-					}
-					if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
-						z.DecExtension(yyv1[yyj1], yyxt3)
-					} else {
-						z.F.DecSliceUint8N(([]uint8)(yyv1[yyj1][:]), d)
-					}
+					yyv1[yyj1].CodecDecodeSelf(d)
 				}
 			}
 		}
@@ -4138,7 +5586,7 @@ func (x codecSelfer943) decSlicePtrtocurve25519_Scalar(v *[]*pkg1_curve25519.Sca
 			yyv1 = yyv1[:yyj1]
 			yyc1 = true
 		} else if yyj1 == 0 && yyv1 == nil {
-			yyv1 = make([]*pkg1_curve25519.Scalar, 0)
+			yyv1 = make([]VerifierBlame, 0)
 			yyc1 = true
 		}
 	}
@@ -4147,86 +5595,3 @@ func (x codecSelfer943) decSlicePtrtocurve25519_Scalar(v *[]*pkg1_curve25519.Sca
 		*v = yyv1
 	}
 }
-
-func (x codecSelfer943) encMapPairIJcurve25519_Scalar(v map[PairIJ]pkg1_curve25519.Scalar, e *codec1978.Encoder) {
-	var h codecSelfer943
-	z, r := codec1978.GenHelper().Encoder(e)
-	_, _, _ = h, z, r
-	if v == nil {
-		r.EncodeNil()
-	} else if z.EncBasicHandle().Canonical {
-		z.EncEncodeMapNonNil(v)
-	} else {
-		z.EncWriteMapStart(len(v))
-		for yyk1, yyv1 := range v {
-			z.EncWriteMapElemKey()
-			yy2 := &yyk1
-			if yyxt3 := z.Extension(yy2); yyxt3 != nil {
-				z.EncExtension(yy2, yyxt3)
-			} else {
-				yy2.CodecEncodeSelf(e)
-			}
-			z.EncWriteMapElemValue()
-			yy4 := &yyv1
-			if yyxt5 := z.Extension(yy4); yyxt5 != nil {
-				z.EncExtension(yy4, yyxt5)
-			} else {
-				z.F.EncSliceUint8V(([]uint8)(yy4[:]), e)
-			}
-		}
-		z.EncWriteMapEnd()
-	}
-}
-
-func (x codecSelfer943) decMapPairIJcurve25519_Scalar(v *map[PairIJ]pkg1_curve25519.Scalar, d *codec1978.Decoder) {
-	var h codecSelfer943
-	z, r := codec1978.GenHelper().Decoder(d)
-	_, _, _ = h, z, r
-
-	yyv1 := *v
-	yyl1 := z.DecReadMapStart()
-	if yyl1 == codecSelferDecContainerLenNil943 {
-		*v = nil
-	} else {
-		if yyv1 == nil {
-			yyrl1 := z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 48)
-			yyv1 = make(map[PairIJ]pkg1_curve25519.Scalar, yyrl1)
-			*v = yyv1
-		}
-		var yymk1 PairIJ
-		var yymv1 pkg1_curve25519.Scalar
-		var yymg1, yymdn1 bool
-		if z.DecBasicHandle().MapValueReset {
-			yymg1 = true
-		}
-		if yyl1 != 0 {
-			yyhl1 := yyl1 > 0
-			for yyj1 := 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ {
-				z.DecReadMapElemKey()
-				if yyxt3 := z.Extension(yymk1); yyxt3 != nil {
-					z.DecExtension(&yymk1, yyxt3)
-				} else {
-					yymk1.CodecDecodeSelf(d)
-				}
-				if yymg1 {
-					yymv1 = yyv1[yymk1]
-				} else {
-					yymv1 = pkg1_curve25519.Scalar{}
-				}
-				z.DecReadMapElemValue()
-				yymdn1 = false
-				if yyxt5 := z.Extension(yymv1); yyxt5 != nil {
-					z.DecExtension(&yymv1, yyxt5)
-				} else {
-					z.F.DecSliceUint8N(([]uint8)(yymv1[:]), d)
-				}
-				if yymdn1 {
-					yyv1[yymk1] = pkg1_curve25519.Scalar{}
-				} else {
-					yyv1[yymk1] = yymv1
-				}
-			}
-		} // else len==0: leave as-is (do not clear map entries)
-		z.DecReadMapEnd()
-	}
-}
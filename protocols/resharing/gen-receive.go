@@ -5,65 +5,94 @@
 package resharing
 
 import (
-	"fmt"
-
 	"github.com/shaih/go-yosovss/communication"
 	"github.com/shaih/go-yosovss/msgpack"
+	log "github.com/sirupsen/logrus"
 )
 
 // This file (receive.go) is a template generating gen-receive.go
 
-// ReceiveDealingMessages receives and parse the messages sent by dealers in the dealing round
+// ReceiveDealingMessages receives and parse the messages sent by the parties in the round
 // parties is the list of parties in the round
-func ReceiveDealingMessages(bc communication.BroadcastChannel, parties []int) ([]DealingMessage, error) {
-	messages := make([]DealingMessage, len(parties))
-
+// A message that cannot be decoded (or that is missing) is not an error:
+// it is replaced by an empty message, which is treated as a message from a malicious party
+func ReceiveDealingMessages(bc communication.BroadcastChannel, parties []int) []DealingMessage {
 	_, bm := bc.ReceiveRound()
+	return DecodeDealingMessages(communication.PayloadsFrom(bm, parties), parties)
+}
+
+// DecodeDealingMessages parses the payloads sent by the parties in the round (see ReceiveDealingMessages)
+// payloads[i] is the payload sent by parties[i] (nil if it did not send any)
+func DecodeDealingMessages(payloads [][]byte, parties []int) []DealingMessage {
+	messages := make([]DealingMessage, len(parties))
 
 	for i, party := range parties {
-		err := msgpack.Decode(bm[party].Payload, &messages[i])
+		var msg DealingMessage
+		err := msgpack.Decode(payloads[i], &msg)
 		if err != nil {
-			return nil, fmt.Errorf("decoding message from party %d (id=%d) failed: %v", i, party, err)
+			log.Infof("decoding message from party %d (id=%d) failed: %v", i, party, err)
+			continue
 		}
+		messages[i] = msg
 	}
 
-	return messages, nil
+	return messages
 }
 
 // This file (receive.go) is a template generating gen-receive.go
 
-// ReceiveVerificationMessages receives and parse the messages sent by dealers in the dealing round
+// ReceiveVerificationMessages receives and parse the messages sent by the parties in the round
 // parties is the list of parties in the round
-func ReceiveVerificationMessages(bc communication.BroadcastChannel, parties []int) ([]VerificationMessage, error) {
-	messages := make([]VerificationMessage, len(parties))
-
+// A message that cannot be decoded (or that is missing) is not an error:
+// it is replaced by an empty message, which is treated as a message from a malicious party
+func ReceiveVerificationMessages(bc communication.BroadcastChannel, parties []int) []VerificationMessage {
 	_, bm := bc.ReceiveRound()
+	return DecodeVerificationMessages(communication.PayloadsFrom(bm, parties), parties)
+}
+
+// DecodeVerificationMessages parses the payloads sent by the parties in the round (see ReceiveVerificationMessages)
+// payloads[i] is the payload sent by parties[i] (nil if it did not send any)
+func DecodeVerificationMessages(payloads [][]byte, parties []int) []VerificationMessage {
+	messages := make([]VerificationMessage, len(parties))
 
 	for i, party := range parties {
-		err := msgpack.Decode(bm[party].Payload, &messages[i])
+		var msg VerificationMessage
+		err := msgpack.Decode(payloads[i], &msg)
 		if err != nil {
-			return nil, fmt.Errorf("decoding message from party %d (id=%d) failed: %v", i, party, err)
+			log.Infof("decoding message from party %d (id=%d) failed: %v", i, party, err)
+			continue
 		}
+		messages[i] = msg
 	}
 
-	return messages, nil
+	return messages
 }
 
 // This file (receive.go) is a template generating gen-receive.go
 
-// ReceiveResolutionMessages receives and parse the messages sent by dealers in the dealing round
+// ReceiveResolutionMessages receives and parse the messages sent by the parties in the round
 // parties is the list of parties in the round
-func ReceiveResolutionMessages(bc communication.BroadcastChannel, parties []int) ([]ResolutionMessage, error) {
-	messages := make([]ResolutionMessage, len(parties))
-
+// A message that cannot be decoded (or that is missing) is not an error:
+// it is replaced by an empty message, which is treated as a message from a malicious party
+func ReceiveResolutionMessages(bc communication.BroadcastChannel, parties []int) []ResolutionMessage {
 	_, bm := bc.ReceiveRound()
+	return DecodeResolutionMessages(communication.PayloadsFrom(bm, parties), parties)
+}
+
+// DecodeResolutionMessages parses the payloads sent by the parties in the round (see ReceiveResolutionMessages)
+// payloads[i] is the payload sent by parties[i] (nil if it did not send any)
+func DecodeResolutionMessages(payloads [][]byte, parties []int) []ResolutionMessage {
+	messages := make([]ResolutionMessage, len(parties))
 
 	for i, party := range parties {
-		err := msgpack.Decode(bm[party].Payload, &messages[i])
+		var msg ResolutionMessage
+		err := msgpack.Decode(payloads[i], &msg)
 		if err != nil {
-			return nil, fmt.Errorf("decoding message from party %d (id=%d) failed: %v", i, party, err)
+			log.Infof("decoding message from party %d (id=%d) failed: %v", i, party, err)
+			continue
 		}
+		messages[i] = msg
 	}
 
-	return messages, nil
+	return messages
 }
@@ -2,10 +2,13 @@ package resharing
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/shaih/go-yosovss/communication"
 	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/elgamal"
 	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/nizk"
 	"github.com/shaih/go-yosovss/primitives/pedersen"
 	"github.com/shaih/go-yosovss/primitives/vss"
 )
@@ -14,14 +17,40 @@ import (
 // Note that to simplify implementation we assume it contains all the committees
 // In the real-world, it would not and additional logic should be used for committee selection
 type PublicInput struct {
-	VCParams    feldman.VCParams           // vector commitment params
-	EncPKs      []curve25519.PublicKey     // encryption public keys
-	SigPKs      []curve25519.PublicSignKey // signature public keys - NOT USED
-	VSSParams   vss.Params                 // parameters for the VSS
-	T           int                        // max number of malicious parties (=VSSParams.D)
-	N           int                        // size of the committee (=VSSParams.N)
-	Committees  Committees                 // list of committees
-	Commitments []pedersen.Commitment      // list of N+1 Feldman commitments to the secret and the secret shared
+	VCParams     feldman.VCParams           // vector commitment params
+	EncPKs       []curve25519.PublicKey     // encryption public keys
+	SigPKs       []curve25519.PublicSignKey // signature public keys - NOT USED
+	VSSParams    vss.Params                 // parameters for the VSS of the holding committee
+	T            int                        // max number of malicious parties in the holding committee (=VSSParams.D)
+	N            int                        // size of the holding committee (=VSSParams.N)
+	Committees   Committees                 // list of committees
+	Commitments  []pedersen.Commitment      // list of N+1 Feldman commitments to the secret and the secret shared
+	SessionID    []byte                     // identifier of the session, bound to all the NIZK proofs
+	Epoch        uint64                     // epoch of the refresh, bound to all the NIZK proofs
+	ProofFormats ProofFormats               // format of the proofs sent, default is batchable
+	VEncPKs      []elgamal.PublicKey        // ElGamal public keys for verifiable encryption, nil to disable it
+	// (see VerifiableEncryption)
+	FeldmanConversion bool // if true, dealers publish the Feldman commitment sigma_{i+1} G of the value they deal
+	// from which everybody derives the Feldman commitments of the sharing (see ComputeFeldmanCommitments)
+	AllQualifiedDealers bool // if true, every dealer is verified and all the qualified dealers are used for refreshing
+	// instead of the first t+1 (see ComputeQualifiedDealers)
+	BatchCommitments [][]pedersen.Commitment // if not nil, K=len(BatchCommitments) secrets are refreshed at once
+	// and BatchCommitments[u] are the N+1 commitments of the secret u in 0,...,K-1 (Commitments must then be nil)
+	// All the secrets share the messages, the encryptions, and the proofs (see DealingMessage)
+	// Verifiable encryption and Feldman conversion are not supported for batched refreshes
+	SecretRangeProof *nizk.RangeProof // if not nil, proof that the secret is in [0, 2^SecretRangeBits)
+	// (see vss.RangeFixedRShareFrom), checked by CheckPublicInput so that parties and auditors reject a refresh
+	// of a secret out of range. It only depends on Commitments[0] so it remains valid for all the refreshes
+	// Not supported for batched refreshes
+	SecretRangeBits int
+
+	// The other committees may have a different size and a different max number of malicious parties
+	// given by the parameters below, where nil means the same as the holding committee (i.e., VSSParams)
+	VerVSSParams *vss.Params // parameters for the second-level VSS toward the verification committee
+	ResVSSParams *vss.Params // size and max number of malicious parties of the resolution committee
+	// (only N and D are used)
+	NextVSSParams *vss.Params // parameters for the first-level VSS toward the next holding committee
+	// VCParams must have 2*NextVSSParams.N bases
 
 	// Note: Commitments[0] is the commitment to the secret,
 	//       and Commitments[i] is the commitment to the first share of the first party
@@ -29,25 +58,173 @@ type PublicInput struct {
 	//       but should not matter in the grand scheme of things
 }
 
-// TODO IMPORTANT: contrary to the paper, we use Pedersen at the top level
-// We don't transform in Feldman hence the Share *vss.Share
-// Difference should be negligible
+// Contrary to the paper, we use Pedersen at the top level hence the Share *vss.Share
+// The sharing can be converted to Feldman by setting PublicInput.FeldmanConversion
 
 type PrivateInput struct {
 	BC    communication.BroadcastChannel
 	EncSK curve25519.PrivateKey
 	SigSK curve25519.PrivateSignKey
-	Share *vss.Share // if the party is not a dealer (i.e., not in the original holding committe), it's nil
-	ID    int
+	// VEncSK is the ElGamal private key corresponding to VEncPKs (only used if verifiable encryption is enabled)
+	VEncSK elgamal.PrivateKey
+	Share  *vss.Share // if the party is not a dealer (i.e., not in the original holding committe), it's nil
+	// BatchShares[u] is the share of the secret u for a batched refresh (see PublicInput.BatchCommitments)
+	// Share must then be nil
+	BatchShares []*vss.Share
+	ID          int
+
+	// Rand is the randomness source used by the party (shares, proofs, encryption)
+	// If nil, system randomness is used
+	// Setting it to a deterministic source allows to reproduce a run bit-exactly
+	Rand io.Reader
+}
+
+// verParams returns the parameters of the verification committee (see PublicInput.VerVSSParams)
+func (pub *PublicInput) verParams() *vss.Params {
+	if pub.VerVSSParams != nil {
+		return pub.VerVSSParams
+	}
+	return &pub.VSSParams
+}
+
+// resParams returns the parameters of the resolution committee (see PublicInput.ResVSSParams)
+func (pub *PublicInput) resParams() *vss.Params {
+	if pub.ResVSSParams != nil {
+		return pub.ResVSSParams
+	}
+	return &pub.VSSParams
+}
+
+// nextParams returns the parameters of the next holding committee (see PublicInput.NextVSSParams)
+func (pub *PublicInput) nextParams() *vss.Params {
+	if pub.NextVSSParams != nil {
+		return pub.NextVSSParams
+	}
+	return &pub.VSSParams
+}
+
+// numSecrets returns the number K of secrets refreshed (see PublicInput.BatchCommitments)
+func (pub *PublicInput) numSecrets() int {
+	if pub.BatchCommitments != nil {
+		return len(pub.BatchCommitments)
+	}
+	return 1
+}
+
+// futureBroadcast returns true if the dealers send the future broadcast material (EncResM, EncEpsK, HashEps)
+// and the complaints are resolved by the resolution committee, i.e., if verifiable encryption is disabled
+// (with verifiable encryption, the shares of the dealers are checked publicly, see VerifiableEncryption)
+func (pub *PublicInput) futureBroadcast() bool {
+	return pub.VEncPKs == nil
+}
+
+// NumRounds returns the number of rounds of messages of the protocol before the refresh:
+// dealing, verification, and resolution, except with verifiable encryption where there is no resolution round
+// (see VerifiableEncryption)
+func (pub *PublicInput) NumRounds() int {
+	if !pub.futureBroadcast() {
+		return numRounds - 1
+	}
+	return numRounds
+}
+
+// secretCommitments returns the commitments of the secret u in 0,...,K-1 (see PublicInput.BatchCommitments)
+func (pub *PublicInput) secretCommitments(u int) []pedersen.Commitment {
+	if pub.BatchCommitments != nil {
+		return pub.BatchCommitments[u]
+	}
+	return pub.Commitments
+}
+
+// secretShare returns the share of the secret u in 0,...,K-1 (see PrivateInput.BatchShares)
+func (prv *PrivateInput) secretShare(u int) *vss.Share {
+	if prv.BatchShares != nil {
+		return prv.BatchShares[u]
+	}
+	return prv.Share
 }
 
 // checkInputs performs basic checks on the inputs to catch most common errors
 func checkInputs(pub *PublicInput, prv *PrivateInput) error {
+	err := CheckPublicInput(pub)
+	if err != nil {
+		return err
+	}
+	if pub.BatchCommitments == nil {
+		if prv.BatchShares != nil {
+			return fmt.Errorf("BatchShares must be nil when BatchCommitments is nil")
+		}
+		return nil
+	}
+	if prv.Share != nil {
+		return fmt.Errorf("Share must be nil when BatchCommitments is set")
+	}
+	if pub.Committees.Indices(prv.ID).Hold >= 0 {
+		if len(prv.BatchShares) != pub.numSecrets() {
+			return fmt.Errorf("a dealer must have one share per secret")
+		}
+		for u := range prv.BatchShares {
+			if prv.BatchShares[u] == nil {
+				return fmt.Errorf("missing share of secret %d", u)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckPublicInput performs basic checks on the public input to catch most common errors
+// (sizes of the committees and of the parameters)
+// and verifies the range proof of the secret if any (see PublicInput.SecretRangeProof)
+func CheckPublicInput(pub *PublicInput) error {
 	if pub.T >= pub.N {
 		return fmt.Errorf("T must be < N")
 	}
-	if len(pub.VCParams.Bases) != pub.N*2 {
-		return fmt.Errorf("len of bases must be N+1")
+	if len(pub.VCParams.Bases) != pub.nextParams().N*2 {
+		return fmt.Errorf("len of bases must be 2N for the next holding committee")
+	}
+	if len(pub.Committees.Hold) != pub.N {
+		return fmt.Errorf("holding committee must have size N")
+	}
+	for _, c := range []struct {
+		name    string
+		members []int
+		params  *vss.Params
+	}{
+		{"verification", pub.Committees.Ver, pub.verParams()},
+		{"resolution", pub.Committees.Res, pub.resParams()},
+		{"next holding", pub.Committees.Next, pub.nextParams()},
+	} {
+		if c.params.D >= c.params.N {
+			return fmt.Errorf("T must be < N for the %s committee", c.name)
+		}
+		if len(c.members) != c.params.N {
+			return fmt.Errorf("%s committee must have size N", c.name)
+		}
+	}
+	if pub.BatchCommitments != nil {
+		if pub.Commitments != nil {
+			return fmt.Errorf("Commitments must be nil when BatchCommitments is set")
+		}
+		if len(pub.BatchCommitments) == 0 {
+			return fmt.Errorf("a batched refresh must have at least one secret")
+		}
+		for u := range pub.BatchCommitments {
+			if len(pub.BatchCommitments[u]) != pub.N+1 {
+				return fmt.Errorf("there must be N+1 commitments for secret %d", u)
+			}
+		}
+		if pub.VEncPKs != nil || pub.FeldmanConversion {
+			return fmt.Errorf("verifiable encryption and Feldman conversion are not supported for batched refreshes")
+		}
+		if pub.SecretRangeProof != nil {
+			return fmt.Errorf("the range proof of the secret is not supported for batched refreshes")
+		}
+	}
+	if pub.SecretRangeProof != nil {
+		err := vss.VerifySecretRange(pub.Commitments, pub.SecretRangeBits, pub.SecretRangeProof)
+		if err != nil {
+			return fmt.Errorf("invalid range proof of the secret: %w", err)
+		}
 	}
 	// FIXME: add more checks
 	return nil
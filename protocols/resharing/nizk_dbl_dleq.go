@@ -2,14 +2,17 @@ package resharing
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/nizk"
+	"github.com/shaih/go-yosovss/primitives/transcript"
 )
 
-// This file should be in a package nizk in primitives
-// but because of weird issues with codecgen, having
+// The proof itself is implemented in primitives/nizk (see nizk.NewDblDLEqStatement)
+// This file only contains the types used in messages
+// because of weird issues with codecgen, having
 // two codecgen seems to create too many problems
-// So to dirtily fix things, we move this file here even if not ideal
 
 // This file is to handle NIZK Proof of Knowledge of discrete logarithms
 // Concretely the statement is
@@ -31,8 +34,8 @@ import (
 // and associated points
 //    com[i] = comGLog[i] * G + comHLog[i] * H
 //    comPrime[i] = comGLog[i] * G[i] + comHLog[i] * H[i]
-// combine those into an input hash (see DLChHashIn below)
-// hash it to get a challenge scalar Ch
+// absorb the statement and those points into the transcript
+// and squeeze a challenge scalar Ch
 // set
 //    respG[i] = comGLog[i] + ch * x_i
 //    respH[i] = comGLog[i] + ch * x_i
@@ -50,254 +53,127 @@ type DblDLEqWitness struct {
 	Y []curve25519.Scalar `codec:"y"`
 }
 
-type DblDLEqHashIn struct {
-	Stmt     DblDLEqStatement     `codec:"s"`
-	Com      []curve25519.PointXY `codec:"g"`
-	ComPrime []curve25519.PointXY `codec:"h"`
-}
-
+// DblDLEqProof is an actual proof
+// Com/ComPrime/RespG/RespH are the commitments/responses of the nizk.LinearProof
+// for nizk.NewDblDLEqStatement
+// In compact form (see ProofFormatCompact), Com/ComPrime are empty and Chal is the challenge
 type DblDLEqProof struct {
 	Com      []curve25519.PointXY `codec:"g"`
 	ComPrime []curve25519.PointXY `codec:"h"`
 	RespG    []curve25519.Scalar  `codec:"G"`
 	RespH    []curve25519.Scalar  `codec:"H"`
+	Chal     *curve25519.Scalar   `codec:"e"` // Chal is the challenge in compact form, nil otherwise
 }
 
-func dblDLEqBasicCheckStatement(stmt DblDLEqStatement) error {
-	if len(stmt.G) != len(stmt.H) {
-		return fmt.Errorf("G and H do not have the same length")
-	}
-	if len(stmt.G) != len(stmt.Z) {
-		return fmt.Errorf("G and Z do not have the same length")
-	}
-	if len(stmt.G) != len(stmt.ZPrime) {
-		return fmt.Errorf("G and ZPrime do not have the same length")
-	}
-
-	if len(stmt.G) == 0 {
-		return fmt.Errorf("G is empty")
-	}
-
-	return nil
+// dblDLEqTranscript returns the transcript for a DblDLEq proof with context ctx
+func dblDLEqTranscript(ctx *ProofContext) *transcript.Transcript {
+	t := transcript.New("dbl_dleq")
+	ctx.bind(t)
+	return t
 }
 
-// dblDLEqProveGenCom generates the commitments DL and the commitments for the NIZK proof
-func dblDLEqProveGenCom(stmt DblDLEqStatement) (
-	comGLog []curve25519.Scalar, comHLog []curve25519.Scalar,
-	com []curve25519.PointXY, comPrime []curve25519.PointXY,
-	err error) {
-
-	n := len(stmt.G)
-
-	chacha20Key, err := curve25519.RandomChacha20Key()
-	if err != nil {
-		return nil, nil, nil, nil, err
-	}
-
-	comGLog = make([]curve25519.Scalar, n)
-	comHLog = make([]curve25519.Scalar, n)
-
-	com = make([]curve25519.PointXY, n)
-	comPrime = make([]curve25519.PointXY, n)
-
-	for i := 0; i < n; i++ {
-		// generate comGLog[i]/comHLog[i] randomly using the Chacha generator
-		curve25519.RandomScalarChacha20C(&comGLog[i], &chacha20Key, uint64(i))
-		curve25519.RandomScalarChacha20C(&comHLog[i], &chacha20Key, uint64(i+n))
-
-		// compute com[i] = comGLog[i] * G + comHLog[i] * H
-		c, err := curve25519.DoubleMultBaseGHPointXYScalar(&comGLog[i], &comHLog[i])
-		if err != nil {
-			return nil, nil, nil, nil, err
-		}
-		com[i] = *c
-
-		// compute com[i] = comGLog[i] * G[i] + comHLog[i] * H[i]
-		c, err = curve25519.MultiMultPointXYScalar(
-			[]curve25519.PointXY{stmt.G[i], stmt.H[i]},
-			[]curve25519.Scalar{comGLog[i], comHLog[i]},
-		)
-		if err != nil {
-			return nil, nil, nil, nil, err
-		}
-		comPrime[i] = *c
-	}
-
-	return comGLog, comHLog, com, comPrime, err
+// linear returns the statement as a nizk.LinearStatement
+func (stmt *DblDLEqStatement) linear() (*nizk.LinearStatement, error) {
+	return nizk.NewDblDLEqStatement(stmt.G, stmt.H, stmt.Z, stmt.ZPrime)
 }
 
-// dblDLEqProveResp computes the response to a challenge in the sigma protocol for the NIZK
-func dblDLEqProveResp(wit DblDLEqWitness,
-	comGLog []curve25519.Scalar, comHLog []curve25519.Scalar,
-	chal *curve25519.Scalar) (respG []curve25519.Scalar, respH []curve25519.Scalar) {
-
-	n := len(wit.X)
-	respG = make([]curve25519.Scalar, n)
-	respH = make([]curve25519.Scalar, n)
-
-	for i := 0; i < n; i++ {
-		// respG[i] = comGLog[i] + chal * X[i]
-		ri := curve25519.MultScalar(chal, &wit.X[i])
-		ri = curve25519.AddScalar(ri, &comGLog[i])
-		respG[i] = *ri
-
-		// respH[i] = comHLog[i] + chal * Y[i]
-		ri = curve25519.MultScalar(chal, &wit.Y[i])
-		ri = curve25519.AddScalar(ri, &comHLog[i])
-		respH[i] = *ri
-	}
-
-	return respG, respH
+// linear returns the proof as a nizk.LinearProof
+func (proof *DblDLEqProof) linear() *nizk.LinearProof {
+	lproof := &nizk.LinearProof{
+		Com:  make([]curve25519.PointXY, 0, len(proof.Com)+len(proof.ComPrime)),
+		Resp: make([]curve25519.Scalar, 0, len(proof.RespG)+len(proof.RespH)),
+	}
+	lproof.Com = append(lproof.Com, proof.Com...)
+	lproof.Com = append(lproof.Com, proof.ComPrime...)
+	lproof.Resp = append(lproof.Resp, proof.RespG...)
+	lproof.Resp = append(lproof.Resp, proof.RespH...)
+	return lproof
 }
 
 // DblDLEqProve generates a NIZK PoK for the statement stmt using witness wit
 // Does not verify the validity of the witness
-func DblDLEqProve(stmt DblDLEqStatement, wit DblDLEqWitness) (DblDLEqProof, error) {
-	err := dblDLEqBasicCheckStatement(stmt)
+// rnd is the randomness source (system randomness if nil)
+// ctx binds the proof to the protocol run and the prover (see ProofContext)
+// format is the format of the output proof
+func DblDLEqProve(
+	rnd io.Reader, ctx *ProofContext, format ProofFormat, stmt DblDLEqStatement, wit DblDLEqWitness,
+) (DblDLEqProof, error) {
+	lstmt, err := stmt.linear()
 	if err != nil {
 		return DblDLEqProof{}, err
 	}
 
-	comGLog, comHLog, com, comPrime, err := dblDLEqProveGenCom(stmt)
-	if err != nil {
-		return DblDLEqProof{}, err
-	}
+	n := len(stmt.G)
+	lwit := nizk.AndWitness(wit.X, wit.Y)
 
-	hin := DblDLEqHashIn{
-		Stmt:     stmt,
-		Com:      com,
-		ComPrime: comPrime,
+	if format == ProofFormatCompact {
+		proof, err := nizk.ProveCompact(rnd, dblDLEqTranscript(ctx), lstmt, lwit)
+		if err != nil {
+			return DblDLEqProof{}, err
+		}
+		return DblDLEqProof{
+			RespG: proof.Resp[:n],
+			RespH: proof.Resp[n:],
+			Chal:  &proof.Chal,
+		}, nil
 	}
 
-	chal := DblDLEqChHash(hin)
-
-	respG, respH := dblDLEqProveResp(wit, comGLog, comHLog, &chal)
-
-	proof := DblDLEqProof{
-		Com:      com,
-		ComPrime: comPrime,
-		RespG:    respG,
-		RespH:    respH,
+	proof, err := nizk.Prove(rnd, dblDLEqTranscript(ctx), lstmt, lwit)
+	if err != nil {
+		return DblDLEqProof{}, err
 	}
 
-	return proof, nil
+	return DblDLEqProof{
+		Com:      proof.Com[:n],
+		ComPrime: proof.Com[n:],
+		RespG:    proof.Resp[:n],
+		RespH:    proof.Resp[n:],
+	}, nil
 }
 
-func DblDLEqVerify(stmt DblDLEqStatement, proof DblDLEqProof) error {
-	err := dblDLEqBasicCheckStatement(stmt)
+// DblDLEqVerify verifies the proof for the statement stmt in context ctx
+// The proof can be in any format
+func DblDLEqVerify(ctx *ProofContext, stmt DblDLEqStatement, proof DblDLEqProof) error {
+	lstmt, err := stmt.linear()
 	if err != nil {
 		return err
 	}
 
 	n := len(stmt.G)
-
-	hin := DblDLEqHashIn{
-		Stmt:     stmt,
-		Com:      proof.Com,
-		ComPrime: proof.ComPrime,
-	}
-	chal := DblDLEqChHash(hin)
-
-	// TODO: optimization is having these values 128 bits long instead
-
-	// generate batching values e[0],...,e[n-1]
-	// randomly
-	chacha20key, err := curve25519.RandomChacha20Key()
-	if err != nil {
-		panic("error generating chacha20 key")
-	}
-
-	e := make([]curve25519.Scalar, 2*n)
-	for i := 0; i < 2*n; i++ {
-		curve25519.RandomScalarChacha20C(&e[i], &chacha20key, uint64(i))
-	}
-
-	// pts contain
-	// [
-	//   G,
-	//   H,
-	//   G[0],...,G[n-1],
-	//   H[0],...,H[n-1],
-	//   Z[0],...,Z[n-1],
-	//   ZPrime[0],...,ZPrime[n-1]
-	//   Com[0],...,Com[n-1],
-	//   ComPrime[0],...,ComPrime[n-1],
-	// ]
-	pts := make([]curve25519.PointXY, 0, 2+6*n)
-	pts = append(pts, curve25519.BaseXYG, curve25519.BaseXYH)
-	pts = append(pts, stmt.G...)
-	pts = append(pts, stmt.H...)
-	pts = append(pts, stmt.Z...)
-	pts = append(pts, stmt.ZPrime...)
-	pts = append(pts, proof.Com...)
-	pts = append(pts, proof.ComPrime...)
-
-	// check pts are all on the curve (except the base points that are necessarily there)
-	for i := n + 2; i < len(pts); i++ {
-		if !curve25519.IsOnCurveXY(&pts[i]) {
-			return fmt.Errorf("pts[%d] is not on the curve", i)
+	if proof.Chal != nil {
+		if len(proof.Com) != 0 || len(proof.ComPrime) != 0 || len(proof.RespG) != n || len(proof.RespH) != n {
+			return fmt.Errorf("invalid proof lengths")
 		}
+		return nizk.VerifyCompact(dblDLEqTranscript(ctx), lstmt, &nizk.CompactLinearProof{
+			Chal: *proof.Chal,
+			Resp: nizk.AndWitness(proof.RespG, proof.RespH),
+		})
 	}
 
-	// scalars contain
-	// [
-	//   sum_i -e[i] * respG[i]
-	//   sum_i -e[i] * respH[i]
-	//   -e[n] * respG[0], ..., -e[2n-1] * respG[n-1],
-	//   -e[n] * respH[0], ..., -e[2n-1] * respH[n-1],
-	//   e[0] * chal, ..., e[n-1] * chal,
-	//   e[n] * chal, ..., e[2n-1] * chal,
-	//   e[0], ..., e[n-1],
-	//   e[n], ..., e[2n-1]
-	// ]
-	scalars := make([]curve25519.Scalar, 2+6*n)
-
-	// scalars[0] = sum_i -e[i] * respG[i]
-	eGVec := curve25519.NewScalarMatrixFromEntries(1, n, e[:n])
-	respGVec := curve25519.NewScalarMatrixFromEntries(n, 1, proof.RespG)
-	s0, err := curve25519.ScalarMatrixMul(eGVec, respGVec)
-	if err != nil {
-		return err
+	if len(proof.Com) != n || len(proof.ComPrime) != n || len(proof.RespG) != n || len(proof.RespH) != n {
+		return fmt.Errorf("invalid proof lengths")
 	}
-	scalars[0] = *curve25519.NegateScalar(s0.At(0, 0))
 
-	// scalars[1] = sum_i -e[i+n] * respH[i]
-	eHVec := curve25519.NewScalarMatrixFromEntries(1, n, e[:n])
-	respHVec := curve25519.NewScalarMatrixFromEntries(n, 1, proof.RespH)
-	s1, err := curve25519.ScalarMatrixMul(eHVec, respHVec)
-	if err != nil {
-		return err
-	}
-	scalars[1] = *curve25519.NegateScalar(s1.At(0, 0))
+	return nizk.Verify(dblDLEqTranscript(ctx), lstmt, proof.linear())
+}
 
-	// scalars[2:]
-	for i := 0; i < n; i++ {
-		scalars[2+i] = *curve25519.NegateScalar(curve25519.MultScalar(&e[i+n], &proof.RespG[i]))
-	}
-	for i := 0; i < n; i++ {
-		scalars[n+2+i] = *curve25519.NegateScalar(curve25519.MultScalar(&e[i+n], &proof.RespH[i]))
-	}
-	for i := 0; i < 2*n; i++ {
-		scalars[2*n+2+i] = *curve25519.MultScalar(&e[i], &chal)
-	}
-	for i := 0; i < 2*n; i++ {
-		scalars[4*n+2+i] = e[i]
+// dblDLEqBatchAdd adds the verification of the proof for the statement stmt in context ctx
+// to the item of the batch b
+// Proofs in compact form cannot be batched and are verified immediately
+func dblDLEqBatchAdd(b *nizk.Batch, item int, ctx *ProofContext, stmt DblDLEqStatement, proof DblDLEqProof) error {
+	if proof.Chal != nil {
+		return DblDLEqVerify(ctx, stmt, proof)
 	}
 
-	// Final verification of the equation
-	// pts scalar product with scalars is the point at infinitiy
-	r, err := curve25519.MultiMultPointXYScalarVarTime(pts, scalars)
+	lstmt, err := stmt.linear()
 	if err != nil {
 		return err
 	}
 
-	if !curve25519.PointXYEqual(r, &curve25519.PointXYInfinity) {
-		return fmt.Errorf("batch verification equation failed")
+	n := len(stmt.G)
+	if len(proof.Com) != n || len(proof.ComPrime) != n || len(proof.RespG) != n || len(proof.RespH) != n {
+		return fmt.Errorf("invalid proof lengths")
 	}
 
-	return nil
-}
-
-func DblDLEqChHash(in DblDLEqHashIn) (chal curve25519.Scalar) {
-	return zkHash("dbl_dleq", in)
+	return b.AddProof(item, dblDLEqTranscript(ctx), lstmt, proof.linear())
 }
@@ -31,13 +31,14 @@ func TestDblDLEqProveCorrect(t *testing.T) {
 			stmt, wit, err := genDblDLEqStmtWit(n)
 			require.NoError(err)
 
-			// Generate the proof
-			proof, err := DblDLEqProve(stmt, wit)
-			require.NoError(err)
-
-			// Verify it
-			err = DblDLEqVerify(stmt, proof)
-			assert.NoError(err)
+			// Generate the proof and verify it, in both formats
+			for _, format := range []ProofFormat{ProofFormatBatchable, ProofFormatCompact} {
+				proof, err := DblDLEqProve(nil, nil, format, stmt, wit)
+				require.NoError(err)
+
+				err = DblDLEqVerify(nil, stmt, proof)
+				assert.NoError(err, "format %d", format)
+			}
 		})
 	}
 }
@@ -65,26 +66,26 @@ func TestDblDLEqProveIncorrect(t *testing.T) {
 
 			// Test breaking in way 1
 			// Generate the proof
-			proof, err := DblDLEqProve(stmt, wit)
+			proof, err := DblDLEqProve(nil, nil, ProofFormatBatchable, stmt, wit)
 			require.NoError(err)
 
 			// Break the proof in one way
 			proof.RespG[0] = *curve25519.NegateScalar(&proof.RespG[0])
 
 			// Verify it
-			err = DblDLEqVerify(stmt, proof)
+			err = DblDLEqVerify(nil, stmt, proof)
 			assert.Error(err)
 
 			// Test breaking in way 2
 			// Generate the proof
-			proof, err = DblDLEqProve(stmt, wit)
+			proof, err = DblDLEqProve(nil, nil, ProofFormatBatchable, stmt, wit)
 			require.NoError(err)
 
 			// Break the proof
 			proof.RespH[n-1] = *curve25519.NegateScalar(&proof.RespH[n-1])
 
 			// Verify it
-			err = DblDLEqVerify(stmt, proof)
+			err = DblDLEqVerify(nil, stmt, proof)
 			assert.Error(err)
 		})
 	}
@@ -1,17 +1,18 @@
 package resharing
 
 import (
-	"crypto/sha256"
 	"fmt"
+	"io"
 
-	"github.com/shaih/go-yosovss/msgpack"
 	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/nizk"
+	"github.com/shaih/go-yosovss/primitives/transcript"
 )
 
-// This file should be in a package nizk in primitives
-// but because of weird issues with codecgen, having
+// The proof itself is implemented in primitives/nizk (see nizk.NewDLStatement)
+// This file only contains the types used in messages
+// because of weird issues with codecgen, having
 // two codecgen seems to create too many problems
-// So to dirtily fix things, we move this file here even if not ideal
 
 // This file is to handle NIZK Proof of Knowledge of discrete logarithms
 // Concretely the statement is (G_0,...,G_{n-1},X_0, ..., X_{n-1})
@@ -25,13 +26,6 @@ import (
 // Verification is batched for efficiency for large n
 // Smaller n may be less efficient
 
-// The proof works as follows:
-// generate random scalars comLog[0],...,comLog[n-1]
-// and associated points com[i] = comLog[i] * G[i]
-// combine those into an input hash (see DLChHashIn below)
-// hash it to get a challenge scalar Ch
-// set resp[i] = comLog[i] + ch * x_i
-
 // DLStatement describes a statement, see comment top of file
 type DLStatement struct {
 	G []curve25519.PointXY `codec:"G"`
@@ -43,190 +37,96 @@ type DLWitness struct {
 	XLog []curve25519.Scalar `codec:"x"` // XLog[i] = x_i = discrete logarithm of X[i] in base G[i]
 }
 
-// DLChHashIn is the input to the hash function used to compute the challenge
-type DLChHashIn struct {
-	Stmt DLStatement          `codec:"s"`
-	Com  []curve25519.PointXY `codec:"c"`
-}
-
 // DLProof is an actual proof
 // For batching the proof contain com and resp instead of ch and resp
 // which is more compact but not possible to batch
+// In compact form (see ProofFormatCompact), Com is empty and Chal is the challenge
 type DLProof struct {
 	Com  []curve25519.PointXY `codec:"c"` // Com is the commtiments
 	Resp []curve25519.Scalar  `codec:"r"` // Resp
+	Chal *curve25519.Scalar   `codec:"e"` // Chal is the challenge in compact form, nil otherwise
 }
 
-func dlBasicCheckStatement(stmt DLStatement) error {
-	if len(stmt.X) != len(stmt.G) {
-		return fmt.Errorf("G and X do not have the same length")
-	}
-
-	if len(stmt.X) == 0 {
-		return fmt.Errorf("G/X is empty")
-	}
-
-	return nil
+// dlTranscript returns the transcript for a DL proof with context ctx
+func dlTranscript(ctx *ProofContext) *transcript.Transcript {
+	t := transcript.New("dlpok")
+	ctx.bind(t)
+	return t
 }
 
-// dlProveGenCom generates the commitments DL and the commitments for the NIZK proof
-func dlProveGenCom(stmt DLStatement) (comLog []curve25519.Scalar, com []curve25519.PointXY, err error) {
-	n := len(stmt.G)
-
-	chacha20Key, err := curve25519.RandomChacha20Key()
+// DLProve generates a NIZK PoK for the statement stmt using witness wit
+// Does not verify the validity of the witness
+// rnd is the randomness source (system randomness if nil)
+// ctx binds the proof to the protocol run and the prover (see ProofContext)
+// format is the format of the output proof
+func DLProve(
+	rnd io.Reader, ctx *ProofContext, format ProofFormat, stmt DLStatement, wit DLWitness,
+) (DLProof, error) {
+	lstmt, err := nizk.NewDLStatement(stmt.G, stmt.X)
 	if err != nil {
-		return nil, nil, err
+		return DLProof{}, err
 	}
 
-	comLog = make([]curve25519.Scalar, n)
-	com = make([]curve25519.PointXY, n)
-
-	for i := 0; i < n; i++ {
-		// generate comLog[i] randomly using the Chacha generator
-		curve25519.RandomScalarChacha20C(&comLog[i], &chacha20Key, uint64(i))
-
-		// compute com[i] = comLog[i] * G[i]
-		c, err := curve25519.MultPointXYScalar(&stmt.G[i], &comLog[i])
+	if format == ProofFormatCompact {
+		proof, err := nizk.ProveCompact(rnd, dlTranscript(ctx), lstmt, wit.XLog)
 		if err != nil {
-			return nil, nil, err
+			return DLProof{}, err
 		}
-		com[i] = *c
+		return DLProof{
+			Resp: proof.Resp,
+			Chal: &proof.Chal,
+		}, nil
 	}
 
-	return comLog, com, err
-}
-
-// dlProveResp computes the response to a challenge in the sigma protocol for the NIZK
-func dlProveResp(wit DLWitness, comLog []curve25519.Scalar, chal *curve25519.Scalar) (resp []curve25519.Scalar) {
-	n := len(wit.XLog)
-	resp = make([]curve25519.Scalar, n)
-
-	for i := 0; i < n; i++ {
-		// resp[i] = comLog[i] + chal * XLog[i]
-		ri := curve25519.MultScalar(chal, &wit.XLog[i])
-		ri = curve25519.AddScalar(ri, &comLog[i])
-		resp[i] = *ri
-	}
-
-	return resp
-}
-
-// DLProve generates a NIZK PoK for the statement stmt using witness wit
-// Does not verify the validity of the witness
-func DLProve(stmt DLStatement, wit DLWitness) (DLProof, error) {
-	err := dlBasicCheckStatement(stmt)
+	proof, err := nizk.Prove(rnd, dlTranscript(ctx), lstmt, wit.XLog)
 	if err != nil {
 		return DLProof{}, err
 	}
 
-	comLog, com, err := dlProveGenCom(stmt)
-	if err != nil {
-		return DLProof{}, err
-	}
-
-	dchi := DLChHashIn{
-		Stmt: stmt,
-		Com:  com,
-	}
-
-	chal := DLChHash(dchi)
-
-	resp := dlProveResp(wit, comLog, &chal)
-
-	proof := DLProof{
-		Com:  com,
-		Resp: resp,
-	}
-
-	return proof, nil
+	return DLProof{
+		Com:  proof.Com,
+		Resp: proof.Resp,
+	}, nil
 }
 
-func DLVerify(stmt DLStatement, proof DLProof) error {
-	err := dlBasicCheckStatement(stmt)
+// DLVerify verifies the proof for the statement stmt in context ctx
+// The proof can be in any format
+func DLVerify(ctx *ProofContext, stmt DLStatement, proof DLProof) error {
+	lstmt, err := nizk.NewDLStatement(stmt.G, stmt.X)
 	if err != nil {
 		return err
 	}
 
-	n := len(stmt.X)
-
-	dchi := DLChHashIn{
-		Stmt: stmt,
-		Com:  proof.Com,
-	}
-	chal := DLChHash(dchi)
-
-	// TODO: optimization is having these values 128 bits long instead
-
-	// generate batching values e[0],...,e[n-1]
-	// randomly
-	chacha20key, err := curve25519.RandomChacha20Key()
-	if err != nil {
-		panic("error generating chacha20 key")
-	}
-
-	e := make([]curve25519.Scalar, n)
-	for i := 0; i < n; i++ {
-		curve25519.RandomScalarChacha20C(&e[i], &chacha20key, uint64(i))
-	}
-
-	// pts contain [G[0],...,G[n-1], X[0],...,X[n-1], com[0],...,com[n-1]]
-	pts := make([]curve25519.PointXY, 0, 3*n)
-	pts = append(pts, stmt.G...)
-	pts = append(pts, stmt.X...)
-	pts = append(pts, proof.Com...)
-
-	// check pts are all on the curve (except the base points that are necessarily there)
-	for i := n; i < len(pts); i++ {
-		if !curve25519.IsOnCurveXY(&pts[i]) {
-			return fmt.Errorf("pts[%d] is not on the curve", i)
+	if proof.Chal != nil {
+		if len(proof.Com) != 0 {
+			return fmt.Errorf("compact proof must not contain commitments")
 		}
+		return nizk.VerifyCompact(dlTranscript(ctx), lstmt, &nizk.CompactLinearProof{
+			Chal: *proof.Chal,
+			Resp: proof.Resp,
+		})
 	}
 
-	// scalars contain [-e[0] * resp[0], ..., -e[n-1] * resp[n-1], e[0] * chal, ..., e[n-1] * chal, e[0], ..., e[n-1]]
-	scalars := make([]curve25519.Scalar, 3*n)
-	for i := 0; i < n; i++ {
-		scalars[i] = *curve25519.NegateScalar(curve25519.MultScalar(&e[i], &proof.Resp[i]))
-	}
-	for i := 0; i < n; i++ {
-		scalars[n+i] = *curve25519.MultScalar(&e[i], &chal)
-	}
-	for i := 0; i < n; i++ {
-		scalars[2*n+i] = e[i]
+	return nizk.Verify(dlTranscript(ctx), lstmt, &nizk.LinearProof{
+		Com:  proof.Com,
+		Resp: proof.Resp,
+	})
+}
+
+// dlBatchAdd adds the verification of the proof for the statement stmt in context ctx
+// to the item of the batch b
+// Proofs in compact form cannot be batched and are verified immediately
+func dlBatchAdd(b *nizk.Batch, item int, ctx *ProofContext, stmt DLStatement, proof DLProof) error {
+	if proof.Chal != nil {
+		return DLVerify(ctx, stmt, proof)
 	}
 
-	// Final verification of the equation
-	// pts scalar product with scalars is the point at infinitiy
-	r, err := curve25519.MultiMultPointXYScalarVarTime(pts, scalars)
+	lstmt, err := nizk.NewDLStatement(stmt.G, stmt.X)
 	if err != nil {
 		return err
 	}
-
-	if !curve25519.PointXYEqual(r, &curve25519.PointXYInfinity) {
-		return fmt.Errorf("batch verification equation failed")
-	}
-
-	return nil
-}
-
-func DLChHash(dchi DLChHashIn) (chal curve25519.Scalar) {
-	return zkHash("dlpok", dchi)
-}
-
-func zkHash(prefix string, in interface{}) (chal curve25519.Scalar) {
-	h := sha256.New()
-	h.Write([]byte(prefix)) // use a prefix for domain separation
-	h.Write(msgpack.Encode(in))
-	out := h.Sum(nil)
-
-	// Convert out into a scalar
-	// a bit non-optimal but simplest solution with what we have
-	if len(out) != curve25519.Chacha20KeyLength {
-		panic("output of sha256 not the right size")
-	}
-
-	var chacha20key [curve25519.Chacha20KeyLength]byte
-	copy(chacha20key[:], out)
-	curve25519.RandomScalarChacha20C(&chal, (*curve25519.Chacha20Key)(&chacha20key), 0)
-
-	return chal
+	return b.AddProof(item, dlTranscript(ctx), lstmt, &nizk.LinearProof{
+		Com:  proof.Com,
+		Resp: proof.Resp,
+	})
 }
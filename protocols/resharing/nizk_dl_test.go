@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/shaih/go-yosovss/msgpack"
 	"github.com/shaih/go-yosovss/primitives/curve25519"
 	"github.com/shaih/go-yosovss/primitives/feldman"
 	"github.com/stretchr/testify/assert"
@@ -32,11 +33,11 @@ func TestDLProveCorrect(t *testing.T) {
 			require.NoError(err)
 
 			// Generate the proof
-			proof, err := DLProve(stmt, wit)
+			proof, err := DLProve(nil, nil, ProofFormatBatchable, stmt, wit)
 			require.NoError(err)
 
 			// Verify it
-			err = DLVerify(stmt, proof)
+			err = DLVerify(nil, stmt, proof)
 			assert.NoError(err)
 		})
 	}
@@ -64,19 +65,89 @@ func TestDLProveIncorrect(t *testing.T) {
 			require.NoError(err)
 
 			// Generate the proof
-			proof, err := DLProve(stmt, wit)
+			proof, err := DLProve(nil, nil, ProofFormatBatchable, stmt, wit)
 			require.NoError(err)
 
 			// Break the proof
 			proof.Resp[0] = *curve25519.NegateScalar(&proof.Resp[0])
 
 			// Verify it
-			err = DLVerify(stmt, proof)
+			err = DLVerify(nil, stmt, proof)
 			assert.Error(err)
 		})
 	}
 }
 
+// TestDLProveContext checks that a proof for one context does not verify for another context
+func TestDLProveContext(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	stmt, wit, err := genDLStmtWit(3)
+	require.NoError(err)
+
+	ctx := &ProofContext{
+		SessionID:  []byte("session"),
+		Epoch:      1,
+		ProverID:   2,
+		N:          3,
+		T:          1,
+		Committees: seqCommittees(3),
+	}
+
+	proof, err := DLProve(nil, ctx, ProofFormatBatchable, stmt, wit)
+	require.NoError(err)
+	assert.NoError(DLVerify(ctx, stmt, proof))
+
+	// Proof must be rejected with no context
+	assert.Error(DLVerify(nil, stmt, proof))
+
+	// Proof must be rejected in another session/epoch or for another prover
+	otherSession := *ctx
+	otherSession.SessionID = []byte("other session")
+	assert.Error(DLVerify(&otherSession, stmt, proof))
+
+	otherEpoch := *ctx
+	otherEpoch.Epoch = 2
+	assert.Error(DLVerify(&otherEpoch, stmt, proof))
+
+	otherProver := *ctx
+	otherProver.ProverID = 1
+	assert.Error(DLVerify(&otherProver, stmt, proof))
+}
+
+func TestDLProveCompact(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	stmt, wit, err := genDLStmtWit(5)
+	require.NoError(err)
+
+	proof, err := DLProve(nil, nil, ProofFormatCompact, stmt, wit)
+	require.NoError(err)
+	require.NotNil(proof.Chal)
+	assert.Empty(proof.Com)
+	assert.NoError(DLVerify(nil, stmt, proof))
+
+	batchable, err := DLProve(nil, nil, ProofFormatBatchable, stmt, wit)
+	require.NoError(err)
+	assert.Less(len(msgpack.Encode(proof)), len(msgpack.Encode(batchable)), "compact proof is smaller")
+
+	// Compact proof with commitments is rejected
+	broken := proof
+	broken.Com = batchable.Com
+	assert.Error(DLVerify(nil, stmt, broken))
+
+	// Broken challenge
+	broken = proof
+	broken.Chal = curve25519.RandomScalar()
+	assert.Error(DLVerify(nil, stmt, broken))
+
+	// Broken response
+	proof.Resp[0] = *curve25519.NegateScalar(&proof.Resp[0])
+	assert.Error(DLVerify(nil, stmt, proof))
+}
+
 // genDLStmtWit generates a random valid statement and witness
 func genDLStmtWit(n int) (stmt DLStatement, wit DLWitness, err error) {
 	vcParams, err := feldman.GenerateVCParams(n)
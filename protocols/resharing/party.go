@@ -0,0 +1,374 @@
+package resharing
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/communication"
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+)
+
+// PartyStatus is the status of a Party
+type PartyStatus int
+
+const (
+	// PartyWaiting means that the party waits for the messages of the current round
+	// after having broadcast its payload (see Party.Payload)
+	PartyWaiting PartyStatus = iota
+	// PartyDone means that the party has finished and its output is available (see Party.Output)
+	PartyDone
+)
+
+// Party is a party of the resharing protocol driven step by step, i.e.,
+// a non-blocking version of StartCommitteeParty that does not use prv.BC
+//
+// At each round, the caller broadcasts Payload() (which may be empty, but must be broadcast)
+// and then calls Step with all the messages broadcast in the round.
+// The state of the party can be saved between two rounds with State and restored with RestoreParty.
+//
+// Example of use (which is what StartCommitteeParty does):
+//
+//	party, err := NewParty(pub, prv, dbg)
+//	payload, status := party.Payload(), party.Status()
+//	for status == PartyWaiting {
+//		prv.BC.Send(payload)
+//		_, bm := prv.BC.ReceiveRound()
+//		payload, status, err = party.Step(bm)
+//	}
+//	nextShare, nextCommitments, feldmanCommitments, err := party.Output()
+type Party struct {
+	pub *PublicInput
+	prv *PrivateInput
+	dbg *PartyDebugParams
+
+	// indices of the party in the committees (see Committees.Indices)
+	indices CommitteeIndices
+
+	state PartyState
+
+	// messages received so far (decoded from state.Received)
+	dealingMessages      []DealingMessage
+	verificationMessages []VerificationMessage
+	resolutionMessages   []ResolutionMessage
+
+	// output of the party, set when done
+	// nextShares[u] and nextCommitments[u] are for the secret u (see PublicInput.BatchCommitments)
+	nextShares         []*vss.Share
+	nextCommitments    [][]pedersen.Commitment
+	feldmanCommitments []feldman.GCommitment
+	qualifiedDealers   []int
+	blame              *BlameReport
+}
+
+// NewParty creates a party for the protocol with the given inputs
+// prv.BC is not used by the party
+// The payload of the first round is computed immediately (see Payload)
+func NewParty(pub *PublicInput, prv *PrivateInput, dbg *PartyDebugParams) (*Party, error) {
+	err := checkInputs(pub, prv) // sanity checks, T<=N, len(pub)=N+1,...
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Party{
+		pub:     pub,
+		prv:     prv,
+		dbg:     dbg,
+		indices: pub.Committees.Indices(prv.ID),
+	}
+
+	p.state.Payload, err = p.perform(0)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// RestoreParty restores a party from its state (see Party.State)
+// pub, prv, and dbg must be the same as the ones given to NewParty,
+// except for prv.BC and prv.Rand which may differ
+func RestoreParty(pub *PublicInput, prv *PrivateInput, dbg *PartyDebugParams, state []byte) (*Party, error) {
+	err := checkInputs(pub, prv)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Party{
+		pub:     pub,
+		prv:     prv,
+		dbg:     dbg,
+		indices: pub.Committees.Indices(prv.ID),
+	}
+
+	err = msgpack.Decode(state, &p.state)
+	if err != nil {
+		return nil, fmt.Errorf("party %d failed to decode its state: %w", prv.ID, err)
+	}
+	if p.state.Round < 0 || p.state.Round > pub.NumRounds() || len(p.state.Received) != p.state.Round {
+		return nil, fmt.Errorf("party %d has an invalid state", prv.ID)
+	}
+	if p.state.Round < pub.NumRounds() && p.state.Payload == nil {
+		p.state.Payload = []byte{} // empty payloads are not encoded
+	}
+
+	// Decode again the messages received so far
+	for r, payloads := range p.state.Received {
+		if len(payloads) != len(p.roundCommittee(r)) {
+			return nil, fmt.Errorf("party %d has an invalid state: invalid number of payloads in round %d", prv.ID, r)
+		}
+		p.receive(r, payloads)
+	}
+
+	// The output is not part of the state, so it is computed again
+	if p.state.Round == pub.NumRounds() {
+		_, err = p.perform(pub.NumRounds())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// Round returns the current round of the party (PublicInput.NumRounds when done)
+func (p *Party) Round() int {
+	return p.state.Round
+}
+
+// Status returns the status of the party
+func (p *Party) Status() PartyStatus {
+	if p.state.Round == p.pub.NumRounds() {
+		return PartyDone
+	}
+	return PartyWaiting
+}
+
+// Payload returns the payload to broadcast in the current round (nil when done)
+// It is empty if the party is not a member of the committee sending messages in the current round
+func (p *Party) Payload() []byte {
+	return p.state.Payload
+}
+
+// State returns the serialized state of the party, which can be restored with RestoreParty
+// WARNING: it is neither encrypted nor authenticated (see SaveCheckpoint)
+func (p *Party) State() []byte {
+	return msgpack.Encode(&p.state)
+}
+
+// Output returns the output of the party, once done (see StartCommitteePartyWithFeldman)
+// For a batched refresh, use BatchOutput
+func (p *Party) Output() (
+	nextShare *vss.Share,
+	nextCommitments []pedersen.Commitment,
+	feldmanCommitments []feldman.GCommitment,
+	err error,
+) {
+	if p.pub.numSecrets() != 1 {
+		return nil, nil, nil, fmt.Errorf("party %d: use BatchOutput for a batched refresh", p.prv.ID)
+	}
+	nextShares, batchNextCommitments, err := p.BatchOutput()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if nextShares != nil {
+		nextShare = nextShares[0]
+	}
+	if batchNextCommitments != nil {
+		nextCommitments = batchNextCommitments[0]
+	}
+	return nextShare, nextCommitments, p.feldmanCommitments, nil
+}
+
+// BatchOutput returns the output of the party for a batched refresh, once done (see StartBatchCommitteeParty)
+// nextShares[u] and nextCommitments[u] are for the secret u in 0,...,K-1
+func (p *Party) BatchOutput() (
+	nextShares []*vss.Share,
+	nextCommitments [][]pedersen.Commitment,
+	err error,
+) {
+	if p.Status() != PartyDone {
+		return nil, nil, fmt.Errorf("party %d is not done: round %d", p.prv.ID, p.state.Round)
+	}
+	return p.nextShares, p.nextCommitments, nil
+}
+
+// QualifiedDealers returns the dealers whose sharings are used for refreshing, once done
+// (indices in the holding committee in increasing order, see ComputeQualifiedDealers)
+// If pub.AllQualifiedDealers is set, it is the full set of qualified dealers
+// It is nil if the party is not done or if refreshing is skipped
+func (p *Party) QualifiedDealers() []int {
+	return p.qualifiedDealers
+}
+
+// QualifiedDealersDigest returns the public commitment to QualifiedDealers (see QualifiedDealersDigest),
+// which is the same for all the parties and for the auditor of the refresh
+// It is nil if the party is not done or if refreshing is skipped
+func (p *Party) QualifiedDealersDigest() []byte {
+	if p.qualifiedDealers == nil {
+		return nil
+	}
+	return QualifiedDealersDigest(p.pub, p.qualifiedDealers)
+}
+
+// Blame returns the blame report of the party, once done (see PerformRefresh)
+// It is nil if the party is not done or if refreshing is skipped
+func (p *Party) Blame() *BlameReport {
+	return p.blame
+}
+
+// Step processes the messages broadcast in the current round
+// and returns the payload to broadcast in the next round and the new status of the party
+// messages must contain the messages of all the parties that sent a message in the round
+// (missing messages are considered to be empty)
+// If an error is returned, the party is not modified
+func (p *Party) Step(messages []communication.BroadcastMessage) (
+	payload []byte,
+	status PartyStatus,
+	err error,
+) {
+	round := p.state.Round
+	if round == p.pub.NumRounds() {
+		return nil, PartyDone, fmt.Errorf("party %d is already done", p.prv.ID)
+	}
+
+	payloads := communication.PayloadsFrom(messages, p.roundCommittee(round))
+
+	// The messages are decoded and the round performed on a copy of the party,
+	// which replaces the party only if no error occurs
+	next := *p
+	next.receive(round, payloads)
+
+	payload, err = next.perform(round + 1)
+	if err != nil {
+		return nil, p.Status(), err
+	}
+
+	next.state.Round = round + 1
+	next.state.Payload = payload
+	next.state.Received = append(next.state.Received, payloads)
+	*p = next
+	return payload, p.Status(), nil
+}
+
+// roundCommittee returns the committee sending messages in the round
+func (p *Party) roundCommittee(round int) []int {
+	switch round {
+	case 0:
+		return p.pub.Committees.Hold
+	case 1:
+		return p.pub.Committees.Ver
+	default:
+		return p.pub.Committees.Res
+	}
+}
+
+// receive decodes the payloads sent in the round (see PartyState.Received)
+func (p *Party) receive(round int, payloads [][]byte) {
+	switch round {
+	case 0:
+		p.dealingMessages = DecodeDealingMessages(payloads, p.pub.Committees.Hold)
+	case 1:
+		p.verificationMessages = DecodeVerificationMessages(payloads, p.pub.Committees.Ver)
+	default:
+		p.resolutionMessages = DecodeResolutionMessages(payloads, p.pub.Committees.Res)
+	}
+}
+
+// perform executes what the party needs to do in the round, when all the messages of the previous rounds
+// are received, and returns the payload to broadcast in the round
+// In the last round (round = PublicInput.NumRounds), the party computes its output instead
+func (p *Party) perform(round int) (payload []byte, err error) {
+	pub, prv, dbg, indices := p.pub, p.prv, p.dbg, p.indices
+
+	switch {
+	case round == 0:
+		// Dealing
+		// =======
+
+		// If this party is part of the holding committee (i.e., holds a share),
+		// then it plays the role of a dealer for its share in the 2-level
+		// sharing and sends  shares to the verification committee.
+		if indices.Hold >= 0 {
+			msg, err := PerformDealing(pub, prv, dbg) // compute msg to be bcast by dealer
+			if err != nil {
+				return nil, fmt.Errorf("party %d failed to perform dealing: %w", prv.ID, err)
+			}
+			return msgpack.Encode(msg), nil
+		}
+		// Do nothing if not part of the holding committee
+		return []byte{}, nil // an empty message
+
+	case round == 1:
+		// Verification
+		// ============
+
+		// If this party is a member of the verification committee then verify all
+		// the messages that were broadcast by dealer from the holding committe.
+		if indices.Ver >= 0 {
+			// For each dealer, either forward its shares to the next holding
+			// committee or broadcast a complaint about it.
+			msg, err := PerformVerification(pub, prv, indices.Ver, p.dealingMessages, dbg)
+			if err != nil {
+				return nil, fmt.Errorf("party %d failed to perform verification: %w", prv.ID, err)
+			}
+			return msgpack.Encode(msg), nil
+		}
+		// Do nothing if not part of the verification committee
+		return []byte{}, nil // an empty message
+
+	case round < pub.NumRounds():
+		// Resolution (= Future Broadcast)
+		// ===============================
+		// (skipped with verifiable encryption, see PublicInput.NumRounds)
+
+		// If this party is a member of the resolution (future broadcast) committee,
+		// then for every complaint (j complain about i) it publishes everything
+		// that the dealer i sent to verifier j
+		if indices.Res >= 0 {
+			msg, err := PerformResolution(pub, prv, indices.Res, p.dealingMessages, p.verificationMessages)
+			if err != nil {
+				return nil, fmt.Errorf("party %d failed to perform resolution: %w", prv.ID, err)
+			}
+			return msgpack.Encode(msg), nil
+		}
+		// Do nothing if not part of the resolution committee
+		return []byte{}, nil
+
+	default:
+		// Refreshing
+		// =========
+
+		// Last phase where everybody computes the commitments of the refreshed shares
+		// and parties in the new holding committee compute their refreshed shares
+		if dbg.SkipRefreshing {
+			return nil, nil
+		}
+
+		nextCommitments, nextShares, qualifiedDealers, blame, err := performRefresh(
+			pub,
+			prv,
+			p.dealingMessages,
+			p.verificationMessages,
+			p.resolutionMessages,
+			indices.Next,
+			dbg,
+		)
+		p.blame = blame
+		if err != nil {
+			return nil, err
+		}
+
+		var feldmanCommitments []feldman.GCommitment
+		if pub.FeldmanConversion {
+			feldmanCommitments, err = ComputeFeldmanCommitments(pub, p.dealingMessages, qualifiedDealers)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compute Feldman commitments: %w", err)
+			}
+		}
+
+		p.nextShares, p.nextCommitments, p.feldmanCommitments = nextShares, nextCommitments, feldmanCommitments
+		p.qualifiedDealers = qualifiedDealers
+		return nil, nil
+	}
+}
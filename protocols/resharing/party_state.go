@@ -0,0 +1,13 @@
+package resharing
+
+// PartyState is the state of a Party between two rounds (see Party.State and RestoreParty)
+// It does not contain the inputs of the party (PublicInput and PrivateInput)
+// which must be provided again when restoring the party
+type PartyState struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+	Round   int      `codec:"r"` // current round in 0,...,NumRounds (NumRounds when done, see PublicInput.NumRounds)
+	Payload []byte   `codec:"p"` // payload to broadcast in the current round
+	// It is kept so that a restored party broadcasts exactly the same payload
+	Received [][][]byte `codec:"m"` // Received[r][x] is the payload sent in round r
+	// by the x-th member of the committee sending in round r (see roundCommittee)
+}
@@ -0,0 +1,188 @@
+package resharing
+
+import (
+	"testing"
+
+	"github.com/shaih/go-yosovss/communication"
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartyEventLoop(t *testing.T) {
+	// Test the resharing protocol when all the parties are driven step by step from a single loop
+	// (without broadcast channels), possibly saving and restoring all the parties at each round
+
+	const (
+		n          = 3                 // number of parties per committee
+		numParties = n * numCommittees // total number of parties
+		tt         = 1                 // threshold of malicious parties
+	)
+
+	testCases := []struct {
+		name    string
+		restore bool
+	}{
+		{"no-restore", false},
+		{"restore", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+			assert := assert.New(t)
+
+			pub, prvs, _, secret, rnd := setupResharingSeq(t, n, tt)
+			pub.FeldmanConversion = true
+			dbg := &PartyDebugParams{}
+
+			parties := make([]*Party, numParties)
+			for party := range parties {
+				var err error
+				parties[party], err = NewParty(pub, &prvs[party], dbg)
+				require.NoError(err)
+				assert.Equal(0, parties[party].Round())
+				assert.Equal(PartyWaiting, parties[party].Status())
+			}
+
+			for round := 0; round < numRounds; round++ {
+				if tc.restore {
+					for party := range parties {
+						restored, err := RestoreParty(pub, &prvs[party], dbg, parties[party].State())
+						require.NoError(err)
+						assert.Equal(parties[party].Round(), restored.Round())
+						assert.Equal(parties[party].Payload(), restored.Payload())
+						parties[party] = restored
+					}
+				}
+
+				// Broadcast the payloads of all the parties
+				messages := make([]communication.BroadcastMessage, numParties)
+				for party := range parties {
+					messages[party] = communication.BroadcastMessage{
+						Payload:  parties[party].Payload(),
+						SenderID: party,
+					}
+				}
+
+				for party := range parties {
+					_, status, err := parties[party].Step(messages)
+					require.NoError(err)
+					assert.Equal(round+1, parties[party].Round())
+					if round+1 < numRounds {
+						assert.Equal(PartyWaiting, status)
+					} else {
+						assert.Equal(PartyDone, status)
+						assert.Nil(parties[party].Payload())
+					}
+				}
+			}
+
+			// Output of all parties
+			outputCommitments := make([][]feldman.GCommitment, numParties)
+			outputShares := make([]*vss.Share, numParties)
+			outputFeldmanCommitments := make([][]feldman.GCommitment, numParties)
+			for party := range parties {
+				if tc.restore {
+					// The output of a restored party is computed again
+					restored, err := RestoreParty(pub, &prvs[party], dbg, parties[party].State())
+					require.NoError(err)
+					parties[party] = restored
+				}
+				var err error
+				outputShares[party], outputCommitments[party], outputFeldmanCommitments[party], err =
+					parties[party].Output()
+				require.NoError(err)
+			}
+
+			checkProtocolResults(t, pub, secret, rnd, outputCommitments, outputShares, false)
+
+			for party := range parties {
+				assert.Equal(outputFeldmanCommitments[0], outputFeldmanCommitments[party])
+			}
+			require.Len(outputFeldmanCommitments[0], n+1)
+		})
+	}
+}
+
+func TestPartyErrors(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n  = 3
+		tt = 1
+	)
+
+	pub, prvs, _, _, _ := setupResharingSeq(t, n, tt)
+	dbg := &PartyDebugParams{SkipRefreshing: true}
+
+	party, err := NewParty(pub, &prvs[0], dbg)
+	require.NoError(err)
+	assert.NotEmpty(party.Payload()) // party 0 is a dealer
+
+	// No output before the end
+	_, _, _, err = party.Output()
+	assert.Error(err)
+
+	// Invalid states
+	_, err = RestoreParty(pub, &prvs[0], dbg, []byte{0xde, 0xad})
+	assert.Error(err)
+	_, err = RestoreParty(pub, &prvs[0], dbg, msgpack.Encode(&PartyState{Round: 1}))
+	assert.Error(err)
+	_, err = RestoreParty(pub, &prvs[0], dbg, msgpack.Encode(&PartyState{Round: numRounds + 1}))
+	assert.Error(err)
+	_, err = RestoreParty(pub, &prvs[0], dbg, msgpack.Encode(&PartyState{Round: 1, Received: [][][]byte{{}}}))
+	assert.Error(err)
+
+	// Step with no message at all: all the parties are considered malicious
+	// but party 0 is only a dealer and refreshing is skipped, so it does not fail
+	for round := 0; round < numRounds; round++ {
+		_, _, err = party.Step(nil)
+		require.NoError(err)
+	}
+	assert.Equal(PartyDone, party.Status())
+
+	_, _, err = party.Step(nil)
+	assert.Error(err)
+
+	nextShare, nextCommitments, _, err := party.Output()
+	assert.NoError(err)
+	assert.Nil(nextShare)
+	assert.Nil(nextCommitments)
+}
+
+func TestPartyStepErrorUnmodified(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n  = 3
+		tt = 1
+	)
+
+	pub, prvs, _, _, _ := setupResharingSeq(t, n, tt)
+	dbg := &PartyDebugParams{}
+
+	// A member of the next holding committee receiving no message at all
+	// fails to refresh in the last round as no dealer is qualified
+	party, err := NewParty(pub, &prvs[pub.Committees.Next[0]], dbg)
+	require.NoError(err)
+	for round := 0; round < numRounds-1; round++ {
+		_, _, err = party.Step(nil)
+		require.NoError(err)
+	}
+
+	state := party.State()
+	_, status, err := party.Step(nil)
+	require.Error(err)
+
+	// The failed step must not modify the party
+	assert.Equal(PartyWaiting, status)
+	assert.Equal(numRounds-1, party.Round())
+	assert.Equal(state, party.State())
+	assert.Nil(party.resolutionMessages)
+	assert.Nil(party.Blame())
+}
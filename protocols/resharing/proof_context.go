@@ -0,0 +1,83 @@
+package resharing
+
+import "github.com/shaih/go-yosovss/primitives/transcript"
+
+// ProofContext binds the Fiat-Shamir challenges of the NIZK proofs to a given run of the protocol
+// and to a given prover
+// Without it, a proof from one refresh could be replayed in another refresh (or by another party)
+// that happens to have the same statement
+type ProofContext struct {
+	SessionID  []byte     // identifier of the session (see PublicInput)
+	Epoch      uint64     // epoch of the refresh (see PublicInput)
+	ProverID   int        // party ID of the prover
+	N          int        // size of the holding committee
+	T          int        // max number of malicious parties in the holding committee
+	TVer       int        // max number of malicious parties in the verification committee
+	TRes       int        // max number of malicious parties in the resolution committee
+	TNext      int        // max number of malicious parties in the next holding committee
+	Committees Committees // committees of the refresh (which also define their sizes)
+}
+
+// ProofFormat is the format of a NIZK proof
+type ProofFormat int
+
+const (
+	// ProofFormatBatchable proofs contain the commitments and the responses
+	// Their verification can be batched, which is faster
+	ProofFormatBatchable ProofFormat = iota
+	// ProofFormatCompact proofs contain the challenge and the responses
+	// They are smaller (no commitment, i.e., 64 bytes less per element)
+	// but their verification cannot be batched
+	ProofFormatCompact
+)
+
+// ProofFormats selects the format of the proofs sent by the parties
+// The verifiers accept both formats whatever the selection is
+type ProofFormats struct {
+	Dealing      ProofFormat // format of DblDLEqProof in DealingMessage
+	Verification ProofFormat // format of VPComProof.DLProofR in VerificationMessage
+}
+
+// ProofContext returns the context for the proofs made by the party proverID
+func (pub *PublicInput) ProofContext(proverID int) *ProofContext {
+	return &ProofContext{
+		SessionID:  pub.SessionID,
+		Epoch:      pub.Epoch,
+		ProverID:   proverID,
+		N:          pub.N,
+		T:          pub.T,
+		TVer:       pub.verParams().D,
+		TRes:       pub.resParams().D,
+		TNext:      pub.nextParams().D,
+		Committees: pub.Committees,
+	}
+}
+
+// bind absorbs the context into the transcript t
+// A nil context does not bind anything: it should only be used for proofs outside of the protocol (e.g., tests)
+func (ctx *ProofContext) bind(t *transcript.Transcript) {
+	if ctx == nil {
+		t.AppendMessage("ctx", nil)
+		return
+	}
+	t.AppendMessage("ctx", []byte{1})
+	t.AppendMessage("session", ctx.SessionID)
+	t.AppendUint64("epoch", ctx.Epoch)
+	t.AppendUint64("prover", uint64(ctx.ProverID))
+	t.AppendUint64("n", uint64(ctx.N))
+	t.AppendUint64("t", uint64(ctx.T))
+	t.AppendUint64("t_ver", uint64(ctx.TVer))
+	t.AppendUint64("t_res", uint64(ctx.TRes))
+	t.AppendUint64("t_next", uint64(ctx.TNext))
+	bindCommittee(t, "hold", ctx.Committees.Hold)
+	bindCommittee(t, "ver", ctx.Committees.Ver)
+	bindCommittee(t, "res", ctx.Committees.Res)
+	bindCommittee(t, "next", ctx.Committees.Next)
+}
+
+func bindCommittee(t *transcript.Transcript, label string, committee []int) {
+	t.AppendUint64(label, uint64(len(committee)))
+	for _, id := range committee {
+		t.AppendUint64(label, uint64(id))
+	}
+}
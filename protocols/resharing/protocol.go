@@ -2,10 +2,9 @@ package resharing
 
 import "C"
 import (
-	"fmt"
 	"github.com/shaih/go-yosovss/primitives/vss"
 
-	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/feldman"
 	"github.com/shaih/go-yosovss/primitives/pedersen"
 )
 
@@ -24,6 +23,7 @@ type PartyDebugParams struct {
 // the new protocol with batching
 // It does one full refresh and returns the next commitments and (if the party if a next-committee member) its new share
 // (or nil otherwise)
+// Use RunParty to also get the qualified dealers
 func StartCommitteeParty(
 	pub *PublicInput,
 	prv *PrivateInput,
@@ -33,107 +33,75 @@ func StartCommitteeParty(
 	nextCommitments []pedersen.Commitment,
 	err error,
 ) {
-	// FIXME: everywhere the protocol may fail if some malicious messages are sent
-	// instead the protocol should continue and treat the party as malicious
-	// but for debugging it is so much simpler not to add this, so we did not yet
-	// However, a real implementation must add all these tests and handle things properly
-
-	err = checkInputs(pub, prv) // sanity checks, T<=N, len(pub)=N+1,...
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Compute the indices of this party in the various initial committees (holding, verfication, ...)
-	// indices.XYZ == -1 means that this party is not a part of the XYZ committee
-	indices := pub.Committees.Indices(prv.ID)
-
-	// Dealing
-	// =======
+	nextShare, nextCommitments, _, err = StartCommitteePartyWithFeldman(pub, prv, dbg)
+	return nextShare, nextCommitments, err
+}
 
-	// If this party is part of the holding committee (i.e., holds a share),
-	// then it plays the role of a dealer for its share in the 2-level
-	// sharing and sends  shares to the verification committee.
-	if indices.Hold >= 0 {
-		msg, err := PerformDealing(pub, prv, dbg) // compute msg to be bcast by dealer
-		if err != nil {
-			return nil, nil, fmt.Errorf("party %d failed to perform dealing: %w", prv.ID, err)
-		}
-		prv.BC.Send(msgpack.Encode(msg)) // breoadcast this msg
-	} else { // Do nothing if not part of the holding committee
-		prv.BC.Send([]byte{}) // an empty message
-	}
+// StartCommitteePartyWithFeldman is the same as StartCommitteeParty but also returns
+// the Feldman commitments of the refreshed sharing if pub.FeldmanConversion is set (see ComputeFeldmanCommitments)
+// feldmanCommitments[0] is then the public key s G of the secret s
+// As the secret does not change, the public key is the same for all the epochs
+func StartCommitteePartyWithFeldman(
+	pub *PublicInput,
+	prv *PrivateInput,
+	dbg *PartyDebugParams,
+) (
+	nextShare *vss.Share,
+	nextCommitments []pedersen.Commitment,
+	feldmanCommitments []feldman.GCommitment,
+	err error,
+) {
+	// Malicious messages (missing, undecodable, or with fields of incorrect length or content)
+	// never make the protocol fail: the sender is treated as malicious instead
+	// (dealers are disqualified and verifiers' shares are ignored)
+	// The protocol only fails if there are more than t malicious parties in a committee
 
-	// Receive the broadcast messages from all the dealers, returns an array of messages
-	dealingMessages, err := ReceiveDealingMessages(prv.BC, pub.Committees.Hold)
+	// The protocol is implemented by Party, which is driven here using prv.BC
+	party, err := RunParty(pub, prv, dbg)
 	if err != nil {
-		return nil, nil, fmt.Errorf("party %d failed receiving dealing messages: %w", prv.ID, err)
-	}
-
-	// Verification
-	// ============
-
-	// If this party is a member of the verification committee then verify all
-	// the messages that were broadcast by dealer from the holding committe.
-	if indices.Ver >= 0 {
-		// For each dealer, either forward its shares to the next holding
-		// committee or broadcast a complaint about it.
-		msg, err := PerformVerification(pub, prv, indices.Ver, dealingMessages, dbg)
-		if err != nil {
-			return nil, nil, fmt.Errorf("party %d failed to perform verification: %w", prv.ID, err)
-		}
-		prv.BC.Send(msgpack.Encode(msg)) // broadcast the message
-	} else { // Do nothing if not part of the verification committee
-		prv.BC.Send([]byte{}) // an empty message
+		return nil, nil, nil, err
 	}
+	return party.Output()
+}
 
-	// Receive broadcast messages from the verification committee
-	verificationMessages, err := ReceiveVerificationMessages(prv.BC, pub.Committees.Ver)
+// StartBatchCommitteeParty is the same as StartCommitteeParty for a batched refresh of K secrets
+// (see PublicInput.BatchCommitments)
+// nextShares[u] and nextCommitments[u] are for the secret u in 0,...,K-1
+// All the secrets share the messages, the encryptions, and the proofs (see DealingMessage)
+// so that refreshing K secrets at once costs much less than K refreshes
+func StartBatchCommitteeParty(
+	pub *PublicInput,
+	prv *PrivateInput,
+	dbg *PartyDebugParams,
+) (
+	nextShares []*vss.Share,
+	nextCommitments [][]pedersen.Commitment,
+	err error,
+) {
+	party, err := RunParty(pub, prv, dbg)
 	if err != nil {
-		return nil, nil, fmt.Errorf("party %d failed receiving verification messages: %w", prv.ID, err)
-	}
-
-	// Resolution (= Future Broadcast)
-	// ===============================
-
-	// If this party is a member of the resolution (future broadcast) committee,
-	// then for every complaint (j complain about i) it publishes everything
-	// that the dealer i sent to verifier j
-	if indices.Res >= 0 {
-		msg, err := PerformResolution(pub, prv, indices.Res, dealingMessages, verificationMessages)
-		if err != nil {
-			return nil, nil, fmt.Errorf("party %d failed to perform resolution: %w", prv.ID, err)
-		}
-		prv.BC.Send(msgpack.Encode(msg))
-	} else { // Do nothing if not part of the resolution committee
-		prv.BC.Send([]byte{})
+		return nil, nil, err
 	}
+	return party.BatchOutput()
+}
 
-	// Receive broadcast messages from the resolution committee
-	resolutionMessages, err := ReceiveResolutionMessages(prv.BC, pub.Committees.Res)
+// RunParty runs a party until it is done, using prv.BC to broadcast and receive the messages,
+// and returns it so that all its outputs are available
+// (e.g., Party.QualifiedDealers and Party.QualifiedDealersDigest, which StartCommitteeParty does not return)
+func RunParty(pub *PublicInput, prv *PrivateInput, dbg *PartyDebugParams) (*Party, error) {
+	party, err := NewParty(pub, prv, dbg)
 	if err != nil {
-		return nil, nil, fmt.Errorf("party %d failed receiving resolution messages: %w", prv.ID, err)
+		return nil, err
 	}
 
-	// Refreshing
-	// =========
-
-	// Last phase where everybody computes the commitments of the refreshed shares
-	// and parties in the new holding committee compute their refreshed shares
-
-	if !dbg.SkipRefreshing {
-		nextCommitments, nextShare, err = PerformRefresh(
-			pub,
-			prv,
-			dealingMessages,
-			verificationMessages,
-			resolutionMessages,
-			indices.Next,
-			dbg,
-		)
+	payload, status := party.Payload(), party.Status()
+	for status == PartyWaiting {
+		prv.BC.Send(payload)
+		_, bm := prv.BC.ReceiveRound()
+		payload, status, err = party.Step(bm)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 	}
-
-	return nextShare, nextCommitments, nil
+	return party, nil
 }
@@ -269,8 +269,6 @@ func TestResharingProtocolBenchmarkManualParty0(t *testing.T) {
 	originalLogLevel := log.GetLevel()
 	log.SetLevel(log.ErrorLevel)
 
-	require := require.New(t)
-
 	var (
 		tt = getBenchTestT() // threshold of malicious parties, use env variable YOSO_BENCH_TEST_T to control
 		n  = 2*tt + 1        // number of parties per committee
@@ -307,8 +305,7 @@ func TestResharingProtocolBenchmarkManualParty0(t *testing.T) {
 	// Remark we only decode dealing messages once here
 	// that means we don't have any copy
 	// The decoding time is counted in party 0 time which is fair
-	dealingMessages, err := ReceiveDealingMessages(prvs[0].BC, pub.Committees.Hold)
-	require.NoError(err)
+	dealingMessages := ReceiveDealingMessages(prvs[0].BC, pub.Committees.Hold)
 
 	runManualRound(t, n, &o, &lastTime, prvs, func(prv *PrivateInput, party int) (interface{}, error) {
 		return PerformVerification(pub, prv, party, dealingMessages, &PartyDebugParams{
@@ -319,8 +316,7 @@ func TestResharingProtocolBenchmarkManualParty0(t *testing.T) {
 	// Res
 	// ===
 
-	verificationMessages, err := ReceiveVerificationMessages(prvs[0].BC, pub.Committees.Ver)
-	require.NoError(err)
+	verificationMessages := ReceiveVerificationMessages(prvs[0].BC, pub.Committees.Ver)
 
 	runManualRound(t, n, &o, &lastTime, prvs, func(prv *PrivateInput, party int) (interface{}, error) {
 		return PerformResolution(pub, prv, party, dealingMessages, verificationMessages)
@@ -329,12 +325,11 @@ func TestResharingProtocolBenchmarkManualParty0(t *testing.T) {
 	// Refreshing
 	// ==========
 
-	resolutionMessages, err := ReceiveResolutionMessages(prvs[0].BC, pub.Committees.Res)
-	require.NoError(err)
+	resolutionMessages := ReceiveResolutionMessages(prvs[0].BC, pub.Committees.Res)
 
 	runManualRound(t, n, &o, &lastTime, prvs, func(prv *PrivateInput, party int) (interface{}, error) {
 		if party == 0 {
-			outputCommitments[0], outputShares[0], err = PerformRefresh(
+			outputCommitments[0], outputShares[0], _, err = PerformRefresh(
 				pub,
 				prv,
 				dealingMessages,
@@ -343,7 +338,7 @@ func TestResharingProtocolBenchmarkManualParty0(t *testing.T) {
 				party,
 				&PartyDebugParams{SkipDealingFutureBroadcast: skipDealingFutureBroadcastOtherParties},
 			)
-			return struct{}{}, nil
+			return struct{}{}, err
 		}
 		// we skip witness for party non-zero
 		return struct{}{}, nil
@@ -4,9 +4,11 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/shaih/go-yosovss/communication"
 	"github.com/shaih/go-yosovss/msgpack"
 	"github.com/shaih/go-yosovss/primitives/curve25519"
 	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
 	"github.com/shaih/go-yosovss/primitives/vss"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -68,6 +70,148 @@ func TestResharingProtocol(t *testing.T) {
 	)
 }
 
+func TestResharingProtocolFromDKG(t *testing.T) {
+	// Test resharing protocol when the initial sharing is generated by a DKG
+	// instead of a trusted dealer
+	const (
+		n  = 3 // number of parties per committee
+		tt = 1 // threshold of malicious parties
+	)
+
+	pub, prvs, o, _, _ := setupResharingSeq(t, n, tt)
+	secret, rnd := replaceSharingByDKG(t, pub, prvs)
+
+	parties, errs := runResharingParties(t, pub, prvs, o)
+	outputShares, outputCommitments, _ := resharingOutputs(t, parties, errs)
+
+	checkProtocolResults(
+		t,
+		pub,
+		secret,
+		rnd,
+		outputCommitments,
+		outputShares,
+		false,
+	)
+}
+
+func TestResharingProtocolFeldman(t *testing.T) {
+	// Test resharing protocol with the conversion of the sharing to Feldman
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n          = 3                 // number of parties per committee
+		numParties = n * numCommittees // total number of parties
+		tt         = 1                 // threshold of malicious parties
+	)
+
+	pub, prvs, o, secret, rnd := setupResharingSeq(t, n, tt)
+	pub.FeldmanConversion = true
+
+	parties, errs := runResharingParties(t, pub, prvs, o)
+	outputShares, outputCommitments, outputFeldmanCommitments := resharingOutputs(t, parties, errs)
+
+	checkProtocolResults(
+		t,
+		pub,
+		secret,
+		rnd,
+		outputCommitments,
+		outputShares,
+		false,
+	)
+
+	// Check the Feldman commitments: F_0 = s G and F_{i+1} = sigma_{i+1} G
+	pk, err := curve25519.MultBaseGPointXYScalar(secret)
+	require.NoError(err)
+	for party := 0; party < numParties; party++ {
+		require.Len(outputFeldmanCommitments[party], n+1)
+		assert.Equal(*pk, outputFeldmanCommitments[party][0])
+		for i, dealer := range pub.Committees.Hold {
+			y, err := curve25519.MultBaseGPointXYScalar(&prvs[dealer].Share.S)
+			require.NoError(err)
+			assert.Equal(*y, outputFeldmanCommitments[party][i+1])
+		}
+	}
+}
+
+func TestResharingProtocolAllQualifiedDealers(t *testing.T) {
+	// Test resharing protocol when all the qualified dealers are used for refreshing
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n          = 5                 // number of parties per committee
+		numParties = n * numCommittees // total number of parties
+		tt         = 2                 // threshold of malicious parties
+	)
+
+	pub, prvs, o, secret, rnd := setupResharingSeq(t, n, tt)
+	pub.AllQualifiedDealers = true
+	pub.FeldmanConversion = true
+
+	parties, errs := runResharingParties(t, pub, prvs, o)
+	outputShares, outputCommitments, outputFeldmanCommitments := resharingOutputs(t, parties, errs)
+
+	checkProtocolResults(
+		t,
+		pub,
+		secret,
+		rnd,
+		outputCommitments,
+		outputShares,
+		false,
+	)
+
+	// All the n dealers are honest, so they are all used for refreshing (and not only the first t+1)
+	allDealers := rangeSlice(0, n)
+	for party := 0; party < numParties; party++ {
+		assert.Equal(allDealers, parties[party].QualifiedDealers())
+		assert.Equal(QualifiedDealersDigest(pub, allDealers), parties[party].QualifiedDealersDigest())
+	}
+
+	pk, err := curve25519.MultBaseGPointXYScalar(secret)
+	require.NoError(err)
+	for party := 0; party < numParties; party++ {
+		require.Len(outputFeldmanCommitments[party], n+1)
+		assert.Equal(*pk, outputFeldmanCommitments[party][0])
+	}
+}
+
+func TestResharingProtocolSecretRange(t *testing.T) {
+	// Test resharing protocol of a secret with a proof that it is in a range (see PublicInput.SecretRangeProof)
+	assert := assert.New(t)
+
+	const (
+		n  = 3  // number of parties per committee
+		tt = 1  // threshold of malicious parties
+		k  = 32 // number of bits of the secret
+	)
+
+	pub, prvs, o, _, _ := setupResharingSeq(t, n, tt)
+	secret := curve25519.GetScalar(0xdeadbeef)
+	rnd := replaceSharingByRange(t, pub, prvs, secret, k)
+
+	parties, errs := runResharingParties(t, pub, prvs, o)
+	outputShares, outputCommitments, _ := resharingOutputs(t, parties, errs)
+
+	checkProtocolResults(
+		t,
+		pub,
+		secret,
+		rnd,
+		outputCommitments,
+		outputShares,
+		false,
+	)
+
+	// The proof remains valid for the next refresh, as the commitment to the secret does not change
+	nextPub := *pub
+	nextPub.Commitments = outputCommitments[pub.Committees.Next[0]]
+	assert.NoError(CheckPublicInput(&nextPub))
+}
+
 func TestResharingProtocolDealerInvalidComC(t *testing.T) {
 	// Make the dealer 0 cheating so that it is disqualified
 	// comC is made incorrect
@@ -115,28 +259,30 @@ func TestResharingProtocolDealerInvalidComC(t *testing.T) {
 			require.NoError(err)
 			msg.ComC[0] = *c
 			prvs[0].BC.Send(msgpack.Encode(msg))
-			dealingMessages, err := ReceiveDealingMessages(prvs[0].BC, pub.Committees.Hold)
-			require.NoError(err)
+			dealingMessages := ReceiveDealingMessages(prvs[0].BC, pub.Committees.Hold)
 
 			// Ver
 			prvs[0].BC.Send([]byte{})
-			verificationMessages, err := ReceiveVerificationMessages(prvs[0].BC, pub.Committees.Ver)
-			require.NoError(err)
+			verificationMessages := ReceiveVerificationMessages(prvs[0].BC, pub.Committees.Ver)
 
 			// Res
 			prvs[0].BC.Send([]byte{})
-			resolutionMessages, err := ReceiveResolutionMessages(prvs[0].BC, pub.Committees.Res)
-			require.NoError(err)
+			resolutionMessages := ReceiveResolutionMessages(prvs[0].BC, pub.Committees.Res)
 
 			// Refreshing
-			_, disqualifiedDealers, err := ResolveComplaints(pub, dealingMessages, verificationMessages,
+			_, disqualifiedDealers, blame, err := ResolveComplaints(pub, dealingMessages, verificationMessages,
 				resolutionMessages, &PartyDebugParams{})
 			require.NoError(err)
-			qualifiedDealers, _, err := ComputeQualifiedDealers(pub, disqualifiedDealers, dealingMessages)
+			qualifiedDealers, _, err := ComputeQualifiedDealers(pub, disqualifiedDealers, dealingMessages, blame)
 			require.NoError(err)
 
 			// Check qualified dealers are [1,...,t+1]
 			assert.Equal(rangeSlice(1, pub.T+1), qualifiedDealers)
+
+			// Check dealer 0 is blamed
+			require.Len(blame.Dealers, 1)
+			assert.Equal(0, blame.Dealers[0].Dealer)
+			assert.Equal(BlameInvalidDealing, blame.Dealers[0].Reason)
 		}(&wg)
 	}
 
@@ -215,8 +361,7 @@ func TestResharingProtocolVerifiedComplain(t *testing.T) {
 
 			// Dealing
 			prv.BC.Send([]byte{})
-			dealingMessages, err := ReceiveDealingMessages(prv.BC, pub.Committees.Hold)
-			require.NoError(err)
+			dealingMessages := ReceiveDealingMessages(prv.BC, pub.Committees.Hold)
 
 			// Ver
 			complaints := make([]bool, n)
@@ -225,15 +370,13 @@ func TestResharingProtocolVerifiedComplain(t *testing.T) {
 				Complaints: complaints,
 				EncShares:  nil,
 			}))
-			verificationMessages, err := ReceiveVerificationMessages(prv.BC, pub.Committees.Ver)
-			require.NoError(err)
+			verificationMessages := ReceiveVerificationMessages(prv.BC, pub.Committees.Ver)
 
 			// Res
 			prv.BC.Send([]byte{})
-			resolutionMessages, err := ReceiveResolutionMessages(prv.BC, pub.Committees.Res)
-			require.NoError(err)
+			resolutionMessages := ReceiveResolutionMessages(prv.BC, pub.Committees.Res)
 
-			_, disqualifiedDealers, err := ResolveComplaints(
+			_, disqualifiedDealers, blame, err := ResolveComplaints(
 				pub,
 				dealingMessages,
 				verificationMessages,
@@ -241,11 +384,14 @@ func TestResharingProtocolVerifiedComplain(t *testing.T) {
 				&PartyDebugParams{},
 			)
 			require.NoError(err)
-			qualifiedDealers, _, err := ComputeQualifiedDealers(pub, disqualifiedDealers, dealingMessages)
+			qualifiedDealers, _, err := ComputeQualifiedDealers(pub, disqualifiedDealers, dealingMessages, blame)
 			require.NoError(err)
 
 			// Check qualified dealers are [0,...,t]
 			assert.Equal(rangeSlice(0, pub.T+1), qualifiedDealers)
+
+			// Check the complaint is resolved so that dealer 0 is not blamed
+			assert.Empty(blame.Dealers)
 		}(&wg)
 	}
 
@@ -277,3 +423,280 @@ func TestResharingProtocolVerifiedComplain(t *testing.T) {
 		false,
 	)
 }
+
+func TestResharingProtocolResized(t *testing.T) {
+	// Test resharing protocol when the committees have different sizes and thresholds
+	// Optionally, the verification member j=0 cheats and complains about dealer 0
+	// so that future broadcast needs to be used
+	testCases := []struct {
+		name         string
+		n, tt        int
+		nVer, tVer   int
+		nRes, tRes   int
+		nNext, tNext int
+		complain     bool
+	}{
+		{"scale up", 3, 1, 5, 2, 4, 1, 7, 3, false},
+		{"scale down", 7, 3, 4, 1, 5, 2, 3, 1, false},
+		{"scale up with complaint", 3, 1, 5, 2, 4, 1, 7, 3, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			pub, prvs, o, secret, rnd := setupResharingResized(
+				t, tc.n, tc.tt, tc.nVer, tc.tVer, tc.nRes, tc.tRes, tc.nNext, tc.tNext)
+			cheater := pub.Committees.Ver[0]
+
+			// Start cheating verification committee member j=0
+			var skipped []int
+			var wg sync.WaitGroup
+			if tc.complain {
+				skipped = append(skipped, cheater)
+				wg.Add(1)
+				go func(wg *sync.WaitGroup) {
+					defer wg.Done()
+
+					prv := prvs[cheater]
+					prv.BC.Send([]byte{})
+					ReceiveDealingMessages(prv.BC, pub.Committees.Hold)
+
+					complaints := make([]bool, tc.n)
+					complaints[0] = true
+					prv.BC.Send(msgpack.Encode(VerificationMessage{
+						Complaints: complaints,
+					}))
+					ReceiveVerificationMessages(prv.BC, pub.Committees.Ver)
+
+					prv.BC.Send([]byte{})
+					ReceiveResolutionMessages(prv.BC, pub.Committees.Res)
+				}(&wg)
+			}
+
+			parties, errs := runResharingParties(t, pub, prvs, o, skipped...)
+			wg.Wait()
+			outputShares, outputCommitments, _ := resharingOutputs(t, parties, errs)
+
+			if tc.complain {
+				outputCommitments[cheater] = outputCommitments[0]
+			}
+
+			require.Len(outputCommitments[0], tc.nNext+1)
+			checkProtocolResults(
+				t,
+				pub,
+				secret,
+				rnd,
+				outputCommitments,
+				outputShares,
+				false,
+			)
+		})
+	}
+}
+
+func TestResharingProtocolBatch(t *testing.T) {
+	// Test resharing protocol when several secrets are refreshed at once and everybody is honest
+	require := require.New(t)
+
+	const (
+		n          = 3                 // number of parties per committee
+		numParties = n * numCommittees // total number of parties
+		tt         = 1                 // threshold of malicious parties
+		numSecrets = 4                 // number of secrets refreshed at once
+	)
+
+	pub, prvs, o, _, _ := setupResharingSeq(t, n, tt)
+	secrets, rnds := replaceSharingByBatch(t, pub, prvs, numSecrets)
+
+	parties, errs := runResharingParties(t, pub, prvs, o)
+
+	// Output of all parties
+	outputCommitments := make([][][]pedersen.Commitment, numParties)
+	outputShares := make([][]*vss.Share, numParties)
+	for party := range parties {
+		require.NoError(errs[party])
+		var err error
+		outputShares[party], outputCommitments[party], err = parties[party].BatchOutput()
+		require.NoError(err)
+	}
+
+	checkBatchProtocolResults(t, pub, secrets, rnds, outputCommitments, outputShares)
+}
+
+func TestResharingProtocolBatchMalicious(t *testing.T) {
+	// Test batched resharing protocol with malicious parties:
+	// dealer 0 sends an incorrect comC[0] for the secret 1 only, so that it is disqualified,
+	// dealer 1 sends an invalid encryption of M[0] to verifier 0, so that future broadcast needs to be used,
+	// and verifier 1 sends an invalid proof
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n          = 5                 // number of parties per committee
+		numParties = n * numCommittees // total number of parties
+		tt         = 2                 // threshold of malicious parties
+		numSecrets = 3                 // number of secrets refreshed at once
+	)
+
+	pub, prvs, _, _, _ := setupResharingSeq(t, n, tt)
+	secrets, rnds := replaceSharingByBatch(t, pub, prvs, numSecrets)
+	dbg := &PartyDebugParams{}
+
+	tamper := func(round int, party int, payload []byte) []byte {
+		switch {
+		case round == 0 && party == pub.Committees.Hold[0]:
+			var msg DealingMessage
+			require.NoError(msgpack.Decode(payload, &msg))
+			c, err := curve25519.AddPointXY(&msg.ComC[n+1], &msg.ComC[n+1])
+			require.NoError(err)
+			msg.ComC[n+1] = *c
+			return msgpack.Encode(&msg)
+		case round == 0 && party == pub.Committees.Hold[1]:
+			var msg DealingMessage
+			require.NoError(msgpack.Decode(payload, &msg))
+			msg.EncVerM[0][len(msg.EncVerM[0])-1] ^= 1
+			return msgpack.Encode(&msg)
+		case round == 1 && party == pub.Committees.Ver[1]:
+			var msg VerificationMessage
+			require.NoError(msgpack.Decode(payload, &msg))
+			msg.VPComProof.HashL[0][0] ^= 1
+			return msgpack.Encode(&msg)
+		default:
+			return payload
+		}
+	}
+
+	parties := make([]*Party, numParties)
+	for party := range parties {
+		var err error
+		parties[party], err = NewParty(pub, &prvs[party], dbg)
+		require.NoError(err)
+	}
+
+	for round := 0; round < numRounds; round++ {
+		messages := make([]communication.BroadcastMessage, numParties)
+		for party := range parties {
+			messages[party] = communication.BroadcastMessage{
+				Payload:  tamper(round, party, parties[party].Payload()),
+				SenderID: party,
+			}
+		}
+		for party := range parties {
+			_, _, err := parties[party].Step(messages)
+			require.NoError(err)
+		}
+	}
+
+	outputCommitments := make([][][]pedersen.Commitment, numParties)
+	outputShares := make([][]*vss.Share, numParties)
+	for party := range parties {
+		var err error
+		outputShares[party], outputCommitments[party], err = parties[party].BatchOutput()
+		require.NoError(err)
+
+		// the single-secret output is not available for a batched refresh
+		_, _, _, err = parties[party].Output()
+		assert.Error(err)
+	}
+
+	checkBatchProtocolResults(t, pub, secrets, rnds, outputCommitments, outputShares)
+
+	for _, party := range pub.Committees.Next {
+		blame := parties[party].Blame()
+		require.NotNil(blame)
+
+		require.Len(blame.Dealers, 1)
+		assert.Equal(0, blame.Dealers[0].Dealer)
+		assert.Equal(BlameInvalidDealing, blame.Dealers[0].Reason)
+
+		require.Len(blame.Verifiers, 1)
+		assert.Equal(1, blame.Verifiers[0].Verifier)
+		assert.Equal(BlameInvalidVerification, blame.Verifiers[0].Reason)
+	}
+}
+
+func TestCheckInputsBatch(t *testing.T) {
+	assert := assert.New(t)
+
+	const (
+		n          = 3 // number of parties per committee
+		tt         = 1 // threshold of malicious parties
+		numSecrets = 2 // number of secrets refreshed at once
+	)
+
+	pub, prvs, _, _, _ := setupResharingSeq(t, n, tt)
+	replaceSharingByBatch(t, pub, prvs, numSecrets)
+	dealer := &prvs[pub.Committees.Hold[0]]
+	assert.NoError(checkInputs(pub, dealer))
+	assert.NoError(checkInputs(pub, &prvs[pub.Committees.Next[0]]))
+
+	// Inconsistent public input
+	invalidPub := *pub
+	invalidPub.Commitments = pub.BatchCommitments[0]
+	assert.Error(CheckPublicInput(&invalidPub))
+
+	invalidPub = *pub
+	invalidPub.BatchCommitments = [][]pedersen.Commitment{}
+	assert.Error(CheckPublicInput(&invalidPub))
+
+	invalidPub = *pub
+	invalidPub.BatchCommitments = [][]pedersen.Commitment{pub.BatchCommitments[0][:n]}
+	assert.Error(CheckPublicInput(&invalidPub))
+
+	invalidPub = *pub
+	invalidPub.FeldmanConversion = true
+	assert.Error(CheckPublicInput(&invalidPub))
+
+	// Inconsistent private input
+	invalidPrv := *dealer
+	invalidPrv.BatchShares = dealer.BatchShares[:1]
+	assert.Error(checkInputs(pub, &invalidPrv))
+
+	invalidPrv = *dealer
+	invalidPrv.BatchShares = []*vss.Share{dealer.BatchShares[0], nil}
+	assert.Error(checkInputs(pub, &invalidPrv))
+
+	invalidPrv = *dealer
+	invalidPrv.Share = dealer.BatchShares[0]
+	assert.Error(checkInputs(pub, &invalidPrv))
+
+	unbatchedPub := *pub
+	unbatchedPub.Commitments = pub.BatchCommitments[0]
+	unbatchedPub.BatchCommitments = nil
+	assert.Error(checkInputs(&unbatchedPub, dealer))
+}
+
+func TestCheckPublicInputSecretRange(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n  = 3  // number of parties per committee
+		tt = 1  // threshold of malicious parties
+		k  = 16 // number of bits of the secret
+	)
+
+	pub, prvs, _, _, _ := setupResharingSeq(t, n, tt)
+	replaceSharingByRange(t, pub, prvs, curve25519.GetScalar(1234), k)
+	assert.NoError(CheckPublicInput(pub))
+	assert.NoError(checkInputs(pub, &prvs[pub.Committees.Hold[0]]))
+
+	// Wrong number of bits
+	invalidPub := *pub
+	invalidPub.SecretRangeBits = k - 1
+	assert.Error(CheckPublicInput(&invalidPub))
+
+	// Proof for another sharing
+	invalidPub = *pub
+	_, otherCommitments, err := vss.FixedRShare(&pub.VSSParams, curve25519.GetScalar(1234), curve25519.RandomScalar())
+	require.NoError(err)
+	invalidPub.Commitments = otherCommitments
+	assert.Error(CheckPublicInput(&invalidPub))
+
+	// Not supported for batched refreshes
+	invalidPub = *pub
+	replaceSharingByBatch(t, &invalidPub, prvs, 2)
+	assert.Error(CheckPublicInput(&invalidPub))
+}
@@ -1,13 +1,17 @@
 package resharing
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/shaih/go-yosovss/communication/fake"
 	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/elgamal"
 	"github.com/shaih/go-yosovss/primitives/feldman"
 	"github.com/shaih/go-yosovss/primitives/pedersen"
 	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/shaih/go-yosovss/protocols/dkg"
+	"github.com/shaih/go-yosovss/protocols/resharing/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -94,6 +98,8 @@ func setupResharing(
 		N:           n,
 		Committees:  committees,
 		Commitments: commitments,
+		SessionID:   []byte("test session"),
+		Epoch:       1,
 	}
 
 	// Initialize channels and connect with orchestrator
@@ -123,6 +129,287 @@ func setupResharing(
 	return
 }
 
+// setupResharingResized setup the resharing protocol where the holding committee has n parties
+// and max tt malicious parties, and the verification, resolution, and next holding committees
+// have respectively nVer, nRes, nNext parties and tVer, tRes, tNext malicious parties
+// Committees are made of different parties taken in order
+func setupResharingResized(
+	t testing.TB,
+	n, tt int,
+	nVer, tVer int,
+	nRes, tRes int,
+	nNext, tNext int,
+) (
+	pub *PublicInput,
+	prvs []PrivateInput,
+	o fake.Orchestrator,
+	secret *curve25519.Scalar,
+	rnd *curve25519.Scalar,
+) {
+	require := require.New(t)
+
+	committees := Committees{
+		Hold: rangeSlice(0, n),
+		Ver:  rangeSlice(n, nVer),
+		Res:  rangeSlice(n+nVer, nRes),
+		Next: rangeSlice(n+nVer+nRes, nNext),
+	}
+	pub, prvs, o, secret, rnd = setupResharing(t, n, tt, n+nVer+nRes+nNext, committees)
+
+	var err error
+	pub.VerVSSParams, err = vss.NewVSSParams(pub.VSSParams.PedersenParams, nVer, tVer)
+	require.NoError(err)
+	pub.ResVSSParams, err = vss.NewVSSParams(pub.VSSParams.PedersenParams, nRes, tRes)
+	require.NoError(err)
+	pub.NextVSSParams, err = vss.NewVSSParams(pub.VSSParams.PedersenParams, nNext, tNext)
+	require.NoError(err)
+
+	vcParams, err := feldman.GenerateVCParams(2 * nNext)
+	require.NoError(err)
+	pub.VCParams = *vcParams
+
+	return
+}
+
+// enableVerifiableEncryption generates ElGamal keys for all the parties
+// and enables verifiable encryption (see VerifiableEncryption)
+func enableVerifiableEncryption(t testing.TB, pub *PublicInput, prvs []PrivateInput) {
+	require := require.New(t)
+
+	pub.VEncPKs = make([]elgamal.PublicKey, len(prvs))
+	for party := range prvs {
+		pk, sk, err := elgamal.GenerateKeyFrom(nil)
+		require.NoError(err)
+		pub.VEncPKs[party] = *pk
+		prvs[party].VEncSK = *sk
+	}
+}
+
+// replaceSharingByDKG replaces the initial sharing of pub/prvs (see setupResharing)
+// by a sharing generated by the holding committee with the DKG protocol (see protocols/dkg)
+// It returns the new secret and randomness
+func replaceSharingByDKG(
+	t testing.TB, pub *PublicInput, prvs []PrivateInput,
+) (
+	secret *curve25519.Scalar, rnd *curve25519.Scalar,
+) {
+	require := require.New(t)
+
+	dkgPub := &dkg.PublicInput{
+		VSSParams: pub.VSSParams,
+		EncPKs:    pub.EncPKs,
+		T:         pub.T,
+		N:         pub.N,
+		Parties:   pub.Committees.Hold,
+	}
+
+	// Only the holding committee runs the DKG, on its own orchestrator
+	o := fake.NewOrchestrator()
+	dkgPrvs := make([]dkg.PrivateInput, pub.N)
+	for i, party := range pub.Committees.Hold {
+		channel := fake.NewPartyBroadcastChannel(party)
+		o.AddChannel(channel)
+		dkgPrvs[i] = dkg.PrivateInput{
+			BC:    channel,
+			EncSK: prvs[party].EncSK,
+			ID:    party,
+		}
+	}
+
+	shares := make([]*vss.Share, pub.N)
+	commitments := make([][]pedersen.Commitment, pub.N)
+	errs := make([]error, pub.N)
+	var wg sync.WaitGroup
+	for i := range dkgPrvs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			shares[i], commitments[i], errs[i] = dkg.StartParty(dkgPub, &dkgPrvs[i])
+		}(i)
+	}
+	for o.Round < 3 { // the DKG protocol has 3 rounds
+		require.NoError(o.ReceiveMessages())
+		require.NoError(o.Broadcast())
+		o.Round++
+	}
+	wg.Wait()
+
+	validShares := make([]vss.Share, pub.N)
+	for i, party := range pub.Committees.Hold {
+		require.NoError(errs[i])
+		require.Equal(commitments[0], commitments[i])
+		prvs[party].Share = shares[i]
+		validShares[i] = *shares[i]
+	}
+	pub.Commitments = commitments[0]
+
+	secret, rnd, err := vss.ReconstructWithRFromValidShares(&pub.VSSParams, validShares)
+	require.NoError(err)
+	return secret, rnd
+}
+
+// replaceSharingByBatch replaces the initial sharing of pub/prvs (see setupResharing)
+// by numSecrets sharings of random secrets for a batched refresh (see PublicInput.BatchCommitments)
+// It returns the secrets and the randomness of each sharing
+func replaceSharingByBatch(
+	t testing.TB, pub *PublicInput, prvs []PrivateInput, numSecrets int,
+) (
+	secrets []*curve25519.Scalar, rnds []*curve25519.Scalar,
+) {
+	require := require.New(t)
+
+	secrets = make([]*curve25519.Scalar, numSecrets)
+	rnds = make([]*curve25519.Scalar, numSecrets)
+	pub.Commitments = nil
+	pub.BatchCommitments = make([][]pedersen.Commitment, numSecrets)
+	for _, party := range pub.Committees.Hold {
+		prvs[party].Share = nil
+		prvs[party].BatchShares = make([]*vss.Share, numSecrets)
+	}
+
+	for u := 0; u < numSecrets; u++ {
+		secrets[u] = curve25519.RandomScalar()
+		rnds[u] = curve25519.RandomScalar()
+		shares, commitments, err := vss.FixedRShare(&pub.VSSParams, secrets[u], rnds[u])
+		require.NoError(err)
+		pub.BatchCommitments[u] = commitments
+		for i, party := range pub.Committees.Hold {
+			prvs[party].BatchShares[u] = &shares[i]
+		}
+	}
+	return secrets, rnds
+}
+
+// replaceSharingByRange replaces the initial sharing of pub/prvs (see setupResharing)
+// by a sharing of the secret with a proof that it is in [0, 2^k) (see PublicInput.SecretRangeProof)
+// It returns the randomness of the sharing
+func replaceSharingByRange(
+	t testing.TB, pub *PublicInput, prvs []PrivateInput, secret *curve25519.Scalar, k int,
+) (
+	rnd *curve25519.Scalar,
+) {
+	require := require.New(t)
+
+	rnd = curve25519.RandomScalar()
+	shares, commitments, proof, err := vss.RangeFixedRShareFrom(nil, &pub.VSSParams, secret, rnd, k)
+	require.NoError(err)
+	pub.Commitments = commitments
+	pub.SecretRangeProof = proof
+	pub.SecretRangeBits = k
+	for i, party := range pub.Committees.Hold {
+		prvs[party].Share = &shares[i]
+	}
+	return rnd
+}
+
+// runResharingParties runs the protocol for all the parties except the ones in skipped, each in its own goroutine,
+// while the orchestrator o switches the rounds
+// It returns the parties once done and their errors: parties[party] is nil if the party fails or is skipped
+// Skipped parties must be run by the caller before calling runResharingParties (e.g., to simulate a cheater)
+func runResharingParties(
+	t testing.TB,
+	pub *PublicInput,
+	prvs []PrivateInput,
+	o fake.Orchestrator,
+	skipped ...int,
+) (
+	parties []*Party,
+	errs []error,
+) {
+	require := require.New(t)
+
+	parties = make([]*Party, len(prvs))
+	errs = make([]error, len(prvs))
+
+	var wg sync.WaitGroup
+	for party := range prvs {
+		if common.IntIndexOf(skipped, party) >= 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(party int) {
+			defer wg.Done()
+			parties[party], errs[party] = RunParty(pub, &prvs[party], &PartyDebugParams{})
+		}(party)
+	}
+
+	// Simulate the protocol for a fixed number of rounds
+	// Naively switches rounds whenever every party has sent a message
+	for o.Round < pub.NumRounds() {
+		require.NoError(o.ReceiveMessages())
+		require.NoError(o.Broadcast())
+		o.Round++
+	}
+
+	wg.Wait()
+	return parties, errs
+}
+
+// resharingOutputs checks that no party failed in runResharingParties
+// and returns the outputs of the parties (see Party.Output), which are nil for skipped parties
+func resharingOutputs(
+	t testing.TB,
+	parties []*Party,
+	errs []error,
+) (
+	outputShares []*vss.Share,
+	outputCommitments [][]pedersen.Commitment,
+	outputFeldmanCommitments [][]feldman.GCommitment,
+) {
+	require := require.New(t)
+
+	outputShares = make([]*vss.Share, len(parties))
+	outputCommitments = make([][]pedersen.Commitment, len(parties))
+	outputFeldmanCommitments = make([][]feldman.GCommitment, len(parties))
+	for party := range parties {
+		require.NoErrorf(errs[party], "party %d failed", party)
+		if parties[party] == nil {
+			continue
+		}
+		var err error
+		outputShares[party], outputCommitments[party], outputFeldmanCommitments[party], err =
+			parties[party].Output()
+		require.NoError(err)
+	}
+	return outputShares, outputCommitments, outputFeldmanCommitments
+}
+
+// checkBatchProtocolResults is the same as checkProtocolResults for a batched refresh
+// where outputCommitments[party][u] and outputShares[party][u] are for the secret u
+// (outputShares[party] is nil for parties that are not in the next holding committee)
+func checkBatchProtocolResults(
+	t *testing.T,
+	pub *PublicInput,
+	secrets []*curve25519.Scalar,
+	rnds []*curve25519.Scalar,
+	outputCommitments [][][]pedersen.Commitment,
+	outputShares [][]*vss.Share,
+) {
+	require := require.New(t)
+
+	for u := range secrets {
+		// public input of the secret u alone
+		pubU := *pub
+		pubU.Commitments = pub.BatchCommitments[u]
+		pubU.BatchCommitments = nil
+
+		commitmentsU := make([][]pedersen.Commitment, len(outputCommitments))
+		for party := range outputCommitments {
+			require.Len(outputCommitments[party], len(secrets))
+			commitmentsU[party] = outputCommitments[party][u]
+		}
+		sharesU := make([]*vss.Share, len(outputShares))
+		for party := range outputShares {
+			if outputShares[party] != nil {
+				require.Len(outputShares[party], len(secrets))
+				sharesU[party] = outputShares[party][u]
+			}
+		}
+
+		checkProtocolResults(t, &pubU, secrets[u], rnds[u], commitmentsU, sharesU, false)
+	}
+}
+
 // checkProtocolResults verify all the results of the protocols are as expected
 // outputCommitments can be an array of any number of output commitments (at least one)
 // outputCommitments[0]=...=outputcommitments[...] are the next commitments (error is printed if they're not all equal)
@@ -144,7 +431,7 @@ func checkProtocolResults(
 	require := require.New(t)
 	assert := assert.New(t)
 
-	vssParams := &pub.VSSParams
+	vssParams := pub.nextParams() // parameters of the next holding committee
 	commitments := pub.Commitments
 
 	// Check output commitments are all the same
@@ -163,19 +450,19 @@ func checkProtocolResults(
 	assert.True(valid, "original commitments must be valid")
 
 	// Check that next commitments are still valid
-	valid, err = vss.VerifyCommitments(&pub.VSSParams, nextCommitments)
+	valid, err = vss.VerifyCommitments(vssParams, nextCommitments)
 	require.NoError(err)
 	assert.True(valid, "next commitments must be valid")
 
 	if !allowMissingShares {
-		require.GreaterOrEqual(len(outputShares), pub.N)
+		require.GreaterOrEqual(len(outputShares), vssParams.N)
 	}
 
 	// Check only next committee members, aka numParties-n, ... numParties-1
 	// have non-empty shares and extract the n above shares
-	firstActualShare := max(len(outputShares)-pub.N, 0)
+	firstActualShare := max(len(outputShares)-vssParams.N, 0)
 	nextShares := make([]vss.Share, len(outputShares)-firstActualShare)
-	for party := 0; party < len(outputShares)-pub.N; party++ {
+	for party := 0; party < len(outputShares)-vssParams.N; party++ {
 		assert.Nil(outputShares[party], "non next-holder committee must output nil shares")
 	}
 	for party := firstActualShare; party < len(outputShares); party++ {
@@ -185,7 +472,7 @@ func checkProtocolResults(
 	}
 
 	if !allowMissingShares {
-		require.Equal(len(nextShares), pub.N)
+		require.Equal(len(nextShares), vssParams.N)
 	}
 
 	// Check that all nextShares are valid
@@ -195,7 +482,7 @@ func checkProtocolResults(
 		assert.True(valid)
 	}
 
-	if len(outputShares) > pub.T+1 {
+	if len(outputShares) > vssParams.D+1 {
 		// Check the reconstructed secret is valid
 		reconsSecret, reconsRnd, err := vss.ReconstructWithR(vssParams, nextShares, nextCommitments)
 		require.NoError(err)
@@ -208,9 +495,9 @@ func checkProtocolResults(
 		assert.True(valid)
 	}
 
-	if len(outputShares) >= pub.T+1 {
+	if len(outputShares) >= vssParams.D+1 {
 		// Check the reconstructed secret is valid
-		reconsSecret, err := vss.Reconstruct(vssParams, nextShares[:pub.T+1], nextCommitments)
+		reconsSecret, err := vss.Reconstruct(vssParams, nextShares[:vssParams.D+1], nextCommitments)
 		require.NoError(err)
 		assert.Equal(*secret, *reconsSecret)
 	}
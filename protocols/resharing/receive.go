@@ -3,30 +3,39 @@ package resharing
 // This file (receive.go) is a template generating gen-receive.go
 
 import (
-	"fmt"
-
 	"github.com/cheekybits/genny/generic"
 	"github.com/shaih/go-yosovss/communication"
 	"github.com/shaih/go-yosovss/msgpack"
+	log "github.com/sirupsen/logrus"
 )
 
 //go:generate genny -in=$GOFILE -out=gen-$GOFILE gen "MessageType=DealingMessage,VerificationMessage,ResolutionMessage"
 
 type MessageType generic.Type
 
-// ReceiveMessageTypes receives and parse the messages sent by dealers in the dealing round
+// ReceiveMessageTypes receives and parse the messages sent by the parties in the round
 // parties is the list of parties in the round
-func ReceiveMessageTypes(bc communication.BroadcastChannel, parties []int) ([]MessageType, error) {
-	messages := make([]MessageType, len(parties))
-
+// A message that cannot be decoded (or that is missing) is not an error:
+// it is replaced by an empty message, which is treated as a message from a malicious party
+func ReceiveMessageTypes(bc communication.BroadcastChannel, parties []int) []MessageType {
 	_, bm := bc.ReceiveRound()
+	return DecodeMessageTypes(communication.PayloadsFrom(bm, parties), parties)
+}
+
+// DecodeMessageTypes parses the payloads sent by the parties in the round (see ReceiveMessageTypes)
+// payloads[i] is the payload sent by parties[i] (nil if it did not send any)
+func DecodeMessageTypes(payloads [][]byte, parties []int) []MessageType {
+	messages := make([]MessageType, len(parties))
 
 	for i, party := range parties {
-		err := msgpack.Decode(bm[party].Payload, &messages[i])
+		var msg MessageType
+		err := msgpack.Decode(payloads[i], &msg)
 		if err != nil {
-			return nil, fmt.Errorf("decoding message from party %d (id=%d) failed: %v", i, party, err)
+			log.Infof("decoding message from party %d (id=%d) failed: %v", i, party, err)
+			continue
 		}
+		messages[i] = msg
 	}
 
-	return messages, nil
+	return messages
 }
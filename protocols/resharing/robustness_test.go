@@ -0,0 +1,353 @@
+package resharing
+
+import (
+	"math/rand"
+	"os"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/shaih/go-yosovss/communication"
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getFuzzIterations returns the number of iterations of the fuzzing tests
+// It can be set with the environment variable YOSO_FUZZ_ITERATIONS
+func getFuzzIterations(t *testing.T) int {
+	envIterations := os.Getenv("YOSO_FUZZ_ITERATIONS")
+	if envIterations == "" {
+		return 8
+	}
+	iterations, err := strconv.Atoi(envIterations)
+	if err != nil {
+		t.Fatalf("YOSO_FUZZ_ITERATIONS variable is \"%s\" which is not a number: %v", envIterations, err)
+	}
+	return iterations
+}
+
+// mutatingChannel is a broadcast channel of a malicious party that randomly mutates
+// the (non-empty) messages it sends
+// The message of round 0, 1, 2 is respectively a DealingMessage, a VerificationMessage, a ResolutionMessage
+type mutatingChannel struct {
+	communication.BroadcastChannel
+	round int
+	rnd   *rand.Rand
+}
+
+func (c *mutatingChannel) Send(msg []byte) {
+	if len(msg) > 0 {
+		msg = mutateMessage(c.rnd, c.round, msg)
+	}
+	c.BroadcastChannel.Send(msg)
+	c.round++
+}
+
+// mutateMessage returns a random mutation of the message msg sent in the round
+// Half of the time, the mutation is done on the decoded message (see mutateValue)
+// so that the message remains well-formed
+func mutateMessage(rnd *rand.Rand, round int, msg []byte) []byte {
+	if rnd.Intn(2) == 0 {
+		return mutateBytes(rnd, msg)
+	}
+
+	var v interface{}
+	switch round {
+	case 0:
+		v = &DealingMessage{}
+	case 1:
+		v = &VerificationMessage{}
+	case 2:
+		v = &ResolutionMessage{}
+	default:
+		return mutateBytes(rnd, msg)
+	}
+	err := msgpack.Decode(msg, v)
+	if err != nil {
+		panic(err) // should never happen as the party is honest before the mutation
+	}
+	for k := rnd.Intn(3); k >= 0; k-- {
+		mutateValue(rnd, reflect.ValueOf(v).Elem())
+	}
+	return msgpack.Encode(v)
+}
+
+// mutateBytes returns a random mutation of the bytes b
+func mutateBytes(rnd *rand.Rand, b []byte) []byte {
+	switch rnd.Intn(4) {
+	case 0:
+		return []byte{}
+	case 1:
+		r := make([]byte, rnd.Intn(2*len(b)+1))
+		rnd.Read(r)
+		return r
+	case 2:
+		return b[:rnd.Intn(len(b)+1)]
+	default:
+		r := make([]byte, len(b))
+		copy(r, b)
+		if len(r) > 0 {
+			r[rnd.Intn(len(r))] ^= byte(1 + rnd.Intn(255))
+		}
+		return r
+	}
+}
+
+// mutateValue applies a random mutation to a random (exported) part of v
+// v must be settable
+func mutateValue(rnd *rand.Rand, v reflect.Value) {
+	var sites []reflect.Value
+	collectMutationSites(v, &sites)
+	if len(sites) == 0 {
+		return
+	}
+	mutateSite(rnd, sites[rnd.Intn(len(sites))])
+}
+
+// collectMutationSites appends to sites all the parts of v that can be mutated
+func collectMutationSites(v reflect.Value, sites *[]reflect.Value) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for f := 0; f < v.NumField(); f++ {
+			if v.Field(f).CanSet() {
+				collectMutationSites(v.Field(f), sites)
+			}
+		}
+	case reflect.Ptr:
+		*sites = append(*sites, v)
+		if !v.IsNil() {
+			collectMutationSites(v.Elem(), sites)
+		}
+	case reflect.Slice:
+		*sites = append(*sites, v)
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			for k := 0; k < v.Len(); k++ {
+				collectMutationSites(v.Index(k), sites)
+			}
+		}
+	case reflect.Array, reflect.Map, reflect.Bool,
+		reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		*sites = append(*sites, v)
+	}
+}
+
+// mutateSite applies a random mutation to v, which is one of the sites of collectMutationSites
+func mutateSite(rnd *rand.Rand, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		} else {
+			v.Set(reflect.Zero(v.Type()))
+		}
+	case reflect.Slice:
+		switch rnd.Intn(4) {
+		case 0:
+			v.Set(reflect.Zero(v.Type()))
+		case 1:
+			v.SetLen(rnd.Intn(v.Len() + 1))
+		case 2:
+			v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+		default:
+			if v.Type().Elem().Kind() == reflect.Uint8 && v.Len() > 0 {
+				b := v.Index(rnd.Intn(v.Len()))
+				b.SetUint(b.Uint() ^ uint64(1+rnd.Intn(255)))
+			} else {
+				v.Set(reflect.AppendSlice(v, v))
+			}
+		}
+	case reflect.Array:
+		if v.Len() > 0 && v.Type().Elem().Kind() == reflect.Uint8 {
+			b := v.Index(rnd.Intn(v.Len()))
+			b.SetUint(b.Uint() ^ uint64(1+rnd.Intn(255)))
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		if len(keys) > 0 {
+			v.SetMapIndex(keys[rnd.Intn(len(keys))], reflect.Value{})
+		} else {
+			v.Set(reflect.MakeMap(v.Type()))
+			v.SetMapIndex(reflect.Zero(v.Type().Key()), reflect.Zero(v.Type().Elem()))
+		}
+	case reflect.Bool:
+		v.SetBool(!v.Bool())
+	case reflect.Int, reflect.Int64:
+		v.SetInt(v.Int() + int64(rnd.Intn(3)) - 1)
+	case reflect.Uint, reflect.Uint64:
+		v.SetUint(v.Uint() ^ uint64(1+rnd.Intn(255)))
+	}
+}
+
+func TestResharingProtocolFuzzing(t *testing.T) {
+	// Test that the resharing protocol never fails nor panics when one member of each committee
+	// (holding, verification, resolution) sends random mutations of its messages
+
+	const (
+		n          = 3                 // number of parties per committee
+		numParties = n * numCommittees // total number of parties
+		tt         = 1                 // threshold of malicious parties
+	)
+
+	testCases := []struct {
+		name string
+		vEnc bool
+	}{
+		{"default", false},
+		{"verifiable-encryption", true},
+	}
+
+	iterations := getFuzzIterations(t)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for seed := 0; seed < iterations; seed++ {
+				require := require.New(t)
+
+				rnd := rand.New(rand.NewSource(int64(seed)))
+
+				pub, prvs, o, secret, secretRnd := setupResharingSeq(t, n, tt)
+				if tc.vEnc {
+					enableVerifiableEncryption(t, pub, prvs)
+				}
+
+				// One malicious party in each committee sending messages
+				malicious := []int{
+					pub.Committees.Hold[rnd.Intn(n)],
+					pub.Committees.Ver[rnd.Intn(n)],
+					pub.Committees.Res[rnd.Intn(n)],
+				}
+				for _, party := range malicious {
+					prvs[party].BC = &mutatingChannel{
+						BroadcastChannel: prvs[party].BC,
+						rnd:              rand.New(rand.NewSource(rnd.Int63())),
+					}
+				}
+
+				// Output of all parties
+				// Malicious parties receive the same messages as honest parties,
+				// so they must also output the correct values
+				outputCommitments := make([][]feldman.GCommitment, numParties)
+				outputShares := make([]*vss.Share, numParties)
+				outputErrs := make([]error, numParties)
+
+				var wg sync.WaitGroup
+				for party := 0; party < numParties; party++ {
+					wg.Add(1)
+					go func(party int, wg *sync.WaitGroup) {
+						defer wg.Done()
+						outputShares[party], outputCommitments[party], outputErrs[party] =
+							StartCommitteeParty(pub, &prvs[party], &PartyDebugParams{})
+					}(party, &wg)
+				}
+
+				for o.Round < pub.NumRounds() {
+					err := o.ReceiveMessages()
+					require.NoError(err)
+					err = o.Broadcast()
+					require.NoError(err)
+					o.Round++
+				}
+
+				wg.Wait()
+
+				for party := 0; party < numParties; party++ {
+					require.NoErrorf(outputErrs[party], "seed %d, malicious parties %v", seed, malicious)
+				}
+				checkProtocolResults(t, pub, secret, secretRnd, outputCommitments, outputShares, false)
+			}
+		})
+	}
+}
+
+// roundChannel is a broadcast channel returning fixed messages for a single round
+type roundChannel struct {
+	messages []communication.BroadcastMessage
+}
+
+func (c *roundChannel) Send([]byte) {}
+
+func (c *roundChannel) ReceiveRound() (int, []communication.BroadcastMessage) {
+	return 0, c.messages
+}
+
+func TestReceiveMessagesMalformed(t *testing.T) {
+	assert := assert.New(t)
+
+	msg := VerificationMessage{Complaints: []bool{true, false, true}}
+	bc := &roundChannel{messages: []communication.BroadcastMessage{
+		{SenderID: 0, Payload: []byte{}},
+		{SenderID: 2, Payload: msgpack.Encode(&msg)},
+		{SenderID: 1, Payload: []byte{0xde, 0xad, 0xbe, 0xef}},
+		// party 3 did not send any message
+	}}
+
+	messages := ReceiveVerificationMessages(bc, []int{0, 1, 2, 3})
+	assert.Equal([]VerificationMessage{{}, {}, msg, {}}, messages)
+}
+
+func TestMalformedMessagesIndexing(t *testing.T) {
+	// Test that functions that index into the messages do not panic on messages of incorrect lengths
+	assert := assert.New(t)
+
+	const (
+		n  = 3
+		tt = 1
+	)
+
+	pub, prvs, _, _, _ := setupResharingSeq(t, n, tt)
+
+	// Complaints against all the dealers that sent empty dealing messages
+	dealingMessages := make([]DealingMessage, n)
+	verificationMessages := make([]VerificationMessage, n)
+	for j := range verificationMessages {
+		verificationMessages[j].Complaints = []bool{true, true, true}
+	}
+
+	msg, err := PerformResolution(pub, &prvs[pub.Committees.Res[0]], 0, dealingMessages, verificationMessages)
+	assert.NoError(err)
+	assert.Empty(msg.EpsShares)
+
+	msgVer, err := PerformVerification(pub, &prvs[pub.Committees.Ver[0]], 0, dealingMessages, &PartyDebugParams{})
+	assert.Error(err) // no qualified dealer
+	assert.Nil(msgVer)
+
+	// Shares of verifiers of incorrect lengths
+	one := curve25519.ScalarOne
+	verSentShares := []VerSentShares{
+		{S: []*curve25519.Scalar{&one, &one, &one}, R: []*curve25519.Scalar{&one}},
+		{S: []*curve25519.Scalar{&one, nil, nil}, R: []*curve25519.Scalar{nil, nil, nil}},
+		{},
+	}
+	_, _, err = ComputeShareIL(pub, 0, 0, verSentShares, map[TripleIJL]curve25519.Scalar{})
+	assert.Error(err)
+
+	// Verifier proof with too few hashL/comR
+	err = VPVerifySpecificL(pub.ProofContext(0), pub.VCParams, 0, nil, VPCommitProof{}, nil)
+	assert.Error(err)
+
+	// Eps shares of incorrect length
+	_, err = ReconstructEpsKey(n, tt, []*curve25519.Scalar{&one}, nil)
+	assert.Error(err)
+}
+
+func TestResolutionMessageEncoding(t *testing.T) {
+	// The keys of EpsShares must be preserved by the encoding
+	// (so that complaints other than from verifier 0 against dealer 0 can be resolved)
+	assert := assert.New(t)
+
+	msg := ResolutionMessage{EpsShares: map[PairIJ]curve25519.Scalar{
+		{0, 0}: *curve25519.GetScalar(1),
+		{1, 2}: *curve25519.GetScalar(2),
+		{2, 1}: *curve25519.GetScalar(3),
+	}}
+
+	var decoded ResolutionMessage
+	err := msgpack.Decode(msgpack.Encode(&msg), &decoded)
+	assert.NoError(err)
+	assert.Equal(msg.EpsShares, decoded.EpsShares)
+}
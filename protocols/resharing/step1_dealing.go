@@ -2,17 +2,21 @@ package resharing
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/shaih/go-yosovss/msgpack"
 	"github.com/shaih/go-yosovss/primitives/curve25519"
 	"github.com/shaih/go-yosovss/primitives/feldman"
 	"github.com/shaih/go-yosovss/primitives/pedersen"
 	"github.com/shaih/go-yosovss/primitives/shamir"
+	"github.com/shaih/go-yosovss/primitives/transcript"
 	"github.com/shaih/go-yosovss/primitives/vss"
 )
 
 // DealingMessage is the message dealers send during dealing round
 // Notations below are for dealer i in [0,n-1]
+// l is a member of the next holding committee and j of the verification committee, k of the resolution committee
+// (those committees may have different sizes, see PublicInput.NextVSSParams)
 type DealingMessage struct {
 	_struct struct{}     `codec:",omitempty,omitemptyarray"`
 	ComC    []feldman.VC `codec:"C"` // ComC[j] is a vector commitment to sigma_{i+1,j+1,l+1}, rho_{i+1,j+1,l+1}
@@ -21,7 +25,7 @@ type DealingMessage struct {
 	// where sigma_{i+1,l+1} for l in [0,n-1] is a sharing of sigma_{i+1}
 	// and similar for rho with regards to the randomness r
 	// comC[j] = sum_l sigma_{i+1,j,l+1} G_l + sum_l rho_{i+1,j,l+1} G_{l+n}
-	// j in 0,...,n
+	// j in 0,...,n' where n' is the size of the verification committee
 	ComZ []pedersen.Commitment `codec:"Z"` // ComZ[l] = Z_{l+1} = sigma_{i+1,0,l} G + rho_{i+1,0,l+1} H
 	// where G and H are the two fixed bases
 	// l in 0,...,n-1
@@ -31,66 +35,86 @@ type DealingMessage struct {
 	// commit to the same values
 	EncVerM []curve25519.Ciphertext `codec:"V"` // EncVerM[j] is an encryption under the verification
 	// committee member j's key of message M[j] (type VerificationMJ)
+	// empty if verifiable encryption is enabled
+	VEncVerM []VerifiableEncryption `codec:"v"` // VEncVerM[j] is a verifiable encryption under the verification
+	// committee member j's ElGamal key of message M[j]
+	// only if verifiable encryption is enabled (see VerifiableEncryption)
 	EncResM []curve25519.SymmetricCiphertext `codec:"R"` // EncResM[j] is a symmetric encryption of M[j]
 	// under a fresh symmetric key K generated as follows:
-	// generate a random scalar eps_{j+1} that is secret-shared into eps_{j+1,1},...,eps_{j+1,n}
+	// generate a random scalar eps_{j+1} that is secret-shared into eps_{j+1,1},...,eps_{j+1,n''}
+	// among the n'' resolution committee members
 	// K = HKDF(eps_{j+1})
-	// j in 0,...,n-1
-	EncEpsK []curve25519.Ciphertext `codec:"e"` // EncEpsK[j] is an encryption under the resolution
-	// committee member j's key of message EpsK described below
-	// j in 0,...,n-1
-	HashEps [][][HashLength]byte `codec:"h"` // HashEps[j][j] is the hash of eps_{j+1,j+1}
-	// j,j in 0,...,n-1
+	// j in 0,...,n'-1
+	EncEpsK []curve25519.Ciphertext `codec:"e"` // EncEpsK[k] is an encryption under the resolution
+	// committee member k's key of message EpsK described below
+	// k in 0,...,n''-1
+	HashEps [][][HashLength]byte `codec:"h"` // HashEps[j][k] is the hash of eps_{j+1,k+1}
+	// j in 0,...,n'-1, k in 0,...,n''-1
+	FeldmanShare *feldman.ExpShare `codec:"f"` // FeldmanShare is sigma_{i+1} G with a proof of consistency
+	// with pub.Commitments[i+1]
+	// only if pub.FeldmanConversion
+
+	// For a batched refresh of K secrets (see PublicInput.BatchCommitments),
+	// ComC, ComZ, and ComZPrime are the concatenations of the values above for each secret u in 0,...,K-1
+	// i.e., ComC[u*(n'+1)+j], ComZ[u*n+l], and ComZPrime[u*n+l] are for the secret u,
+	// DblDLEqProof is a single proof for all the secrets,
+	// and M[j] is the concatenation of the M[j] of each secret (see VerificationMJ)
+	// so that the encryptions and the future broadcast are shared by all the secrets
 }
 
 // VerificationMJ is the message M[j] for verification committee member j+1
 type VerificationMJ struct {
 	SR []curve25519.Scalar // sigma_ij0,..., sigma_ijn-1, rho_ij0, ... (size = 2n)
+	// for a batched refresh of K secrets, the concatenation of the above for each secret (size = 2nK)
 }
 
-// EpsK is the message for resolution committee member j
+// EpsK is the message for resolution committee member k
 type EpsK struct {
-	Eps []curve25519.Scalar // eps_{i,1,j+1},...,eps_{i,n,j+1}
+	Eps []curve25519.Scalar // eps_{i,1,k+1},...,eps_{i,n',k+1} for the n' verification committee members
 	// TODO: not optimized as we could use a smaller modulus, but that's good enough for this implementation
 }
 
 // GenerateDealerSharesCommitments generate sigmaRho and comC for secret s and randomness r for dealer D_i
-// where sigmaRho[j][l] is a (n+1)*2n matrix, see ComC in DealingMessage
-//     sigmaRho[j][l]   = sigma_{i+1,j,l+1} for j in [0,n], l in [0,n-1]
-// and sigmaRho[j][l+n] = rho_{i+1,j,l+1}   for j in [0,n], l in [0,n-1]
+// where sigmaRho[j][l] is a (n'+1)*2n matrix, see ComC in DealingMessage
+//     sigmaRho[j][l]   = sigma_{i+1,j,l+1} for j in [0,n'], l in [0,n-1]
+// and sigmaRho[j][l+n] = rho_{i+1,j,l+1}   for j in [0,n'], l in [0,n-1]
+// n is the size of the next holding committee (nextParams.N) and n' the one of the verification committee
+// (verParams.N)
 // sigma_{i+1,l+1} = sigma_{i+1,0,l+1} (for l in [0,n-1]) is a sharing of s
 // same for rho
+// rnd is the randomness source (system randomness if nil)
 func GenerateDealerSharesCommitments(
-	vssParams *vss.Params, vcParams *feldman.VCParams, s *curve25519.Scalar, r *curve25519.Scalar,
+	rnd io.Reader, verParams *vss.Params, nextParams *vss.Params, vcParams *feldman.VCParams,
+	s *curve25519.Scalar, r *curve25519.Scalar,
 ) (
 	sigmaRho [][]curve25519.Scalar, comC []feldman.VC, err error,
 ) {
-	n := vssParams.N
-	t := vssParams.D + 1
+	nVer := verParams.N
+	n := nextParams.N
 
 	// Generate sigma
-	sigma, err := genSigmaOrRho(s, t, n)
+	sigma, err := genSigmaOrRho(rnd, s, verParams, nextParams)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// Generate rho
-	rho, err := genSigmaOrRho(r, t, n)
+	rho, err := genSigmaOrRho(rnd, r, verParams, nextParams)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// Concatenate to obtain sigmaRho
-	sigmaRho = make([][]curve25519.Scalar, n+1)
-	for j := 0; j <= n; j++ {
+	sigmaRho = make([][]curve25519.Scalar, nVer+1)
+	for j := 0; j <= nVer; j++ {
 		sigmaRho[j] = make([]curve25519.Scalar, 2*n)
 		copy(sigmaRho[j][0:n], sigma[j])
 		copy(sigmaRho[j][n:2*n], rho[j])
 	}
 
 	// Commitment
-	comC = make([]feldman.VC, n+1)
-	for j := 0; j <= n; j++ {
+	comC = make([]feldman.VC, nVer+1)
+	for j := 0; j <= nVer; j++ {
 		cj, err := curve25519.MultiMultPointXYScalar(vcParams.Bases, sigmaRho[j])
 		if err != nil {
 			return nil, nil, err
@@ -102,12 +126,17 @@ func GenerateDealerSharesCommitments(
 }
 
 // genSigmaOrRho generates the matrix sigma or rho as defined in GenerateDealerSharesCommitments
-func genSigmaOrRho(s *curve25519.Scalar, t int, n int) (
+// The first-level sharing is toward the next holding committee (nextParams)
+// and the second-level sharing toward the verification committee (verParams)
+func genSigmaOrRho(rnd io.Reader, s *curve25519.Scalar, verParams *vss.Params, nextParams *vss.Params) (
 	sigma [][]curve25519.Scalar, err error) {
 
+	nVer := verParams.N
+	n := nextParams.N
+
 	// First-level sharing
 	// shares0[l] = sigma_{i+1,l+1} = sigma_{i+1,0,l+1} for
-	shares0, err := shamir.GenerateShares(shamir.Message(*s), t, n)
+	shares0, err := shamir.GenerateSharesFrom(rnd, shamir.Message(*s), nextParams.D+1, n)
 	if err != nil {
 		return nil, err
 	}
@@ -116,16 +145,16 @@ func genSigmaOrRho(s *curve25519.Scalar, t int, n int) (
 	// shares[l][j] = sigma_{i+1,j+1,l+1}
 	shares := make([][]shamir.Share, n)
 	for l := 0; l < n; l++ {
-		shares[l], err = shamir.GenerateShares(shamir.Message(shares0[l].S), t, n)
+		shares[l], err = shamir.GenerateSharesFrom(rnd, shamir.Message(shares0[l].S), verParams.D+1, nVer)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	// Reorganize the shares into sigma
-	sigma = make([][]curve25519.Scalar, n+1)
-	for j := 0; j <= n; j++ {
-		sigma[j] = make([]curve25519.Scalar, n+1)
+	sigma = make([][]curve25519.Scalar, nVer+1)
+	for j := 0; j <= nVer; j++ {
+		sigma[j] = make([]curve25519.Scalar, n)
 	}
 	// handle j=0
 	for l := 0; l < n; l++ {
@@ -133,55 +162,100 @@ func genSigmaOrRho(s *curve25519.Scalar, t int, n int) (
 	}
 	// handle j>0, i.e., sigma[j][l] = shares[l][j-1]
 	for l := 0; l < n; l++ {
-		for j := 1; j < n+1; j++ {
+		for j := 1; j < nVer+1; j++ {
 			sigma[j][l] = shares[l][j-1].S
 		}
 	}
 	return sigma, nil
 }
 
-func genComZComZPrimeProof(n int, vcParams *feldman.VCParams, sigmaRho [][]curve25519.Scalar) (
+func genComZComZPrimeProof(
+	rnd io.Reader, ctx *ProofContext, format ProofFormat,
+	n int, vcParams *feldman.VCParams, sigmaRho [][]curve25519.Scalar,
+) (
 	comZ []pedersen.Commitment, comZPrime []curve25519.PointXY, proof DblDLEqProof, err error,
 ) {
+	return genBatchComZComZPrimeProof(rnd, ctx, format, n, vcParams, [][][]curve25519.Scalar{sigmaRho})
+}
 
-	comZ = make([]pedersen.Commitment, n)
-	comZPrime = make([]curve25519.PointXY, n)
+// genBatchComZComZPrimeProof is the same as genComZComZPrimeProof for the K secrets of a batched refresh
+// where sigmaRhos[u] is the matrix sigmaRho of the secret u (see GenerateDealerSharesCommitments)
+// comZ and comZPrime are the concatenations of the values for each secret
+// and proof is a single proof for all the secrets
+func genBatchComZComZPrimeProof(
+	rnd io.Reader, ctx *ProofContext, format ProofFormat,
+	n int, vcParams *feldman.VCParams, sigmaRhos [][][]curve25519.Scalar,
+) (
+	comZ []pedersen.Commitment, comZPrime []curve25519.PointXY, proof DblDLEqProof, err error,
+) {
+	numSecrets := len(sigmaRhos)
 
-	for l := 0; l < n; l++ {
-		zl, err := curve25519.DoubleMultBaseGHPointXYScalar(
-			&sigmaRho[0][l], &sigmaRho[0][l+n],
-		)
-		if err != nil {
-			return nil, nil, DblDLEqProof{}, err
-		}
-		comZ[l] = *zl
+	comZ = make([]pedersen.Commitment, numSecrets*n)
+	comZPrime = make([]curve25519.PointXY, numSecrets*n)
+	witness := DblDLEqWitness{
+		X: make([]curve25519.Scalar, 0, numSecrets*n),
+		Y: make([]curve25519.Scalar, 0, numSecrets*n),
+	}
 
-		zlPrime, err := curve25519.MultiMultPointXYScalar(
-			[]curve25519.PointXY{vcParams.Bases[l], vcParams.Bases[n+l]},
-			[]curve25519.Scalar{sigmaRho[0][l], sigmaRho[0][l+n]},
-		)
-		if err != nil {
-			return nil, nil, DblDLEqProof{}, err
+	for u, sigmaRho := range sigmaRhos {
+		for l := 0; l < n; l++ {
+			zl, err := curve25519.DoubleMultBaseGHPointXYScalar(
+				&sigmaRho[0][l], &sigmaRho[0][l+n],
+			)
+			if err != nil {
+				return nil, nil, DblDLEqProof{}, err
+			}
+			comZ[u*n+l] = *zl
+
+			zlPrime, err := curve25519.MultiMultPointXYScalar(
+				[]curve25519.PointXY{vcParams.Bases[l], vcParams.Bases[n+l]},
+				[]curve25519.Scalar{sigmaRho[0][l], sigmaRho[0][l+n]},
+			)
+			if err != nil {
+				return nil, nil, DblDLEqProof{}, err
+			}
+			comZPrime[u*n+l] = *zlPrime
 		}
-		comZPrime[l] = *zlPrime
+		witness.X = append(witness.X, sigmaRho[0][:n]...)
+		witness.Y = append(witness.Y, sigmaRho[0][n:]...)
 	}
 
+	g, h := batchDblDLEqBases(n, vcParams, numSecrets)
 	proof, err = DblDLEqProve(
+		rnd,
+		ctx,
+		format,
 		DblDLEqStatement{
-			G:      vcParams.Bases[:n],
-			H:      vcParams.Bases[n:],
+			G:      g,
+			H:      h,
 			Z:      comZ,
 			ZPrime: comZPrime,
 		},
-		DblDLEqWitness{
-			X: sigmaRho[0][:n],
-			Y: sigmaRho[0][n:],
-		},
+		witness,
 	)
 
 	return
 }
 
+// batchDblDLEqBases returns the bases G and H of the statement of DblDLEqProof for numSecrets secrets
+// i.e., the first n and the last n bases of vcParams, repeated numSecrets times
+func batchDblDLEqBases(n int, vcParams *feldman.VCParams, numSecrets int) (g, h []curve25519.PointXY) {
+	g = make([]curve25519.PointXY, 0, numSecrets*n)
+	h = make([]curve25519.PointXY, 0, numSecrets*n)
+	for u := 0; u < numSecrets; u++ {
+		g = append(g, vcParams.Bases[:n]...)
+		h = append(h, vcParams.Bases[n:2*n]...)
+	}
+	return g, h
+}
+
+// feldmanShareTranscript returns the transcript for the Feldman share of a dealer with context ctx
+func feldmanShareTranscript(ctx *ProofContext) *transcript.Transcript {
+	t := transcript.New("feldman_share")
+	ctx.bind(t)
+	return t
+}
+
 // PerformDealing executes what a dealer does in the dealing round
 // and returns the message it should broadcast
 func PerformDealing(
@@ -189,33 +263,66 @@ func PerformDealing(
 	prv *PrivateInput,
 	dbg *PartyDebugParams,
 ) (*DealingMessage, error) {
-	msg := &DealingMessage{
-		EncVerM: make([]curve25519.Ciphertext, pub.N),
-		EncResM: make([]curve25519.SymmetricCiphertext, pub.N),
-		EncEpsK: make([]curve25519.Ciphertext, pub.N),
+	nVer := pub.verParams().N
+	nRes := pub.resParams().N
+
+	msg := &DealingMessage{}
+	if pub.futureBroadcast() {
+		msg.EncResM = make([]curve25519.SymmetricCiphertext, nVer)
+		msg.EncEpsK = make([]curve25519.Ciphertext, nRes)
+	}
+	if pub.VEncPKs != nil {
+		msg.VEncVerM = make([]VerifiableEncryption, nVer)
+	} else {
+		msg.EncVerM = make([]curve25519.Ciphertext, nVer)
 	}
 
-	sigmaRho, comC, err := GenerateDealerSharesCommitments(&pub.VSSParams, &pub.VCParams,
-		&prv.Share.S, &prv.Share.R)
-	if err != nil {
-		return nil, fmt.Errorf("error while generating shares commitments: %w", err)
+	var err error
+
+	// Share each secret and concatenate the commitments (see DealingMessage)
+	numSecrets := pub.numSecrets()
+	sigmaRhos := make([][][]curve25519.Scalar, numSecrets)
+	msg.ComC = make([]feldman.VC, 0, numSecrets*(nVer+1))
+	for u := 0; u < numSecrets; u++ {
+		share := prv.secretShare(u)
+		sigmaRho, comC, err := GenerateDealerSharesCommitments(prv.Rand, pub.verParams(), pub.nextParams(),
+			&pub.VCParams, &share.S, &share.R)
+		if err != nil {
+			return nil, fmt.Errorf("error while generating shares commitments: %w", err)
+		}
+		sigmaRhos[u] = sigmaRho
+		msg.ComC = append(msg.ComC, comC...)
 	}
-	msg.ComC = comC
 
-	msg.ComZ, msg.ComZPrime, msg.DblDLEqProof, err = genComZComZPrimeProof(pub.N, &pub.VCParams, sigmaRho)
+	msg.ComZ, msg.ComZPrime, msg.DblDLEqProof, err = genBatchComZComZPrimeProof(
+		prv.Rand, pub.ProofContext(prv.ID), pub.ProofFormats.Dealing, pub.nextParams().N, &pub.VCParams, sigmaRhos)
 	if err != nil {
 		return nil, fmt.Errorf("error while generating Z/Z'/proof: %w", err)
 	}
 
-	if len(pub.Committees.Ver) != pub.N {
+	if len(pub.Committees.Ver) != nVer {
 		return nil, fmt.Errorf("invalid committee length")
 	}
 
+	// Publish the dealt value in the exponent
+	if pub.FeldmanConversion {
+		i := pub.Committees.Indices(prv.ID).Hold
+		msg.FeldmanShare, err = feldman.ProveExpShare(
+			prv.Rand, feldmanShareTranscript(pub.ProofContext(prv.ID)), &pub.Commitments[i+1],
+			&prv.Share.S, &prv.Share.R,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error while generating Feldman share: %w", err)
+		}
+	}
+
 	// Generate keys and shares for resolution committee (future broadcast)
+	// There is no future broadcast with verifiable encryption (see VerifiableEncryption)
+	futureBroadcast := pub.futureBroadcast() && !dbg.SkipDealingFutureBroadcast
 	var epsK []EpsK
 	var epsKeys []curve25519.Key
-	if !dbg.SkipDealingFutureBroadcast {
-		epsKeys, epsK, msg.HashEps, err = GenerateAllEps(pub.N, pub.T)
+	if futureBroadcast {
+		epsKeys, epsK, msg.HashEps, err = GenerateAllEps(prv.Rand, nVer, nRes, pub.resParams().D)
 		if err != nil {
 			return nil, err
 		}
@@ -224,19 +331,29 @@ func PerformDealing(
 	}
 
 	// Encryption for verification committee and resolution committee
-	for j := 0; j < pub.N; j++ {
+	for j := 0; j < nVer; j++ {
 		// Compute M[j]
-		mj := VerificationMJ{SR: sigmaRho[j+1]}
+		mj := VerificationMJ{SR: make([]curve25519.Scalar, 0, numSecrets*2*pub.nextParams().N)}
+		for u := range sigmaRhos {
+			mj.SR = append(mj.SR, sigmaRhos[u][j+1]...)
+		}
 
 		mjMsg := msgpack.Encode(mj)
 
 		// Encrypt M[j] for the verification member j+1
-		msg.EncVerM[j], err = curve25519.Encrypt(pub.EncPKs[pub.Committees.Ver[j]], mjMsg)
+		if pub.VEncPKs != nil {
+			msg.VEncVerM[j], err = vEncEncryptAndProve(
+				prv.Rand, pub.ProofContext(prv.ID), pub.ProofFormats.Dealing, j,
+				&pub.VCParams, &msg.ComC[j+1], &pub.VEncPKs[pub.Committees.Ver[j]], mj.SR,
+			)
+		} else {
+			msg.EncVerM[j], err = curve25519.EncryptFrom(prv.Rand, pub.EncPKs[pub.Committees.Ver[j]], mjMsg)
+		}
 		if err != nil {
 			return nil, err
 		}
 
-		if !dbg.SkipDealingFutureBroadcast {
+		if futureBroadcast {
 			// Encrypt M[j] for resolution / future broadcast
 			// Use a zero nonce as the key is fresh
 			zeroNonce := curve25519.Nonce{}
@@ -244,15 +361,15 @@ func PerformDealing(
 			if err != nil {
 				return nil, err
 			}
-		} else {
+		} else if pub.futureBroadcast() {
 			msg.EncResM[j] = curve25519.SymmetricCiphertext{} // just to please go-codec
 		}
 	}
 
 	// Encrypt epsK for each resolution committee member
-	for k := 0; k < pub.N; k++ {
-		if !dbg.SkipDealingFutureBroadcast {
-			msg.EncEpsK[k], err = curve25519.Encrypt(pub.EncPKs[pub.Committees.Res[k]], msgpack.Encode(epsK[k]))
+	for k := range msg.EncEpsK {
+		if futureBroadcast {
+			msg.EncEpsK[k], err = curve25519.EncryptFrom(prv.Rand, pub.EncPKs[pub.Committees.Res[k]], msgpack.Encode(epsK[k]))
 			if err != nil {
 				return nil, err
 			}
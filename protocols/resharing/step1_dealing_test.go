@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/shaih/go-yosovss/msgpack"
 	"github.com/shaih/go-yosovss/primitives/curve25519"
 	"github.com/shaih/go-yosovss/primitives/feldman"
 	"github.com/shaih/go-yosovss/primitives/pedersen"
@@ -43,7 +44,7 @@ func TestGenerateDealerSharesCommitmentsValid(t *testing.T) {
 			s := curve25519.RandomScalar()
 
 			// Generate comC
-			_, comC, err := GenerateDealerSharesCommitments(vssParams, vcParams, s, r)
+			_, comC, err := GenerateDealerSharesCommitments(nil, vssParams, vssParams, vcParams, s, r)
 			require.NoError(err)
 
 			// Verify validity of comC, that is they must be in the correct linear space
@@ -82,11 +83,11 @@ func TestGenComZComZPrimeProofValidProof(t *testing.T) {
 			r := curve25519.RandomScalar()
 			s := curve25519.RandomScalar()
 
-			sigmaRho, _, err := GenerateDealerSharesCommitments(vssParams, vcParams, s, r)
+			sigmaRho, _, err := GenerateDealerSharesCommitments(nil, vssParams, vssParams, vcParams, s, r)
 			require.NoError(err)
 
 			// Generate Z/Z'/proof
-			comZ, comZPrime, proof, err := genComZComZPrimeProof(n, vcParams, sigmaRho)
+			comZ, comZPrime, proof, err := genComZComZPrimeProof(nil, nil, ProofFormatBatchable, n, vcParams, sigmaRho)
 			require.NoError(err)
 
 			// Verify validity of the proof
@@ -96,7 +97,7 @@ func TestGenComZComZPrimeProofValidProof(t *testing.T) {
 				Z:      comZ,
 				ZPrime: comZPrime,
 			}
-			err = DblDLEqVerify(stmt, proof)
+			err = DblDLEqVerify(nil, stmt, proof)
 			assert.NoError(err)
 		})
 	}
@@ -132,11 +133,11 @@ func TestGenComZComZPrimeProofValidComZ(t *testing.T) {
 			r := curve25519.RandomScalar()
 			s := curve25519.RandomScalar()
 
-			sigmaRho, _, err := GenerateDealerSharesCommitments(vssParams, vcParams, s, r)
+			sigmaRho, _, err := GenerateDealerSharesCommitments(nil, vssParams, vssParams, vcParams, s, r)
 			require.NoError(err)
 
 			// Generate Z/Z'/proof
-			comZ, _, _, err := genComZComZPrimeProof(n, vcParams, sigmaRho)
+			comZ, _, _, err := genComZComZPrimeProof(nil, nil, ProofFormatBatchable, n, vcParams, sigmaRho)
 			require.NoError(err)
 
 			// Verify validity of comZ, that is they must be in the correct linear space
@@ -153,3 +154,109 @@ func TestGenComZComZPrimeProofValidComZ(t *testing.T) {
 		})
 	}
 }
+
+// TestPerformDealingDeterministic checks that dealing with the same randomness source
+// gives bit-exactly the same message, and that it is still valid
+func TestPerformDealingDeterministic(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n  = 3
+		tt = 1
+	)
+
+	pub, prvs, _, _, _ := setupResharingSeq(t, n, tt)
+	dealer := pub.Committees.Hold[0]
+
+	vectorV, err := generateDealerVectorsV(pub)
+	require.NoError(err)
+
+	deal := func(seed string) []byte {
+		prv := prvs[dealer]
+		prv.Rand = curve25519.NewDeterministicRandom([]byte(seed))
+		msg, err := PerformDealing(pub, &prv, &PartyDebugParams{})
+		require.NoError(err)
+		require.NoError(checkDealerQualified(pub, 0, *msg, vectorV))
+		return msgpack.Encode(msg)
+	}
+
+	assert.Equal(deal("seed"), deal("seed"), "same seed gives same dealing message")
+	assert.NotEqual(deal("seed"), deal("other seed"), "different seeds give different dealing messages")
+}
+
+func TestPerformDealingCompact(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n  = 3
+		tt = 1
+	)
+
+	pub, prvs, _, _, _ := setupResharingSeq(t, n, tt)
+	dealer := pub.Committees.Hold[0]
+	prv := prvs[dealer]
+
+	vectorV, err := generateDealerVectorsV(pub)
+	require.NoError(err)
+
+	msg, err := PerformDealing(pub, &prv, &PartyDebugParams{})
+	require.NoError(err)
+	batchableLen := len(msgpack.Encode(msg))
+
+	pub.ProofFormats.Dealing = ProofFormatCompact
+	msg, err = PerformDealing(pub, &prv, &PartyDebugParams{})
+	require.NoError(err)
+	require.NotNil(msg.DblDLEqProof.Chal)
+	assert.Less(len(msgpack.Encode(msg)), batchableLen, "compact dealing message is smaller")
+
+	// Compact proofs are accepted whatever the selected format is
+	pub.ProofFormats.Dealing = ProofFormatBatchable
+	assert.NoError(checkDealerQualified(pub, 0, *msg, vectorV))
+}
+
+func TestPerformDealingFeldman(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n  = 3
+		tt = 1
+	)
+
+	pub, prvs, _, _, _ := setupResharingSeq(t, n, tt)
+	dealer := pub.Committees.Hold[0]
+	prv := prvs[dealer]
+
+	vectorV, err := generateDealerVectorsV(pub)
+	require.NoError(err)
+
+	pub.FeldmanConversion = true
+	msg, err := PerformDealing(pub, &prv, &PartyDebugParams{})
+	require.NoError(err)
+	require.NotNil(msg.FeldmanShare)
+	assert.NoError(checkDealerQualified(pub, 0, *msg, vectorV))
+
+	y, err := curve25519.MultBaseGPointXYScalar(&prv.Share.S)
+	require.NoError(err)
+	assert.Equal(*y, msg.FeldmanShare.Y)
+
+	// Feldman share checked for another dealer
+	assert.Error(checkDealerQualified(pub, 1, *msg, vectorV))
+
+	// Wrong Feldman share
+	feldmanShare := *msg.FeldmanShare
+	msg.FeldmanShare.Y = *curve25519.RandomPointXY()
+	assert.Error(checkDealerQualified(pub, 0, *msg, vectorV))
+
+	// Missing Feldman share
+	msg.FeldmanShare = nil
+	assert.Error(checkDealerQualified(pub, 0, *msg, vectorV))
+
+	// Feldman share not required
+	pub.FeldmanConversion = false
+	assert.NoError(checkDealerQualified(pub, 0, *msg, vectorV))
+	msg.FeldmanShare = &feldmanShare
+	assert.NoError(checkDealerQualified(pub, 0, *msg, vectorV))
+}
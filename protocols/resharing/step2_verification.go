@@ -2,6 +2,7 @@ package resharing
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/shaih/go-yosovss/msgpack"
 	"github.com/shaih/go-yosovss/primitives/curve25519"
@@ -13,6 +14,8 @@ import (
 // contains the shares sigma_{i+1,j+1,l}/rho_{i+1,j+1,l},
 // for i in [0,n-1] (i+1 corresponding to the dealer)
 // with nil for every dealer i that has incorrect shares
+// For a batched refresh of K secrets, S and R are the concatenations of the shares of each secret
+// i.e., S[u*n+i] is the share of the secret u from dealer i
 type VerSentShares struct {
 	S []*curve25519.Scalar // sigma_{1,j+1,l},..., sigma_{n,j+1,l}
 	R []*curve25519.Scalar // same for rho
@@ -51,23 +54,27 @@ func PerformVerification(
 		"l":         j,
 	})
 
+	nNext := pub.nextParams().N
+	numSecrets := pub.numSecrets()
+
 	msg := &VerificationMessage{
-		EncShares:  make([]curve25519.Ciphertext, pub.N),
+		EncShares:  make([]curve25519.Ciphertext, nNext),
 		Complaints: make([]bool, pub.N),
 	}
 
 	// Allocate verSentShares, the values to be encrypted in msg.EncShares
 	// verSentShares[l] contains the shares sigma_{i+1,l+1,l+1} for i in [0,n-1]
-	verSentShares := make([]VerSentShares, pub.N)
+	verSentShares := make([]VerSentShares, nNext)
 
-	for l := 0; l < pub.N; l++ {
+	for l := 0; l < nNext; l++ {
 		verSentShares[l] = VerSentShares{
-			S: make([]*curve25519.Scalar, pub.N),
-			R: make([]*curve25519.Scalar, pub.N),
+			S: make([]*curve25519.Scalar, numSecrets*pub.N),
+			R: make([]*curve25519.Scalar, numSecrets*pub.N),
 		}
 	}
 
 	// sigmaRho[i][l] = sigma_{i+1,l+1} for all dealers i
+	// (for a batched refresh, sigmaRho[i][u*2n+l] for the secret u, see VerificationMJ)
 	// if i disqualified, sigmaRho[i] = nil
 	sigmaRho := make([][]curve25519.Scalar, pub.N)
 
@@ -85,7 +92,7 @@ func PerformVerification(
 
 		// Verify shares
 		if !dbg.SkipVerificationVerifyShare {
-			err := VerifyMJ(&pub.VCParams, &dealingMessages[i].ComC[j+1], mj)
+			err := verifyBatchMJ(pub, &dealingMessages[i], j, mj)
 			if err != nil {
 				// invalid dealer
 				msg.Complaints[i] = true
@@ -96,7 +103,7 @@ func PerformVerification(
 
 		// the dealer is good
 
-		sigmaRho[i] = make([]curve25519.Scalar, 2*pub.N)
+		sigmaRho[i] = make([]curve25519.Scalar, numSecrets*2*nNext)
 		copy(sigmaRho[i], mj.SR)
 	}
 
@@ -104,9 +111,11 @@ func PerformVerification(
 	qualDealers := 0
 	for i := 0; i < pub.N; i++ {
 		if !msg.Complaints[i] {
-			for l := 0; l < pub.N; l++ {
-				verSentShares[l].S[i] = &sigmaRho[i][l]
-				verSentShares[l].R[i] = &sigmaRho[i][l+pub.N]
+			for u := 0; u < numSecrets; u++ {
+				for l := 0; l < nNext; l++ {
+					verSentShares[l].S[u*pub.N+i] = &sigmaRho[i][u*2*nNext+l]
+					verSentShares[l].R[u*pub.N+i] = &sigmaRho[i][u*2*nNext+l+nNext]
+				}
 			}
 			qualDealers++
 		}
@@ -117,7 +126,11 @@ func PerformVerification(
 	}
 
 	// Generate the commit and proof
-	vpcp, err := genVPComProof(&pub.VCParams, sigmaRho)
+	// There is a single proof for all the secrets of a batched refresh, where each secret of each dealer
+	// is handled as a different dealer (see verifierComC)
+	vpcp, err := genVPComProof(
+		prv.Rand, pub.ProofContext(prv.ID), pub.ProofFormats.Verification, &pub.VCParams,
+		splitSecrets(sigmaRho, numSecrets))
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +138,7 @@ func PerformVerification(
 
 	// Encrypt all the verSentShares
 	for l, nextHolder := range pub.Committees.Next {
-		msg.EncShares[l], err = curve25519.Encrypt(pub.EncPKs[nextHolder], msgpack.Encode(&verSentShares[l]))
+		msg.EncShares[l], err = curve25519.EncryptFrom(prv.Rand, pub.EncPKs[nextHolder], msgpack.Encode(&verSentShares[l]))
 		if err != nil {
 			return nil, err
 		}
@@ -134,6 +147,37 @@ func PerformVerification(
 	return msg, nil
 }
 
+// splitSecrets returns the matrix whose row i*K+u is the part of sigmaRho[i] for the secret u in 0,...,K-1
+// (nil if sigmaRho[i] is nil), where K is numSecrets (see VerificationMJ)
+func splitSecrets(sigmaRho [][]curve25519.Scalar, numSecrets int) [][]curve25519.Scalar {
+	rows := make([][]curve25519.Scalar, len(sigmaRho)*numSecrets)
+	for i := range sigmaRho {
+		if sigmaRho[i] == nil {
+			continue
+		}
+		size := len(sigmaRho[i]) / numSecrets
+		for u := 0; u < numSecrets; u++ {
+			rows[i*numSecrets+u] = sigmaRho[i][u*size : (u+1)*size]
+		}
+	}
+	return rows
+}
+
+// verifyBatchMJ verifies that the message mj sent by the dealer to verifier j matches the commitments
+// ComC[u*(n'+1)+j+1] for each secret u (see DealingMessage)
+// The lengths of mj.SR and of msg.ComC must have been checked
+func verifyBatchMJ(pub *PublicInput, msg *DealingMessage, j int, mj *VerificationMJ) error {
+	nVer := pub.verParams().N
+	size := 2 * pub.nextParams().N
+	for u := 0; u < pub.numSecrets(); u++ {
+		err := VerifyMJ(&pub.VCParams, &msg.ComC[u*(nVer+1)+j+1], &VerificationMJ{SR: mj.SR[u*size : (u+1)*size]})
+		if err != nil {
+			return fmt.Errorf("secret %d: %w", u, err)
+		}
+	}
+	return nil
+}
+
 func VerifyMJ(vcParams *feldman.VCParams, comCIJ *feldman.VC, mj *VerificationMJ) error {
 	tmp, err := curve25519.MultiMultPointXYScalar(vcParams.Bases, mj.SR)
 	if err != nil {
@@ -149,7 +193,9 @@ func VerifyMJ(vcParams *feldman.VCParams, comCIJ *feldman.VC, mj *VerificationMJ
 // Importantly the disqualified dealer's shares should be nil
 // sigma[i] contains the shares sigma_{i+1,j+1,l+1} for qualified dealers i in [0,n-1]
 // sigma[i] = nil for non-qualified dealers
-func genVPComProof(vcParams *feldman.VCParams, allSigmaRho [][]curve25519.Scalar) (VPCommitProof, error) {
+func genVPComProof(
+	rnd io.Reader, ctx *ProofContext, format ProofFormat, vcParams *feldman.VCParams, allSigmaRho [][]curve25519.Scalar,
+) (VPCommitProof, error) {
 	n := len(allSigmaRho)
 
 	// computing the number of qualified dealers
@@ -167,13 +213,13 @@ func genVPComProof(vcParams *feldman.VCParams, allSigmaRho [][]curve25519.Scalar
 		if allSigmaRho[i] == nil {
 			continue // skip disqualified dealers
 		}
-		sigmaRho[ii] = make([]curve25519.Scalar, 2*n)
+		sigmaRho[ii] = make([]curve25519.Scalar, len(allSigmaRho[i]))
 		copy(sigmaRho[ii], allSigmaRho[i])
 		ii++
 	}
 
 	//
-	return VPCommitAndProve(vcParams, sigmaRho)
+	return VPCommitAndProve(rnd, ctx, format, vcParams, sigmaRho)
 }
 
 // getMJ decrypts and decode message MK sent by dealer i to verifier j
@@ -185,6 +231,17 @@ func getMJ(
 	myLog *log.Entry,
 ) (mk *VerificationMJ) {
 
+	if pub.VEncPKs != nil {
+		return getVEncMJ(pub, prv, j, dealingMessages, i, myLog)
+	}
+
+	if len(dealingMessages[i].EncVerM) != pub.verParams().N ||
+		len(dealingMessages[i].ComC) != pub.numSecrets()*(pub.verParams().N+1) {
+		// invalid dealer
+		myLog.Infof("complain against dealer %d: EncVerM or ComC of incorrect length", i)
+		return nil
+	}
+
 	// Decrypt and decode M_k
 	b, err := curve25519.Decrypt(pub.EncPKs[prv.ID], prv.EncSK, dealingMessages[i].EncVerM[j])
 	if err != nil {
@@ -202,7 +259,7 @@ func getMJ(
 	}
 
 	// Verify Mk lists are the correct length
-	if len(mk.SR) != 2*pub.N {
+	if len(mk.SR) != pub.numSecrets()*2*pub.nextParams().N {
 		// invalid dealer
 		myLog.Infof("complain against dealer %d: SR of incorrect length", i)
 		return nil
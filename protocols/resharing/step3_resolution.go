@@ -7,9 +7,11 @@ import (
 )
 
 // PairIJ is a pair of two integers i and j
-// i,j in 0,...,n-1 and represent a dealer i and a verification member committee j (Vk) respectively
+// i in 0,...,n-1 and j in 0,...,n'-1 represent a dealer i and a verification member committee j (Vk) respectively
+// The fields must be exported as PairIJ is encoded as a key of ResolutionMessage.EpsShares
 type PairIJ struct {
-	i, j int
+	I int `codec:"i"`
+	J int `codec:"j"`
 }
 
 // ResolutionMessage is the message resolution committee members send during resolution round
@@ -36,6 +38,7 @@ func PerformResolution(
 	})
 
 	n := pub.N
+	nVer := pub.verParams().N
 
 	msg := ResolutionMessage{
 		EpsShares: map[PairIJ]curve25519.Scalar{},
@@ -48,7 +51,7 @@ func PerformResolution(
 	// It is unclear that it matters though...
 	// Most likely the decoding of the messages cost already much more...
 
-	for k := 0; k < n; k++ { // message sent by party j in verification cmte
+	for k := 0; k < nVer; k++ { // message sent by party j in verification cmte
 		if len(verificationMessages[k].Complaints) != n {
 			// the verifier j is invalid
 			continue
@@ -61,9 +64,9 @@ func PerformResolution(
 				// Decrypt and decode epsL if not yet decrypted
 				if epsLI[i] == nil {
 					epsLI[i] = DecryptEpsK(pub, prv, l, dealingMessages, i, myLog)
-					// If it fails, stops with this dealer
+					// If it fails, skip this dealer
 					if epsLI[i] == nil {
-						break
+						continue
 					}
 				}
 
@@ -84,6 +87,12 @@ func DecryptEpsK(
 	i int,
 	myLog *log.Entry,
 ) *EpsK {
+	if len(dealingMessages[i].EncEpsK) != pub.resParams().N {
+		// invalid dealer
+		myLog.Infof("dealer %d sent EncEpsK of incorrect length", i)
+		return nil
+	}
+
 	b, err := curve25519.Decrypt(pub.EncPKs[prv.ID], prv.EncSK, dealingMessages[i].EncEpsK[k])
 	if err != nil {
 		// invalid dealer
@@ -98,6 +107,11 @@ func DecryptEpsK(
 		myLog.Infof("dealer %d did not encode properly epsL[%d]: %v", i, k, err)
 		return nil
 	}
+	if len(epsL.Eps) != pub.verParams().N {
+		// invalid dealer
+		myLog.Infof("dealer %d sent epsL[%d] of incorrect length", i, k)
+		return nil
+	}
 
 	return &epsL
 }
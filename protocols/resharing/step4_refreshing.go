@@ -1,16 +1,25 @@
 package resharing
 
 import (
+	"crypto/sha512"
+	"encoding/binary"
 	"fmt"
+	"sort"
 
 	"github.com/shaih/go-yosovss/msgpack"
 	"github.com/shaih/go-yosovss/primitives/curve25519"
 	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/nizk"
 	"github.com/shaih/go-yosovss/primitives/pedersen"
 	"github.com/shaih/go-yosovss/primitives/vss"
 	log "github.com/sirupsen/logrus"
 )
 
+// PerformRefresh executes what every party does in the refreshing round and returns the next commitments,
+// the next share (if the party is a member of the next holding committee), and the blame report
+// listing the dealers disqualified and the verifiers found invalid by the party (see BlameReport)
+// The blame report is returned even if refreshing fails (e.g., if there are not enough qualified dealers)
+// For a batched refresh, use PerformBatchRefresh
 func PerformRefresh(
 	pub *PublicInput,
 	prv *PrivateInput,
@@ -22,50 +31,196 @@ func PerformRefresh(
 ) (
 	[]pedersen.Commitment,
 	*vss.Share,
+	*BlameReport,
 	error,
 ) {
-	resolvedSharesS, disqualifiedDealersByComplaints, err := ResolveComplaints(
+	if pub.numSecrets() != 1 {
+		return nil, nil, nil, fmt.Errorf("use PerformBatchRefresh for a batched refresh")
+	}
+	nextCommitments, nextShares, blame, err := PerformBatchRefresh(
+		pub, prv, dealingMessages, verificationMessages, resolutionMessages, indexNext, dbg)
+	if err != nil {
+		return nil, nil, blame, err
+	}
+	return nextCommitments[0], nextShares[0], blame, nil
+}
+
+// PerformBatchRefresh is the same as PerformRefresh for a batched refresh of K secrets
+// (see PublicInput.BatchCommitments): nextCommitments[u] and nextShares[u] are for the secret u in 0,...,K-1
+// (nextShares is nil if the party is not a member of the next holding committee)
+func PerformBatchRefresh(
+	pub *PublicInput,
+	prv *PrivateInput,
+	dealingMessages []DealingMessage,
+	verificationMessages []VerificationMessage,
+	resolutionMessages []ResolutionMessage,
+	indexNext int, // if >=0, the party is the member number indexNext in the next holding committee
+	dbg *PartyDebugParams,
+) (
+	[][]pedersen.Commitment,
+	[]*vss.Share,
+	*BlameReport,
+	error,
+) {
+	nextCommitments, nextShares, _, blame, err := performRefresh(
+		pub, prv, dealingMessages, verificationMessages, resolutionMessages, indexNext, dbg)
+	return nextCommitments, nextShares, blame, err
+}
+
+// performRefresh is the same as PerformBatchRefresh but also returns the qualified dealers
+func performRefresh(
+	pub *PublicInput,
+	prv *PrivateInput,
+	dealingMessages []DealingMessage,
+	verificationMessages []VerificationMessage,
+	resolutionMessages []ResolutionMessage,
+	indexNext int,
+	dbg *PartyDebugParams,
+) (
+	nextCommitments [][]pedersen.Commitment,
+	nextShares []*vss.Share,
+	qualifiedDealers []int,
+	blame *BlameReport,
+	err error,
+) {
+	resolvedSharesS, disqualifiedDealersByComplaints, blame, err := ResolveComplaints(
 		pub, dealingMessages, verificationMessages, resolutionMessages, dbg)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to resolve complaints: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("failed to resolve complaints: %w", err)
 	}
 
 	qualifiedDealers, lagrangeCoefs, err := ComputeQualifiedDealers(
-		pub, disqualifiedDealersByComplaints, dealingMessages)
+		pub, disqualifiedDealersByComplaints, dealingMessages, blame)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to compute qualified dealers: %w", err)
+		return nil, nil, nil, blame, fmt.Errorf("failed to compute qualified dealers: %w", err)
 	}
 	log.WithField("indexNext", indexNext).WithField("party", prv.ID).Infof("qualified dealers: %v", qualifiedDealers)
 
-	nextCommitments, err := ComputeRefreshedCommitments(pub, dealingMessages, qualifiedDealers, lagrangeCoefs)
+	nextCommitments, err = ComputeRefreshedBatchCommitments(pub, dealingMessages, qualifiedDealers, lagrangeCoefs)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to compute refreshed commitments: %w", err)
+		return nil, nil, nil, blame, fmt.Errorf("failed to compute refreshed commitments: %w", err)
 	}
 
-	var nextShare *vss.Share
 	if indexNext >= 0 {
 		// We're in the next committee
-		nextShare, err = ComputeRefreshedShare(
+		nextShares, err = ComputeRefreshedBatchShares(
 			pub, prv, indexNext,
 			dealingMessages, verificationMessages,
 			qualifiedDealers, lagrangeCoefs,
 			resolvedSharesS,
+			blame,
 		)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, blame, err
 		}
 	}
-	return nextCommitments, nextShare, nil
+	return nextCommitments, nextShares, qualifiedDealers, blame, nil
+}
+
+// dealerVectorsV are the random vectors used to verify the linearity of the commitments of the dealers
+// (see vss.VerifyCommitmentsWithVectorV)
+type dealerVectorsV struct {
+	Ver  *curve25519.ScalarMatrix // for the second-level sharing toward the verification committee (ComC)
+	Next *curve25519.ScalarMatrix // for the first-level sharing toward the next holding committee (ComZ)
+}
+
+// generateDealerVectorsV generates the random vectors to verify the dealers
+// The same vector is used for both sharings when they have the same parameters
+func generateDealerVectorsV(pub *PublicInput) (*dealerVectorsV, error) {
+	vectorVVer, err := vss.GenerateVectorV(pub.verParams())
+	if err != nil {
+		return nil, err
+	}
+	if pub.verParams() == pub.nextParams() {
+		return &dealerVectorsV{Ver: vectorVVer, Next: vectorVVer}, nil
+	}
+	vectorVNext, err := vss.GenerateVectorV(pub.nextParams())
+	if err != nil {
+		return nil, err
+	}
+	return &dealerVectorsV{Ver: vectorVVer, Next: vectorVNext}, nil
 }
 
 // checkDealerQualified verifies whether the message of a dealer are valid
 // return non-nil error if they are not
-// vectorV is generated by vss.GenerateVectorV
-func checkDealerQualified(pub *PublicInput, i int, msg DealingMessage, vectorV *curve25519.ScalarMatrix) error {
+// vectorV is generated by generateDealerVectorsV
+func checkDealerQualified(pub *PublicInput, i int, msg DealingMessage, vectorV *dealerVectorsV) error {
+	b := nizk.NewBatch()
+	err := addDealerClaims(b, pub, i, msg, vectorV)
+	if err != nil {
+		return err
+	}
+
+	invalid, err := b.Verify()
+	if err != nil {
+		return err
+	}
+	if len(invalid) != 0 {
+		return fmt.Errorf("invalid DblDLEqProof or commitments")
+	}
+	return nil
+}
+
+// checkDealersQualified is the same as checkDealerQualified for all the dealers in dealers
+// but verifies all of them at once (see nizk.Batch)
+// It returns invalidDealers[i] = reason for each dealer i in dealers that is not qualified
+// dealingMessages[i] is the message of dealer i
+func checkDealersQualified(
+	pub *PublicInput, dealers []int, dealingMessages []DealingMessage, vectorV *dealerVectorsV,
+) (
+	invalidDealers map[int]error,
+	err error,
+) {
+	invalidDealers = map[int]error{}
+
+	b := nizk.NewBatch()
+	for _, i := range dealers {
+		err = addDealerClaims(b, pub, i, dealingMessages[i], vectorV)
+		if err != nil {
+			// there may be claims of the dealer in the batch, but it is already disqualified
+			invalidDealers[i] = err
+		}
+	}
+
+	invalid, err := b.Verify()
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range invalid {
+		if _, ok := invalidDealers[i]; !ok {
+			invalidDealers[i] = fmt.Errorf("invalid DblDLEqProof or commitments")
+		}
+	}
+
+	return invalidDealers, nil
+}
+
+// addDealerClaims adds to the batch b (item i) all the checks of the message of dealer i
+// Checks that do not require any multi-scalar multiplication are done immediately
+// and an error is returned if they fail
+// vectorV is generated by generateDealerVectorsV
+func addDealerClaims(
+	b *nizk.Batch, pub *PublicInput, i int, msg DealingMessage, vectorV *dealerVectorsV,
+) error {
 	var err error
 
+	nVer := pub.verParams().N
+	nNext := pub.nextParams().N
+	numSecrets := pub.numSecrets()
+
+	if len(msg.ComC) != numSecrets*(nVer+1) {
+		return fmt.Errorf("comC has invalid length")
+	}
+	if len(msg.ComZ) != numSecrets*nNext || len(msg.ComZPrime) != numSecrets*nNext {
+		return fmt.Errorf("comZ or comZPrime has invalid length")
+	}
+	if vectorV.Ver.Columns() != 1 || vectorV.Ver.Rows() != nVer+1 ||
+		vectorV.Next.Columns() != 1 || vectorV.Next.Rows() != nNext+1 {
+		return fmt.Errorf("wrong size of vector v")
+	}
+
 	// Check that comC is on the curve
-	for j := 0; j < pub.N; j++ {
+	for j := range msg.ComC {
 		if !curve25519.IsOnCurveXY(&msg.ComC[j]) {
 			return fmt.Errorf("comC[%d] is not on the curve", j)
 		}
@@ -73,9 +228,10 @@ func checkDealerQualified(pub *PublicInput, i int, msg DealingMessage, vectorV *
 
 	// Verify the proofs that comZ and comZPrime are committing to the same values
 	// This implies that the points are on the curve
-	err = DblDLEqVerify(DblDLEqStatement{
-		G:      pub.VCParams.Bases[:pub.N],
-		H:      pub.VCParams.Bases[pub.N:],
+	g, h := batchDblDLEqBases(nNext, &pub.VCParams, numSecrets)
+	err = dblDLEqBatchAdd(b, i, pub.ProofContext(pub.Committees.Hold[i]), DblDLEqStatement{
+		G:      g,
+		H:      h,
 		Z:      msg.ComZ,
 		ZPrime: msg.ComZPrime,
 	}, msg.DblDLEqProof)
@@ -83,84 +239,169 @@ func checkDealerQualified(pub *PublicInput, i int, msg DealingMessage, vectorV *
 		return fmt.Errorf("error while verifying DblDLEqProof: %w", err)
 	}
 
-	// Verify the linearity of the comC
-	valid, err := vss.VerifyCommitmentsWithVectorV(&pub.VSSParams, msg.ComC, vectorV)
+	// Verify the verifiable encryptions of the shares sent to the verifiers
+	if pub.VEncPKs != nil {
+		if len(msg.VEncVerM) != nVer {
+			return fmt.Errorf("VEncVerM has invalid length")
+		}
+		for j := 0; j < nVer; j++ {
+			err = vEncBatchAdd(b, i, pub.ProofContext(pub.Committees.Hold[i]), j,
+				&pub.VCParams, &msg.ComC[j+1], &pub.VEncPKs[pub.Committees.Ver[j]], &msg.VEncVerM[j])
+			if err != nil {
+				return fmt.Errorf("error while verifying VEncVerM[%d]: %w", j, err)
+			}
+		}
+	}
+
+	// Verify the Feldman share of the dealt value
+	if pub.FeldmanConversion {
+		if msg.FeldmanShare == nil {
+			return fmt.Errorf("missing Feldman share")
+		}
+		err = feldman.BatchAddExpShare(b, i, feldmanShareTranscript(pub.ProofContext(pub.Committees.Hold[i])),
+			&pub.Commitments[i+1], msg.FeldmanShare)
+		if err != nil {
+			return fmt.Errorf("error while verifying Feldman share: %w", err)
+		}
+	}
+
+	// The remaining checks are done for each secret u of a batched refresh (see DealingMessage)
+	for u := 0; u < numSecrets; u++ {
+		err = addDealerSecretClaims(b, pub, i, u, msg, vectorV)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addDealerSecretClaims adds to the batch b (item i) the checks of the commitments of dealer i
+// for the secret u in 0,...,K-1 (see addDealerClaims)
+// The lengths of the commitments must have been checked
+func addDealerSecretClaims(
+	b *nizk.Batch, pub *PublicInput, i int, u int, msg DealingMessage, vectorV *dealerVectorsV,
+) error {
+	nVer := pub.verParams().N
+	nNext := pub.nextParams().N
+
+	comC := msg.ComC[u*(nVer+1) : (u+1)*(nVer+1)]
+	comZ := msg.ComZ[u*nNext : (u+1)*nNext]
+	comZPrime := msg.ComZPrime[u*nNext : (u+1)*nNext]
+
+	// Verify the linearity of the comC (see vss.VerifyCommitmentsWithVectorV)
+	err := b.AddClaim(i, nizk.Claim{
+		Points:  comC,
+		Scalars: vectorV.Ver.Entries(),
+	})
 	if err != nil {
 		return fmt.Errorf("error while verifying comC: %w", err)
 	}
-	if !valid {
-		return fmt.Errorf("error while verifying comC: failed linearity test")
-	}
 
 	// Verifying the linearity of the comZ when prepended with the actual Pedersen commitment
-	allZ := make([]pedersen.Commitment, pub.N+1)
-	allZ[0] = pub.Commitments[i+1]
-	copy(allZ[1:], msg.ComZ)
-	valid, err = vss.VerifyCommitmentsWithVectorV(&pub.VSSParams, allZ, vectorV)
+	allZ := make([]pedersen.Commitment, nNext+1)
+	allZ[0] = pub.secretCommitments(u)[i+1]
+	copy(allZ[1:], comZ)
+	err = b.AddClaim(i, nizk.Claim{
+		Points:  allZ,
+		Scalars: vectorV.Next.Entries(),
+	})
 	if err != nil {
 		return fmt.Errorf("error while verifying comZ: %w", err)
 	}
-	if !valid {
-		return fmt.Errorf("error while verifying comZ: failed linearity test")
-	}
 
 	// Verify that the sum of comZPrime match comC[0]
-	sumComZ, err := curve25519.AddPointsXY(msg.ComZPrime)
-	if err != nil {
-		return fmt.Errorf("error while verifying DblDLEqProof: %w", err)
+	sumClaim := nizk.Claim{
+		Points:  make([]curve25519.PointXY, 0, nNext+1),
+		Scalars: make([]curve25519.Scalar, 0, nNext+1),
 	}
-	if !curve25519.PointXYEqual(sumComZ, &msg.ComC[0]) {
-		return fmt.Errorf("error: sum of comZ does not match comC[0]")
+	for j := range comZPrime {
+		sumClaim.Points = append(sumClaim.Points, comZPrime[j])
+		sumClaim.Scalars = append(sumClaim.Scalars, curve25519.ScalarOne)
 	}
-
-	return nil
+	sumClaim.Points = append(sumClaim.Points, comC[0])
+	sumClaim.Scalars = append(sumClaim.Scalars, *curve25519.NegateScalar(&curve25519.ScalarOne))
+	return b.AddClaim(i, sumClaim)
 }
 
 // ComputeQualifiedDealers returns the list of the first t+1 qualified dealers whose shares
 // will be used for refreshing (qualifiedDealers[x] is a dealer index in 0,...,n-1)
 // and the corresponding Lagrange coefficients
 // disqualifiedDealersByComplaints is an output of ResolveComplaints
+// Candidate dealers are verified all at once (see checkDealersQualified),
+// so that in the honest case, a single multi-scalar multiplication is required
+// The candidates that are not qualified are added to blame (if not nil)
+// Dealers after the last qualified dealer are not verified, hence never blamed
+//
+// If pub.AllQualifiedDealers is set, all the dealers not disqualified by complaints are verified
+// (in a single batch in the honest case) and all the qualified dealers are returned,
+// so that the qualified dealers only depend on the public transcript and not on the order of verification
 func ComputeQualifiedDealers(
 	pub *PublicInput,
 	disqualifiedDealersByComplaints map[int]bool,
 	dealingMessages []DealingMessage,
+	blame *BlameReport,
 ) (
 	qualifiedDealers []int,
 	lagrangeCoeffs []curve25519.Scalar,
 	err error,
 ) {
-	qualifiedDealers = make([]int, pub.T+1)
-	qualifiedDealersScalars := make([]curve25519.Scalar, pub.T+1)
+	// number of qualified dealers to find
+	wanted := pub.T + 1
+	if pub.AllQualifiedDealers {
+		wanted = pub.N
+	}
+
+	qualifiedDealers = make([]int, 0, wanted)
 
-	vectorV, err := vss.GenerateVectorV(&pub.VSSParams)
+	vectorV, err := generateDealerVectorsV(pub)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// Find the first t+1 qualified dealers
-	ii := 0
-	for i := 0; i < pub.N && ii < pub.T+1; i++ {
-		if _, ok := disqualifiedDealersByComplaints[i]; ok {
-			// disqualified by complaints
-			continue
+	// Find the first wanted qualified dealers
+	// At each iteration, the next candidates are the dealers required to complete
+	// the qualified dealers if they are all qualified
+	next := 0
+	for len(qualifiedDealers) < wanted && next < pub.N {
+		candidates := make([]int, 0, wanted-len(qualifiedDealers))
+		for ; next < pub.N && len(qualifiedDealers)+len(candidates) < wanted; next++ {
+			if _, ok := disqualifiedDealersByComplaints[next]; ok {
+				// disqualified by complaints
+				continue
+			}
+			candidates = append(candidates, next)
 		}
 
-		err = checkDealerQualified(pub, i, dealingMessages[i], vectorV)
+		invalidDealers, err := checkDealersQualified(pub, candidates, dealingMessages, vectorV)
 		if err != nil {
-			log.Infof("dealer %d not qualified because: %v", i, err)
-			continue
+			return nil, nil, err
 		}
 
-		// The dealer is qualified
-		qualifiedDealers[ii] = i
-		qualifiedDealersScalars[ii] = *curve25519.GetScalar(uint64(i + 1))
-		ii++
+		for _, i := range candidates {
+			if reason, ok := invalidDealers[i]; ok {
+				blame.blameDealer(DealerBlame{
+					Dealer: i,
+					Reason: BlameInvalidDealing,
+					Detail: reason.Error(),
+				})
+				continue
+			}
+			// The dealer is qualified
+			qualifiedDealers = append(qualifiedDealers, i)
+		}
 	}
-	if ii != pub.T+1 {
-		return nil, nil, fmt.Errorf("not enough qualified dealers: found %d, but need t+1=%d", ii, pub.T+1)
+	if len(qualifiedDealers) < pub.T+1 {
+		return nil, nil, fmt.Errorf(
+			"not enough qualified dealers: found %d, but need t+1=%d", len(qualifiedDealers), pub.T+1)
 	}
 
 	// Compute the Lagrange coefficients
+	// When there are more than t+1 qualified dealers, their shares still lie on a polynomial of degree t
+	// so that the interpolation gives the same result
+	qualifiedDealersScalars := make([]curve25519.Scalar, len(qualifiedDealers))
+	for ii, i := range qualifiedDealers {
+		qualifiedDealersScalars[ii] = *curve25519.GetScalar(uint64(i + 1))
+	}
 	lagrangeCoeffs, err = curve25519.LagrangeCoeffs(qualifiedDealersScalars, curve25519.GetScalar(uint64(0)))
 	if err != nil {
 		return nil, nil,
@@ -170,23 +411,96 @@ func ComputeQualifiedDealers(
 	return qualifiedDealers, lagrangeCoeffs, nil
 }
 
+// QualifiedDealersDigest returns a public commitment to the set of qualified dealers of the refresh of pub
+// (see ComputeQualifiedDealers) so that parties and auditors can publish it and check that they agree on the set
+// It is SHA512(len(SessionID) || SessionID || Epoch || len(qualifiedDealers) || qualifiedDealers)
+// where the dealers are sorted and all the integers are 8-byte little-endian
+func QualifiedDealersDigest(pub *PublicInput, qualifiedDealers []int) []byte {
+	sorted := append([]int{}, qualifiedDealers...)
+	sort.Ints(sorted)
+
+	h := sha512.New()
+	var b [8]byte
+	writeUint64 := func(x uint64) {
+		binary.LittleEndian.PutUint64(b[:], x)
+		h.Write(b[:])
+	}
+	writeUint64(uint64(len(pub.SessionID)))
+	h.Write(pub.SessionID)
+	writeUint64(pub.Epoch)
+	writeUint64(uint64(len(sorted)))
+	for _, i := range sorted {
+		writeUint64(uint64(i))
+	}
+	return h.Sum(nil)
+}
+
 // ComputeRefreshedShare returns the fresh share of a party l in the new holding committee
 // resolvedSharesS, resolvedSharesR come from ResolveComplaints (i.e., via future broadcast)
+// The invalid verifiers are added to blame (if not nil)
+// For a batched refresh, use ComputeRefreshedBatchShares
 func ComputeRefreshedShare(
 	pub *PublicInput, prv *PrivateInput, l int,
 	dealingMessages []DealingMessage, verificationMessages []VerificationMessage,
 	qualifiedDealers []int, lagrangeCoeffs []curve25519.Scalar,
 	resolvedSharesSR map[TripleIJL]curve25519.Scalar,
+	blame *BlameReport,
 ) (
 	share *vss.Share,
 	err error,
 ) {
+	if pub.numSecrets() != 1 {
+		return nil, fmt.Errorf("use ComputeRefreshedBatchShares for a batched refresh")
+	}
+	shares, err := ComputeRefreshedBatchShares(
+		pub, prv, l, dealingMessages, verificationMessages, qualifiedDealers, lagrangeCoeffs, resolvedSharesSR, blame)
+	if err != nil {
+		return nil, err
+	}
+	return shares[0], nil
+}
+
+// ComputeRefreshedBatchShares is the same as ComputeRefreshedShare for a batched refresh of K secrets
+// (see PublicInput.BatchCommitments): shares[u] is the fresh share of the secret u in 0,...,K-1
+// The verifiers are checked once for all the secrets
+func ComputeRefreshedBatchShares(
+	pub *PublicInput, prv *PrivateInput, l int,
+	dealingMessages []DealingMessage, verificationMessages []VerificationMessage,
+	qualifiedDealers []int, lagrangeCoeffs []curve25519.Scalar,
+	resolvedSharesSR map[TripleIJL]curve25519.Scalar,
+	blame *BlameReport,
+) (
+	shares []*vss.Share,
+	err error,
+) {
 
 	verSentShares := DecryptVerSentShares(pub, prv, l, verificationMessages)
 
 	// Remove invalid shares of invalid verifiers
-	cleanInvalidVerSentShares(pub, l, dealingMessages, verificationMessages, verSentShares)
+	cleanInvalidVerSentShares(pub, l, dealingMessages, verificationMessages, verSentShares, blame)
+
+	shares = make([]*vss.Share, pub.numSecrets())
+	for u := range shares {
+		shares[u], err = computeRefreshedSecretShare(
+			pub, u, l, qualifiedDealers, lagrangeCoeffs, verSentShares, resolvedSharesSR)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return shares, nil
+}
 
+// computeRefreshedSecretShare returns the fresh share of the secret u of a party l in the new holding committee
+// from the shares sent by the valid verifiers (see ComputeRefreshedBatchShares)
+func computeRefreshedSecretShare(
+	pub *PublicInput, u int, l int,
+	qualifiedDealers []int, lagrangeCoeffs []curve25519.Scalar,
+	verSentShares []VerSentShares,
+	resolvedSharesSR map[TripleIJL]curve25519.Scalar,
+) (
+	share *vss.Share,
+	err error,
+) {
 	share = &vss.Share{
 		Index:       l + 1,
 		IndexScalar: *curve25519.GetScalar(uint64(l + 1)),
@@ -201,7 +515,7 @@ func ComputeRefreshedShare(
 	*sumR = curve25519.ScalarZero
 
 	for ii, i := range qualifiedDealers {
-		sIL, rIL, err := ComputeShareIL(pub, i, l, verSentShares, resolvedSharesSR)
+		sIL, rIL, err := computeSecretShareIL(pub, i, u, l, verSentShares, resolvedSharesSR)
 		if err != nil {
 			return nil, err
 		}
@@ -221,28 +535,70 @@ func ComputeRefreshedShare(
 
 // cleanInvalidVerSentShares removes from verSentShares the shares of invalid verifiers
 // i.e., make verSentShares[j].SR = nil for invalid verifiers
+// and adds them to blame (if not nil)
+// All the verifiers are verified at once (see nizk.Batch)
 func cleanInvalidVerSentShares(pub *PublicInput, l int,
 	dealingMessages []DealingMessage,
 	verificationMessages []VerificationMessage,
-	verSentShares []VerSentShares) {
+	verSentShares []VerSentShares,
+	blame *BlameReport) {
 
-	for j := 0; j < pub.N; j++ {
-		// Verify verifier
-		err := isValidVerifier(pub, j, l, dealingMessages, verificationMessages[j], verSentShares[j])
+	myLog := log.WithField("party", l).WithField("committee", "new holding")
+
+	// invalidVerifiers[j] is the reason why verifier j is invalid
+	invalidVerifiers := map[int]error{}
+
+	nVer := pub.verParams().N
+
+	b := nizk.NewBatch()
+	for j := 0; j < nVer; j++ {
+		err := addVerifierClaims(b, pub, j, l, dealingMessages, verificationMessages[j], verSentShares[j])
 		if err != nil {
-			// If invalid log it and return the shares of this verifier
+			invalidVerifiers[j] = err
+		}
+	}
+
+	invalid, err := b.Verify()
+	if err != nil {
+		// this should never happen, but to be safe, consider that all the verifiers are invalid
+		myLog.Errorf("batch verification of verifiers failed: %v", err)
+		invalid = make([]int, nVer)
+		for j := range invalid {
+			invalid[j] = j
+		}
+	}
+	for _, j := range invalid {
+		if _, ok := invalidVerifiers[j]; !ok {
+			invalidVerifiers[j] = fmt.Errorf("invalid VPComProof")
+		}
+	}
+
+	for j := 0; j < nVer; j++ {
+		if reason, ok := invalidVerifiers[j]; ok {
+			// If invalid blame it and remove the shares of this verifier
+			b := VerifierBlame{
+				Verifier:   j,
+				Reason:     BlameInvalidVerification,
+				Detail:     reason.Error(),
+				NextHolder: l,
+			}
+			if verSentShares[j].S != nil || verSentShares[j].R != nil {
+				shares := verSentShares[j]
+				b.Shares = &shares
+			}
+			blame.blameVerifier(b)
+
 			verSentShares[j].S = nil
 			verSentShares[j].R = nil
-			log.WithField("party", l).
-				WithField("committee", "new holding").
-				Infof("verifier %d disqualified because: %v", j, err)
-			continue
 		}
 	}
 }
 
-// isValidVerifier checks whether the verifier message for new holding party l are valid
-func isValidVerifier(pub *PublicInput, j int, l int,
+// addVerifierClaims adds to the batch b (item j) the checks whether the verifier message
+// for new holding party l are valid
+// Checks that do not require any multi-scalar multiplication are done immediately
+// and an error is returned if they fail
+func addVerifierClaims(b *nizk.Batch, pub *PublicInput, j int, l int,
 	dealingMessages []DealingMessage,
 	verMsg VerificationMessage,
 	verSentShares VerSentShares) error {
@@ -256,42 +612,164 @@ func isValidVerifier(pub *PublicInput, j int, l int,
 	if verSentShares.R == nil {
 		return fmt.Errorf("empty list of shares R")
 	}
+	numSecrets := pub.numSecrets()
+	if len(verSentShares.S) != numSecrets*pub.N || len(verSentShares.R) != numSecrets*pub.N {
+		return fmt.Errorf("invalid size of shares")
+	}
 
 	// generating sigmaL = sigma_{i+1,j+1,l+1} only for qualified dealers
 	// so may be shorter than n
-	sigmaL := make([]curve25519.Scalar, 0, pub.N)
-	rhoL := make([]curve25519.Scalar, 0, pub.N)
-	comC := make([]feldman.VC, 0, pub.N)
+	// for a batched refresh, the shares of the secrets of each dealer follow each other (see verifierComC)
+	sigmaL := make([]curve25519.Scalar, 0, numSecrets*pub.N)
+	rhoL := make([]curve25519.Scalar, 0, numSecrets*pub.N)
 	for i := 0; i < pub.N; i++ {
-		if verMsg.Complaints[i] != (verSentShares.S[i] == nil) ||
-			(verSentShares.S[i] == nil) != (verSentShares.R[i] == nil) {
-			return fmt.Errorf("complaining and providing shares inconsistently")
-		}
-		if verSentShares.S[i] != nil {
-			// the dealer is qualified from the point of view of this verifier
-			sigmaL = append(sigmaL, *verSentShares.S[i])
-			rhoL = append(rhoL, *verSentShares.R[i])
-			// normally dealing messages are good at this point
-			comC = append(comC, dealingMessages[i].ComC[j+1])
+		for u := 0; u < numSecrets; u++ {
+			x := u*pub.N + i
+			if verMsg.Complaints[i] != (verSentShares.S[x] == nil) ||
+				(verSentShares.S[x] == nil) != (verSentShares.R[x] == nil) {
+				return fmt.Errorf("complaining and providing shares inconsistently")
+			}
+			if verSentShares.S[x] != nil {
+				// the dealer is qualified from the point of view of this verifier
+				sigmaL = append(sigmaL, *verSentShares.S[x])
+				rhoL = append(rhoL, *verSentShares.R[x])
+			}
 		}
+	}
 
+	comC, err := verifierComC(pub, j, dealingMessages, verMsg.Complaints)
+	if err != nil {
+		return err
 	}
 
 	// Verify the generic part
-	err := VPVerifyGenericL(pub.VCParams, comC, verMsg.VPComProof)
+	// It must be done first as it checks the lengths of verMsg.VPComProof
+	ctx := pub.ProofContext(pub.Committees.Ver[j])
+	err = vpBatchAddGenericL(b, j, ctx, pub.VCParams, comC, verMsg.VPComProof)
 	if err != nil {
 		return err
 	}
 
 	// verify the sigma part
-	err = VPVerifySpecificL(pub.VCParams, l, comC, verMsg.VPComProof, sigmaL)
+	err = VPVerifySpecificL(ctx, pub.VCParams, l, comC, verMsg.VPComProof, sigmaL)
 	if err != nil {
 		return err
 	}
 
 	// verify the rho part
-	err = VPVerifySpecificL(pub.VCParams, l+pub.N, comC, verMsg.VPComProof, rhoL)
-	return err
+	return VPVerifySpecificL(ctx, pub.VCParams, l+pub.nextParams().N, comC, verMsg.VPComProof, rhoL)
+}
+
+// verifierComC returns the commitments comC[j+1] of the dealers i against which verifier j did not complain
+// (i.e., the commitments to the shares it forwards to the next holding committee, see VPCommitProof)
+// For a batched refresh, it returns the commitments of all the secrets of each dealer in a row
+// (i.e., comC[u*(n'+1)+j+1] for u in 0,...,K-1, see DealingMessage)
+// complaints must be of size n
+func verifierComC(
+	pub *PublicInput, j int, dealingMessages []DealingMessage, complaints []bool,
+) ([]feldman.VC, error) {
+	nVer := pub.verParams().N
+	numSecrets := pub.numSecrets()
+
+	comC := make([]feldman.VC, 0, numSecrets*pub.N)
+	for i := 0; i < pub.N; i++ {
+		if complaints[i] {
+			continue
+		}
+		// normally dealing messages are good at this point
+		// but the points must be on the curve to be added to the batch
+		if len(dealingMessages[i].ComC) != numSecrets*(nVer+1) {
+			return nil, fmt.Errorf("invalid comC of dealer %d", i)
+		}
+		for u := 0; u < numSecrets; u++ {
+			c := &dealingMessages[i].ComC[u*(nVer+1)+j+1]
+			if !curve25519.IsOnCurveXY(c) {
+				return nil, fmt.Errorf("invalid comC of dealer %d", i)
+			}
+			comC = append(comC, *c)
+		}
+	}
+	return comC, nil
+}
+
+// CheckVerifiers verifies the messages of all the verifiers publicly, i.e., without the shares they sent:
+// the sizes of the complaints and of the encrypted shares, and the generic part of VPComProof
+// (see VPVerifyGenericL)
+// It returns invalidVerifiers[j] = reason for each verifier j whose message is invalid
+// and adds them to blame (if not nil)
+// A verifier passing these checks may still have sent invalid shares to some members of the next holding committee
+// (who then ignore them, see ComputeRefreshedShare)
+// All the verifiers are verified at once (see nizk.Batch)
+func CheckVerifiers(
+	pub *PublicInput,
+	dealingMessages []DealingMessage,
+	verificationMessages []VerificationMessage,
+	blame *BlameReport,
+) (
+	invalidVerifiers map[int]error,
+	err error,
+) {
+	invalidVerifiers = map[int]error{}
+
+	b := nizk.NewBatch()
+	for j := 0; j < pub.verParams().N; j++ {
+		err = addVerifierGenericClaims(b, pub, j, dealingMessages, verificationMessages[j])
+		if err != nil {
+			invalidVerifiers[j] = err
+		}
+	}
+
+	invalid, err := b.Verify()
+	if err != nil {
+		return nil, err
+	}
+	for _, j := range invalid {
+		if _, ok := invalidVerifiers[j]; !ok {
+			invalidVerifiers[j] = fmt.Errorf("invalid VPComProof")
+		}
+	}
+
+	for j := 0; j < pub.verParams().N; j++ {
+		if reason, ok := invalidVerifiers[j]; ok {
+			blame.blameVerifier(VerifierBlame{
+				Verifier:   j,
+				Reason:     BlameInvalidVerification,
+				Detail:     reason.Error(),
+				NextHolder: -1,
+			})
+		}
+	}
+
+	return invalidVerifiers, nil
+}
+
+// addVerifierGenericClaims adds to the batch b (item j) the checks of the verifier message
+// that do not depend on the shares it sent (see addVerifierClaims)
+// Checks that do not require any multi-scalar multiplication are done immediately
+// and an error is returned if they fail
+func addVerifierGenericClaims(b *nizk.Batch, pub *PublicInput, j int,
+	dealingMessages []DealingMessage,
+	verMsg VerificationMessage) error {
+
+	nNext := pub.nextParams().N
+
+	if len(verMsg.Complaints) != pub.N {
+		return fmt.Errorf("invalid size of complaints")
+	}
+	if len(verMsg.EncShares) != nNext {
+		return fmt.Errorf("invalid size of encrypted shares")
+	}
+	if len(verMsg.VPComProof.HashL) != 2*nNext {
+		return fmt.Errorf("invalid size of hashL")
+	}
+
+	comC, err := verifierComC(pub, j, dealingMessages, verMsg.Complaints)
+	if err != nil {
+		return err
+	}
+
+	ctx := pub.ProofContext(pub.Committees.Ver[j])
+	return vpBatchAddGenericL(b, j, ctx, pub.VCParams, comC, verMsg.VPComProof)
 }
 
 // ComputeShareIL computes sigma_{i+1,l+1} = sigma_{i+1,0,l+1} from shares from verification committee
@@ -300,6 +778,7 @@ func isValidVerifier(pub *PublicInput, j int, l int,
 // l in [0,n-1]
 // verSentShares[j].S should be nil for invalid verifier
 // which means all non-nil shares are valid
+// For a batched refresh, it computes the share of the first secret (see computeSecretShareIL)
 func ComputeShareIL(
 	pub *PublicInput, i int, l int,
 	verSentShares []VerSentShares,
@@ -309,11 +788,32 @@ func ComputeShareIL(
 	rIL *curve25519.Scalar,
 	err error,
 ) {
-	sharesIL := make([]vss.Share, 0, pub.VSSParams.D+1)
+	return computeSecretShareIL(pub, i, 0, l, verSentShares, resolvedSharesSR)
+}
+
+// computeSecretShareIL is the same as ComputeShareIL for the secret u in 0,...,K-1 of a batched refresh
+// (see VerSentShares and TripleIJL for the layout of the shares of the secrets)
+func computeSecretShareIL(
+	pub *PublicInput, i int, u int, l int,
+	verSentShares []VerSentShares,
+	resolvedSharesSR map[TripleIJL]curve25519.Scalar,
+) (
+	sIL *curve25519.Scalar,
+	rIL *curve25519.Scalar,
+	err error,
+) {
+	// sigma_{i+1,l+1} is shared among the verification committee
+	verParams := pub.verParams()
+	sharesIL := make([]vss.Share, 0, verParams.D+1)
+
+	numSecrets := pub.numSecrets()
+	nNext := pub.nextParams().N
+	base := u * 2 * nNext // first index of the secret u in the resolved shares
+	x := u*pub.N + i      // index of the dealer i for the secret u in verSentShares
 
 	// Get the first T valid shares
-	for j := 0; j < pub.N && len(sharesIL) < pub.VSSParams.D+1; j++ {
-		if _, ok := resolvedSharesSR[TripleIJL{i, j, l + 1}]; ok {
+	for j := 0; j < verParams.N && len(sharesIL) < verParams.D+1; j++ {
+		if _, ok := resolvedSharesSR[TripleIJL{i, j, base + l}]; ok {
 			// If future broadcast/resolution is available, we must use that
 			// Note that these shares are necessarily ok because verified to match C_ij
 			log.Infof("use resolved shares for i=%d,j=%d,l=%d", i, j, l)
@@ -321,10 +821,11 @@ func ComputeShareIL(
 			sharesIL = append(sharesIL, vss.Share{
 				Index:       j + 1,
 				IndexScalar: *curve25519.GetScalar(uint64(j + 1)),
-				S:           resolvedSharesSR[TripleIJL{i, j, l}],
-				R:           resolvedSharesSR[TripleIJL{i, j, l + pub.N}],
+				S:           resolvedSharesSR[TripleIJL{i, j, base + l}],
+				R:           resolvedSharesSR[TripleIJL{i, j, base + l + nNext}],
 			})
-		} else if len(verSentShares[j].S) == pub.N && verSentShares[j].S[i] != nil {
+		} else if len(verSentShares[j].S) == numSecrets*pub.N && len(verSentShares[j].R) == numSecrets*pub.N &&
+			verSentShares[j].S[x] != nil && verSentShares[j].R[x] != nil {
 			// Otherwise we use the shares from the verification committee if available
 			// Not that this function is supposed to be called with nil V_j messages
 			// if V_j created invalid messages.
@@ -333,18 +834,18 @@ func ComputeShareIL(
 			sharesIL = append(sharesIL, vss.Share{
 				Index:       j + 1,
 				IndexScalar: *curve25519.GetScalar(uint64(j + 1)),
-				S:           *verSentShares[j].S[i],
-				R:           *verSentShares[j].R[i],
+				S:           *verSentShares[j].S[x],
+				R:           *verSentShares[j].R[x],
 			})
 		}
 	}
 
-	if len(sharesIL) != pub.VSSParams.D+1 {
+	if len(sharesIL) != verParams.D+1 {
 		return nil, nil, fmt.Errorf("failed to reconstruct sigma_{i+1,l+1} for i=%d, l=%d: "+
-			"not enough shares, got %d but need %d", i, l, len(sharesIL), pub.VSSParams.D+1)
+			"not enough shares, got %d but need %d", i, l, len(sharesIL), verParams.D+1)
 	}
 
-	sIL, rIL, err = vss.ReconstructWithRFromValidShares(&pub.VSSParams, sharesIL)
+	sIL, rIL, err = vss.ReconstructWithRFromValidShares(verParams, sharesIL)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to reconstruct sigma/rho_{i+1,l+1} for i=%d, l=%d: %w", i, l, err)
 	}
@@ -357,9 +858,9 @@ func DecryptVerSentShares(
 ) (
 	verSentShares []VerSentShares,
 ) {
-	verSentShares = make([]VerSentShares, pub.N)
-	for j := 0; j < pub.N; j++ {
-		if len(verificationMessages[j].EncShares) != pub.N {
+	verSentShares = make([]VerSentShares, pub.verParams().N)
+	for j := range verSentShares {
+		if len(verificationMessages[j].EncShares) != pub.nextParams().N {
 			// when the length is incorrect, we continue and consider the verification committee member to be malicious
 			log.Infof("verificationMessages[%d].EncShares has incorrect length", j)
 			continue
@@ -385,6 +886,7 @@ func DecryptVerSentShares(
 
 // ComputeRefreshedCommitments returns the new commitments of the new holding committee
 // Executed by all parties in the YOSO protocol
+// For a batched refresh, use ComputeRefreshedBatchCommitments
 func ComputeRefreshedCommitments(
 	pub *PublicInput,
 	dealingMessages []DealingMessage,
@@ -393,20 +895,59 @@ func ComputeRefreshedCommitments(
 	commitments []pedersen.Commitment,
 	err error,
 ) {
+	if pub.numSecrets() != 1 {
+		return nil, fmt.Errorf("use ComputeRefreshedBatchCommitments for a batched refresh")
+	}
+	return computeRefreshedSecretCommitments(pub, 0, dealingMessages, qualifiedDealers, lagrangeCoeffs)
+}
+
+// ComputeRefreshedBatchCommitments is the same as ComputeRefreshedCommitments for a batched refresh of K secrets
+// (see PublicInput.BatchCommitments): commitments[u] are the new commitments of the secret u in 0,...,K-1
+func ComputeRefreshedBatchCommitments(
+	pub *PublicInput,
+	dealingMessages []DealingMessage,
+	qualifiedDealers []int, lagrangeCoeffs []curve25519.Scalar,
+) (
+	commitments [][]pedersen.Commitment,
+	err error,
+) {
+	commitments = make([][]pedersen.Commitment, pub.numSecrets())
+	for u := range commitments {
+		commitments[u], err = computeRefreshedSecretCommitments(
+			pub, u, dealingMessages, qualifiedDealers, lagrangeCoeffs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return commitments, nil
+}
+
+// computeRefreshedSecretCommitments returns the new commitments of the secret u in 0,...,K-1
+// (see ComputeRefreshedBatchCommitments)
+func computeRefreshedSecretCommitments(
+	pub *PublicInput,
+	u int,
+	dealingMessages []DealingMessage,
+	qualifiedDealers []int, lagrangeCoeffs []curve25519.Scalar,
+) (
+	commitments []pedersen.Commitment,
+	err error,
+) {
+	nNext := pub.nextParams().N
 
 	// Recall that commitments[0] is the commitment to the secret
 	// and commitments[j+1] is the commitment to the new share held by party j
-	commitments = make([]pedersen.Commitment, pub.N+1)
-	commitments[0] = pub.Commitments[0]
-	comSJ := make([]curve25519.PointXY, pub.T+1)
-	for l := 0; l < pub.N; l++ {
+	commitments = make([]pedersen.Commitment, nNext+1)
+	commitments[0] = pub.secretCommitments(u)[0]
+	comSJ := make([]curve25519.PointXY, len(qualifiedDealers))
+	for l := 0; l < nNext; l++ {
 		// Computing commitments[l+1] for the new holding committee member l
 		// This is the Lagrange reconsturction
 		// of all the original commitments S_ij for qualified dealers i
 
 		// Faster code
 		for ii, i := range qualifiedDealers {
-			comSJ[ii] = dealingMessages[i].ComZ[l]
+			comSJ[ii] = dealingMessages[i].ComZ[u*nNext+l]
 		}
 		com, err := curve25519.MultiMultPointXYScalarVarTime(comSJ, lagrangeCoeffs)
 		if err != nil {
@@ -417,3 +958,37 @@ func ComputeRefreshedCommitments(
 
 	return commitments, nil
 }
+
+// ComputeFeldmanCommitments returns the Feldman commitments F_0,...,F_n of the sharing being refreshed
+// where F_0 = s G is the public key of the secret s and F_{i+1} = sigma_{i+1} G for the share of dealer i
+// It requires pub.FeldmanConversion and interpolates the Feldman shares of the qualified dealers
+// (which have been verified by ComputeQualifiedDealers)
+// Executed by all parties in the YOSO protocol
+func ComputeFeldmanCommitments(
+	pub *PublicInput,
+	dealingMessages []DealingMessage,
+	qualifiedDealers []int,
+) (
+	feldmanCommitments []feldman.GCommitment,
+	err error,
+) {
+	if !pub.FeldmanConversion {
+		return nil, fmt.Errorf("Feldman conversion is disabled")
+	}
+
+	indices := make([]int, len(qualifiedDealers))
+	ys := make([]feldman.GCommitment, len(qualifiedDealers))
+	for ii, i := range qualifiedDealers {
+		if dealingMessages[i].FeldmanShare == nil {
+			return nil, fmt.Errorf("missing Feldman share of dealer %d", i)
+		}
+		indices[ii] = i + 1
+		ys[ii] = dealingMessages[i].FeldmanShare.Y
+	}
+
+	ks := make([]int, pub.N+1)
+	for k := range ks {
+		ks[k] = k
+	}
+	return feldman.InterpolateGCommitments(indices, ys, ks)
+}
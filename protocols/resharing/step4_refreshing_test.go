@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -25,7 +25,7 @@ func TestCheckDealerQualifiedValid(t *testing.T) {
 			assert := assert.New(t)
 
 			pub, prvs, _, _, _ := setupResharingSeq(t, tc.n, tc.d)
-			vectorV, err := vss.GenerateVectorV(&pub.VSSParams)
+			vectorV, err := generateDealerVectorsV(pub)
 			require.NoError(err)
 
 			for i := 0; i < tc.n; i++ {
@@ -39,3 +39,141 @@ func TestCheckDealerQualifiedValid(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckDealersQualifiedBatch(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n  = 7
+		tt = 2
+	)
+
+	pub, prvs, _, _, _ := setupResharingSeq(t, n, tt)
+	vectorV, err := generateDealerVectorsV(pub)
+	require.NoError(err)
+
+	dealingMessages := make([]DealingMessage, n)
+	for i := 0; i < n; i++ {
+		// mix batchable and compact proofs
+		pub.ProofFormats.Dealing = ProofFormat(i % 2)
+		msg, err := PerformDealing(pub, &prvs[i], &PartyDebugParams{})
+		require.NoError(err)
+		dealingMessages[i] = *msg
+	}
+	pub.ProofFormats.Dealing = ProofFormatBatchable
+
+	// Corrupt dealers 1 (comC), 2 (proof) and 4 (comZ)
+	c, err := curve25519.AddPointXY(&dealingMessages[1].ComC[2], &dealingMessages[1].ComC[2])
+	require.NoError(err)
+	dealingMessages[1].ComC[2] = *c
+	dealingMessages[2].DblDLEqProof.RespH[0] = *curve25519.RandomScalar()
+	dealingMessages[4].ComZ[1], dealingMessages[4].ComZ[2] = dealingMessages[4].ComZ[2], dealingMessages[4].ComZ[1]
+
+	invalidDealers, err := checkDealersQualified(pub, rangeSlice(0, n), dealingMessages, vectorV)
+	require.NoError(err)
+	assert.Len(invalidDealers, 3)
+	for _, i := range []int{1, 2, 4} {
+		assert.Error(invalidDealers[i], "dealer %d", i)
+	}
+
+	// The batch gives the same result as verifying each dealer individually
+	for i := 0; i < n; i++ {
+		_, invalid := invalidDealers[i]
+		assert.Equal(invalid, checkDealerQualified(pub, i, dealingMessages[i], vectorV) != nil, "dealer %d", i)
+	}
+
+	// The qualified dealers are the first t+1 valid dealers not disqualified by complaints
+	blame := &BlameReport{}
+	qualifiedDealers, _, err := ComputeQualifiedDealers(pub, map[int]bool{0: true}, dealingMessages, blame)
+	require.NoError(err)
+	assert.Equal([]int{3, 5, 6}, qualifiedDealers)
+
+	// The invalid candidates are blamed
+	require.Len(blame.Dealers, 3)
+	for x, i := range []int{1, 2, 4} {
+		assert.Equal(i, blame.Dealers[x].Dealer)
+		assert.Equal(BlameInvalidDealing, blame.Dealers[x].Reason)
+		assert.NotEmpty(blame.Dealers[x].Detail)
+	}
+
+	_, _, err = ComputeQualifiedDealers(pub, map[int]bool{0: true, 3: true}, dealingMessages, nil)
+	assert.Error(err)
+}
+
+func TestComputeQualifiedDealersAll(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n  = 7
+		tt = 2
+	)
+
+	pub, prvs, _, secret, _ := setupResharingSeq(t, n, tt)
+
+	dealingMessages := make([]DealingMessage, n)
+	for i := 0; i < n; i++ {
+		msg, err := PerformDealing(pub, &prvs[i], &PartyDebugParams{})
+		require.NoError(err)
+		dealingMessages[i] = *msg
+	}
+
+	// Corrupt dealer 1 (proof) and disqualify dealer 4 by complaints
+	dealingMessages[1].DblDLEqProof.RespH[0] = *curve25519.RandomScalar()
+	disqualifiedDealers := map[int]bool{4: true}
+
+	testCases := []struct {
+		allQualifiedDealers bool
+		qualifiedDealers    []int
+	}{
+		{false, []int{0, 2, 3}},
+		{true, []int{0, 2, 3, 5, 6}},
+	}
+
+	for _, tc := range testCases {
+		pub.AllQualifiedDealers = tc.allQualifiedDealers
+
+		blame := &BlameReport{}
+		qualifiedDealers, lagrangeCoeffs, err := ComputeQualifiedDealers(
+			pub, disqualifiedDealers, dealingMessages, blame)
+		require.NoError(err)
+		assert.Equal(tc.qualifiedDealers, qualifiedDealers)
+		require.Len(blame.Dealers, 1)
+		assert.Equal(1, blame.Dealers[0].Dealer)
+
+		// The Lagrange coefficients interpolate the secret from the shares of all the qualified dealers
+		s := &curve25519.Scalar{}
+		*s = curve25519.ScalarZero
+		for ii, i := range qualifiedDealers {
+			s = curve25519.AddScalar(s, curve25519.MultScalar(&prvs[i].Share.S, &lagrangeCoeffs[ii]))
+		}
+		assert.Equal(*secret, *s)
+
+		// Commitments of the next holding committee are computed from all the qualified dealers
+		_, err = ComputeRefreshedCommitments(pub, dealingMessages, qualifiedDealers, lagrangeCoeffs)
+		require.NoError(err)
+	}
+
+	// Not enough qualified dealers
+	pub.AllQualifiedDealers = true
+	_, _, err := ComputeQualifiedDealers(pub, map[int]bool{0: true, 2: true, 3: true, 5: true}, dealingMessages, nil)
+	assert.Error(err)
+}
+
+func TestQualifiedDealersDigest(t *testing.T) {
+	assert := assert.New(t)
+
+	pub, _, _, _, _ := setupResharingSeq(t, 3, 1)
+	digest := QualifiedDealersDigest(pub, []int{0, 1, 2})
+	assert.Len(digest, 64)
+
+	// The digest does not depend on the order of the dealers but only on the set
+	assert.Equal(digest, QualifiedDealersDigest(pub, []int{2, 0, 1}))
+	assert.NotEqual(digest, QualifiedDealersDigest(pub, []int{0, 1}))
+
+	// The digest is bound to the refresh
+	other := *pub
+	other.Epoch++
+	assert.NotEqual(digest, QualifiedDealersDigest(&other, []int{0, 1, 2}))
+}
@@ -1,6 +1,8 @@
 package resharing
 
 import (
+	"fmt"
+
 	"github.com/shaih/go-yosovss/msgpack"
 	"github.com/shaih/go-yosovss/primitives/curve25519"
 	log "github.com/sirupsen/logrus"
@@ -8,8 +10,10 @@ import (
 
 type TripleIJL struct {
 	i int // corresponding to dealer D_i, i in [0,n-1]
-	j int // corresponding to verifier V_j, j in [0,n-1]
-	l int // corresponding to new holder P_{l+1} / 0, l in [0,n]
+	j int // corresponding to verifier V_j, j in [0,n'-1] where n' is the size of the verification committee
+	l int // corresponding to sigma/rho for the new holder P_{l+1}, l in [0,2n''-1]
+	// where n'' is the size of the next holding committee (see VerificationMJ)
+	// for a batched refresh of K secrets, l in [0,2Kn''-1] and l = u*2n''+l' for the secret u
 }
 
 // CheckDealingMessages check if msg is valid
@@ -19,20 +23,22 @@ type TripleIJL struct {
 // The function checkDealerQualified is actually doing the heavy-work
 // checking the dealer
 func CheckDealingMessages(pub *PublicInput, msg DealingMessage, i int, dbg *PartyDebugParams) bool {
-	n := pub.N
+	nVer := pub.verParams().N
+	futureBroadcast := pub.futureBroadcast() && !dbg.SkipDealingFutureBroadcast
 
 	// Check dealer message are valid and disqualify if invalid
-	if (!dbg.SkipDealingFutureBroadcast && len(msg.EncResM) != n) ||
-		(!dbg.SkipDealingFutureBroadcast && len(msg.HashEps) != n) ||
-		len(msg.EncVerM) != n ||
-		len(msg.ComC) != n+1 {
+	if (futureBroadcast && len(msg.EncResM) != nVer) ||
+		(futureBroadcast && len(msg.HashEps) != nVer) ||
+		(pub.VEncPKs == nil && len(msg.EncVerM) != nVer) ||
+		(pub.VEncPKs != nil && len(msg.VEncVerM) != nVer) ||
+		len(msg.ComC) != pub.numSecrets()*(nVer+1) {
 		log.Infof("dealer %d disqualified as it sent incorrect message", i)
 		return false
 	}
 
-	if !dbg.SkipDealingFutureBroadcast {
-		for k := 0; k < n; k++ {
-			if len(msg.HashEps) != n {
+	if futureBroadcast {
+		for j := 0; j < nVer; j++ {
+			if len(msg.HashEps[j]) != pub.resParams().N {
 				log.Infof("dealer %d disqualified as it sent incorrect message", i)
 				return false
 			}
@@ -48,6 +54,12 @@ func CheckDealingMessages(pub *PublicInput, msg DealingMessage, i int, dbg *Part
 // if decryption fails or if it leads to incorrect shares, it make the dealer disqualified
 //   (disqualifiedDealers[i] = true)
 // otherwise it stores the relevant shares in resolvedSharesS (for sigma) and resolvedSharesR (for rho)
+// blame contains the evidence of each disqualification (see BlameReport)
+//
+// With verifiable encryption, there is no resolution round and the complaints are not resolved:
+// the shares sent by the dealers are checked publicly (see checkDealerQualified),
+// so a complaint against a qualified dealer is false and the complaining verifier is just not used
+// (see ComputeRefreshedBatchShares)
 func ResolveComplaints(
 	pub *PublicInput,
 	dealingMessages []DealingMessage,
@@ -57,27 +69,40 @@ func ResolveComplaints(
 ) (
 	resolvedSharesSR map[TripleIJL]curve25519.Scalar,
 	disqualifiedDealers map[int]bool,
+	blame *BlameReport,
 	err error,
 ) {
 	n := pub.N
+	nVer := pub.verParams().N
+	nRes := pub.resParams().N
+	nNext := pub.nextParams().N
 
 	resolvedSharesSR = map[TripleIJL]curve25519.Scalar{}
 
 	disqualifiedDealers = map[int]bool{}
+	blame = &BlameReport{}
 
 	for i := 0; i < n; i++ {
 		if !CheckDealingMessages(pub, dealingMessages[i], i, dbg) {
 			disqualifiedDealers[i] = true
+			blame.blameDealer(DealerBlame{
+				Dealer: i,
+				Reason: BlameMalformedDealing,
+				Detail: "missing message or fields of incorrect length",
+			})
+			continue
+		}
+		if !pub.futureBroadcast() {
 			continue
 		}
 
-		for j := 0; j < n; j++ {
+		for j := 0; j < nVer; j++ {
 			if len(verificationMessages[j].Complaints) == n && verificationMessages[j].Complaints[i] {
 				// Vj complained against dealer i
 
 				// Recovering all epsShares (eps_{i+1,j+1,k+1}) we can
-				epsShares := make([]*curve25519.Scalar, n)
-				for k := 0; k < n; k++ {
+				epsShares := make([]*curve25519.Scalar, nRes)
+				for k := 0; k < nRes; k++ {
 					epsIJK, ok := resolutionMessages[k].EpsShares[PairIJ{i, j}]
 					if ok {
 						epsShares[k] = &epsIJK
@@ -86,15 +111,22 @@ func ResolveComplaints(
 
 				// Get the M[j] by reconstructing the key and decrypting it
 				// also verify shares are valid
-				mj := getAndVerifyResolutionMJ(pub, &dealingMessages[i], epsShares, i, j)
-				if mj == nil {
+				mj, err := getAndVerifyResolutionMJ(pub, &dealingMessages[i], epsShares, j)
+				if err != nil {
 					// impossible to get a correct MK, reject
 					disqualifiedDealers[i] = true
+					blame.blameDealer(DealerBlame{
+						Dealer:    i,
+						Reason:    BlameUnresolvedComplaint,
+						Detail:    err.Error(),
+						Verifier:  j,
+						EpsShares: epsShares,
+					})
 					break
 				}
 
 				// Store the shares
-				for l := 0; l < 2*n; l++ {
+				for l := 0; l < pub.numSecrets()*2*nNext; l++ {
 					resolvedSharesSR[TripleIJL{i, j, l}] = mj.SR[l]
 				}
 			}
@@ -104,52 +136,50 @@ func ResolveComplaints(
 	return
 }
 
+// IsResolved returns true if the shares sent by dealer i to verifier j have been resolved,
+// i.e., are in resolvedSharesSR (which is an output of ResolveComplaints)
+func IsResolved(resolvedSharesSR map[TripleIJL]curve25519.Scalar, i, j int) bool {
+	_, ok := resolvedSharesSR[TripleIJL{i, j, 0}]
+	return ok
+}
+
 func getAndVerifyResolutionMJ(
 	pub *PublicInput,
 	msg *DealingMessage,
 	epsShares []*curve25519.Scalar,
-	i int,
 	j int,
-) *VerificationMJ {
-	n := pub.N
-
+) (*VerificationMJ, error) {
 	// Reconstructing the key
-	epsKey, err := ReconstructEpsKey(n, pub.T, epsShares, msg.HashEps[j])
+	epsKey, err := ReconstructEpsKey(pub.resParams().N, pub.resParams().D, epsShares, msg.HashEps[j])
 	if err != nil {
-		log.Infof("dealer %d provided incorrect shares to resolution committee: %v", i, err)
-		return nil
+		return nil, fmt.Errorf("incorrect shares to resolution committee: %w", err)
 	}
 
 	// Decrypting the message M[j]
 	zeroNonce := curve25519.Nonce{}
 	mkMsg, err := curve25519.SymmetricDecrypt(epsKey, zeroNonce, msg.EncResM[j])
 	if err != nil {
-		log.Infof("dealer %d provided incorrect encryption of M[j] to resolution committee: %v", i, err)
-		return nil
+		return nil, fmt.Errorf("incorrect encryption of M[j] to resolution committee: %w", err)
 	}
 
 	// Decoding of M[j]
 	mj := VerificationMJ{}
 	err = msgpack.Decode(mkMsg, &mj)
 	if err != nil {
-		log.Infof("dealer %d provided incorrect encoding of M[j] to resolution committee: %v", i, err)
-		return nil
+		return nil, fmt.Errorf("incorrect encoding of M[j] to resolution committee: %w", err)
 	}
 
 	// Verify Mj lists are the correct length
-	if len(mj.SR) != pub.N*2 {
-		log.Infof("dealer %d provided incorrect M[j] - wrong list length", i)
-		return nil
+	if len(mj.SR) != pub.numSecrets()*pub.nextParams().N*2 {
+		return nil, fmt.Errorf("incorrect M[j] - wrong list length")
 	}
 
 	// Verify Mj contains valid shares
-	err = VerifyMJ(&pub.VCParams, &msg.ComC[j+1], &mj)
+	err = verifyBatchMJ(pub, msg, j, &mj)
 	if err != nil {
 		// invalid dealer
-		log.Infof("dealer %d provided a commitment/share that make the verifcation returns an error: %v",
-			i, err)
-		return nil
+		return nil, fmt.Errorf("a commitment/share that make the verification returns an error: %w", err)
 	}
 
-	return &mj
+	return &mj, nil
 }
@@ -1,7 +1,7 @@
 package resharing
 
 const (
-	numRounds     = 3 // number of rounds of messaging required for the protocol
+	numRounds     = 3 // number of rounds of messaging required for the protocol (see PublicInput.NumRounds)
 	numCommittees = 4 // number of committees
 )
 
@@ -36,3 +36,26 @@ func sameCommittees(n int) Committees {
 		Next: rangeSlice(0, n),
 	}
 }
+
+// rotatingCommittees generates the committees of numEpochs consecutive refreshes (see StartEpochsParty)
+// among n*numCommittees parties split in numCommittees blocks of n parties
+// The committees of the first refresh are seqCommittees(n)
+// and each block of parties plays a different role in each refresh
+func rotatingCommittees(n int, numEpochs int) []Committees {
+	block := func(b int) []int {
+		return rangeSlice((b%numCommittees)*n, n)
+	}
+
+	committees := make([]Committees, numEpochs)
+	for e := 0; e < numEpochs; e++ {
+		// the next holding committee is the block numCommittees-1 blocks after the holding committee
+		h := e * (numCommittees - 1)
+		committees[e] = Committees{
+			Hold: block(h),
+			Ver:  block(h + 1),
+			Res:  block(h + 2),
+			Next: block(h + 3),
+		}
+	}
+	return committees
+}
@@ -28,3 +28,23 @@ func TestSeqCommittees(t *testing.T) {
 		seqCommittees(3),
 	)
 }
+
+func TestRotatingCommittees(t *testing.T) {
+	assert := assert.New(t)
+
+	committees := rotatingCommittees(2, 5)
+	assert.Len(committees, 5)
+	assert.Equal(seqCommittees(2), committees[0])
+	assert.Equal(
+		Committees{
+			Hold: []int{6, 7},
+			Ver:  []int{0, 1},
+			Res:  []int{2, 3},
+			Next: []int{4, 5},
+		},
+		committees[1],
+	)
+	for e := 1; e < len(committees); e++ {
+		assert.Equal(committees[e-1].Next, committees[e].Hold)
+	}
+}
@@ -0,0 +1,320 @@
+package resharing
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/elgamal"
+	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/nizk"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+	log "github.com/sirupsen/logrus"
+)
+
+// This file handles the (optional) verifiable encryption of the messages M[j]
+// from dealer i to verifier j (see VerificationMJ)
+// It is enabled when PublicInput.VEncPKs is not nil
+//
+// Instead of EncVerM[j], the dealer sends in VEncVerM[j]:
+//    the ElGamal encryptions (see primitives/elgamal) of sigmaRho_{i+1,j+1,l} under
+//    the key VEncPKs of verifier j, for l in [0,2n-1]
+//    a NIZK proof that these ciphertexts encrypt an opening of ComC[j+1]
+//    (see nizk.NewVerifiableEncStatement)
+//    for each ciphertext, a proof that its chunks are ChunkBits-bit integers (see VEncRangeProof),
+//    so that verifier j can always decrypt it
+// Anybody can then verify that the dealer sent valid and decryptable shares to every verifier
+// (see checkDealerQualified), hence an honest verifier never complains against such a dealer
+// The dealers do not send the future broadcast material (EncResM, EncEpsK, HashEps)
+// and the protocol skips the resolution round (see PublicInput.NumRounds)
+//
+// The range proofs are the main cost: 2n NumChunks range proofs of ChunkBits bits per verifier
+
+// VerifiableEncryption is the verifiable encryption of M[j] for verifier j
+type VerifiableEncryption struct {
+	C      []elgamal.Ciphertext `codec:"c"` // C[l] is the encryption of sigmaRho_{i+1,j+1,l}, l in [0,2n-1]
+	Proof  VEncProof            `codec:"p"` // Proof that C encrypts an opening of ComC[j+1]
+	Ranges []VEncRangeProof     `codec:"r"` // Ranges[l] proves that the chunks of C[l] are small
+}
+
+// VEncProof is the proof for nizk.NewVerifiableEncStatement
+// In compact form (see ProofFormatCompact), Com is empty and Chal is the challenge
+type VEncProof struct {
+	Com  []curve25519.PointXY `codec:"c"`
+	Resp []curve25519.Scalar  `codec:"r"`
+	Chal *curve25519.Scalar   `codec:"e"` // Chal is the challenge in compact form, nil otherwise
+}
+
+// VEncRangeProof proves that the chunks m_k of an ElGamal ciphertext are ChunkBits-bit integers
+// It commits to each chunk with a Pedersen commitment P[k] = m_k G + s_k H,
+// proves that the chunks of the ciphertext encrypt the committed values
+// (see nizk.NewElGamalPedersenEqStatement) and that each P[k] commits to a ChunkBits-bit integer
+// (see nizk.ProveRange)
+// The proofs are always in batchable form, whatever the ProofFormat
+type VEncRangeProof struct {
+	P      []curve25519.PointXY `codec:"P"` // P[k] is the Pedersen commitment to the chunk m_k
+	Eq     nizk.LinearProof     `codec:"q"` // proof for nizk.NewElGamalPedersenEqStatement
+	Ranges []nizk.RangeProof    `codec:"r"` // Ranges[k] proves that P[k] commits to a ChunkBits-bit integer
+}
+
+// vEncTranscript returns the transcript for the proof of the verifiable encryption
+// for verifier j with context ctx (of the dealer)
+func vEncTranscript(ctx *ProofContext, j int) *transcript.Transcript {
+	t := transcript.New("venc")
+	ctx.bind(t)
+	t.AppendUint64("j", uint64(j))
+	return t
+}
+
+// vEncRangeTranscripts returns the transcripts of the range proof of the ciphertext l
+// for verifier j with context ctx (of the dealer) and Pedersen commitments p:
+// the transcript of the proof for nizk.NewElGamalPedersenEqStatement and the transcripts of the range proofs
+func vEncRangeTranscripts(
+	ctx *ProofContext, j int, l int, p []curve25519.PointXY,
+) (*transcript.Transcript, []*transcript.Transcript) {
+	t := transcript.New("venc_range")
+	ctx.bind(t)
+	t.AppendUint64("j", uint64(j))
+	t.AppendUint64("l", uint64(l))
+	t.AppendPointsXY("P", p)
+
+	ts := make([]*transcript.Transcript, len(p))
+	for k := range p {
+		ts[k] = t.Clone()
+		ts[k].AppendUint64("k", uint64(k))
+	}
+	return t, ts
+}
+
+// vEncStatement returns the statement proven by venc (see top of file)
+// The shape of the ciphertexts must have been checked before (see elgamal.Ciphertext.CheckShape)
+func vEncStatement(
+	vcParams *feldman.VCParams, comCJ *feldman.VC, pk *elgamal.PublicKey, c []elgamal.Ciphertext,
+) (*nizk.LinearStatement, error) {
+	if len(c) != len(vcParams.Bases) {
+		return nil, fmt.Errorf("invalid number of ciphertexts")
+	}
+	aggR := make([]curve25519.PointXY, len(c))
+	aggE := make([]curve25519.PointXY, len(c))
+	for l := range c {
+		r, e, err := c[l].Aggregate()
+		if err != nil {
+			return nil, err
+		}
+		aggR[l] = *r
+		aggE[l] = *e
+	}
+	return nizk.NewVerifiableEncStatement(vcParams.Bases, comCJ, pk, aggR, aggE)
+}
+
+// vEncEncryptAndProve encrypts sigmaRhoJ = sigmaRho_{i+1,j+1,l} (l in [0,2n-1]) for verifier j
+// with key pk and proves it is an opening of comCJ = ComC[j+1]
+// rnd is the randomness source (system randomness if nil)
+// ctx binds the proof to the protocol run and the dealer (see ProofContext)
+// format is the format of the proof
+func vEncEncryptAndProve(
+	rnd io.Reader, ctx *ProofContext, format ProofFormat, j int,
+	vcParams *feldman.VCParams, comCJ *feldman.VC, pk *elgamal.PublicKey, sigmaRhoJ []curve25519.Scalar,
+) (venc VerifiableEncryption, err error) {
+	venc.C = make([]elgamal.Ciphertext, len(sigmaRhoJ))
+	venc.Ranges = make([]VEncRangeProof, len(sigmaRhoJ))
+	r := make([]curve25519.Scalar, len(sigmaRhoJ))
+	for l := range sigmaRhoJ {
+		c, chunks, rs, err := elgamal.EncryptChunksFrom(rnd, pk, &sigmaRhoJ[l])
+		if err != nil {
+			return VerifiableEncryption{}, err
+		}
+		venc.C[l] = *c
+		r[l] = *elgamal.AggregateRandomness(rs)
+
+		venc.Ranges[l], err = vEncProveRange(rnd, ctx, j, l, pk, c, chunks, rs)
+		if err != nil {
+			return VerifiableEncryption{}, err
+		}
+	}
+
+	stmt, err := vEncStatement(vcParams, comCJ, pk, venc.C)
+	if err != nil {
+		return VerifiableEncryption{}, err
+	}
+	wit := nizk.AndWitness(sigmaRhoJ, r)
+
+	if format == ProofFormatCompact {
+		proof, err := nizk.ProveCompact(rnd, vEncTranscript(ctx, j), stmt, wit)
+		if err != nil {
+			return VerifiableEncryption{}, err
+		}
+		venc.Proof = VEncProof{Resp: proof.Resp, Chal: &proof.Chal}
+		return venc, nil
+	}
+
+	proof, err := nizk.Prove(rnd, vEncTranscript(ctx, j), stmt, wit)
+	if err != nil {
+		return VerifiableEncryption{}, err
+	}
+	venc.Proof = VEncProof{Com: proof.Com, Resp: proof.Resp}
+	return venc, nil
+}
+
+// vEncProveRange proves that the chunks of the ciphertext l for verifier j are small (see VEncRangeProof)
+// c is the encryption under pk of the chunks with randomness rs (see elgamal.EncryptChunksFrom)
+func vEncProveRange(
+	rnd io.Reader, ctx *ProofContext, j int, l int,
+	pk *elgamal.PublicKey, c *elgamal.Ciphertext, chunks []curve25519.Scalar, rs []curve25519.Scalar,
+) (VEncRangeProof, error) {
+	s := make([]curve25519.Scalar, len(chunks))
+	proof := VEncRangeProof{
+		P:      make([]curve25519.PointXY, len(chunks)),
+		Ranges: make([]nizk.RangeProof, len(chunks)),
+	}
+	for k := range chunks {
+		sK, err := curve25519.RandomScalarFrom(rnd)
+		if err != nil {
+			return VEncRangeProof{}, err
+		}
+		s[k] = *sK
+		p, err := curve25519.DoubleMultBaseGHPointXYScalar(&chunks[k], &s[k])
+		if err != nil {
+			return VEncRangeProof{}, err
+		}
+		proof.P[k] = *p
+	}
+
+	stmt, err := nizk.NewElGamalPedersenEqStatement(pk, c.R, c.E, proof.P)
+	if err != nil {
+		return VEncRangeProof{}, err
+	}
+	tEq, ts := vEncRangeTranscripts(ctx, j, l, proof.P)
+	eq, err := nizk.Prove(rnd, tEq, stmt, nizk.AndWitness(chunks, rs, s))
+	if err != nil {
+		return VEncRangeProof{}, err
+	}
+	proof.Eq = *eq
+
+	for k := range chunks {
+		rangeProof, err := nizk.ProveRange(rnd, ts[k], &proof.P[k], elgamal.ChunkBits, &chunks[k], &s[k])
+		if err != nil {
+			return VEncRangeProof{}, err
+		}
+		proof.Ranges[k] = *rangeProof
+	}
+	return proof, nil
+}
+
+// vEncCheckShape checks the shape of the ciphertexts and of the range proofs
+// and returns the statement of the proof
+func vEncCheckShape(
+	vcParams *feldman.VCParams, comCJ *feldman.VC, pk *elgamal.PublicKey, venc *VerifiableEncryption,
+) (*nizk.LinearStatement, error) {
+	if len(venc.Ranges) != len(venc.C) {
+		return nil, fmt.Errorf("invalid number of range proofs")
+	}
+	for l := range venc.C {
+		err := venc.C[l].CheckShape()
+		if err != nil {
+			return nil, fmt.Errorf("ciphertext %d: %w", l, err)
+		}
+		if len(venc.Ranges[l].P) != elgamal.NumChunks || len(venc.Ranges[l].Ranges) != elgamal.NumChunks {
+			return nil, fmt.Errorf("range proof %d: invalid number of chunks", l)
+		}
+		for k := range venc.Ranges[l].P {
+			if !curve25519.IsOnCurveXY(&venc.Ranges[l].P[k]) {
+				return nil, fmt.Errorf("range proof %d: commitment %d is not on the curve", l, k)
+			}
+		}
+	}
+	return vEncStatement(vcParams, comCJ, pk, venc.C)
+}
+
+// vEncBatchAdd adds the verification of the verifiable encryption for verifier j to the item of the batch b
+// comCJ = ComC[j+1] must be on the curve
+// The proof can be in any format: proofs in compact form cannot be batched and are verified immediately
+// (the range proofs are always batched)
+func vEncBatchAdd(
+	b *nizk.Batch, item int,
+	ctx *ProofContext, j int, vcParams *feldman.VCParams, comCJ *feldman.VC, pk *elgamal.PublicKey,
+	venc *VerifiableEncryption,
+) error {
+	stmt, err := vEncCheckShape(vcParams, comCJ, pk, venc)
+	if err != nil {
+		return err
+	}
+
+	for l := range venc.C {
+		rangeProof := &venc.Ranges[l]
+		eqStmt, err := nizk.NewElGamalPedersenEqStatement(pk, venc.C[l].R, venc.C[l].E, rangeProof.P)
+		if err != nil {
+			return err
+		}
+		tEq, ts := vEncRangeTranscripts(ctx, j, l, rangeProof.P)
+		err = b.AddProof(item, tEq, eqStmt, &rangeProof.Eq)
+		if err != nil {
+			return fmt.Errorf("range proof %d: %w", l, err)
+		}
+		for k := range rangeProof.P {
+			err = b.AddRangeProof(item, ts[k], &rangeProof.P[k], elgamal.ChunkBits, &rangeProof.Ranges[k])
+			if err != nil {
+				return fmt.Errorf("range proof %d: chunk %d: %w", l, k, err)
+			}
+		}
+	}
+
+	if venc.Proof.Chal != nil {
+		if len(venc.Proof.Com) != 0 {
+			return fmt.Errorf("compact proof must not contain commitments")
+		}
+		return nizk.VerifyCompact(vEncTranscript(ctx, j), stmt, &nizk.CompactLinearProof{
+			Chal: *venc.Proof.Chal,
+			Resp: venc.Proof.Resp,
+		})
+	}
+	return b.AddProof(item, vEncTranscript(ctx, j), stmt, &nizk.LinearProof{
+		Com:  venc.Proof.Com,
+		Resp: venc.Proof.Resp,
+	})
+}
+
+// vEncDecrypt decrypts the verifiable encryption using the private key sk
+func vEncDecrypt(sk *elgamal.PrivateKey, venc *VerifiableEncryption) (*VerificationMJ, error) {
+	mj := &VerificationMJ{
+		SR: make([]curve25519.Scalar, len(venc.C)),
+	}
+	for l := range venc.C {
+		m, err := elgamal.Decrypt(sk, &venc.C[l])
+		if err != nil {
+			return nil, fmt.Errorf("ciphertext %d: %w", l, err)
+		}
+		mj.SR[l] = *m
+	}
+	return mj, nil
+}
+
+// getVEncMJ is the same as getMJ when verifiable encryption is enabled
+// The proofs are not verified: the verifier only needs to decrypt its shares, which are then checked
+// against ComC[j+1] (see verifyBatchMJ), while the proofs are verified publicly by everybody
+// when computing the qualified dealers (see checkDealerQualified)
+func getVEncMJ(
+	pub *PublicInput, prv *PrivateInput, j int,
+	dealingMessages []DealingMessage, i int,
+	myLog *log.Entry,
+) *VerificationMJ {
+	msg := &dealingMessages[i]
+	if len(msg.VEncVerM) != pub.verParams().N || len(msg.ComC) != pub.verParams().N+1 {
+		// invalid dealer
+		myLog.Infof("complain against dealer %d: VEncVerM or ComC of incorrect length", i)
+		return nil
+	}
+
+	mj, err := vEncDecrypt(&prv.VEncSK, &msg.VEncVerM[j])
+	if err != nil {
+		// invalid dealer
+		myLog.Infof("complain against dealer %d: %v", i, err)
+		return nil
+	}
+	if len(mj.SR) != pub.numSecrets()*2*pub.nextParams().N {
+		// invalid dealer
+		myLog.Infof("complain against dealer %d: SR of incorrect length", i)
+		return nil
+	}
+	return mj
+}
@@ -0,0 +1,195 @@
+package resharing
+
+import (
+	"testing"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/elgamal"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPerformDealingVerifiableEnc(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n  = 3
+		tt = 1
+	)
+
+	pub, prvs, _, _, _ := setupResharingSeq(t, n, tt)
+	enableVerifiableEncryption(t, pub, prvs)
+	dealer := pub.Committees.Hold[0]
+
+	vectorV, err := generateDealerVectorsV(pub)
+	require.NoError(err)
+
+	myLog := log.WithField("test", t.Name())
+
+	// 2^ChunkBits G
+	chunkOverflow, err := curve25519.MultBaseGPointXYScalar(curve25519.GetScalar(1 << elgamal.ChunkBits))
+	require.NoError(err)
+
+	otherSR := make([]curve25519.Scalar, 2*n)
+	for l := range otherSR {
+		otherSR[l] = *curve25519.RandomScalar()
+	}
+
+	for _, format := range []ProofFormat{ProofFormatBatchable, ProofFormatCompact} {
+		pub.ProofFormats.Dealing = format
+		msg, err := PerformDealing(pub, &prvs[dealer], &PartyDebugParams{})
+		require.NoError(err)
+		require.Len(msg.VEncVerM, n)
+		assert.Empty(msg.EncVerM)
+		// No future broadcast
+		assert.Empty(msg.EncResM)
+		assert.Empty(msg.EncEpsK)
+		assert.Empty(msg.HashEps)
+		assert.True(CheckDealingMessages(pub, *msg, 0, &PartyDebugParams{}))
+		assert.NoError(checkDealerQualified(pub, 0, *msg, vectorV), "format %d", format)
+
+		// Each verifier can decrypt its shares
+		for j, verifier := range pub.Committees.Ver {
+			mj := getMJ(pub, &prvs[verifier], j, []DealingMessage{*msg}, 0, myLog)
+			require.NotNil(mj)
+			assert.NoError(VerifyMJ(&pub.VCParams, &msg.ComC[j+1], mj))
+		}
+
+		// Ciphertext for verifier 1 of another message
+		other, err := vEncEncryptAndProve(nil, pub.ProofContext(dealer), format, 1,
+			&pub.VCParams, &msg.ComC[2], &pub.VEncPKs[pub.Committees.Ver[1]], otherSR)
+		require.NoError(err)
+		good := msg.VEncVerM[1]
+		msg.VEncVerM[1] = other
+		assert.Error(checkDealerQualified(pub, 0, *msg, vectorV), "format %d", format)
+		// verifier 1 decrypts the other message, which does not match ComC[2]
+		mj := getMJ(pub, &prvs[pub.Committees.Ver[1]], 1, []DealingMessage{*msg}, 0, myLog)
+		require.NotNil(mj)
+		assert.Error(VerifyMJ(&pub.VCParams, &msg.ComC[2], mj))
+
+		// Valid proof but for another verifier
+		msg.VEncVerM[1] = msg.VEncVerM[0]
+		assert.Error(checkDealerQualified(pub, 0, *msg, vectorV), "format %d", format)
+
+		// Broken chunk
+		msg.VEncVerM[1] = copyVEnc(good)
+		msg.VEncVerM[1].C[0].E[0] = *curve25519.RandomPointXY()
+		assert.Error(checkDealerQualified(pub, 0, *msg, vectorV), "format %d", format)
+
+		// Chunks out of range: chunk 0 is increased by 2^ChunkBits and chunk 1 decreased by 1
+		// so that the aggregated ciphertext and its proof are unchanged but the range proof fails
+		msg.VEncVerM[1] = copyVEnc(good)
+		e0, err := curve25519.AddPointXY(&msg.VEncVerM[1].C[0].E[0], chunkOverflow)
+		require.NoError(err)
+		msg.VEncVerM[1].C[0].E[0] = *e0
+		e1, err := curve25519.SubPointXY(&msg.VEncVerM[1].C[0].E[1], &curve25519.BaseXYG)
+		require.NoError(err)
+		msg.VEncVerM[1].C[0].E[1] = *e1
+		stmt, err := vEncStatement(&pub.VCParams, &msg.ComC[2], &pub.VEncPKs[pub.Committees.Ver[1]], msg.VEncVerM[1].C)
+		require.NoError(err)
+		goodStmt, err := vEncStatement(&pub.VCParams, &msg.ComC[2], &pub.VEncPKs[pub.Committees.Ver[1]], good.C)
+		require.NoError(err)
+		require.Equal(goodStmt, stmt)
+		assert.Error(checkDealerQualified(pub, 0, *msg, vectorV), "format %d", format)
+		assert.Nil(getMJ(pub, &prvs[pub.Committees.Ver[1]], 1, []DealingMessage{*msg}, 0, myLog))
+
+		// Range proof of another ciphertext
+		msg.VEncVerM[1] = copyVEnc(good)
+		msg.VEncVerM[1].Ranges[0] = good.Ranges[1]
+		assert.Error(checkDealerQualified(pub, 0, *msg, vectorV), "format %d", format)
+
+		// Missing range proofs
+		msg.VEncVerM[1] = copyVEnc(good)
+		msg.VEncVerM[1].Ranges = nil
+		assert.Error(checkDealerQualified(pub, 0, *msg, vectorV), "format %d", format)
+
+		msg.VEncVerM[1] = good
+		assert.NoError(checkDealerQualified(pub, 0, *msg, vectorV), "format %d", format)
+	}
+}
+
+// copyVEnc returns a copy of venc whose ciphertexts and range proofs can be modified
+func copyVEnc(venc VerifiableEncryption) VerifiableEncryption {
+	c := make([]elgamal.Ciphertext, len(venc.C))
+	for l := range venc.C {
+		c[l] = elgamal.Ciphertext{
+			R: append([]curve25519.PointXY{}, venc.C[l].R...),
+			E: append([]curve25519.PointXY{}, venc.C[l].E...),
+		}
+	}
+	venc.C = c
+	venc.Ranges = append([]VEncRangeProof{}, venc.Ranges...)
+	return venc
+}
+
+func TestResolveComplaintsVerifiableEnc(t *testing.T) {
+	// With verifiable encryption, the complaints are not resolved
+	// so that a false complaint does not disqualify a valid dealer
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n  = 3
+		tt = 1
+	)
+
+	pub, prvs, _, _, _ := setupResharingSeq(t, n, tt)
+	enableVerifiableEncryption(t, pub, prvs)
+
+	dealingMessages := make([]DealingMessage, n)
+	for i, dealer := range pub.Committees.Hold {
+		msg, err := PerformDealing(pub, &prvs[dealer], &PartyDebugParams{})
+		require.NoError(err)
+		dealingMessages[i] = *msg
+	}
+	verificationMessages := make([]VerificationMessage, n)
+	for j, verifier := range pub.Committees.Ver {
+		msg, err := PerformVerification(pub, &prvs[verifier], j, dealingMessages, &PartyDebugParams{})
+		require.NoError(err)
+		verificationMessages[j] = *msg
+	}
+
+	// Verifier 0 falsely complains against dealer 0
+	verificationMessages[0].Complaints[0] = true
+
+	resolvedSharesSR, disqualifiedDealers, _, err := ResolveComplaints(
+		pub, dealingMessages, verificationMessages, nil, &PartyDebugParams{})
+	require.NoError(err)
+	assert.Empty(resolvedSharesSR)
+	assert.Empty(disqualifiedDealers)
+}
+
+func TestResharingProtocolVerifiableEnc(t *testing.T) {
+	// Test resharing protocol when everybody is honest and verifiable encryption is enabled
+	// There is no resolution round
+	assert := assert.New(t)
+
+	const (
+		n          = 3                 // number of parties per committee
+		numParties = n * numCommittees // total number of parties
+		tt         = 1                 // threshold of malicious parties
+	)
+
+	pub, prvs, o, secret, rnd := setupResharingSeq(t, n, tt)
+	enableVerifiableEncryption(t, pub, prvs)
+	assert.Equal(numRounds-1, pub.NumRounds())
+
+	parties, errs := runResharingParties(t, pub, prvs, o)
+	outputShares, outputCommitments, _ := resharingOutputs(t, parties, errs)
+
+	checkProtocolResults(
+		t,
+		pub,
+		secret,
+		rnd,
+		outputCommitments,
+		outputShares,
+		false,
+	)
+
+	for party := 0; party < numParties; party++ {
+		assert.Equal(pub.NumRounds(), parties[party].Round())
+	}
+}
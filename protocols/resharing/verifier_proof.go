@@ -3,10 +3,13 @@ package resharing
 import (
 	"crypto/sha256"
 	"fmt"
+	"io"
 
 	"github.com/shaih/go-yosovss/msgpack"
 	"github.com/shaih/go-yosovss/primitives/curve25519"
 	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/nizk"
+	"github.com/shaih/go-yosovss/primitives/transcript"
 )
 
 // This file is to handle the proof made by each verifier V_j
@@ -37,8 +40,12 @@ type VPHashEIn struct {
 // where j is the index of the verifier making the call
 // and i is in 0,...,m-1
 // See comment at top of file
-func VPCommitAndProve(vcParams *feldman.VCParams, sigmaRho [][]curve25519.Scalar) (
-	vpcp VPCommitProof, err error) {
+// rnd is the randomness source for the proof (system randomness if nil)
+// ctx binds the proof to the protocol run and the verifier (see ProofContext)
+// format is the format of the DL proof
+func VPCommitAndProve(
+	rnd io.Reader, ctx *ProofContext, format ProofFormat, vcParams *feldman.VCParams, sigmaRho [][]curve25519.Scalar,
+) (vpcp VPCommitProof, err error) {
 
 	bigN := vcParams.N
 	m := len(sigmaRho)
@@ -47,7 +54,7 @@ func VPCommitAndProve(vcParams *feldman.VCParams, sigmaRho [][]curve25519.Scalar
 
 	vpcp.HashL = vpCommitAndProveComputeHashL(sigmaTranspose)
 
-	e := VPComputeHashE(VPHashEIn{HashL: vpcp.HashL}, m)
+	e := VPComputeHashE(ctx, VPHashEIn{HashL: vpcp.HashL}, m)
 
 	// Compute comR and their log
 	comRLog := make([]curve25519.Scalar, bigN)
@@ -70,7 +77,7 @@ func VPCommitAndProve(vcParams *feldman.VCParams, sigmaRho [][]curve25519.Scalar
 	vpcp.ComR = comR
 
 	// Compute the proof vcpc.DLProofR
-	proof, err := DLProve(DLStatement{
+	proof, err := DLProve(rnd, ctx, format, DLStatement{
 		G: vcParams.Bases,
 		X: vpcp.ComR,
 	}, DLWitness{
@@ -139,28 +146,16 @@ func VPComputeHashL(in VPHashLIn) (hashL [HashLength]byte) {
 }
 
 // VPComputeHashE computes the elements e_{j,0}, ..., e_{j, m-1}
-// as output of hash of the input
-func VPComputeHashE(in VPHashEIn, m int) (e []curve25519.Scalar) {
-	h := sha256.New()
-	h.Write([]byte("vphe")) // use a prefix for domain separation
-	h.Write(msgpack.Encode(in))
-	out := h.Sum(nil)
-
-	// Convert out into a scalar
-	// a bit non-optimal but simplest solution with what we have
-	if len(out) != curve25519.Chacha20KeyLength {
-		panic("output of sha256 not the right size")
-	}
-
-	var chacha20key [curve25519.Chacha20KeyLength]byte
-	copy(chacha20key[:], out)
-
-	e = make([]curve25519.Scalar, m)
-	for i := 0; i < m; i++ {
-		curve25519.RandomScalarChacha20C(&e[i], (*curve25519.Chacha20Key)(&chacha20key), 0)
+// as output of hash of the context and the input
+func VPComputeHashE(ctx *ProofContext, in VPHashEIn, m int) (e []curve25519.Scalar) {
+	t := transcript.New("vphe")
+	ctx.bind(t)
+	t.AppendUint64("m", uint64(m))
+	t.AppendUint64("N", uint64(len(in.HashL)))
+	for i := range in.HashL {
+		t.AppendMessage("hl", in.HashL[i][:])
 	}
-
-	return e
+	return t.ChallengeScalars("e", m)
 }
 
 // VPVerify verifies a VP proof for a
@@ -168,21 +163,21 @@ func VPComputeHashE(in VPHashEIn, m int) (e []curve25519.Scalar) {
 // (from Verifier j point of view)
 // so it may have less than n commitments
 // l is in range [0,N-1]
-func VPVerify(vcParams feldman.VCParams, l int, comC []curve25519.PointXY,
+func VPVerify(ctx *ProofContext, vcParams feldman.VCParams, l int, comC []curve25519.PointXY,
 	vpcp VPCommitProof, sigmaRhoL []curve25519.Scalar) error {
 
-	err := VPVerifyGenericL(vcParams, comC, vpcp)
+	err := VPVerifyGenericL(ctx, vcParams, comC, vpcp)
 	if err != nil {
 		return err
 	}
 
-	return VPVerifySpecificL(vcParams, l, comC, vpcp, sigmaRhoL)
+	return VPVerifySpecificL(ctx, vcParams, l, comC, vpcp, sigmaRhoL)
 }
 
 // VPVerifySpecificL is like VPVerify except it only verifies what is specific
 // to l and sigmaRhoL. Must be called AFTER VPVerifyGenericL
 // This separation is to obtain higher performance when checking for multiple l
-func VPVerifySpecificL(vcParams feldman.VCParams, l int, comC []curve25519.PointXY,
+func VPVerifySpecificL(ctx *ProofContext, vcParams feldman.VCParams, l int, comC []curve25519.PointXY,
 	vpcp VPCommitProof, sigmaRhoL []curve25519.Scalar) error {
 
 	m := len(comC)
@@ -191,7 +186,7 @@ func VPVerifySpecificL(vcParams feldman.VCParams, l int, comC []curve25519.Point
 		return fmt.Errorf("comC and sigmaRhoL have different lengths")
 	}
 
-	if len(vpcp.ComR) < l {
+	if l < 0 || l >= len(vpcp.ComR) || l >= len(vpcp.HashL) || l >= len(vcParams.Bases) {
 		return fmt.Errorf("not enough hashL/comR")
 	}
 
@@ -204,7 +199,7 @@ func VPVerifySpecificL(vcParams feldman.VCParams, l int, comC []curve25519.Point
 	}
 
 	// Re-compute e
-	e := VPComputeHashE(VPHashEIn{HashL: vpcp.HashL}, m)
+	e := VPComputeHashE(ctx, VPHashEIn{HashL: vpcp.HashL}, m)
 
 	// Re-compute comRL and verify it matches vpcp for l
 	comRLLog, err := vpComputeComRLLog(e, sigmaRhoL)
@@ -226,7 +221,7 @@ func VPVerifySpecificL(vcParams feldman.VCParams, l int, comC []curve25519.Point
 
 // VPVerifyGenericL is like VPVerify doing only the generic part of the check
 // See VPVerifySpecificL
-func VPVerifyGenericL(vcParams feldman.VCParams, comC []curve25519.PointXY,
+func VPVerifyGenericL(ctx *ProofContext, vcParams feldman.VCParams, comC []curve25519.PointXY,
 	vpcp VPCommitProof) error {
 	m := len(comC)
 
@@ -235,11 +230,11 @@ func VPVerifyGenericL(vcParams feldman.VCParams, comC []curve25519.PointXY,
 	}
 
 	// Re-compute e
-	e := VPComputeHashE(VPHashEIn{HashL: vpcp.HashL}, m)
+	e := VPComputeHashE(ctx, VPHashEIn{HashL: vpcp.HashL}, m)
 	// doing this both in Generic and Specific, but it's not an expensive operation
 
 	// Verify vpcp.ComR have valid NIZK
-	err := DLVerify(DLStatement{
+	err := DLVerify(ctx, DLStatement{
 		G: vcParams.Bases,
 		X: vpcp.ComR,
 	}, vpcp.DLProofR)
@@ -263,3 +258,42 @@ func VPVerifyGenericL(vcParams feldman.VCParams, comC []curve25519.PointXY,
 
 	return nil
 }
+
+// vpBatchAddGenericL adds the checks of VPVerifyGenericL to the item of the batch b
+// All the points of comC must be on the curve
+func vpBatchAddGenericL(b *nizk.Batch, item int, ctx *ProofContext, vcParams feldman.VCParams,
+	comC []curve25519.PointXY, vpcp VPCommitProof) error {
+	m := len(comC)
+
+	if len(vpcp.ComR) != len(vpcp.HashL) {
+		return fmt.Errorf("different length for comR and hashL")
+	}
+
+	// Re-compute e
+	e := VPComputeHashE(ctx, VPHashEIn{HashL: vpcp.HashL}, m)
+
+	// vpcp.ComR have valid NIZK
+	// This implies that the points of vpcp.ComR are on the curve
+	err := dlBatchAdd(b, item, ctx, DLStatement{
+		G: vcParams.Bases,
+		X: vpcp.ComR,
+	}, vpcp.DLProofR)
+	if err != nil {
+		return err
+	}
+
+	// vpcp.ComR matches comC, that is sum_l ComR[l] - sum_i e_i C_i = 0
+	c := nizk.Claim{
+		Points:  make([]curve25519.PointXY, 0, len(vpcp.ComR)+m),
+		Scalars: make([]curve25519.Scalar, 0, len(vpcp.ComR)+m),
+	}
+	for l := range vpcp.ComR {
+		c.Points = append(c.Points, vpcp.ComR[l])
+		c.Scalars = append(c.Scalars, curve25519.ScalarOne)
+	}
+	for i := range comC {
+		c.Points = append(c.Points, comC[i])
+		c.Scalars = append(c.Scalars, *curve25519.NegateScalar(&e[i]))
+	}
+	return b.AddClaim(item, c)
+}
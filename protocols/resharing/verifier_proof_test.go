@@ -41,7 +41,7 @@ func TestVPProveCorrect(t *testing.T) {
 			require.NoError(err)
 
 			// Generate the proof
-			vpcp, err := VPCommitAndProve(vcParams, sigma[tc.iFirst:(tc.iLast+1)])
+			vpcp, err := VPCommitAndProve(nil, nil, ProofFormatBatchable, vcParams, sigma[tc.iFirst:(tc.iLast+1)])
 			require.NoError(err)
 
 			// Verify it
@@ -50,13 +50,33 @@ func TestVPProveCorrect(t *testing.T) {
 				for i := 0; i < n; i++ {
 					sigmaL[i] = sigma[i][l]
 				}
-				err = VPVerify(*vcParams, l, comC[tc.iFirst:(tc.iLast+1)], vpcp, sigmaL[tc.iFirst:(tc.iLast+1)])
+				err = VPVerify(nil, *vcParams, l, comC[tc.iFirst:(tc.iLast+1)], vpcp, sigmaL[tc.iFirst:(tc.iLast+1)])
 				assert.NoError(err)
 			}
 		})
 	}
 }
 
+// TestVPComputeHashEDistinct checks that the e_i are all distinct
+// and depend on the input
+func TestVPComputeHashEDistinct(t *testing.T) {
+	assert := assert.New(t)
+
+	const m = 10
+
+	in := VPHashEIn{HashL: make([][HashLength]byte, 4)}
+	e := VPComputeHashE(nil, in, m)
+	assert.Equal(m, len(e))
+	for i := 0; i < m; i++ {
+		for j := i + 1; j < m; j++ {
+			assert.NotEqual(e[i], e[j], "e[%d] and e[%d] are equal", i, j)
+		}
+	}
+
+	in.HashL[1][0] = 1
+	assert.NotEqual(e, VPComputeHashE(nil, in, m))
+}
+
 func TestVPProveIncorrect(t *testing.T) {
 	testCases := []struct {
 		n      int
@@ -82,7 +102,7 @@ func TestVPProveIncorrect(t *testing.T) {
 			require.NoError(err)
 
 			// Generate the proof
-			vpcp, err := VPCommitAndProve(vcParams, sigma[tc.iFirst:(tc.iLast+1)])
+			vpcp, err := VPCommitAndProve(nil, nil, ProofFormatBatchable, vcParams, sigma[tc.iFirst:(tc.iLast+1)])
 			require.NoError(err)
 
 			// Verify it
@@ -96,14 +116,14 @@ func TestVPProveIncorrect(t *testing.T) {
 				badSigmaL := make([]curve25519.Scalar, n)
 				copy(badSigmaL, sigmaL)
 				badSigmaL[tc.iFirst] = *curve25519.RandomScalar()
-				err = VPVerify(*vcParams, l, comC[tc.iFirst:(tc.iLast+1)], vpcp, badSigmaL[tc.iFirst:(tc.iLast+1)])
+				err = VPVerify(nil, *vcParams, l, comC[tc.iFirst:(tc.iLast+1)], vpcp, badSigmaL[tc.iFirst:(tc.iLast+1)])
 				assert.Error(err)
 
 				// Make it incorrect by making one of the commitment incorrect
 				badComC := make([]curve25519.PointXY, n)
 				copy(badComC, comC)
 				badComC[tc.iLast] = *curve25519.RandomPointXY()
-				err = VPVerify(*vcParams, l, badComC[tc.iFirst:(tc.iLast+1)], vpcp, sigmaL[tc.iFirst:(tc.iLast+1)])
+				err = VPVerify(nil, *vcParams, l, badComC[tc.iFirst:(tc.iLast+1)], vpcp, sigmaL[tc.iFirst:(tc.iLast+1)])
 				assert.Error(err)
 			}
 		})
@@ -141,7 +161,7 @@ func genVPInputs(n, d, j int) (
 	for i := 0; i < n; i++ {
 		s := curve25519.RandomScalar()
 		r := curve25519.RandomScalar()
-		allSigmaRho[i], allComC[i], err = GenerateDealerSharesCommitments(vssParams, vcParams, s, r)
+		allSigmaRho[i], allComC[i], err = GenerateDealerSharesCommitments(nil, vssParams, vssParams, vcParams, s, r)
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -0,0 +1,7 @@
+//go:build generate
+// +build generate
+
+package signing
+
+//go:generate codecgen -o gen-codecgen.go exp_share.go step1_dealing.go step4_nonce.go step5_signing.go
+//go:generate gofmt -w gen-codecgen.go
@@ -0,0 +1,99 @@
+package signing
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/transcript"
+	"github.com/shaih/go-yosovss/primitives/vss"
+)
+
+// This file handles the conversion of Pedersen-shared values to values "in the exponent"
+// (see feldman.ExpShare)
+//
+// Let C_0,...,C_n be Pedersen commitments to a value x and its shares x_1,...,x_n
+// Party j publishes Y_{j+1} = x_{j+1} G with a proof that it is consistent with C_{j+1}
+// Then anybody can compute x G from any t+1 valid Y_{j+1} by Lagrange interpolation in the exponent
+//
+// This is used for the public key s G of the secret key s and the nonce k G of a signature
+
+// KeyShareMessage is the message parties send in the public key protocol (see StartPublicKeyParty)
+type KeyShareMessage struct {
+	_struct  struct{}          `codec:",omitempty,omitemptyarray"`
+	KeyShare *feldman.ExpShare `codec:"k"` // KeyShare is s_{j+1} G where s_{j+1} is the share of the secret key
+}
+
+// expShareTranscript returns the transcript for the proof of the share of party j
+// label distinguishes the values converted during the same session (e.g., "key" or "nonce")
+func expShareTranscript(sessionID []byte, label string, j int) *transcript.Transcript {
+	t := transcript.New("exp_share")
+	t.AppendMessage("session", sessionID)
+	t.AppendMessage("label", []byte(label))
+	t.AppendUint64("j", uint64(j))
+	return t
+}
+
+// ComputeExpShare computes the share in the exponent of the share of party j and its proof
+// c is the commitment to the share, i.e., the commitment of index j+1
+// rnd is the randomness source (system randomness if nil)
+func ComputeExpShare(
+	rnd io.Reader, sessionID []byte, label string, j int, c *pedersen.Commitment, share *vss.Share,
+) (*feldman.ExpShare, error) {
+	return feldman.ProveExpShare(rnd, expShareTranscript(sessionID, label, j), c, &share.S, &share.R)
+}
+
+// VerifyExpShare verifies the share in the exponent of party j
+// c is the commitment to the share, i.e., the commitment of index j+1
+func VerifyExpShare(sessionID []byte, label string, j int, c *pedersen.Commitment, es *feldman.ExpShare) error {
+	return feldman.VerifyExpShare(expShareTranscript(sessionID, label, j), c, es)
+}
+
+// validExpShares returns the list of parties j whose share in the exponent is valid, in increasing order
+// A missing share (nil) is invalid
+// commitments are the n+1 commitments C_0,...,C_n
+func validExpShares(
+	sessionID []byte, label string, commitments []pedersen.Commitment, expShares []*feldman.ExpShare,
+) []int {
+	var valid []int
+	for j := range expShares {
+		if expShares[j] != nil && VerifyExpShare(sessionID, label, j, &commitments[j+1], expShares[j]) == nil {
+			valid = append(valid, j)
+		}
+	}
+	return valid
+}
+
+// lagrangeCoeffsAtZero returns the Lagrange coefficients to interpolate at 0
+// the shares of indices js[0]+1,...,js[t]+1
+func lagrangeCoeffsAtZero(js []int) ([]curve25519.Scalar, error) {
+	indices := make([]curve25519.Scalar, len(js))
+	for q, j := range js {
+		curve25519.GetScalarC(&indices[q], uint64(j+1))
+	}
+	return curve25519.LagrangeCoeffs(indices, &curve25519.ScalarZero)
+}
+
+// InterpolateExp computes x G from the shares in the exponent of the parties js
+// (which must have been verified, see VerifyExpShare)
+// Only the first t+1 parties of js are used
+func InterpolateExp(t int, js []int, expShares []*feldman.ExpShare) (*curve25519.PointXY, error) {
+	if len(js) < t+1 {
+		return nil, fmt.Errorf("only %d valid shares (less than t+1)", len(js))
+	}
+	js = js[:t+1]
+
+	indices := make([]int, len(js))
+	ys := make([]feldman.GCommitment, len(js))
+	for q, j := range js {
+		indices[q] = j + 1
+		ys[q] = expShares[j].Y
+	}
+	fs, err := feldman.InterpolateGCommitments(indices, ys, []int{0})
+	if err != nil {
+		return nil, err
+	}
+	return &fs[0], nil
+}
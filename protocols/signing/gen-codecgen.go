@@ -0,0 +1,1056 @@
+// #nosec
+//go:build go1.6
+// +build go1.6
+
+// Code generated by codecgen - DO NOT EDIT.
+
+package signing
+
+import (
+	"errors"
+	pkg2_curve25519 "github.com/shaih/go-yosovss/primitives/curve25519"
+	pkg1_feldman "github.com/shaih/go-yosovss/primitives/feldman"
+	codec1978 "github.com/ugorji/go/codec"
+	"runtime"
+	"strconv"
+)
+
+const (
+	// ----- content types ----
+	codecSelferCcUTF8943 = 1
+	codecSelferCcRAW943  = 255
+	// ----- value types used ----
+	codecSelferValueTypeArray943     = 10
+	codecSelferValueTypeMap943       = 9
+	codecSelferValueTypeString943    = 6
+	codecSelferValueTypeInt943       = 2
+	codecSelferValueTypeUint943      = 3
+	codecSelferValueTypeFloat943     = 4
+	codecSelferValueTypeNil943       = 1
+	codecSelferBitsize943            = uint8(32 << (^uint(0) >> 63))
+	codecSelferDecContainerLenNil943 = -2147483648
+)
+
+var (
+	errCodecSelferOnlyMapOrArrayEncodeToStruct943 = errors.New(`only encoded map or array can be decoded into a struct`)
+)
+
+type codecSelfer943 struct{}
+
+func codecSelfer943False() bool { return false }
+func codecSelfer943True() bool  { return true }
+
+func init() {
+	if codec1978.GenVersion != 25 {
+		_, file, _, _ := runtime.Caller(0)
+		ver := strconv.FormatInt(int64(codec1978.GenVersion), 10)
+		panic(errors.New("codecgen version mismatch: current: 25, need " + ver + ". Re-generate file: " + file))
+	}
+	if false { // reference the types, but skip this branch at build/run time
+		var _ pkg2_curve25519.PointXY
+		var _ pkg1_feldman.ExpShare
+	}
+}
+
+func (KeyShareMessage) codecSelferViaCodecgen() {}
+func (x *KeyShareMessage) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
+		return
+	}
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyn3 bool = x.KeyShare == nil
+		var yyq2 = [1]bool{ // should field at this index be written?
+			x.KeyShare != nil, // k
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(1)
+			if yyn3 {
+				z.EncWriteArrayElem()
+				r.EncodeNil()
+			} else {
+				z.EncWriteArrayElem()
+				if yyq2[0] {
+					if yyxt4 := z.Extension(x.KeyShare); yyxt4 != nil {
+						z.EncExtension(x.KeyShare, yyxt4)
+					} else {
+						z.EncFallback(x.KeyShare)
+					}
+				} else {
+					r.EncodeNil()
+				}
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
+				}
+			}
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"k\"")
+				} else {
+					r.EncodeString(`k`)
+				}
+				z.EncWriteMapElemValue()
+				if yyn3 {
+					r.EncodeNil()
+				} else {
+					if yyxt5 := z.Extension(x.KeyShare); yyxt5 != nil {
+						z.EncExtension(x.KeyShare, yyxt5)
+					} else {
+						z.EncFallback(x.KeyShare)
+					}
+				}
+			}
+			z.EncWriteMapEnd()
+		}
+	}
+}
+
+func (x *KeyShareMessage) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = KeyShareMessage{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
+		} else {
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
+		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
+	}
+}
+
+func (x *KeyShareMessage) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "k":
+			if r.TryNil() {
+				if x.KeyShare != nil { // remove the if-true
+					x.KeyShare = nil
+				}
+			} else {
+				if x.KeyShare == nil {
+					x.KeyShare = new(pkg1_feldman.ExpShare)
+				}
+				if yyxt5 := z.Extension(x.KeyShare); yyxt5 != nil {
+					z.DecExtension(x.KeyShare, yyxt5)
+				} else {
+					z.DecFallback(x.KeyShare, false)
+				}
+			}
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *KeyShareMessage) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj6 int
+	var yyb6 bool
+	var yyhl6 bool = l >= 0
+	yyj6++
+	if yyhl6 {
+		yyb6 = yyj6 > l
+	} else {
+		yyb6 = z.DecCheckBreak()
+	}
+	if yyb6 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if r.TryNil() {
+		if x.KeyShare != nil { // remove the if-true
+			x.KeyShare = nil
+		}
+	} else {
+		if x.KeyShare == nil {
+			x.KeyShare = new(pkg1_feldman.ExpShare)
+		}
+		if yyxt8 := z.Extension(x.KeyShare); yyxt8 != nil {
+			z.DecExtension(x.KeyShare, yyxt8)
+		} else {
+			z.DecFallback(x.KeyShare, false)
+		}
+	}
+	for {
+		yyj6++
+		if yyhl6 {
+			yyb6 = yyj6 > l
+		} else {
+			yyb6 = z.DecCheckBreak()
+		}
+		if yyb6 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj6-1, "")
+	}
+}
+
+func (x *KeyShareMessage) IsCodecEmpty() bool {
+	return !(false)
+}
+
+func (DealingMessage) codecSelferViaCodecgen() {}
+func (x *DealingMessage) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
+		return
+	}
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyn5 bool = x.KeyShare == nil
+		var yyq2 = [3]bool{ // should field at this index be written?
+			len(x.NonceCommitments) != 0, // C
+			len(x.NonceEncShares) != 0,   // E
+			x.KeyShare != nil,            // k
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(3)
+			z.EncWriteArrayElem()
+			if yyq2[0] {
+				if x.NonceCommitments == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_PointXY(([]pkg2_curve25519.PointXY)(x.NonceCommitments), e)
+				} // end block: if x.NonceCommitments slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayElem()
+			if yyq2[1] {
+				if x.NonceEncShares == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_Ciphertext(([]pkg2_curve25519.Ciphertext)(x.NonceEncShares), e)
+				} // end block: if x.NonceEncShares slice == nil
+			} else {
+				r.EncodeNil()
+			}
+			if yyn5 {
+				z.EncWriteArrayElem()
+				r.EncodeNil()
+			} else {
+				z.EncWriteArrayElem()
+				if yyq2[2] {
+					if yyxt8 := z.Extension(x.KeyShare); yyxt8 != nil {
+						z.EncExtension(x.KeyShare, yyxt8)
+					} else {
+						z.EncFallback(x.KeyShare)
+					}
+				} else {
+					r.EncodeNil()
+				}
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
+				}
+			}
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"C\"")
+				} else {
+					r.EncodeString(`C`)
+				}
+				z.EncWriteMapElemValue()
+				if x.NonceCommitments == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_PointXY(([]pkg2_curve25519.PointXY)(x.NonceCommitments), e)
+				} // end block: if x.NonceCommitments slice == nil
+			}
+			if yyq2[1] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"E\"")
+				} else {
+					r.EncodeString(`E`)
+				}
+				z.EncWriteMapElemValue()
+				if x.NonceEncShares == nil {
+					r.EncodeNil()
+				} else {
+					h.encSlicecurve25519_Ciphertext(([]pkg2_curve25519.Ciphertext)(x.NonceEncShares), e)
+				} // end block: if x.NonceEncShares slice == nil
+			}
+			if yyq2[2] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"k\"")
+				} else {
+					r.EncodeString(`k`)
+				}
+				z.EncWriteMapElemValue()
+				if yyn5 {
+					r.EncodeNil()
+				} else {
+					if yyxt11 := z.Extension(x.KeyShare); yyxt11 != nil {
+						z.EncExtension(x.KeyShare, yyxt11)
+					} else {
+						z.EncFallback(x.KeyShare)
+					}
+				}
+			}
+			z.EncWriteMapEnd()
+		}
+	}
+}
+
+func (x *DealingMessage) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = DealingMessage{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
+		} else {
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
+		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
+	}
+}
+
+func (x *DealingMessage) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "C":
+			h.decSlicecurve25519_PointXY((*[]pkg2_curve25519.PointXY)(&x.NonceCommitments), d)
+		case "E":
+			h.decSlicecurve25519_Ciphertext((*[]pkg2_curve25519.Ciphertext)(&x.NonceEncShares), d)
+		case "k":
+			if r.TryNil() {
+				if x.KeyShare != nil { // remove the if-true
+					x.KeyShare = nil
+				}
+			} else {
+				if x.KeyShare == nil {
+					x.KeyShare = new(pkg1_feldman.ExpShare)
+				}
+				if yyxt9 := z.Extension(x.KeyShare); yyxt9 != nil {
+					z.DecExtension(x.KeyShare, yyxt9)
+				} else {
+					z.DecFallback(x.KeyShare, false)
+				}
+			}
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *DealingMessage) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj10 int
+	var yyb10 bool
+	var yyhl10 bool = l >= 0
+	yyj10++
+	if yyhl10 {
+		yyb10 = yyj10 > l
+	} else {
+		yyb10 = z.DecCheckBreak()
+	}
+	if yyb10 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSlicecurve25519_PointXY((*[]pkg2_curve25519.PointXY)(&x.NonceCommitments), d)
+	yyj10++
+	if yyhl10 {
+		yyb10 = yyj10 > l
+	} else {
+		yyb10 = z.DecCheckBreak()
+	}
+	if yyb10 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	h.decSlicecurve25519_Ciphertext((*[]pkg2_curve25519.Ciphertext)(&x.NonceEncShares), d)
+	yyj10++
+	if yyhl10 {
+		yyb10 = yyj10 > l
+	} else {
+		yyb10 = z.DecCheckBreak()
+	}
+	if yyb10 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if r.TryNil() {
+		if x.KeyShare != nil { // remove the if-true
+			x.KeyShare = nil
+		}
+	} else {
+		if x.KeyShare == nil {
+			x.KeyShare = new(pkg1_feldman.ExpShare)
+		}
+		if yyxt16 := z.Extension(x.KeyShare); yyxt16 != nil {
+			z.DecExtension(x.KeyShare, yyxt16)
+		} else {
+			z.DecFallback(x.KeyShare, false)
+		}
+	}
+	for {
+		yyj10++
+		if yyhl10 {
+			yyb10 = yyj10 > l
+		} else {
+			yyb10 = z.DecCheckBreak()
+		}
+		if yyb10 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj10-1, "")
+	}
+}
+
+func (x *DealingMessage) IsCodecEmpty() bool {
+	return !(len(x.NonceCommitments) != 0 || len(x.NonceEncShares) != 0 || false)
+}
+
+func (NonceMessage) codecSelferViaCodecgen() {}
+func (x *NonceMessage) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
+		return
+	}
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyn3 bool = x.NonceShare == nil
+		var yyq2 = [1]bool{ // should field at this index be written?
+			x.NonceShare != nil, // r
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(1)
+			if yyn3 {
+				z.EncWriteArrayElem()
+				r.EncodeNil()
+			} else {
+				z.EncWriteArrayElem()
+				if yyq2[0] {
+					if yyxt4 := z.Extension(x.NonceShare); yyxt4 != nil {
+						z.EncExtension(x.NonceShare, yyxt4)
+					} else {
+						z.EncFallback(x.NonceShare)
+					}
+				} else {
+					r.EncodeNil()
+				}
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
+				}
+			}
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"r\"")
+				} else {
+					r.EncodeString(`r`)
+				}
+				z.EncWriteMapElemValue()
+				if yyn3 {
+					r.EncodeNil()
+				} else {
+					if yyxt5 := z.Extension(x.NonceShare); yyxt5 != nil {
+						z.EncExtension(x.NonceShare, yyxt5)
+					} else {
+						z.EncFallback(x.NonceShare)
+					}
+				}
+			}
+			z.EncWriteMapEnd()
+		}
+	}
+}
+
+func (x *NonceMessage) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = NonceMessage{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
+		} else {
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
+		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
+	}
+}
+
+func (x *NonceMessage) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "r":
+			if r.TryNil() {
+				if x.NonceShare != nil { // remove the if-true
+					x.NonceShare = nil
+				}
+			} else {
+				if x.NonceShare == nil {
+					x.NonceShare = new(pkg1_feldman.ExpShare)
+				}
+				if yyxt5 := z.Extension(x.NonceShare); yyxt5 != nil {
+					z.DecExtension(x.NonceShare, yyxt5)
+				} else {
+					z.DecFallback(x.NonceShare, false)
+				}
+			}
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *NonceMessage) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj6 int
+	var yyb6 bool
+	var yyhl6 bool = l >= 0
+	yyj6++
+	if yyhl6 {
+		yyb6 = yyj6 > l
+	} else {
+		yyb6 = z.DecCheckBreak()
+	}
+	if yyb6 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if r.TryNil() {
+		if x.NonceShare != nil { // remove the if-true
+			x.NonceShare = nil
+		}
+	} else {
+		if x.NonceShare == nil {
+			x.NonceShare = new(pkg1_feldman.ExpShare)
+		}
+		if yyxt8 := z.Extension(x.NonceShare); yyxt8 != nil {
+			z.DecExtension(x.NonceShare, yyxt8)
+		} else {
+			z.DecFallback(x.NonceShare, false)
+		}
+	}
+	for {
+		yyj6++
+		if yyhl6 {
+			yyb6 = yyj6 > l
+		} else {
+			yyb6 = z.DecCheckBreak()
+		}
+		if yyb6 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj6-1, "")
+	}
+}
+
+func (x *NonceMessage) IsCodecEmpty() bool {
+	return !(false)
+}
+
+func (PartialSignatureMessage) codecSelferViaCodecgen() {}
+func (x *PartialSignatureMessage) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if z.EncBasicHandle().CheckCircularRef {
+		z.EncEncode(x)
+		return
+	}
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yy2arr2 := z.EncBasicHandle().StructToArray
+		_ = yy2arr2
+		const yyr2 bool = false // struct tag has 'toArray'
+		var yyq2 = [1]bool{     // should field at this index be written?
+			len(x.Z) != 0, // z
+		}
+		_ = yyq2
+		if yyr2 || yy2arr2 {
+			z.EncWriteArrayStart(1)
+			z.EncWriteArrayElem()
+			if yyq2[0] {
+				yy4 := &x.Z
+				if yyxt5 := z.Extension(yy4); yyxt5 != nil {
+					z.EncExtension(yy4, yyxt5)
+				} else {
+					z.F.EncSliceUint8V(([]uint8)(yy4[:]), e)
+				}
+			} else {
+				r.EncodeNil()
+			}
+			z.EncWriteArrayEnd()
+		} else {
+			var yynn2 int
+			for _, b := range yyq2 {
+				if b {
+					yynn2++
+				}
+			}
+			z.EncWriteMapStart(yynn2)
+			yynn2 = 0
+			if yyq2[0] {
+				z.EncWriteMapElemKey()
+				if z.IsJSONHandle() {
+					z.WriteStr("\"z\"")
+				} else {
+					r.EncodeString(`z`)
+				}
+				z.EncWriteMapElemValue()
+				yy6 := &x.Z
+				if yyxt7 := z.Extension(yy6); yyxt7 != nil {
+					z.EncExtension(yy6, yyxt7)
+				} else {
+					z.F.EncSliceUint8V(([]uint8)(yy6[:]), e)
+				}
+			}
+			z.EncWriteMapEnd()
+		}
+	}
+}
+
+func (x *PartialSignatureMessage) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	yyct2 := r.ContainerType()
+	if yyct2 == codecSelferValueTypeNil943 {
+		*(x) = PartialSignatureMessage{}
+	} else if yyct2 == codecSelferValueTypeMap943 {
+		yyl2 := z.DecReadMapStart()
+		if yyl2 == 0 {
+		} else {
+			x.codecDecodeSelfFromMap(yyl2, d)
+		}
+		z.DecReadMapEnd()
+	} else if yyct2 == codecSelferValueTypeArray943 {
+		yyl2 := z.DecReadArrayStart()
+		if yyl2 != 0 {
+			x.codecDecodeSelfFromArray(yyl2, d)
+		}
+		z.DecReadArrayEnd()
+	} else {
+		panic(errCodecSelferOnlyMapOrArrayEncodeToStruct943)
+	}
+}
+
+func (x *PartialSignatureMessage) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if z.DecCheckBreak() {
+				break
+			}
+		}
+		z.DecReadMapElemKey()
+		yys3 := r.DecodeStringAsBytes()
+		z.DecReadMapElemValue()
+		switch string(yys3) {
+		case "z":
+			if yyxt5 := z.Extension(x.Z); yyxt5 != nil {
+				z.DecExtension(&x.Z, yyxt5)
+			} else {
+				z.F.DecSliceUint8N(([]uint8)(x.Z[:]), d)
+			}
+		default:
+			z.DecStructFieldNotFound(-1, string(yys3))
+		} // end switch yys3
+	} // end for yyj3
+}
+
+func (x *PartialSignatureMessage) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+	var yyj6 int
+	var yyb6 bool
+	var yyhl6 bool = l >= 0
+	yyj6++
+	if yyhl6 {
+		yyb6 = yyj6 > l
+	} else {
+		yyb6 = z.DecCheckBreak()
+	}
+	if yyb6 {
+		z.DecReadArrayEnd()
+		return
+	}
+	z.DecReadArrayElem()
+	if yyxt8 := z.Extension(x.Z); yyxt8 != nil {
+		z.DecExtension(&x.Z, yyxt8)
+	} else {
+		z.F.DecSliceUint8N(([]uint8)(x.Z[:]), d)
+	}
+	for {
+		yyj6++
+		if yyhl6 {
+			yyb6 = yyj6 > l
+		} else {
+			yyb6 = z.DecCheckBreak()
+		}
+		if yyb6 {
+			break
+		}
+		z.DecReadArrayElem()
+		z.DecStructFieldNotFound(yyj6-1, "")
+	}
+}
+
+func (x *PartialSignatureMessage) IsCodecEmpty() bool {
+	return !(len(x.Z) != 0 || false)
+}
+
+func (x codecSelfer943) encSlicecurve25519_PointXY(v []pkg2_curve25519.PointXY, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	z.EncWriteArrayStart(len(v))
+	for yyv1 := range v {
+		z.EncWriteArrayElem()
+		yy2 := &v[yyv1]
+		if yyxt3 := z.Extension(yy2); yyxt3 != nil {
+			z.EncExtension(yy2, yyxt3)
+		} else {
+			z.F.EncSliceUint8V(([]uint8)(yy2[:]), e)
+		}
+	}
+	z.EncWriteArrayEnd()
+}
+
+func (x codecSelfer943) decSlicecurve25519_PointXY(v *[]pkg2_curve25519.PointXY, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyh1.IsNil {
+		if yyv1 != nil {
+			yyv1 = nil
+			yyc1 = true
+		}
+	} else if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = []pkg2_curve25519.PointXY{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else {
+		yyhl1 := yyl1 > 0
+		var yyrl1 int
+		_ = yyrl1
+		if yyhl1 {
+			if yyl1 > cap(yyv1) {
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 64)
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
+				} else {
+					yyv1 = make([]pkg2_curve25519.PointXY, yyrl1)
+				}
+				yyc1 = true
+			} else if yyl1 != len(yyv1) {
+				yyv1 = yyv1[:yyl1]
+				yyc1 = true
+			}
+		}
+		var yyj1 int
+		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
+			if yyj1 == 0 && yyv1 == nil {
+				if yyhl1 {
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 64)
+				} else {
+					yyrl1 = 8
+				}
+				yyv1 = make([]pkg2_curve25519.PointXY, yyrl1)
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			var yydb1 bool
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, pkg2_curve25519.PointXY{})
+				yyc1 = true
+			}
+			if yydb1 {
+				z.DecSwallow()
+			} else {
+				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
+					z.DecExtension(&yyv1[yyj1], yyxt3)
+				} else {
+					z.F.DecSliceUint8N(([]uint8)(yyv1[yyj1][:]), d)
+				}
+			}
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = make([]pkg2_curve25519.PointXY, 0)
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
+
+func (x codecSelfer943) encSlicecurve25519_Ciphertext(v []pkg2_curve25519.Ciphertext, e *codec1978.Encoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Encoder(e)
+	_, _, _ = h, z, r
+	if v == nil {
+		r.EncodeNil()
+		return
+	}
+	z.EncWriteArrayStart(len(v))
+	for yyv1 := range v {
+		z.EncWriteArrayElem()
+		if yyxt2 := z.Extension(v[yyv1]); yyxt2 != nil {
+			z.EncExtension(v[yyv1], yyxt2)
+		} else {
+			if v[yyv1] == nil {
+				r.EncodeNil()
+			} else {
+				z.F.EncSliceUint8V(([]uint8)(v[yyv1]), e)
+			} // end block: if v[yyv1] slice == nil
+		}
+	}
+	z.EncWriteArrayEnd()
+}
+
+func (x codecSelfer943) decSlicecurve25519_Ciphertext(v *[]pkg2_curve25519.Ciphertext, d *codec1978.Decoder) {
+	var h codecSelfer943
+	z, r := codec1978.GenHelper().Decoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyh1.IsNil {
+		if yyv1 != nil {
+			yyv1 = nil
+			yyc1 = true
+		}
+	} else if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = []pkg2_curve25519.Ciphertext{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else {
+		yyhl1 := yyl1 > 0
+		var yyrl1 int
+		_ = yyrl1
+		if yyhl1 {
+			if yyl1 > cap(yyv1) {
+				yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 24)
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
+				} else {
+					yyv1 = make([]pkg2_curve25519.Ciphertext, yyrl1)
+				}
+				yyc1 = true
+			} else if yyl1 != len(yyv1) {
+				yyv1 = yyv1[:yyl1]
+				yyc1 = true
+			}
+		}
+		var yyj1 int
+		for yyj1 = 0; (yyhl1 && yyj1 < yyl1) || !(yyhl1 || z.DecCheckBreak()); yyj1++ { // bounds-check-elimination
+			if yyj1 == 0 && yyv1 == nil {
+				if yyhl1 {
+					yyrl1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 24)
+				} else {
+					yyrl1 = 8
+				}
+				yyv1 = make([]pkg2_curve25519.Ciphertext, yyrl1)
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			var yydb1 bool
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, nil)
+				yyc1 = true
+			}
+			if yydb1 {
+				z.DecSwallow()
+			} else {
+				if yyxt3 := z.Extension(yyv1[yyj1]); yyxt3 != nil {
+					z.DecExtension(&yyv1[yyj1], yyxt3)
+				} else {
+					z.F.DecSliceUint8X((*[]uint8)(&yyv1[yyj1]), d)
+				}
+			}
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = make([]pkg2_curve25519.Ciphertext, 0)
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
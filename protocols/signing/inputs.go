@@ -0,0 +1,64 @@
+package signing
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/shaih/go-yosovss/communication"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/shaih/go-yosovss/protocols/dkg"
+)
+
+// PublicInput contain the public common inputs that are used in the signing protocol
+type PublicInput struct {
+	VSSParams   vss.Params             // parameters for the VSS
+	EncPKs      []curve25519.PublicKey // encryption public keys (indexed by party ID)
+	T           int                    // max number of malicious parties (=VSSParams.D)
+	N           int                    // number of parties holding a share (=VSSParams.N)
+	Parties     []int                  // Parties[j] is the ID of the party holding the share of index j+1
+	Commitments []pedersen.Commitment  // list of N+1 Pedersen commitments to the secret key s and its shares
+	// (output of the DKG or of the resharing protocol)
+	SessionID []byte // identifier of the session, bound to all the NIZK proofs
+	Message   []byte // message to sign (only used by StartSigningParty)
+}
+
+// PrivateInput contains the private inputs of a party in the signing protocol
+type PrivateInput struct {
+	BC    communication.BroadcastChannel
+	EncSK curve25519.PrivateKey
+	Share *vss.Share // share of the secret key, nil if the party is not in Parties
+	ID    int
+
+	// Rand is the randomness source used by the party (nonce, proofs, encryption)
+	// If nil, system randomness is used
+	Rand io.Reader
+}
+
+// checkInputs performs basic checks on the inputs to catch most common errors
+func checkInputs(pub *PublicInput, prv *PrivateInput, j int) error {
+	if len(pub.Commitments) != pub.N+1 {
+		return fmt.Errorf("number of commitments must be N+1")
+	}
+	if j >= 0 && (prv.Share == nil || prv.Share.Index != j+1) {
+		return fmt.Errorf("party %d must have the share of index %d", prv.ID, j+1)
+	}
+	return nil
+}
+
+// dkgInputs returns the inputs of the DKG used to generate the nonce
+func dkgInputs(pub *PublicInput, prv *PrivateInput) (*dkg.PublicInput, *dkg.PrivateInput) {
+	return &dkg.PublicInput{
+		VSSParams: pub.VSSParams,
+		EncPKs:    pub.EncPKs,
+		T:         pub.T,
+		N:         pub.N,
+		Parties:   pub.Parties,
+	}, &dkg.PrivateInput{
+		BC:    prv.BC,
+		EncSK: prv.EncSK,
+		ID:    prv.ID,
+		Rand:  prv.Rand,
+	}
+}
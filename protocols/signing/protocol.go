@@ -0,0 +1,212 @@
+package signing
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/shaih/go-yosovss/protocols/dkg"
+	"github.com/shaih/go-yosovss/protocols/resharing/common"
+)
+
+const (
+	numRounds          = 5 // number of rounds of messaging required for the signing protocol
+	numRoundsPublicKey = 1 // number of rounds of messaging required for the public key protocol
+)
+
+// DerivePublicKey computes the public key s G from the shares in the exponent of the secret key
+// keyShares[j] is the share of party j (see feldman.ExpShare)
+// Invalid shares are ignored
+func DerivePublicKey(pub *PublicInput, keyShares []*feldman.ExpShare) (*curve25519.PointXY, error) {
+	valid := validExpShares(pub.SessionID, keyLabel, pub.Commitments, keyShares)
+	return InterpolateExp(pub.T, valid, keyShares)
+}
+
+// StartPublicKeyParty initiates the protocol for a party computing the public key s G
+// of the secret key s committed in pub.Commitments
+// Parties in pub.Parties publish their share of the secret key in the exponent
+func StartPublicKeyParty(
+	pub *PublicInput,
+	prv *PrivateInput,
+) (
+	pk *curve25519.PointXY,
+	err error,
+) {
+	j := common.IntIndexOf(pub.Parties, prv.ID)
+	err = checkInputs(pub, prv, j)
+	if err != nil {
+		return nil, err
+	}
+
+	if j >= 0 {
+		keyShare, err := ComputeExpShare(prv.Rand, pub.SessionID, keyLabel, j, &pub.Commitments[j+1], prv.Share)
+		if err != nil {
+			return nil, fmt.Errorf("party %d failed to compute key share: %w", prv.ID, err)
+		}
+		prv.BC.Send(msgpack.Encode(&KeyShareMessage{KeyShare: keyShare}))
+	} else {
+		prv.BC.Send([]byte{}) // an empty message
+	}
+
+	keyShareMessages := ReceiveKeyShareMessages(prv.BC, pub.Parties)
+	keyShares := make([]*feldman.ExpShare, pub.N)
+	for q := range keyShareMessages {
+		keyShares[q] = keyShareMessages[q].KeyShare
+	}
+
+	return DerivePublicKey(pub, keyShares)
+}
+
+// StartSigningParty initiates the protocol for a party signing pub.Message
+// with the secret key s committed in pub.Commitments
+// It returns the signature and the public key s G under which it verifies (see VerifySignature)
+func StartSigningParty(
+	pub *PublicInput,
+	prv *PrivateInput,
+) (
+	sig *Signature,
+	pk *curve25519.PointXY,
+	err error,
+) {
+	// index of the party in pub.Parties, -1 if the party does not hold a share
+	j := common.IntIndexOf(pub.Parties, prv.ID)
+	err = checkInputs(pub, prv, j)
+	if err != nil {
+		return nil, nil, err
+	}
+	dkgPub, dkgPrv := dkgInputs(pub, prv)
+
+	// Dealing
+	// =======
+
+	// Each party deals a random nonce share (see dkg) and publishes its key share in the exponent
+	var dealtShares []dkg.DealtShare
+	if j >= 0 {
+		var msg *DealingMessage
+		msg, dealtShares, err = PerformDealing(pub, prv, j)
+		if err != nil {
+			return nil, nil, fmt.Errorf("party %d failed to perform dealing: %w", prv.ID, err)
+		}
+		prv.BC.Send(msgpack.Encode(msg))
+	} else {
+		prv.BC.Send([]byte{})
+	}
+
+	dealingMessages := ReceiveDealingMessages(prv.BC, pub.Parties)
+	nonceDealingMessages := make([]dkg.DealingMessage, pub.N)
+	keyShares := make([]*feldman.ExpShare, pub.N)
+	for i := range dealingMessages {
+		nonceDealingMessages[i] = dealingMessages[i].nonceDealing()
+		keyShares[i] = dealingMessages[i].KeyShare
+	}
+
+	// Complaint
+	// =========
+
+	// Each party complains against the dealers that sent it an invalid nonce share (see dkg)
+	var receivedShares []*vss.Share
+	if j >= 0 {
+		var msg *dkg.ComplaintMessage
+		msg, receivedShares = dkg.PerformComplaint(dkgPub, dkgPrv, j, nonceDealingMessages)
+		prv.BC.Send(msgpack.Encode(msg))
+	} else {
+		prv.BC.Send([]byte{})
+	}
+
+	complaintMessages := dkg.ReceiveComplaintMessages(prv.BC, pub.Parties)
+
+	// Answer
+	// ======
+
+	// Each dealer answers the complaints against it (see dkg)
+	if j >= 0 {
+		msg, err := dkg.PerformAnswer(dkgPub, j, dealtShares, complaintMessages)
+		if err != nil {
+			return nil, nil, fmt.Errorf("party %d failed to perform answer: %w", prv.ID, err)
+		}
+		prv.BC.Send(msgpack.Encode(msg))
+	} else {
+		prv.BC.Send([]byte{})
+	}
+
+	answerMessages := dkg.ReceiveAnswerMessages(prv.BC, pub.Parties)
+
+	nonceShare, nonceCommitments, err := dkg.PerformOutput(
+		dkgPub, j, nonceDealingMessages, complaintMessages, answerMessages, receivedShares,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("party %d failed to generate the nonce: %w", prv.ID, err)
+	}
+
+	// Nonce
+	// =====
+
+	// Each party publishes its nonce share in the exponent
+	if j >= 0 {
+		msg, err := PerformNonce(pub, prv, j, nonceCommitments, nonceShare)
+		if err != nil {
+			return nil, nil, fmt.Errorf("party %d failed to perform nonce: %w", prv.ID, err)
+		}
+		prv.BC.Send(msgpack.Encode(msg))
+	} else {
+		prv.BC.Send([]byte{})
+	}
+
+	nonceMessages := ReceiveNonceMessages(prv.BC, pub.Parties)
+	nonceShares := make([]*feldman.ExpShare, pub.N)
+	for i := range nonceMessages {
+		nonceShares[i] = nonceMessages[i].NonceShare
+	}
+
+	// Compute the public key A = s G, the nonce R = k G, and the challenge e
+	keyValid := validExpShares(pub.SessionID, keyLabel, pub.Commitments, keyShares)
+	pk, err = InterpolateExp(pub.T, keyValid, keyShares)
+	if err != nil {
+		return nil, nil, fmt.Errorf("party %d failed to compute the public key: %w", prv.ID, err)
+	}
+	nonceValid := validExpShares(pub.SessionID, nonceLabel, nonceCommitments, nonceShares)
+	r, err := InterpolateExp(pub.T, nonceValid, nonceShares)
+	if err != nil {
+		return nil, nil, fmt.Errorf("party %d failed to compute the nonce: %w", prv.ID, err)
+	}
+	e, err := challenge(r, pk, pub.Message)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Signing
+	// =======
+
+	// Each party publishes its share of S = k + e s
+	if j >= 0 {
+		prv.BC.Send(msgpack.Encode(PerformPartialSignature(prv, e, nonceShare)))
+	} else {
+		prv.BC.Send([]byte{})
+	}
+
+	partialSigs := ReceivePartialSignatureMessages(prv.BC, pub.Parties)
+
+	sig, err = ComputeSignature(
+		pub, e, r, intersect(keyValid, nonceValid), keyShares, nonceShares, partialSigs,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("party %d failed to compute the signature: %w", prv.ID, err)
+	}
+	return sig, pk, nil
+}
+
+// intersect returns the elements of the sorted list a that are in the sorted list b
+func intersect(a, b []int) []int {
+	var c []int
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				c = append(c, x)
+				break
+			}
+		}
+	}
+	return c
+}
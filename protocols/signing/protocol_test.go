@@ -0,0 +1,240 @@
+package signing
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/shaih/go-yosovss/communication"
+	"github.com/shaih/go-yosovss/communication/fake"
+	"github.com/shaih/go-yosovss/msgpack"
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSigning setup the signing protocol for n parties holding a share of a random secret key
+// with threshold t
+// numParties-n additional parties only observe the protocol
+func setupSigning(
+	t testing.TB,
+	n int,
+	tt int,
+	numParties int,
+) (
+	pub *PublicInput,
+	prvs []PrivateInput,
+	o fake.Orchestrator,
+	secret *curve25519.Scalar,
+) {
+	require := require.New(t)
+
+	o = fake.NewOrchestrator()
+
+	encPKs, encSKs := curve25519.SetupKeys(numParties)
+	vssParams, err := vss.NewVSSParams(pedersen.GenerateParams(), n, tt)
+	require.NoError(err)
+
+	secret = curve25519.RandomScalar()
+	shares, commitments, err := vss.FixedRShare(vssParams, secret, curve25519.RandomScalar())
+	require.NoError(err)
+
+	parties := make([]int, n)
+	for j := range parties {
+		parties[j] = j
+	}
+
+	pub = &PublicInput{
+		VSSParams:   *vssParams,
+		EncPKs:      encPKs,
+		T:           tt,
+		N:           n,
+		Parties:     parties,
+		Commitments: commitments,
+		SessionID:   []byte("test session"),
+		Message:     []byte("test message"),
+	}
+
+	prvs = make([]PrivateInput, numParties)
+	for party := 0; party < numParties; party++ {
+		channel := fake.NewPartyBroadcastChannel(party)
+		o.AddChannel(channel)
+		prvs[party] = PrivateInput{
+			BC:    channel,
+			EncSK: encSKs[party],
+			ID:    party,
+		}
+		if party < n {
+			prvs[party].Share = &shares[party]
+		}
+	}
+
+	return pub, prvs, o, secret
+}
+
+// tamperingChannel is a broadcast channel that modifies the messages sent by the party
+// tamper(round, msg) returns the message actually sent in the round
+type tamperingChannel struct {
+	communication.BroadcastChannel
+	round  int
+	tamper func(round int, msg []byte) []byte
+}
+
+func (c *tamperingChannel) Send(msg []byte) {
+	c.BroadcastChannel.Send(c.tamper(c.round, msg))
+	c.round++
+}
+
+// runSigning runs the signing protocol with all the parties and returns their outputs
+func runSigning(
+	t *testing.T,
+	pub *PublicInput,
+	prvs []PrivateInput,
+	o fake.Orchestrator,
+) (
+	sigs []*Signature,
+	pks []*curve25519.PointXY,
+) {
+	require := require.New(t)
+
+	sigs = make([]*Signature, len(prvs))
+	pks = make([]*curve25519.PointXY, len(prvs))
+	errs := make([]error, len(prvs))
+
+	var wg sync.WaitGroup
+	for party := range prvs {
+		wg.Add(1)
+		go func(party int) {
+			defer wg.Done()
+			sigs[party], pks[party], errs[party] = StartSigningParty(pub, &prvs[party])
+		}(party)
+	}
+
+	for o.Round < numRounds {
+		require.NoError(o.ReceiveMessages())
+		require.NoError(o.Broadcast())
+		o.Round++
+	}
+
+	wg.Wait()
+
+	for party := range prvs {
+		require.NoError(errs[party])
+	}
+	return sigs, pks
+}
+
+// checkSigningResults checks that all the parties output the same valid signature
+// under the public key secret G
+func checkSigningResults(
+	t *testing.T,
+	pub *PublicInput,
+	secret *curve25519.Scalar,
+	sigs []*Signature,
+	pks []*curve25519.PointXY,
+) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	expectedPK, err := curve25519.MultBaseGPointXYScalar(secret)
+	require.NoError(err)
+
+	for party := range sigs {
+		assert.Equal(*expectedPK, *pks[party], "public key of party %d", party)
+		assert.Equal(*sigs[0], *sigs[party], "all signatures must be the same")
+	}
+
+	assert.NoError(VerifySignature(expectedPK, pub.Message, sigs[0]))
+
+	// the signature is a standard Ed25519 signature
+	psk, err := PublicKeyBytes(expectedPK)
+	require.NoError(err)
+	assert.True(curve25519.Verify(*psk, pub.Message, sigs[0][:]))
+}
+
+func TestPublicKeyProtocol(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	const (
+		n  = 5
+		tt = 2
+	)
+
+	pub, prvs, o, secret := setupSigning(t, n, tt, n+1) // party n is an observer
+
+	pks := make([]*curve25519.PointXY, len(prvs))
+	errs := make([]error, len(prvs))
+	var wg sync.WaitGroup
+	for party := range prvs {
+		wg.Add(1)
+		go func(party int) {
+			defer wg.Done()
+			pks[party], errs[party] = StartPublicKeyParty(pub, &prvs[party])
+		}(party)
+	}
+	for o.Round < numRoundsPublicKey {
+		require.NoError(o.ReceiveMessages())
+		require.NoError(o.Broadcast())
+		o.Round++
+	}
+	wg.Wait()
+
+	expectedPK, err := curve25519.MultBaseGPointXYScalar(secret)
+	require.NoError(err)
+	for party := range prvs {
+		require.NoError(errs[party])
+		assert.Equal(*expectedPK, *pks[party])
+	}
+}
+
+func TestSigningProtocol(t *testing.T) {
+	const (
+		n  = 5
+		tt = 2
+	)
+
+	pub, prvs, o, secret := setupSigning(t, n, tt, n+1) // party n is an observer
+	sigs, pks := runSigning(t, pub, prvs, o)
+	checkSigningResults(t, pub, secret, sigs, pks)
+
+	// the signature does not verify for another message
+	assert.Error(t, VerifySignature(pks[0], []byte("other message"), sigs[0]))
+}
+
+func TestSigningProtocolMalicious(t *testing.T) {
+	// Party 0 sends an invalid key share in the exponent
+	// and party 1 sends an invalid partial signature
+	const (
+		n  = 5
+		tt = 2
+	)
+
+	pub, prvs, o, secret := setupSigning(t, n, tt, n)
+
+	prvs[0].BC = &tamperingChannel{
+		BroadcastChannel: prvs[0].BC,
+		tamper: func(round int, msg []byte) []byte {
+			if round != 0 {
+				return msg
+			}
+			var dealingMsg DealingMessage
+			require.NoError(t, msgpack.Decode(msg, &dealingMsg))
+			dealingMsg.KeyShare.Y = *curve25519.RandomPointXY()
+			return msgpack.Encode(dealingMsg)
+		},
+	}
+	prvs[1].BC = &tamperingChannel{
+		BroadcastChannel: prvs[1].BC,
+		tamper: func(round int, msg []byte) []byte {
+			if round != numRounds-1 {
+				return msg
+			}
+			return msgpack.Encode(PartialSignatureMessage{Z: *curve25519.RandomScalar()})
+		},
+	}
+
+	sigs, pks := runSigning(t, pub, prvs, o)
+	checkSigningResults(t, pub, secret, sigs, pks)
+}
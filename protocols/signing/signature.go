@@ -0,0 +1,108 @@
+package signing
+
+import (
+	"crypto/sha512"
+	"fmt"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+)
+
+// This file implements Ed25519 signatures (RFC 8032) from their components
+//
+// A signature of the message M under the public key A = s G is (R, S)
+// where R = k G for a random nonce k, and S = k + e s with e = SHA512(R || A || M) mod L
+// (R and A are encoded in compressed form)
+// The signatures produced by the signing protocol can be verified by any Ed25519 implementation
+// (e.g., curve25519.Verify)
+//
+// Contrary to standard Ed25519, the secret key s is not derived from a seed by hashing and clamping
+// and the nonce k is random instead of being derived from the message
+
+// SignatureLength is the length of a signature in bytes
+const SignatureLength = 64
+
+// Signature is an Ed25519 signature R || S
+type Signature [SignatureLength]byte
+
+// PublicKeyBytes returns the Ed25519 encoding of the public key pk (i.e., its compressed form)
+func PublicKeyBytes(pk *curve25519.PointXY) (*curve25519.PublicSignKey, error) {
+	p, err := curve25519.PointXYToPoint(pk)
+	if err != nil {
+		return nil, err
+	}
+	psk := curve25519.PublicSignKey(*p)
+	return &psk, nil
+}
+
+// challenge computes e = SHA512(R || A || M) mod L
+func challenge(r *curve25519.PointXY, pk *curve25519.PointXY, msg []byte) (*curve25519.Scalar, error) {
+	rBytes, err := curve25519.PointXYToPoint(r)
+	if err != nil {
+		return nil, err
+	}
+	pkBytes, err := curve25519.PointXYToPoint(pk)
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha512.New()
+	h.Write(rBytes[:])
+	h.Write(pkBytes[:])
+	h.Write(msg)
+	var digest [64]byte
+	copy(digest[:], h.Sum(nil))
+	return curve25519.ReduceScalar(&digest), nil
+}
+
+// newSignature encodes the signature (R, S)
+func newSignature(r *curve25519.PointXY, s *curve25519.Scalar) (*Signature, error) {
+	rBytes, err := curve25519.PointXYToPoint(r)
+	if err != nil {
+		return nil, err
+	}
+	var sig Signature
+	copy(sig[:32], rBytes[:])
+	copy(sig[32:], s[:])
+	return &sig, nil
+}
+
+// VerifySignature verifies the signature sig of the message msg under the public key pk
+// It checks S G = R + e A (cofactorless verification, as in libsodium)
+func VerifySignature(pk *curve25519.PointXY, msg []byte, sig *Signature) error {
+	var rBytes curve25519.Point
+	copy(rBytes[:], sig[:32])
+	var s curve25519.Scalar
+	copy(s[:], sig[32:])
+
+	// S must be reduced
+	var sWide [64]byte
+	copy(sWide[:], s[:])
+	if *curve25519.ReduceScalar(&sWide) != s {
+		return fmt.Errorf("S is not reduced")
+	}
+
+	r, err := curve25519.PointToPointXY(&rBytes)
+	if err != nil {
+		return fmt.Errorf("invalid R: %w", err)
+	}
+
+	e, err := challenge(r, pk, msg)
+	if err != nil {
+		return err
+	}
+
+	left, err := curve25519.MultBaseGPointXYScalar(&s)
+	if err != nil {
+		return err
+	}
+	right, err := curve25519.MultiMultPointXYScalarVarTime(
+		[]curve25519.PointXY{*r, *pk}, []curve25519.Scalar{curve25519.ScalarOne, *e},
+	)
+	if err != nil {
+		return err
+	}
+	if *left != *right {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
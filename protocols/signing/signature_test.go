@@ -0,0 +1,58 @@
+package signing
+
+import (
+	"testing"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatureEd25519(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	msg := []byte("test message")
+
+	// Sign without threshold
+	s := curve25519.RandomScalar()
+	pk, err := curve25519.MultBaseGPointXYScalar(s)
+	require.NoError(err)
+	k := curve25519.RandomScalar()
+	r, err := curve25519.MultBaseGPointXYScalar(k)
+	require.NoError(err)
+	e, err := challenge(r, pk, msg)
+	require.NoError(err)
+	sig, err := newSignature(r, curve25519.AddScalar(k, curve25519.MultScalar(e, s)))
+	require.NoError(err)
+
+	assert.NoError(VerifySignature(pk, msg, sig))
+	psk, err := PublicKeyBytes(pk)
+	require.NoError(err)
+	assert.True(curve25519.Verify(*psk, msg, sig[:]))
+
+	// Invalid signatures
+	assert.Error(VerifySignature(pk, []byte("other message"), sig))
+
+	otherPK, err := curve25519.MultBaseGPointXYScalar(curve25519.RandomScalar())
+	require.NoError(err)
+	assert.Error(VerifySignature(otherPK, msg, sig))
+
+	invalidSig := *sig
+	invalidSig[40] ^= 1
+	assert.Error(VerifySignature(pk, msg, &invalidSig))
+
+	// S not reduced (S + L < 2^256 as S < L < 2^253)
+	invalidSig = *sig
+	var l = [32]byte{
+		0xed, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58, 0xd6, 0x9c, 0xf7, 0xa2, 0xde, 0xf9, 0xde, 0x14,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+	}
+	carry := 0
+	for b := 0; b < 32; b++ {
+		x := int(invalidSig[32+b]) + int(l[b]) + carry
+		invalidSig[32+b] = byte(x)
+		carry = x >> 8
+	}
+	assert.Error(VerifySignature(pk, msg, &invalidSig))
+}
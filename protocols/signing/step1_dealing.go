@@ -0,0 +1,58 @@
+package signing
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/protocols/dkg"
+)
+
+const (
+	keyLabel   = "key"   // label of the shares in the exponent of the secret key (see feldman.ExpShare)
+	nonceLabel = "nonce" // label of the shares in the exponent of the nonce (see feldman.ExpShare)
+)
+
+// DealingMessage is the message parties send during dealing round
+// Notations below are for party j in [0,n-1]
+type DealingMessage struct {
+	_struct          struct{}              `codec:",omitempty,omitemptyarray"`
+	NonceCommitments []pedersen.Commitment `codec:"C"` // NonceCommitments and NonceEncShares are
+	// the dealing of the DKG generating the nonce k (see dkg.DealingMessage)
+	NonceEncShares []curve25519.Ciphertext `codec:"E"`
+	KeyShare       *feldman.ExpShare       `codec:"k"` // KeyShare is s_{j+1} G where s_{j+1} is the share
+	// of the secret key
+}
+
+// nonceDealing returns the dealing of the DKG generating the nonce
+func (msg *DealingMessage) nonceDealing() dkg.DealingMessage {
+	return dkg.DealingMessage{
+		Commitments: msg.NonceCommitments,
+		EncShares:   msg.NonceEncShares,
+	}
+}
+
+// PerformDealing executes what party j does in the dealing round
+// and returns the message it should broadcast together with the nonce shares it dealt
+// (see dkg.PerformDealing)
+func PerformDealing(
+	pub *PublicInput, prv *PrivateInput, j int,
+) (*DealingMessage, []dkg.DealtShare, error) {
+	dkgPub, dkgPrv := dkgInputs(pub, prv)
+	nonceMsg, dealtShares, err := dkg.PerformDealing(dkgPub, dkgPrv)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while dealing the nonce: %w", err)
+	}
+
+	keyShare, err := ComputeExpShare(prv.Rand, pub.SessionID, keyLabel, j, &pub.Commitments[j+1], prv.Share)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while computing the key share: %w", err)
+	}
+
+	return &DealingMessage{
+		NonceCommitments: nonceMsg.Commitments,
+		NonceEncShares:   nonceMsg.EncShares,
+		KeyShare:         keyShare,
+	}, dealtShares, nil
+}
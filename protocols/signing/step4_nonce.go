@@ -0,0 +1,30 @@
+package signing
+
+import (
+	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/pedersen"
+	"github.com/shaih/go-yosovss/primitives/vss"
+)
+
+// NonceMessage is the message parties send during the nonce round
+// Notations below are for party j in [0,n-1]
+type NonceMessage struct {
+	_struct    struct{}          `codec:",omitempty,omitemptyarray"`
+	NonceShare *feldman.ExpShare `codec:"r"` // NonceShare is k_{j+1} G where k_{j+1} is the share of the nonce
+}
+
+// PerformNonce executes what party j does in the nonce round:
+// it publishes its share of the nonce in the exponent
+// nonceCommitments and nonceShare are the outputs of the DKG generating the nonce
+func PerformNonce(
+	pub *PublicInput, prv *PrivateInput, j int,
+	nonceCommitments []pedersen.Commitment, nonceShare *vss.Share,
+) (*NonceMessage, error) {
+	nonceExpShare, err := ComputeExpShare(
+		prv.Rand, pub.SessionID, nonceLabel, j, &nonceCommitments[j+1], nonceShare,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &NonceMessage{NonceShare: nonceExpShare}, nil
+}
@@ -0,0 +1,76 @@
+package signing
+
+import (
+	"fmt"
+
+	"github.com/shaih/go-yosovss/primitives/curve25519"
+	"github.com/shaih/go-yosovss/primitives/feldman"
+	"github.com/shaih/go-yosovss/primitives/vss"
+	log "github.com/sirupsen/logrus"
+)
+
+// PartialSignatureMessage is the message parties send during the signing round
+// Notations below are for party j in [0,n-1]
+type PartialSignatureMessage struct {
+	_struct struct{}          `codec:",omitempty,omitemptyarray"`
+	Z       curve25519.Scalar `codec:"z"` // Z = k_{j+1} + e s_{j+1} is the share of S (see Signature)
+}
+
+// PerformPartialSignature executes what party j does in the signing round
+// e is the challenge of the signature
+func PerformPartialSignature(
+	prv *PrivateInput, e *curve25519.Scalar, nonceShare *vss.Share,
+) *PartialSignatureMessage {
+	return &PartialSignatureMessage{
+		Z: *curve25519.AddScalar(&nonceShare.S, curve25519.MultScalar(e, &prv.Share.S)),
+	}
+}
+
+// ComputeSignature computes the signature from the partial signatures
+// The partial signature of party j is valid if Z_j G = R_j + e Y_j
+// where Y_j and R_j are the shares in the exponent of the secret key and of the nonce
+// valid is the list of parties for which both are valid
+func ComputeSignature(
+	pub *PublicInput, e *curve25519.Scalar, r *curve25519.PointXY,
+	valid []int,
+	keyShares []*feldman.ExpShare,
+	nonceShares []*feldman.ExpShare,
+	partialSigs []PartialSignatureMessage,
+) (*Signature, error) {
+	var js []int
+	for _, j := range valid {
+		left, err := curve25519.MultBaseGPointXYScalar(&partialSigs[j].Z)
+		if err != nil {
+			return nil, err
+		}
+		right, err := curve25519.MultiMultPointXYScalarVarTime(
+			[]curve25519.PointXY{nonceShares[j].Y, keyShares[j].Y},
+			[]curve25519.Scalar{curve25519.ScalarOne, *e},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if *left != *right {
+			log.Infof("invalid partial signature of party %d", j)
+			continue
+		}
+		js = append(js, j)
+		if len(js) == pub.T+1 {
+			break
+		}
+	}
+	if len(js) < pub.T+1 {
+		return nil, fmt.Errorf("only %d valid partial signatures (less than t+1)", len(js))
+	}
+
+	coeffs, err := lagrangeCoeffsAtZero(js)
+	if err != nil {
+		return nil, err
+	}
+	s := curve25519.ScalarZero
+	for q, j := range js {
+		s = *curve25519.AddScalar(&s, curve25519.MultScalar(&coeffs[q], &partialSigs[j].Z))
+	}
+
+	return newSignature(r, &s)
+}